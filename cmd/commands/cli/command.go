@@ -66,9 +66,13 @@ func NewCommand() *cli.Command {
 		Action: func(ctx *cli.Context) error {
 			config.ParseFlagsNode(ctx)
 			nodeOptions := node.GetOptions()
+			tequilapi, err := cmd.NewTequilapiClient(*nodeOptions)
+			if err != nil {
+				return err
+			}
 			cmdCLI := &cliApp{
 				historyFile: filepath.Join(nodeOptions.Directories.Data, ".cli_history"),
-				tequilapi:   tequilapi_client.NewClient(nodeOptions.TequilapiAddress, nodeOptions.TequilapiPort),
+				tequilapi:   tequilapi,
 			}
 			cmd.RegisterSignalCallback(utils.SoftKiller(cmdCLI.Kill))
 
@@ -167,6 +171,7 @@ func (c *cliApp) handleActions(line string) {
 		command string
 		handler func(argsString string)
 	}{
+		{"login", c.login},
 		{"connect", c.connect},
 		{"identities", c.identities},
 		{"payout", c.payout},
@@ -512,6 +517,9 @@ func (c *cliApp) proposals(filter string) {
 		}
 
 		msg := fmt.Sprintf("- provider id: %v\ttype: %v\tcountry: %v\taccess policies: %v", proposal.ProviderID, proposal.ServiceType, country, strings.Join(policies, ","))
+		if proposal.PaymentMethod != nil {
+			msg = fmt.Sprintf("%s\tprice: %v", msg, *proposal.PaymentMethod)
+		}
 
 		if filter == "" ||
 			strings.Contains(proposal.ProviderID, filter) ||
@@ -533,6 +541,28 @@ func (c *cliApp) fetchProposals() []tequilapi_client.ProposalDTO {
 	return proposals
 }
 
+func (c *cliApp) login(argsString string) {
+	username := strings.TrimSpace(argsString)
+	if username == "" {
+		info("Please provide username.\n", "login <username>")
+		return
+	}
+
+	password, err := c.reader.ReadPassword("Password: ")
+	if err != nil {
+		warn(err)
+		return
+	}
+	user, err := c.tequilapi.AuthLogin(username, string(password))
+	if err != nil {
+		warn(err)
+		return
+	}
+
+	c.fetchedProposals = c.fetchProposals()
+	success(fmt.Sprintf("Logged in as %s (%s).", user.Username, user.Role))
+}
+
 func (c *cliApp) location() {
 	location, err := c.tequilapi.OriginLocation()
 	if err != nil {
@@ -642,7 +672,9 @@ func newAutocompleter(tequilapi *tequilapi_client.Client, proposals []tequilapi_
 			readline.PcItem("unlock", readline.PcItemDynamic(getIdentityOptionList(tequilapi))),
 			readline.PcItem("register", readline.PcItemDynamic(getIdentityOptionList(tequilapi))),
 			readline.PcItem("topup", readline.PcItemDynamic(getIdentityOptionList(tequilapi))),
+			readline.PcItem("channel", readline.PcItemDynamic(getIdentityOptionList(tequilapi))),
 		),
+		readline.PcItem("login"),
 		readline.PcItem("status"),
 		readline.PcItem("healthcheck"),
 		readline.PcItem("nat"),
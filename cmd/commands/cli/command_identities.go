@@ -19,6 +19,7 @@ package cli
 
 import (
 	"fmt"
+	"io/ioutil"
 	"strconv"
 	"strings"
 	"time"
@@ -35,9 +36,13 @@ func (c *cliApp) identities(argsString string) {
 		"  " + usageListIdentities,
 		"  " + usageNewIdentity,
 		"  " + usageUnlockIdentity,
+		"  " + usagePassphraseIdentity,
+		"  " + usageExportIdentity,
+		"  " + usageImportIdentity,
 		"  " + usageRegisterIdentity,
 		"  " + usageTopupIdentity,
 		"  " + usageSettle,
+		"  " + usageChannel,
 	}, "\n")
 
 	if len(argsString) == 0 {
@@ -56,12 +61,20 @@ func (c *cliApp) identities(argsString string) {
 		c.newIdentity(actionArgs)
 	case "unlock":
 		c.unlockIdentity(actionArgs)
+	case "passphrase":
+		c.changeIdentityPassphrase(actionArgs)
+	case "export":
+		c.exportIdentity(actionArgs)
+	case "import":
+		c.importIdentity(actionArgs)
 	case "register":
 		c.registerIdentity(actionArgs)
 	case "topup":
 		c.topupIdentity(actionArgs)
 	case "settle":
 		c.settle(actionArgs)
+	case "channel":
+		c.channel(actionArgs)
 	default:
 		warnf("Unknown sub-command '%s'\n", argsString)
 		fmt.Println(usage)
@@ -131,6 +144,83 @@ func (c *cliApp) unlockIdentity(actionArgs []string) {
 	success(fmt.Sprintf("Identity %s unlocked.", identity))
 }
 
+const usagePassphraseIdentity = "passphrase <identity> <passphrase> <new passphrase>"
+
+func (c *cliApp) changeIdentityPassphrase(actionArgs []string) {
+	if len(actionArgs) != 3 {
+		info("Usage: " + usagePassphraseIdentity)
+		return
+	}
+
+	identity := actionArgs[0]
+	err := c.tequilapi.ChangePassphrase(identity, actionArgs[1], actionArgs[2])
+	if err != nil {
+		warn(err)
+		return
+	}
+	success(fmt.Sprintf("Passphrase of identity %s changed.", identity))
+}
+
+const usageExportIdentity = "export <identity> <file> [passphrase] [export passphrase]"
+
+func (c *cliApp) exportIdentity(actionArgs []string) {
+	if len(actionArgs) < 2 || len(actionArgs) > 4 {
+		info("Usage: " + usageExportIdentity)
+		return
+	}
+
+	identity, file := actionArgs[0], actionArgs[1]
+	var passphrase string
+	if len(actionArgs) >= 3 {
+		passphrase = actionArgs[2]
+	}
+	exportPassphrase := passphrase
+	if len(actionArgs) == 4 {
+		exportPassphrase = actionArgs[3]
+	}
+
+	blob, err := c.tequilapi.ExportIdentity(identity, passphrase, exportPassphrase)
+	if err != nil {
+		warn(err)
+		return
+	}
+	if err := ioutil.WriteFile(file, blob, 0600); err != nil {
+		warn(errors.Wrap(err, "could not write identity backup"))
+		return
+	}
+	success(fmt.Sprintf("Identity %s exported to %s", identity, file))
+}
+
+const usageImportIdentity = "import <file> [passphrase] [new passphrase]"
+
+func (c *cliApp) importIdentity(actionArgs []string) {
+	if len(actionArgs) < 1 || len(actionArgs) > 3 {
+		info("Usage: " + usageImportIdentity)
+		return
+	}
+
+	blob, err := ioutil.ReadFile(actionArgs[0])
+	if err != nil {
+		warn(errors.Wrap(err, "could not read identity backup"))
+		return
+	}
+	var passphrase string
+	if len(actionArgs) >= 2 {
+		passphrase = actionArgs[1]
+	}
+	newPassphrase := passphrase
+	if len(actionArgs) == 3 {
+		newPassphrase = actionArgs[2]
+	}
+
+	id, err := c.tequilapi.ImportIdentity(blob, passphrase, newPassphrase)
+	if err != nil {
+		warn(err)
+		return
+	}
+	success("Identity imported:", id.Address)
+}
+
 const usageRegisterIdentity = "register <identity> [stake] [beneficiary]"
 
 func (c *cliApp) registerIdentity(actionArgs []string) {
@@ -246,3 +336,32 @@ func (c *cliApp) settle(args []string) {
 		}
 	}
 }
+
+const usageChannel = "channel <identity>"
+
+func (c *cliApp) channel(args []string) {
+	if len(args) != 1 {
+		info("Usage: " + usageChannel)
+		return
+	}
+
+	channel, err := c.tequilapi.IdentityChannel(args[0])
+	if err != nil {
+		warn(err)
+		return
+	}
+
+	info("Channel address:", channel.ChannelAddress)
+	info("Accountant:", channel.AccountantID)
+	info("Registered:", channel.Registered)
+	info("Balance:", channel.Balance)
+	info("Settled:", channel.Settled)
+	info("Grand total promised:", channel.GrandTotalPromised)
+	if channel.LastPromise == nil {
+		info("Last accountant promise:", "none")
+		return
+	}
+	info(fmt.Sprintf("Last accountant promise: amount %d, fee %d, agreement %d, revealed %t",
+		channel.LastPromise.Amount, channel.LastPromise.Fee, channel.LastPromise.AgreementID, channel.LastPromise.Revealed))
+	info("Last promise hashlock:", channel.LastPromise.Hashlock)
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/mysteriumnetwork/node/config"
 	"github.com/mysteriumnetwork/node/config/urfavecli/clicontext"
 	"github.com/mysteriumnetwork/node/core/node"
+	"github.com/mysteriumnetwork/node/core/service/declarative"
 	"github.com/rs/zerolog/log"
 	"github.com/urfave/cli/v2"
 )
@@ -35,6 +36,7 @@ func NewCommand() *cli.Command {
 		Usage:     "Starts Mysterium Tequilapi service",
 		ArgsUsage: " ",
 		Before:    clicontext.LoadUserConfigQuietly,
+		Flags:     []cli.Flag{&config.FlagServicesFile},
 		Action: func(ctx *cli.Context) error {
 			quit := make(chan error, 2)
 			config.ParseFlagsServiceShared(ctx)
@@ -48,7 +50,11 @@ func NewCommand() *cli.Command {
 			}
 			go func() { quit <- di.Node.Wait() }()
 
-			cmd.RegisterSignalCallback(func() { quit <- nil })
+			if di.ServicesReconciler != nil {
+				cmd.RegisterSignalCallbacks(func() { quit <- nil }, reloadServicesFile(di.ServicesReconciler))
+			} else {
+				cmd.RegisterSignalCallback(func() { quit <- nil })
+			}
 
 			return describeQuit(<-quit)
 		},
@@ -60,6 +66,15 @@ func NewCommand() *cli.Command {
 	return command
 }
 
+func reloadServicesFile(reconciler *declarative.Reconciler) cmd.SignalCallback {
+	return func() {
+		log.Info().Msg("Reloading services file")
+		if err := reconciler.Reload(); err != nil {
+			log.Error().Err(err).Msg("Failed to reload services file")
+		}
+	}
+}
+
 func describeQuit(err error) error {
 	if err == nil {
 		log.Info().Msg("Stopping application")
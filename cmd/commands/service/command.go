@@ -63,8 +63,12 @@ func NewCommand(licenseCommandName string) *cli.Command {
 
 			cmd.RegisterSignalCallback(func() { quit <- nil })
 
+			tequilapi, err := di.TequilapiClient(*nodeOptions)
+			if err != nil {
+				return err
+			}
 			cmdService := &serviceCommand{
-				tequilapi:    client.NewClient(nodeOptions.TequilapiAddress, nodeOptions.TequilapiPort),
+				tequilapi:    tequilapi,
 				errorChannel: quit,
 				ap: client.AccessPoliciesRequest{
 					IDs: services.SharedConfiguredOptions().AccessPolicyList,
@@ -20,32 +20,41 @@ package cmd
 import (
 	"fmt"
 	"net"
+	"net/http"
+	"os"
 	"path/filepath"
 	"time"
 
 	"github.com/ethereum/go-ethereum/accounts/keystore"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/julienschmidt/httprouter"
 	"github.com/mysteriumnetwork/node/communication"
 	"github.com/mysteriumnetwork/node/communication/nats"
 	nats_dialog "github.com/mysteriumnetwork/node/communication/nats/dialog"
 	nats_discovery "github.com/mysteriumnetwork/node/communication/nats/discovery"
 	appconfig "github.com/mysteriumnetwork/node/config"
 	"github.com/mysteriumnetwork/node/consumer/bandwidth"
+	"github.com/mysteriumnetwork/node/consumer/budget"
 	consumer_session "github.com/mysteriumnetwork/node/consumer/session"
 	"github.com/mysteriumnetwork/node/consumer/statistics"
+	"github.com/mysteriumnetwork/node/core/audit"
 	"github.com/mysteriumnetwork/node/core/auth"
 	"github.com/mysteriumnetwork/node/core/connection"
 	"github.com/mysteriumnetwork/node/core/discovery"
 	"github.com/mysteriumnetwork/node/core/discovery/brokerdiscovery"
 	"github.com/mysteriumnetwork/node/core/discovery/proposal"
 	"github.com/mysteriumnetwork/node/core/ip"
+	"github.com/mysteriumnetwork/node/core/ledger"
 	"github.com/mysteriumnetwork/node/core/location"
+	"github.com/mysteriumnetwork/node/core/metrics"
 	"github.com/mysteriumnetwork/node/core/node"
 	nodevent "github.com/mysteriumnetwork/node/core/node/event"
 	"github.com/mysteriumnetwork/node/core/policy"
 	"github.com/mysteriumnetwork/node/core/quality"
 	"github.com/mysteriumnetwork/node/core/service"
+	"github.com/mysteriumnetwork/node/core/service/declarative"
+	"github.com/mysteriumnetwork/node/core/service/pricing"
 	"github.com/mysteriumnetwork/node/core/state"
 	statevent "github.com/mysteriumnetwork/node/core/state/event"
 	"github.com/mysteriumnetwork/node/core/storage/boltdb"
@@ -84,6 +93,7 @@ import (
 	"github.com/mysteriumnetwork/node/tequilapi"
 	tequilapi_endpoints "github.com/mysteriumnetwork/node/tequilapi/endpoints"
 	"github.com/mysteriumnetwork/node/tequilapi/sse"
+	"github.com/mysteriumnetwork/node/tequilapi/ws"
 	"github.com/mysteriumnetwork/node/utils"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog/log"
@@ -110,7 +120,7 @@ type Dependencies struct {
 
 	NATService       nat.NATService
 	Storage          *boltdb.Bolt
-	Keystore         *keystore.KeyStore
+	Keystore         identity.Keystore
 	PromiseStorage   *promise.Storage
 	IdentityManager  identity.Manager
 	SignerFactory    identity.SignerFactory
@@ -142,6 +152,7 @@ type Dependencies struct {
 	ServicesManager       *service.Manager
 	ServiceRegistry       *service.Registry
 	ServiceSessionStorage *session.EventBasedStorage
+	ServicesReconciler    *declarative.Reconciler
 
 	NATPinger      traversal.NATPinger
 	NATTracker     *event.Tracker
@@ -153,8 +164,10 @@ type Dependencies struct {
 
 	Authenticator     *auth.Authenticator
 	JWTAuthenticator  *auth.JWTAuthenticator
+	APITokens         *auth.APITokens
 	UIServer          UIServer
 	SSEHandler        *sse.Handler
+	WebSocketHandler  *ws.Handler
 	Transactor        *registry.Transactor
 	BCHelper          *pingpong.BlockchainWithRetries
 	ProviderRegistrar *registry.ProviderRegistrar
@@ -166,14 +179,20 @@ type Dependencies struct {
 	ConsumerTotalsStorage    *pingpong.ConsumerTotalsStorage
 	AccountantPromiseStorage *pingpong.AccountantPromiseStorage
 	ConsumerBalanceTracker   *pingpong.ConsumerBalanceTracker
-	AccountantPromiseSettler *pingpong.AccountantPromiseSettler
+	AccountantPromiseSettler *pingpong.AccountantPromiseSettlers
+	InvoiceTrackerStates     *pingpong.InvoiceTrackerStateStorage
+	TrialStorage             *pingpong.TrialStorage
+	SpendingLimiter          *budget.Limiter
+	Ledger                   *ledger.Ledger
+	MoneyConverter           *money.Converter
+	AuditLog                 *audit.Log
+	Metrics                  *metrics.Collector
 }
 
 // Bootstrap initiates all container dependencies
 func (di *Dependencies) Bootstrap(nodeOptions node.Options) error {
 	logconfig.Configure(&nodeOptions.LogOptions)
 	nats_discovery.Bootstrap()
-	di.BrokerConnector = nats.NewBrokerConnector()
 
 	log.Info().Msg("Starting Mysterium Node " + metadata.VersionAsString())
 
@@ -184,6 +203,10 @@ func (di *Dependencies) Bootstrap(nodeOptions node.Options) error {
 	if err != nil {
 		return err
 	}
+	tequilaSocketListener, err := di.createTequilaSocketListener(nodeOptions)
+	if err != nil {
+		return err
+	}
 
 	if err := nodeOptions.Directories.Check(); err != nil {
 		return err
@@ -198,6 +221,10 @@ func (di *Dependencies) Bootstrap(nodeOptions node.Options) error {
 	}
 
 	di.bootstrapEventBus()
+	if err := di.bootstrapMetrics(); err != nil {
+		return err
+	}
+	di.BrokerConnector = nats.NewBrokerConnector(di.EventBus)
 
 	if err := di.bootstrapNetworkComponents(nodeOptions); err != nil {
 		return err
@@ -215,7 +242,9 @@ func (di *Dependencies) Bootstrap(nodeOptions node.Options) error {
 		return err
 	}
 
-	di.bootstrapUIServer(nodeOptions)
+	if err := di.bootstrapUIServer(nodeOptions); err != nil {
+		return err
+	}
 	di.bootstrapMMN(nodeOptions)
 	if err := di.bootstrapBandwidthTracker(); err != nil {
 		return err
@@ -235,11 +264,19 @@ func (di *Dependencies) Bootstrap(nodeOptions node.Options) error {
 		return err
 	}
 
+	if err := di.bootstrapWebSocketHandler(); err != nil {
+		return err
+	}
+
 	if err := di.bootstrapQualityComponents(nodeOptions.BindAddress, nodeOptions.Quality); err != nil {
 		return err
 	}
 
-	if err := di.bootstrapNodeComponents(nodeOptions, tequilaListener); err != nil {
+	if err := di.bootstrapMoneyConverter(nodeOptions.Payments); err != nil {
+		return err
+	}
+
+	if err := di.bootstrapNodeComponents(nodeOptions, tequilaListener, tequilaSocketListener); err != nil {
 		return err
 	}
 
@@ -254,6 +291,10 @@ func (di *Dependencies) Bootstrap(nodeOptions node.Options) error {
 
 	appconfig.Current.EnableEventPublishing(di.EventBus)
 
+	if err := di.bootstrapServicesFile(services.SharedConfiguredOptions()); err != nil {
+		return err
+	}
+
 	log.Info().Msg("Mysterium node started!")
 	return nil
 }
@@ -267,9 +308,40 @@ func (di *Dependencies) createTequilaListener(nodeOptions node.Options) (net.Lis
 	if err != nil {
 		return nil, errors.Wrap(err, fmt.Sprintf("the port %v seems to be taken. Either you're already running a node or it is already used by another application", nodeOptions.TequilapiPort))
 	}
+
+	if nodeOptions.TequilapiTLS.Enabled {
+		tlsOptions := nodeOptions.TequilapiTLS
+		hosts := []string{"localhost", "127.0.0.1", "::1", nodeOptions.TequilapiAddress}
+		if hostname, err := os.Hostname(); err == nil {
+			hosts = append(hosts, hostname)
+		}
+		if err := tequilapi.EnsureSelfSignedCertificate(tlsOptions.CertFile, tlsOptions.KeyFile, hosts); err != nil {
+			tequilaListener.Close()
+			return nil, err
+		}
+		tlsListener, err := tequilapi.NewTLSListener(tequilaListener, tlsOptions.CertFile, tlsOptions.KeyFile)
+		if err != nil {
+			tequilaListener.Close()
+			return nil, err
+		}
+		return tlsListener, nil
+	}
 	return tequilaListener, nil
 }
 
+// createTequilaSocketListener returns listener of the unix domain socket or nil if socket is not configured
+func (di *Dependencies) createTequilaSocketListener(nodeOptions node.Options) (net.Listener, error) {
+	if !nodeOptions.TequilapiEnabled || nodeOptions.TequilapiSocket.Path == "" {
+		return nil, nil
+	}
+
+	mode, err := nodeOptions.TequilapiSocket.FileMode()
+	if err != nil {
+		return nil, err
+	}
+	return tequilapi.NewUnixListener(nodeOptions.TequilapiSocket.Path, mode)
+}
+
 func (di *Dependencies) bootstrapStateKeeper(options node.Options) error {
 	var lastStageName string
 	if options.ExperimentNATPunching {
@@ -322,9 +394,40 @@ func (di *Dependencies) bootstrapSSEHandler() error {
 		return err
 	}
 	err = di.EventBus.Subscribe(statevent.AppTopicState, di.SSEHandler.ConsumeStateEvent)
+	if err != nil {
+		return err
+	}
+	err = di.EventBus.Subscribe(budget.AppTopicSpendingAlert, di.SSEHandler.ConsumeSpendingAlert)
 	return err
 }
 
+// bootstrapMetrics bootstraps the metrics collector fed from the event bus
+func (di *Dependencies) bootstrapMetrics() error {
+	di.Metrics = metrics.NewCollector()
+	return di.Metrics.Subscribe(di.EventBus)
+}
+
+// bootstrapWebSocketHandler bootstraps the WebSocketHandler and subscribes it to the streamed events
+func (di *Dependencies) bootstrapWebSocketHandler() error {
+	di.WebSocketHandler = ws.NewHandler(di.StateKeeper, tequilapi.NewMysteriumCorsPolicy())
+	subscriptions := map[string]interface{}{
+		nodevent.AppTopicNode:                      di.WebSocketHandler.ConsumeNodeEvent,
+		statevent.AppTopicState:                    di.WebSocketHandler.ConsumeStateEvent,
+		budget.AppTopicSpendingAlert:               di.WebSocketHandler.ConsumeSpendingAlert,
+		connection.AppTopicConsumerConnectionState: di.WebSocketHandler.ConsumeConnectionState,
+		connection.AppTopicConsumerStatistics:      di.WebSocketHandler.ConsumeConnectionStatistics,
+		connection.AppTopicConsumerSession:         di.WebSocketHandler.ConsumeConnectionSession,
+		sessionevent.AppTopicSession:               di.WebSocketHandler.ConsumeServiceSession,
+		pingpong.AppTopicBalanceChanged:            di.WebSocketHandler.ConsumeBalanceChange,
+	}
+	for topic, fn := range subscriptions {
+		if err := di.EventBus.Subscribe(topic, fn); err != nil {
+			return errors.Wrapf(err, "could not subscribe websocket handler to %q", topic)
+		}
+	}
+	return nil
+}
+
 // Shutdown stops container
 func (di *Dependencies) Shutdown() (err error) {
 	var errs []error
@@ -337,6 +440,10 @@ func (di *Dependencies) Shutdown() (err error) {
 		}
 	}()
 
+	if di.ServicesReconciler != nil {
+		di.ServicesReconciler.Stop()
+	}
+
 	if di.ServicesManager != nil {
 		if err := di.ServicesManager.Kill(); err != nil {
 			errs = append(errs, err)
@@ -392,6 +499,9 @@ func (di *Dependencies) bootstrapStorage(path string) error {
 	di.ProviderInvoiceStorage = pingpong.NewProviderInvoiceStorage(invoiceStorage)
 	di.ConsumerTotalsStorage = pingpong.NewConsumerTotalsStorage(di.Storage)
 	di.AccountantPromiseStorage = pingpong.NewAccountantPromiseStorage(di.Storage)
+	di.InvoiceTrackerStates = pingpong.NewInvoiceTrackerStateStorage(di.Storage)
+	di.TrialStorage = pingpong.NewTrialStorage(di.Storage)
+	di.Ledger = ledger.NewLedger(di.Storage)
 	return nil
 }
 
@@ -452,7 +562,7 @@ func (di *Dependencies) subscribeEventConsumers() error {
 	return di.EventBus.SubscribeAsync(nodevent.AppTopicNode, di.QualityMetricsSender.SendStartupEvent)
 }
 
-func (di *Dependencies) bootstrapNodeComponents(nodeOptions node.Options, tequilaListener net.Listener) error {
+func (di *Dependencies) bootstrapNodeComponents(nodeOptions node.Options, tequilaListener, tequilaSocketListener net.Listener) error {
 	dialogFactory := func(consumerID, providerID identity.Identity, contact market.Contact) (communication.Dialog, error) {
 		dialogEstablisher := nats_dialog.NewDialogEstablisher(consumerID, di.SignerFactory(consumerID), di.BrokerConnector)
 		return dialogEstablisher.EstablishDialog(providerID, contact)
@@ -486,20 +596,22 @@ func (di *Dependencies) bootstrapNodeComponents(nodeOptions node.Options, tequil
 		return err
 	}
 
+	if err := di.bootstrapInvoiceTrackerRecovery(nodeOptions); err != nil {
+		return err
+	}
+
 	if err := di.bootstrapProviderRegistrar(nodeOptions); err != nil {
 		return err
 	}
 
-	consumerDataGetter := pingpong.NewAccountantCaller(requests.NewHTTPClient(nodeOptions.BindAddress, time.Second*5), nodeOptions.Accountant.AccountantEndpointAddress).GetConsumerData
+	consumerDataGetter := pingpong.NewAccountantCallers(requests.NewHTTPClient(nodeOptions.BindAddress, time.Second*5), nodeOptions.Accountant.Accountants()).GetConsumerData
 	di.ConsumerBalanceTracker = pingpong.NewConsumerBalanceTracker(
 		di.EventBus,
 		common.HexToAddress(nodeOptions.Payments.MystSCAddress),
+		nodeOptions.Accountant.AccountantIDs(),
 		di.BCHelper,
-		pingpong.NewChannelAddressCalculator(
-			nodeOptions.Accountant.AccountantID,
-			nodeOptions.Transactor.ChannelImplementation,
-			nodeOptions.Transactor.RegistryAddress,
-		),
+		nodeOptions.Transactor.ChannelImplementation,
+		nodeOptions.Transactor.RegistryAddress,
 		consumerDataGetter,
 	)
 
@@ -508,11 +620,12 @@ func (di *Dependencies) bootstrapNodeComponents(nodeOptions node.Options, tequil
 		return errors.Wrap(err, "could not subscribe consumer balance tracker to relevant events")
 	}
 
+	di.SpendingLimiter = budget.NewLimiter(di.Storage, di.EventBus)
 	di.ConnectionRegistry = connection.NewRegistry()
 	di.ConnectionManager = connection.NewManager(
 		dialogFactory,
 		pingpong.BackwardsCompatibleExchangeFactoryFunc(
-			di.Keystore,
+			di.paymentKeystore(),
 			nodeOptions,
 			di.SignerFactory,
 			di.ConsumerTotalsStorage,
@@ -520,12 +633,26 @@ func (di *Dependencies) bootstrapNodeComponents(nodeOptions node.Options, tequil
 			nodeOptions.Transactor.RegistryAddress,
 			di.EventBus,
 			consumerDataGetter,
+			di.StatisticsTracker,
+			di.SpendingLimiter,
+			di.Ledger,
+			di.TrialStorage,
 		),
 		di.ConnectionRegistry.CreateConnection,
 		di.EventBus,
 		connectivity.NewStatusSender(),
 		di.IPResolver,
 		connection.DefaultIPCheckParams(),
+		di.originCountryResolver(nodeOptions.Location),
+		pingpong.NewAccountantSelector(
+			nodeOptions.Accountant.SelectionPolicy,
+			nodeOptions.Accountant.AccountantIDs(),
+			di.BCHelper,
+			nodeOptions.Transactor.ChannelImplementation,
+			nodeOptions.Transactor.RegistryAddress,
+			common.HexToAddress(nodeOptions.Payments.MystSCAddress),
+		),
+		di.SpendingLimiter,
 		nodeOptions.Payments.PaymentsDisabled,
 	)
 
@@ -536,43 +663,80 @@ func (di *Dependencies) bootstrapNodeComponents(nodeOptions node.Options, tequil
 	}
 	di.Reporter = reporter
 
-	tequilapiHTTPServer := di.bootstrapTequilapi(nodeOptions, tequilaListener, channelImplementation)
+	di.AuditLog = audit.NewLog(di.Storage, nodeOptions.TequilapiAudit.MaxRecords)
+	tequilapiHTTPServer := di.bootstrapTequilapi(nodeOptions, tequilaListener, tequilaSocketListener, channelImplementation)
 
 	di.Node = node.NewNode(di.ConnectionManager, tequilapiHTTPServer, di.EventBus, di.NATPinger, di.UIServer)
 	return nil
 }
 
-func (di *Dependencies) bootstrapTequilapi(nodeOptions node.Options, listener net.Listener, channelImplementation string) tequilapi.APIServer {
+func (di *Dependencies) bootstrapTequilapi(nodeOptions node.Options, listener, socketListener net.Listener, channelImplementation string) tequilapi.APIServer {
 	if !nodeOptions.TequilapiEnabled {
 		return tequilapi.NewNoopAPIServer()
 	}
 
+	var router http.Handler = di.tequilapiRouter(nodeOptions, channelImplementation)
+	if nodeOptions.TequilapiAudit.MaxRecords > 0 {
+		router = tequilapi.ApplyAudit(router, di.AuditLog)
+	}
+	corsPolicy := tequilapi.NewMysteriumCorsPolicy()
+	handler := tequilapi.ApplyAuthorization(router, di.JWTAuthenticator, di.APITokens, di.Authenticator)
+	di.WebSocketHandler.SetCommandHandler(handler)
+	server := tequilapi.NewServer(listener, handler, corsPolicy)
+	if socketListener == nil {
+		return server
+	}
+	socketServer := tequilapi.NewServer(socketListener, tequilapi.ApplySocketTrust(router), corsPolicy)
+	return tequilapi.NewMultiServer(server, socketServer)
+}
+
+// tequilapiRouter returns router with all Tequilapi endpoints attached
+func (di *Dependencies) tequilapiRouter(nodeOptions node.Options, channelImplementation string) *httprouter.Router {
 	router := tequilapi.NewAPIRouter()
 	tequilapi_endpoints.AddRouteForStop(router, utils.SoftKiller(di.Shutdown))
+	tequilapi_endpoints.AddRouteForOpenAPI(router)
 	tequilapi_endpoints.AddRoutesForAuthentication(router, di.Authenticator, di.JWTAuthenticator)
+	tequilapi_endpoints.AddRoutesForAPITokens(router, di.APITokens)
+	tequilapi_endpoints.AddRoutesForBudget(router, di.SpendingLimiter)
+	tequilapi_endpoints.AddRoutesForTransactions(router, di.Ledger)
+	tequilapi_endpoints.AddRoutesForAudit(router, di.AuditLog)
+	tequilapi_endpoints.AddRouteForMetrics(router, di.Metrics)
+	if di.AccountantPromiseSettler != nil {
+		tequilapi_endpoints.AddRoutesForEarnings(router, di.Ledger, di.AccountantPromiseSettler, di.Transactor)
+	}
+	tequilapi_endpoints.AddRoutesForChannel(router, pingpong.NewChannelInspector(
+		identity.FromAddress(nodeOptions.Accountant.AccountantID),
+		common.HexToAddress(nodeOptions.Transactor.RegistryAddress),
+		common.HexToAddress(nodeOptions.Payments.MystSCAddress),
+		pingpong.NewChannelAddressCalculator(nodeOptions.Accountant.AccountantID, channelImplementation, nodeOptions.Transactor.RegistryAddress),
+		di.BCHelper,
+		di.ConsumerTotalsStorage,
+		di.AccountantPromiseStorage,
+	))
 	tequilapi_endpoints.AddRoutesForIdentities(router, di.IdentityManager, di.IdentitySelector, di.IdentityRegistry, nodeOptions.Transactor.RegistryAddress, channelImplementation, di.ConsumerBalanceTracker.GetBalance)
 	tequilapi_endpoints.AddRoutesForConnection(router, di.ConnectionManager, di.StatisticsTracker, di.ProposalRepository, di.IdentityRegistry)
 	tequilapi_endpoints.AddRoutesForConnectionSessions(router, di.SessionStorage)
 	tequilapi_endpoints.AddRoutesForConnectionLocation(router, di.ConnectionManager, di.IPResolver, di.LocationResolver, di.LocationResolver)
-	tequilapi_endpoints.AddRoutesForProposals(router, di.ProposalRepository, di.QualityClient)
+	tequilapi_endpoints.AddRoutesForProposals(router, di.ProposalRepository, di.QualityClient, di.MoneyConverter)
 	tequilapi_endpoints.AddRoutesForService(router, di.ServicesManager, serviceTypesRequestParser)
 	tequilapi_endpoints.AddRoutesForServiceSessions(router, di.StateKeeper)
 	tequilapi_endpoints.AddRoutesForPayout(router, di.IdentityManager, di.SignerFactory, di.MysteriumAPI)
 	tequilapi_endpoints.AddRoutesForAccessPolicies(di.HTTPClient, router, services.SharedConfiguredOptions().AccessPolicyAddress)
 	tequilapi_endpoints.AddRoutesForNAT(router, di.StateKeeper.GetState)
 	tequilapi_endpoints.AddRoutesForSSE(router, di.SSEHandler)
-	tequilapi_endpoints.AddRoutesForTransactor(router, di.Transactor, di.AccountantPromiseSettler)
+	tequilapi_endpoints.AddRoutesForWebSocket(router, di.WebSocketHandler)
+	tequilapi_endpoints.AddRoutesForTransactor(router, di.Transactor, di.AccountantPromiseSettler, di.MoneyConverter)
 	tequilapi_endpoints.AddRoutesForConfig(router)
 	tequilapi_endpoints.AddRoutesForFeedback(router, di.Reporter)
 	tequilapi_endpoints.AddRoutesForConnectivityStatus(router, di.SessionConnectivityStatusStorage)
 	identity_registry.AddIdentityRegistrationEndpoint(router, di.IdentityRegistry)
-	corsPolicy := tequilapi.NewMysteriumCorsPolicy()
-	return tequilapi.NewServer(listener, router, corsPolicy)
+	return router
 }
 
 func newSessionManagerFactory(
 	nodeOptions node.Options,
-	proposal market.ServiceProposal,
+	currentProposal func() market.ServiceProposal,
+	currentQuote func() pricing.Quote,
 	sessionStorage *session.EventBasedStorage,
 	providerInvoiceStorage *pingpong.ProviderInvoiceStorage,
 	accountantPromiseStorage *pingpong.AccountantPromiseStorage,
@@ -584,9 +748,13 @@ func newSessionManagerFactory(
 	bcHelper *pingpong.BlockchainWithRetries,
 	transactor *registry.Transactor,
 	paymentsDisabled bool,
-	settler *pingpong.AccountantPromiseSettler,
+	settler *pingpong.AccountantPromiseSettlers,
+	ledger *ledger.Ledger,
+	invoiceTrackerStates *pingpong.InvoiceTrackerStateStorage,
+	trialStorage *pingpong.TrialStorage,
 ) session.ManagerFactory {
 	return func(dialog communication.Dialog) *session.Manager {
+		proposal := currentProposal()
 		providerBalanceTrackerFactory := func(consumerID, receiverID, issuerID identity.Identity) (session.PaymentEngine, error) {
 			timeTracker := session.NewTracker(time.Now)
 			// TODO: set the time and proper payment info
@@ -615,7 +783,7 @@ func newSessionManagerFactory(
 		paymentEngineFactory := pingpong.InvoiceFactoryCreator(
 			dialog, payment_factory.BalanceSendPeriod,
 			payment_factory.PromiseWaitTimeout, providerInvoiceStorage,
-			pingpong.NewAccountantCaller(requests.NewHTTPClient(nodeOptions.BindAddress, time.Second*5), nodeOptions.Accountant.AccountantEndpointAddress),
+			pingpong.NewAccountantCallers(requests.NewHTTPClient(nodeOptions.BindAddress, time.Second*5), nodeOptions.Accountant.Accountants()),
 			accountantPromiseStorage,
 			nodeOptions.Transactor.RegistryAddress,
 			nodeOptions.Transactor.ChannelImplementation,
@@ -626,7 +794,12 @@ func newSessionManagerFactory(
 			eventbus,
 			transactor,
 			proposal,
+			currentQuote,
 			settler.ForceSettle,
+			sessionStorage,
+			ledger,
+			invoiceTrackerStates,
+			trialStorage,
 		)
 		return session.NewManager(
 			proposal,
@@ -674,12 +847,15 @@ func (di *Dependencies) bootstrapNetworkComponents(options node.Options) (err er
 
 	di.NetworkDefinition = network
 
-	if _, err := firewall.AllowURLAccess(
+	allowedURLs := []string{
 		network.EtherClientRPC,
 		network.MysteriumAPIAddress,
 		options.Transactor.TransactorEndpointAddress,
-		options.Accountant.AccountantEndpointAddress,
-	); err != nil {
+	}
+	for _, accountant := range options.Accountant.Accountants() {
+		allowedURLs = append(allowedURLs, accountant.EndpointAddress)
+	}
+	if _, err := firewall.AllowURLAccess(allowedURLs...); err != nil {
 		return err
 	}
 
@@ -709,8 +885,21 @@ func (di *Dependencies) bootstrapEventBus() {
 	di.EventBus = eventbus.New()
 }
 
+// paymentKeystore returns the file keystore payment promises are signed with, nil if keys are held by external signer
+func (di *Dependencies) paymentKeystore() *keystore.KeyStore {
+	if ks, ok := di.Keystore.(*identity.KeystoreFilesystem); ok {
+		return ks.KeyStore
+	}
+	return nil
+}
+
 func (di *Dependencies) bootstrapIdentityComponents(options node.Options) {
-	di.Keystore = identity.NewKeystoreFilesystem(options.Directories.Keystore, options.Keystore.UseLightweight)
+	if options.Keystore.Signer != "" {
+		log.Info().Msgf("Using external signer at %s", options.Keystore.Signer)
+		di.Keystore = identity.NewKeystoreExternal(options.Keystore.Signer, options.Keystore.SignerTimeout)
+	} else {
+		di.Keystore = identity.NewKeystoreFilesystem(options.Directories.Keystore, options.Keystore.UseLightweight)
+	}
 	di.IdentityManager = identity.NewIdentityManager(di.Keystore, di.EventBus)
 	di.SignerFactory = func(id identity.Identity) identity.Signer {
 		return identity.NewSigner(di.Keystore, id)
@@ -755,6 +944,21 @@ func (di *Dependencies) bootstrapQualityComponents(bindAddress string, options n
 	return nil
 }
 
+// bootstrapMoneyConverter sets up conversion of prices to the display currency.
+// Without the exchange rates file prices are shown in MYST only.
+func (di *Dependencies) bootstrapMoneyConverter(options node.OptionsPayments) error {
+	var source money.RateSource
+	if options.DisplayRatesFile != "" {
+		rates, err := money.NewStaticRatesFromFile(options.DisplayRatesFile)
+		if err != nil {
+			return err
+		}
+		source = rates
+	}
+	di.MoneyConverter = money.NewConverter(source, money.Currency(options.DisplayCurrency))
+	return nil
+}
+
 func (di *Dependencies) bootstrapLocationComponents(options node.Options) (err error) {
 	if _, err = firewall.AllowURLAccess(options.Location.IPDetectorURL); err != nil {
 		return errors.Wrap(err, "failed to add firewall exception")
@@ -796,6 +1000,24 @@ func (di *Dependencies) bootstrapLocationComponents(options node.Options) (err e
 	return nil
 }
 
+// originCountryResolver returns the resolver of the country shared with providers, nil unless sharing is enabled.
+func (di *Dependencies) originCountryResolver(options node.OptionsLocation) connection.CountryResolver {
+	if !options.ShareCountry {
+		return nil
+	}
+	return di.originCountry
+}
+
+// originCountry returns the country node connects from, it lets providers see where their consumers come from.
+func (di *Dependencies) originCountry() string {
+	origin, err := di.LocationResolver.GetOrigin()
+	if err != nil {
+		log.Warn().Err(err).Msg("Could not resolve origin country")
+		return ""
+	}
+	return origin.Country
+}
+
 func (di *Dependencies) bootstrapAuthenticator() error {
 	key, err := auth.NewJWTEncryptionKey(di.Storage)
 	if err != nil {
@@ -803,6 +1025,7 @@ func (di *Dependencies) bootstrapAuthenticator() error {
 	}
 	di.Authenticator = auth.NewAuthenticator(di.Storage)
 	di.JWTAuthenticator = auth.NewJWTAuthenticator(key)
+	di.APITokens = auth.NewAPITokens(di.Storage, di.Authenticator)
 
 	return nil
 }
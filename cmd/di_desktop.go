@@ -20,6 +20,7 @@
 package cmd
 
 import (
+	"crypto/tls"
 	"fmt"
 	"time"
 
@@ -33,6 +34,7 @@ import (
 	"github.com/mysteriumnetwork/node/core/policy"
 	"github.com/mysteriumnetwork/node/core/port"
 	"github.com/mysteriumnetwork/node/core/service"
+	"github.com/mysteriumnetwork/node/core/service/declarative"
 	"github.com/mysteriumnetwork/node/identity"
 	"github.com/mysteriumnetwork/node/identity/registry"
 	"github.com/mysteriumnetwork/node/market"
@@ -40,6 +42,7 @@ import (
 	"github.com/mysteriumnetwork/node/nat"
 	"github.com/mysteriumnetwork/node/nat/mapping"
 	"github.com/mysteriumnetwork/node/nat/traversal"
+	"github.com/mysteriumnetwork/node/requests"
 	service_noop "github.com/mysteriumnetwork/node/services/noop"
 	service_openvpn "github.com/mysteriumnetwork/node/services/openvpn"
 	openvpn_discovery "github.com/mysteriumnetwork/node/services/openvpn/discovery"
@@ -73,6 +76,27 @@ func (di *Dependencies) bootstrapServices(nodeOptions node.Options, servicesOpti
 	return nil
 }
 
+// bootstrapServicesFile starts services listed in the services file and keeps them in line with it
+func (di *Dependencies) bootstrapServicesFile(servicesOptions config.ServicesOptions) error {
+	if servicesOptions.ServicesFile == "" {
+		return nil
+	}
+
+	parsers := make(map[string]declarative.OptionsParser, len(serviceTypesRequestParser))
+	for serviceType, parser := range serviceTypesRequestParser {
+		parsers[serviceType] = declarative.OptionsParser(parser)
+	}
+	di.ServicesReconciler = declarative.NewReconciler(
+		servicesOptions.ServicesFile,
+		di.ServicesManager,
+		di.IdentityManager,
+		config.GetString(config.FlagIdentityPassphrase),
+		parsers,
+		di.EventBus,
+	)
+	return errors.Wrap(di.ServicesReconciler.Start(), "failed to apply services file")
+}
+
 func (di *Dependencies) bootstrapServiceWireguard(nodeOptions node.Options) {
 	di.ServiceRegistry.Register(
 		wireguard.ServiceType,
@@ -214,14 +238,69 @@ func (di *Dependencies) bootstrapProviderRegistrar(nodeOptions node.Options) err
 }
 
 func (di *Dependencies) bootstrapAccountantPromiseSettler(nodeOptions node.Options) error {
-	cfg := pingpong.AccountantPromiseSettlerConfig{
-		AccountantAddress:    common.HexToAddress(nodeOptions.Accountant.AccountantID),
-		Threshold:            nodeOptions.Payments.AccountantPromiseSettlingThreshold,
-		MaxWaitForSettlement: nodeOptions.Payments.SettlementTimeout,
+	var settlers []*pingpong.AccountantPromiseSettler
+	for _, accountant := range nodeOptions.Accountant.Accountants() {
+		cfg := pingpong.AccountantPromiseSettlerConfig{
+			AccountantAddress:    common.HexToAddress(accountant.ID),
+			Threshold:            nodeOptions.Payments.AccountantPromiseSettlingThreshold,
+			MaxWaitForSettlement: nodeOptions.Payments.SettlementTimeout,
+			Strategy:             di.settlementStrategy(nodeOptions.Payments),
+			CheckInterval:        settlementCheckInterval,
+		}
+		settlers = append(settlers, pingpong.NewAccountantPromiseSettler(di.Transactor, di.AccountantPromiseStorage, di.BCHelper, di.IdentityRegistry, di.Keystore, di.AccountantPromiseStorage, di.Ledger, di.EventBus, cfg))
+	}
+	di.AccountantPromiseSettler = pingpong.NewAccountantPromiseSettlers(settlers...)
+	return di.AccountantPromiseSettler.Subscribe(di.EventBus)
+}
+
+// bootstrapInvoiceTrackerRecovery closes the provider sessions which were interrupted by the node stop.
+// The states are listed before any new session starts, while the accountant is contacted in the background.
+func (di *Dependencies) bootstrapInvoiceTrackerRecovery(nodeOptions node.Options) error {
+	states, err := di.InvoiceTrackerStates.List()
+	if err != nil {
+		return err
+	}
+	if len(states) == 0 {
+		return nil
+	}
+
+	recovery := pingpong.NewInvoiceTrackerRecovery(
+		di.InvoiceTrackerStates,
+		di.ProviderInvoiceStorage,
+		pingpong.NewAccountantCallers(requests.NewHTTPClient(nodeOptions.BindAddress, time.Second*5), nodeOptions.Accountant.Accountants()),
+		di.AccountantPromiseStorage,
+		di.Transactor,
+		di.EventBus,
+		di.AccountantPromiseSettler.ForceSettle,
+		di.Ledger,
+	)
+	go recovery.Recover(states)
+	return nil
+}
+
+// settlementCheckInterval is how often the settlement strategy is consulted while no new promises arrive.
+const settlementCheckInterval = 10 * time.Minute
+
+// settlementStrategy builds the accountant promise settlement strategy from the payment options.
+// Threshold, schedule and amount trigger the settlement, fee ratio postpones it while fees are too high,
+// while the reserve overrides the fees to avoid running out of the channel capacity.
+func (di *Dependencies) settlementStrategy(options node.OptionsPayments) pingpong.SettlementStrategy {
+	triggers := pingpong.AnyOf{pingpong.ThresholdStrategy{Threshold: options.AccountantPromiseSettlingThreshold}}
+	if options.SettlementInterval > 0 {
+		triggers = append(triggers, pingpong.NewScheduleStrategy(options.SettlementInterval))
+	}
+	if options.SettlementMinAmount > 0 {
+		triggers = append(triggers, pingpong.AmountStrategy{Amount: options.SettlementMinAmount})
 	}
-	settler := pingpong.NewAccountantPromiseSettler(di.Transactor, di.AccountantPromiseStorage, di.BCHelper, di.IdentityRegistry, di.Keystore, di.AccountantPromiseStorage, cfg)
-	di.AccountantPromiseSettler = settler
-	return settler.Subscribe(di.EventBus)
+
+	var strategy pingpong.SettlementStrategy = triggers
+	if options.SettlementMaxFeeRatio > 0 {
+		strategy = pingpong.AllOf{triggers, pingpong.FeeRatioStrategy{MaxFeeRatio: options.SettlementMaxFeeRatio}}
+	}
+	if options.SettlementReserve > 0 {
+		strategy = pingpong.AnyOf{strategy, pingpong.CapacityStrategy{Reserve: options.SettlementReserve}}
+	}
+	return strategy
 }
 
 // bootstrapServiceComponents initiates ServicesManager dependency
@@ -241,7 +320,7 @@ func (di *Dependencies) bootstrapServiceComponents(nodeOptions node.Options, ser
 	di.PolicyRepository = policy.NewRepository(di.HTTPClient, servicesOptions.AccessPolicyAddress, servicesOptions.AccessPolicyFetchInterval)
 	di.PolicyRepository.Start()
 
-	newDialogWaiter := func(providerID identity.Identity, serviceType string, policies *[]market.AccessPolicy) (communication.DialogWaiter, error) {
+	newDialogWaiter := func(providerID identity.Identity, serviceType string, policies policy.PoliciesProvider) (communication.DialogWaiter, error) {
 		return nats_dialog.NewDialogWaiter(
 			di.BrokerConnection,
 			fmt.Sprintf("%v.%v", providerID.Address, serviceType),
@@ -249,10 +328,11 @@ func (di *Dependencies) bootstrapServiceComponents(nodeOptions node.Options, ser
 			policy.ValidateAllowedIdentity(di.PolicyRepository, policies),
 		), nil
 	}
-	newDialogHandler := func(proposal market.ServiceProposal, configProvider session.ConfigProvider, serviceID string) (communication.DialogHandler, error) {
+	newDialogHandler := func(currentProposal service.ProposalProvider, currentQuote service.PriceQuoter, configProvider session.ConfigProvider, serviceID string) (communication.DialogHandler, error) {
 		sessionManagerFactory := newSessionManagerFactory(
 			nodeOptions,
-			proposal,
+			currentProposal,
+			currentQuote,
 			di.ServiceSessionStorage,
 			di.ProviderInvoiceStorage,
 			di.AccountantPromiseStorage,
@@ -265,13 +345,16 @@ func (di *Dependencies) bootstrapServiceComponents(nodeOptions node.Options, ser
 			di.Transactor,
 			nodeOptions.Payments.PaymentsDisabled,
 			di.AccountantPromiseSettler,
+			di.Ledger,
+			di.InvoiceTrackerStates,
+			di.TrialStorage,
 		)
 
 		return session.NewDialogHandler(
 			sessionManagerFactory,
 			configProvider,
 			di.PromiseStorage,
-			identity.FromAddress(proposal.ProviderID),
+			identity.FromAddress(currentProposal().ProviderID),
 			connectivity.NewStatusSubscriber(di.SessionConnectivityStatusStorage),
 		), nil
 	}
@@ -283,13 +366,38 @@ func (di *Dependencies) bootstrapServiceComponents(nodeOptions node.Options, ser
 		di.DiscoveryFactory,
 		di.EventBus,
 		di.PolicyRepository,
+		func(id service.ID) (count int) {
+			for _, s := range di.ServiceSessionStorage.GetAll() {
+				if s.ServiceID == string(id) {
+					count++
+				}
+			}
+			return count
+		},
 	)
+	di.ServicesManager.SetAccountants(nodeOptions.Accountant.AccountantIDs())
+	if nodeOptions.Payments.PackageDuration > 0 || nodeOptions.Payments.PackageBytes > 0 {
+		di.ServicesManager.SetPaymentPackage(&service.PackageOptions{
+			Duration: nodeOptions.Payments.PackageDuration,
+			Bytes:    nodeOptions.Payments.PackageBytes,
+			Price:    nodeOptions.Payments.PackagePrice,
+		})
+	}
+	di.ServicesManager.SetTrial(&market.Trial{
+		Duration: nodeOptions.Payments.TrialDuration,
+		Bytes:    nodeOptions.Payments.TrialBytes,
+	})
 
 	serviceCleaner := service.Cleaner{SessionStorage: di.ServiceSessionStorage}
 	if err := di.EventBus.Subscribe(service.AppTopicServiceStatus, serviceCleaner.HandleServiceStatus); err != nil {
 		log.Error().Msg("Failed to subscribe service cleaner")
 	}
 
+	policyEnforcer := service.PolicyEnforcer{SessionStorage: di.ServiceSessionStorage, PolicyRepository: di.PolicyRepository}
+	if err := di.EventBus.SubscribeAsync(service.AppTopicServiceAccessPolicies, policyEnforcer.HandleAccessPolicies); err != nil {
+		log.Error().Msg("Failed to subscribe service policy enforcer")
+	}
+
 	return nil
 }
 
@@ -318,13 +426,21 @@ func (di *Dependencies) registerWireguardConnection(nodeOptions node.Options) {
 	di.ConnectionRegistry.Register(wireguard.ServiceType, connFactory)
 }
 
-func (di *Dependencies) bootstrapUIServer(options node.Options) {
+func (di *Dependencies) bootstrapUIServer(options node.Options) error {
 	if options.UI.UIEnabled {
-		di.UIServer = ui.NewServer(options.BindAddress, options.UI.UIPort, options.TequilapiPort, di.JWTAuthenticator, di.HTTPClient)
-		return
+		var tequilapiTLS *tls.Config
+		if options.TequilapiTLS.Enabled {
+			var err error
+			if tequilapiTLS, err = ui.NewPinnedTLSConfig(options.TequilapiTLS.CertFile); err != nil {
+				return err
+			}
+		}
+		di.UIServer = ui.NewServer(options.BindAddress, options.UI.UIPort, options.TequilapiPort, tequilapiTLS, di.JWTAuthenticator, di.HTTPClient)
+		return nil
 	}
 
 	di.UIServer = uinoop.NewServer()
+	return nil
 }
 
 func (di *Dependencies) bootstrapMMN(options node.Options) {
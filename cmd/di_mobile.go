@@ -31,12 +31,17 @@ func (di *Dependencies) bootstrapServices(nodeOptions node.Options, servicesOpti
 	return nil
 }
 
+func (di *Dependencies) bootstrapServicesFile(servicesOptions config.ServicesOptions) error {
+	return nil
+}
+
 func (di *Dependencies) registerConnections(nodeOptions node.Options) {
 	di.registerNoopConnection()
 }
 
-func (di *Dependencies) bootstrapUIServer(options node.Options) {
+func (di *Dependencies) bootstrapUIServer(options node.Options) error {
 	di.UIServer = noop.NewServer()
+	return nil
 }
 
 func (di *Dependencies) bootstrapMMN(options node.Options) {
@@ -49,3 +54,7 @@ func (di *Dependencies) bootstrapProviderRegistrar(nodeOptions node.Options) err
 func (di *Dependencies) bootstrapAccountantPromiseSettler(nodeOptions node.Options) error {
 	return nil
 }
+
+func (di *Dependencies) bootstrapInvoiceTrackerRecovery(nodeOptions node.Options) error {
+	return nil
+}
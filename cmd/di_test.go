@@ -0,0 +1,124 @@
+/*
+ * Copyright (C) 2020 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/mysteriumnetwork/node/core/node"
+	"github.com/mysteriumnetwork/node/session/pingpong"
+	"github.com/mysteriumnetwork/node/tequilapi/openapi"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	specPathParameter   = regexp.MustCompile(`\{([^}/]+)\}`)
+	routerPathParameter = regexp.MustCompile(`:[^/]+`)
+)
+
+// routeAliases are routes registered with the wildcard matching the documented static path,
+// as httprouter can not register the static segment next to the wildcard.
+var routeAliases = map[string]string{
+	"POST /identities/:id": "POST /identities/import",
+}
+
+func Test_TequilapiRouter_RegistersRoutes(t *testing.T) {
+	di := &Dependencies{AccountantPromiseSettler: &pingpong.AccountantPromiseSettlers{}}
+
+	var router *httprouter.Router
+	assert.NotPanics(t, func() {
+		router = di.tequilapiRouter(node.Options{}, "")
+	}, "conflicting routes make httprouter panic")
+
+	routes := registeredRoutes(router)
+	assert.Contains(t, routes, "POST /identities/:id/export")
+	assert.Contains(t, routes, "POST /identities/:id")
+
+	handle, params, _ := router.Lookup(http.MethodPost, "/identities/import")
+	assert.NotNil(t, handle)
+	assert.Equal(t, "import", params.ByName("id"))
+}
+
+func Test_TequilapiRoutes_AreDescribedInOpenAPISpec(t *testing.T) {
+	di := &Dependencies{AccountantPromiseSettler: &pingpong.AccountantPromiseSettlers{}}
+	router := di.tequilapiRouter(node.Options{}, "")
+
+	spec, err := openapi.Spec("test")
+	assert.NoError(t, err)
+	var doc struct {
+		Paths map[string]map[string]interface{} `json:"paths"`
+	}
+	assert.NoError(t, json.Unmarshal(spec, &doc))
+
+	documented := make(map[string]bool)
+	for path, operations := range doc.Paths {
+		for method := range operations {
+			documented[strings.ToUpper(method)+" "+specPathParameter.ReplaceAllString(path, ":$1")] = true
+		}
+	}
+
+	routes := registeredRoutes(router)
+	assert.NotEmpty(t, routes)
+	for _, route := range routes {
+		if alias, ok := routeAliases[route]; ok {
+			route = alias
+		}
+		assert.True(t, documented[route], "route %s is missing in OpenAPI spec, annotate it with swagger:operation and run go generate ./tequilapi/openapi", route)
+	}
+	for route := range documented {
+		parts := strings.SplitN(route, " ", 2)
+		handle, _, _ := router.Lookup(parts[0], routerPathParameter.ReplaceAllString(parts[1], "0x1"))
+		assert.NotNil(t, handle, "route %s is described in OpenAPI spec, but not registered", route)
+	}
+}
+
+// registeredRoutes lists routes of the router by walking its trees, as httprouter does not expose registered routes
+func registeredRoutes(router *httprouter.Router) []string {
+	var routes []string
+	trees := reflect.ValueOf(router).Elem().FieldByName("trees")
+	for _, method := range trees.MapKeys() {
+		routes = append(routes, walkRoutes(method.String(), "", trees.MapIndex(method))...)
+	}
+	sort.Strings(routes)
+	return routes
+}
+
+func walkRoutes(method, prefix string, node reflect.Value) []string {
+	if node.IsNil() {
+		return nil
+	}
+	node = node.Elem()
+
+	var routes []string
+	path := prefix + node.FieldByName("path").String()
+	if !node.FieldByName("handle").IsNil() && method != http.MethodOptions {
+		routes = append(routes, method+" "+path)
+	}
+	children := node.FieldByName("children")
+	for i := 0; i < children.Len(); i++ {
+		routes = append(routes, walkRoutes(method, path, children.Index(i))...)
+	}
+	return routes
+}
@@ -34,6 +34,24 @@ func RegisterSignalCallback(callback SignalCallback) {
 	go waitTerminationSignal(sigterm, callback)
 }
 
+// RegisterSignalCallbacks registers given terminate callback to call on SIGTERM interrupts
+// and reload callback to call on every SIGHUP interrupt
+func RegisterSignalCallbacks(terminate SignalCallback, reload SignalCallback) {
+	sigterm := make(chan os.Signal, 1)
+	signal.Notify(sigterm, os.Interrupt, syscall.SIGTERM)
+	go waitTerminationSignal(sigterm, terminate)
+
+	sighup := make(chan os.Signal, 1)
+	signal.Notify(sighup, syscall.SIGHUP)
+	go waitReloadSignal(sighup, reload)
+}
+
+func waitReloadSignal(reload chan os.Signal, callback SignalCallback) {
+	for range reload {
+		callback()
+	}
+}
+
 func waitTerminationSignal(termination chan os.Signal, callback SignalCallback) {
 	<-termination
 	callback()
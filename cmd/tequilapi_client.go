@@ -0,0 +1,63 @@
+/*
+ * Copyright (C) 2020 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package cmd
+
+import (
+	"github.com/mysteriumnetwork/node/core/auth"
+	"github.com/mysteriumnetwork/node/core/node"
+	tequilapi_client "github.com/mysteriumnetwork/node/tequilapi/client"
+	"github.com/pkg/errors"
+)
+
+// NewTequilapiClient returns Tequilapi client for the configured node.
+// Unix domain socket is preferred when configured, so that local tools do not need to authenticate.
+func NewTequilapiClient(nodeOptions node.Options) (*tequilapi_client.Client, error) {
+	return tequilapi_client.NewClientWithOptions(tequilapi_client.Options{
+		Address:  nodeOptions.TequilapiAddress,
+		Port:     nodeOptions.TequilapiPort,
+		Socket:   nodeOptions.TequilapiSocket.Path,
+		TLS:      nodeOptions.TequilapiTLS.Enabled,
+		CertFile: nodeOptions.TequilapiTLS.CertFile,
+	})
+}
+
+// TequilapiClient returns Tequilapi client for the node running in this process.
+// Requests are authenticated as the admin user of the node.
+func (di *Dependencies) TequilapiClient(nodeOptions node.Options) (*tequilapi_client.Client, error) {
+	client, err := NewTequilapiClient(nodeOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	users, err := di.Authenticator.Users()
+	if err != nil {
+		return nil, err
+	}
+	for _, user := range users {
+		if user.Role != auth.RoleAdmin {
+			continue
+		}
+		token, err := di.JWTAuthenticator.CreateToken(user)
+		if err != nil {
+			return nil, err
+		}
+		client.SetAuthToken(token.Token)
+		return client, nil
+	}
+	return nil, errors.New("no admin user found to authenticate Tequilapi client")
+}
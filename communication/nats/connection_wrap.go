@@ -53,8 +53,9 @@ func ParseServerURI(serverURI string) (*url.URL, error) {
 
 func newConnection(serverURIs ...string) (*ConnectionWrap, error) {
 	connection := &ConnectionWrap{
-		servers: make([]string, len(serverURIs)),
-		onClose: func() {},
+		servers:  make([]string, len(serverURIs)),
+		onClose:  func() {},
+		onStatus: func(ConnectionStatus) {},
 	}
 
 	for i, server := range serverURIs {
@@ -71,8 +72,9 @@ func newConnection(serverURIs ...string) (*ConnectionWrap, error) {
 // ConnectionWrap defines wrapped connection to NATS server(s)
 type ConnectionWrap struct {
 	*nats_lib.Conn
-	servers []string
-	onClose func()
+	servers  []string
+	onClose  func()
+	onStatus func(ConnectionStatus)
 }
 
 func (c *ConnectionWrap) connectOptions() nats_lib.Options {
@@ -82,9 +84,18 @@ func (c *ConnectionWrap) connectOptions() nats_lib.Options {
 	options.ReconnectWait = 1 * time.Second
 	options.Timeout = 5 * time.Second
 	options.PingInterval = 10 * time.Second
-	options.ClosedCB = func(conn *nats_lib.Conn) { log.Warn().Msg("NATS: connection closed") }
-	options.DisconnectedCB = func(nc *nats_lib.Conn) { log.Warn().Msg("NATS: disconnected") }
-	options.ReconnectedCB = func(nc *nats_lib.Conn) { log.Warn().Msg("NATS: reconnected") }
+	options.ClosedCB = func(conn *nats_lib.Conn) {
+		log.Warn().Msg("NATS: connection closed")
+		c.onStatus(ConnectionStatusClosed)
+	}
+	options.DisconnectedCB = func(nc *nats_lib.Conn) {
+		log.Warn().Msg("NATS: disconnected")
+		c.onStatus(ConnectionStatusDisconnected)
+	}
+	options.ReconnectedCB = func(nc *nats_lib.Conn) {
+		log.Warn().Msg("NATS: reconnected")
+		c.onStatus(ConnectionStatusReconnected)
+	}
 	return options
 }
 
@@ -22,6 +22,7 @@ import (
 	"sync"
 
 	"github.com/gofrs/uuid"
+	"github.com/mysteriumnetwork/node/eventbus"
 	"github.com/mysteriumnetwork/node/firewall"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog/log"
@@ -29,14 +30,16 @@ import (
 
 // BrokerConnector establishes new connections to NATS servers and handles reconnects.
 type BrokerConnector struct {
-	registry map[uuid.UUID]Connection
-	mu       sync.Mutex
+	registry  map[uuid.UUID]Connection
+	mu        sync.Mutex
+	publisher eventbus.Publisher
 }
 
-// NewBrokerConnector creates a new BrokerConnector.
-func NewBrokerConnector() *BrokerConnector {
+// NewBrokerConnector creates a new BrokerConnector publishing the connection status changes.
+func NewBrokerConnector(publisher eventbus.Publisher) *BrokerConnector {
 	return &BrokerConnector{
-		registry: make(map[uuid.UUID]Connection),
+		registry:  make(map[uuid.UUID]Connection),
+		publisher: publisher,
 	}
 }
 
@@ -54,14 +57,19 @@ func (b *BrokerConnector) Connect(serverURIs ...string) (Connection, error) {
 		return nil, errors.Wrapf(err, `failed to allow NATS servers "%v" in firewall`, conn.servers)
 	}
 
-	if err := conn.Open(); err != nil {
-		return nil, errors.Wrapf(err, `failed to connect to NATS servers "%v"`, conn.servers)
-	}
 	id, err := uuid.NewV4()
 	if err != nil {
 		removeFirewallRule()
 		return nil, errors.Wrap(err, "could not generate UUID for the new connection")
 	}
+	conn.onStatus = func(status ConnectionStatus) {
+		b.publishStatus(id, conn.servers, status)
+	}
+
+	if err := conn.Open(); err != nil {
+		return nil, errors.Wrapf(err, `failed to connect to NATS servers "%v"`, conn.servers)
+	}
+	b.publishStatus(id, conn.servers, ConnectionStatusConnected)
 
 	b.mu.Lock()
 	b.registry[id] = conn
@@ -77,6 +85,17 @@ func (b *BrokerConnector) Connect(serverURIs ...string) (Connection, error) {
 	return conn, nil
 }
 
+func (b *BrokerConnector) publishStatus(id uuid.UUID, servers []string, status ConnectionStatus) {
+	if b.publisher == nil {
+		return
+	}
+	b.publisher.Publish(AppTopicConnectionStatus, ConnectionStatusEvent{
+		ConnectionID: id.String(),
+		Servers:      servers,
+		Status:       status,
+	})
+}
+
 // ReconnectAll checks all established connections to trigger reconnects.
 func (b *BrokerConnector) ReconnectAll() {
 	b.mu.Lock()
@@ -34,7 +34,7 @@ func TestDialogEstablisher_Factory(t *testing.T) {
 	id := identity.FromAddress("123456")
 	signer := &identity.SignerFake{}
 
-	establisher := NewDialogEstablisher(id, signer, nats.NewBrokerConnector())
+	establisher := NewDialogEstablisher(id, signer, nats.NewBrokerConnector(nil))
 	assert.NotNil(t, establisher)
 	assert.Equal(t, id, establisher.ID)
 	assert.Equal(t, signer, establisher.Signer)
@@ -0,0 +1,42 @@
+/*
+ * Copyright (C) 2020 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package nats
+
+// AppTopicConnectionStatus represents the topic to which broker connection status changes are published.
+const AppTopicConnectionStatus = "nats_connection_status"
+
+// ConnectionStatus represents the status of broker connection
+type ConnectionStatus string
+
+const (
+	// ConnectionStatusConnected means the connection was established
+	ConnectionStatusConnected ConnectionStatus = "Connected"
+	// ConnectionStatusDisconnected means the connection was lost and is being re-established
+	ConnectionStatusDisconnected ConnectionStatus = "Disconnected"
+	// ConnectionStatusReconnected means the lost connection was re-established
+	ConnectionStatusReconnected ConnectionStatus = "Reconnected"
+	// ConnectionStatusClosed means the connection was closed and will not be re-established
+	ConnectionStatusClosed ConnectionStatus = "Closed"
+)
+
+// ConnectionStatusEvent represents the broker connection status change
+type ConnectionStatusEvent struct {
+	ConnectionID string
+	Servers      []string
+	Status       ConnectionStatus
+}
@@ -35,6 +35,17 @@ var (
 		Usage: "accountant contract address used to register identity",
 		Value: metadata.DefaultNetwork.AccountantID,
 	}
+	// FlagAccountantAccepted lists the additional accountants node accepts
+	FlagAccountantAccepted = cli.StringSliceFlag{
+		Name:  "accountant.accepted",
+		Usage: "Additional accountants to accept payments through, separated by comma. Each given as <accountant ID>[@<accountant URL>], the URL defaults to accountant.address",
+	}
+	// FlagAccountantSelection determines how consumer picks the accountant among the ones accepted by both sides
+	FlagAccountantSelection = cli.StringFlag{
+		Name:  "accountant.selection",
+		Usage: `Accountant selection policy. Options: { "preferred", "lowest-fee", "balance" }`,
+		Value: "preferred",
+	}
 )
 
 // RegisterFlagsAccountant function register network flags to flag list
@@ -43,6 +54,8 @@ func RegisterFlagsAccountant(flags *[]cli.Flag) {
 		*flags,
 		&FlagAccountantAddress,
 		&FlagAccountantID,
+		&FlagAccountantAccepted,
+		&FlagAccountantSelection,
 	)
 }
 
@@ -50,4 +63,6 @@ func RegisterFlagsAccountant(flags *[]cli.Flag) {
 func ParseFlagsAccountant(ctx *cli.Context) {
 	Current.ParseStringFlag(ctx, FlagAccountantAddress)
 	Current.ParseStringFlag(ctx, FlagAccountantID)
+	Current.ParseStringSliceFlag(ctx, FlagAccountantAccepted)
+	Current.ParseStringFlag(ctx, FlagAccountantSelection)
 }
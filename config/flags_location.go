@@ -60,6 +60,11 @@ var (
 		Name:  "location.node-type",
 		Usage: "Service location node type",
 	}
+	// FlagLocationShareCountry shares the country consumer connects from with the providers.
+	FlagLocationShareCountry = cli.BoolFlag{
+		Name:  "location.share-country",
+		Usage: "Share the country node connects from with the providers it connects to",
+	}
 )
 
 // RegisterFlagsLocation function registers location flags to flag list.
@@ -71,6 +76,7 @@ func RegisterFlagsLocation(flags *[]cli.Flag) {
 		&FlagLocationCountry,
 		&FlagLocationCity,
 		&FlagLocationNodeType,
+		&FlagLocationShareCountry,
 	)
 }
 
@@ -82,4 +88,5 @@ func ParseFlagsLocation(ctx *cli.Context) {
 	Current.ParseStringFlag(ctx, FlagLocationCountry)
 	Current.ParseStringFlag(ctx, FlagLocationCity)
 	Current.ParseStringFlag(ctx, FlagLocationNodeType)
+	Current.ParseBoolFlag(ctx, FlagLocationShareCountry)
 }
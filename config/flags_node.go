@@ -77,6 +77,18 @@ var (
 		Name:  "keystore.lightweight",
 		Usage: "Determines the scrypt memory complexity. If set to true, will use 4MB blocks instead of the standard 256MB ones",
 	}
+	// FlagKeystoreSigner sets the socket of external signer holding the keys.
+	FlagKeystoreSigner = cli.StringFlag{
+		Name:  "keystore.signer",
+		Usage: "Unix socket of Clef compatible external signer holding the keys instead of local keystore",
+		Value: "",
+	}
+	// FlagKeystoreSignerTimeout sets how long to wait for the external signer to respond.
+	FlagKeystoreSignerTimeout = cli.DurationFlag{
+		Name:  "keystore.signer.timeout",
+		Usage: "Time to wait for the external signer to respond",
+		Value: 30 * time.Second,
+	}
 	// FlagLogHTTP enables HTTP payload logging.
 	FlagLogHTTP = cli.BoolFlag{
 		Name:  "log.http",
@@ -144,6 +156,38 @@ var (
 		Usage: "Port for listening incoming api requests",
 		Value: 4050,
 	}
+	// FlagTequilapiTLS serves API requests over TLS.
+	FlagTequilapiTLS = cli.BoolFlag{
+		Name:  "tequilapi.tls",
+		Usage: "Serve API requests over TLS. Self-signed certificate is generated if certificate files do not exist",
+	}
+	// FlagTequilapiTLSCert path of TLS certificate for API.
+	FlagTequilapiTLSCert = cli.StringFlag{
+		Name:  "tequilapi.tls.cert",
+		Usage: "PEM encoded TLS certificate for API. Defaults to tequilapi.crt in data directory",
+	}
+	// FlagTequilapiTLSKey path of TLS private key for API.
+	FlagTequilapiTLSKey = cli.StringFlag{
+		Name:  "tequilapi.tls.key",
+		Usage: "PEM encoded TLS private key for API. Defaults to tequilapi.key in data directory",
+	}
+	// FlagTequilapiSocket unix domain socket for listening incoming API requests.
+	FlagTequilapiSocket = cli.StringFlag{
+		Name:  "tequilapi.socket",
+		Usage: "Unix domain socket for listening incoming api requests. Requests via socket are trusted without authentication, access is controlled by socket file permissions",
+	}
+	// FlagTequilapiSocketMode file permissions of the API socket.
+	FlagTequilapiSocketMode = cli.StringFlag{
+		Name:  "tequilapi.socket.mode",
+		Usage: "File permissions of API socket in octal notation",
+		Value: "0660",
+	}
+	// FlagTequilapiAuditMaxRecords number of latest API requests kept in the audit log.
+	FlagTequilapiAuditMaxRecords = cli.IntFlag{
+		Name:  "tequilapi.audit.max.records",
+		Usage: "Number of latest API requests changing the node state kept in the audit log. Set 0 to disable auditing",
+		Value: 10000,
+	}
 	// FlagUIEnable enables built-in web UI for node.
 	FlagUIEnable = cli.BoolFlag{
 		Name:  "ui.enable",
@@ -185,6 +229,8 @@ func RegisterFlagsNode(flags *[]cli.Flag) error {
 		&FlagFirewallKillSwitch,
 		&FlagFirewallProtectedNetworks,
 		&FlagKeystoreLightweight,
+		&FlagKeystoreSigner,
+		&FlagKeystoreSignerTimeout,
 		&FlagLogHTTP,
 		&FlagLogLevel,
 		&FlagMMNAddress,
@@ -194,6 +240,12 @@ func RegisterFlagsNode(flags *[]cli.Flag) error {
 		&FlagQualityAddress,
 		&FlagTequilapiAddress,
 		&FlagTequilapiPort,
+		&FlagTequilapiTLS,
+		&FlagTequilapiTLSCert,
+		&FlagTequilapiTLSKey,
+		&FlagTequilapiSocket,
+		&FlagTequilapiSocketMode,
+		&FlagTequilapiAuditMaxRecords,
 		&FlagUIEnable,
 		&FlagUIPort,
 		&FlagVendorID,
@@ -220,6 +272,8 @@ func ParseFlagsNode(ctx *cli.Context) {
 	Current.ParseBoolFlag(ctx, FlagFirewallKillSwitch)
 	Current.ParseStringFlag(ctx, FlagFirewallProtectedNetworks)
 	Current.ParseBoolFlag(ctx, FlagKeystoreLightweight)
+	Current.ParseStringFlag(ctx, FlagKeystoreSigner)
+	Current.ParseDurationFlag(ctx, FlagKeystoreSignerTimeout)
 	Current.ParseBoolFlag(ctx, FlagLogHTTP)
 	Current.ParseStringFlag(ctx, FlagLogLevel)
 	Current.ParseStringFlag(ctx, FlagMMNAddress)
@@ -229,6 +283,12 @@ func ParseFlagsNode(ctx *cli.Context) {
 	Current.ParseStringFlag(ctx, FlagQualityType)
 	Current.ParseStringFlag(ctx, FlagTequilapiAddress)
 	Current.ParseIntFlag(ctx, FlagTequilapiPort)
+	Current.ParseBoolFlag(ctx, FlagTequilapiTLS)
+	Current.ParseStringFlag(ctx, FlagTequilapiTLSCert)
+	Current.ParseStringFlag(ctx, FlagTequilapiTLSKey)
+	Current.ParseStringFlag(ctx, FlagTequilapiSocket)
+	Current.ParseStringFlag(ctx, FlagTequilapiSocketMode)
+	Current.ParseIntFlag(ctx, FlagTequilapiAuditMaxRecords)
 	Current.ParseBoolFlag(ctx, FlagUIEnable)
 	Current.ParseIntFlag(ctx, FlagUIPort)
 	Current.ParseStringFlag(ctx, FlagVendorID)
@@ -48,6 +48,30 @@ var (
 		Value: time.Hour * 2,
 		Usage: "The duration we'll wait before timing out our wait for promise settle.",
 	}
+	// FlagPaymentsSettleMaxFeeRatio represents the max share of the settled amount we agree to pay as fees.
+	FlagPaymentsSettleMaxFeeRatio = cli.Float64Flag{
+		Name:  "payments.settle.max.fee.ratio",
+		Value: 0,
+		Usage: "The max share of the unsettled amount the settlement fees may take, settlement is postponed while fees are higher. 0.05 means 5%, 0 disables the check",
+	}
+	// FlagPaymentsSettleInterval represents how often promises are settled regardless of the balance left.
+	FlagPaymentsSettleInterval = cli.DurationFlag{
+		Name:  "payments.settle.interval",
+		Value: 0,
+		Usage: "Settle the unsettled promises every given duration, 0 disables scheduled settlement",
+	}
+	// FlagPaymentsSettleMinAmount represents the unsettled amount at which promises are settled.
+	FlagPaymentsSettleMinAmount = cli.Uint64Flag{
+		Name:  "payments.settle.min.amount",
+		Value: 0,
+		Usage: "Settle the promises once the unsettled amount reaches the given amount, 0 disables the check",
+	}
+	// FlagPaymentsSettleReserve represents the channel balance at which promises are settled before the channel runs out.
+	FlagPaymentsSettleReserve = cli.Uint64Flag{
+		Name:  "payments.settle.reserve",
+		Value: 0,
+		Usage: "Settle the promises regardless of fees once the channel balance drops to the given amount, 0 disables the check",
+	}
 	// FlagPaymentsMystSCAddress represents the myst smart contract address
 	FlagPaymentsMystSCAddress = cli.StringFlag{
 		Name:  "payments.mystscaddress",
@@ -77,6 +101,48 @@ var (
 		Usage: "Sets the minimum price of the service. All proposals with a below above this bound will be filtered out and not visible.",
 		Value: 0,
 	}
+	// FlagPaymentsDisplayCurrency sets the currency approximate values of prices are shown in.
+	FlagPaymentsDisplayCurrency = cli.StringFlag{
+		Name:  "payments.display.currency",
+		Usage: "The currency approximate values of prices and fees are shown in",
+		Value: "USD",
+	}
+	// FlagPaymentsDisplayRatesFile sets the file exchange rates of myst are read from.
+	FlagPaymentsDisplayRatesFile = cli.StringFlag{
+		Name:  "payments.display.rates-file",
+		Usage: `JSON file with the price of one MYST in other currencies, e.g. {"USD": 0.25}. Approximate values are not shown if not set`,
+		Value: "",
+	}
+	// FlagPaymentsPackageDuration sets the service duration included in a prepaid package.
+	FlagPaymentsPackageDuration = cli.DurationFlag{
+		Name:  "payments.package.duration",
+		Usage: "Sell the service in packages paid up front, each lasting the given duration. 0 disables the limit by time",
+		Value: 0,
+	}
+	// FlagPaymentsPackageBytes sets the data included in a prepaid package.
+	FlagPaymentsPackageBytes = cli.Uint64Flag{
+		Name:  "payments.package.bytes",
+		Usage: "Sell the service in packages paid up front, each including the given number of bytes. 0 disables the limit by data. Services not reporting transferred data refuse to start with a data limit",
+		Value: 0,
+	}
+	// FlagPaymentsPackagePrice sets the price of a prepaid package.
+	FlagPaymentsPackagePrice = cli.Uint64Flag{
+		Name:  "payments.package.price",
+		Usage: "The price of a single package, 0 prices the package at what its duration and data cost at the service price",
+		Value: 0,
+	}
+	// FlagPaymentsTrialDuration sets the service duration offered free of charge to new consumers.
+	FlagPaymentsTrialDuration = cli.DurationFlag{
+		Name:  "payments.trial.duration",
+		Usage: "Offer new consumers a free trial lasting the given duration. 0 disables the limit by time",
+		Value: 0,
+	}
+	// FlagPaymentsTrialBytes sets the data offered free of charge to new consumers.
+	FlagPaymentsTrialBytes = cli.Uint64Flag{
+		Name:  "payments.trial.bytes",
+		Usage: "Offer new consumers a free trial including the given number of bytes. 0 disables the limit by data",
+		Value: 0,
+	}
 )
 
 // RegisterFlagsPayments function register payments flags to flag list.
@@ -87,11 +153,22 @@ func RegisterFlagsPayments(flags *[]cli.Flag) {
 		&FlagPaymentsBCTimeout,
 		&FlagPaymentsAccountantPromiseSettleThreshold,
 		&FlagPaymentsAccountantPromiseSettleTimeout,
+		&FlagPaymentsSettleMaxFeeRatio,
+		&FlagPaymentsSettleInterval,
+		&FlagPaymentsSettleMinAmount,
+		&FlagPaymentsSettleReserve,
 		&FlagPaymentsMystSCAddress,
 		&FlagPaymentsMaxRRecovery,
 		&FlagPaymentsDisable,
 		&FlagPaymentsConsumerUpperPriceBound,
 		&FlagPaymentsConsumerLowerPriceBound,
+		&FlagPaymentsDisplayCurrency,
+		&FlagPaymentsDisplayRatesFile,
+		&FlagPaymentsPackageDuration,
+		&FlagPaymentsPackageBytes,
+		&FlagPaymentsPackagePrice,
+		&FlagPaymentsTrialDuration,
+		&FlagPaymentsTrialBytes,
 	)
 }
 
@@ -101,9 +178,20 @@ func ParseFlagsPayments(ctx *cli.Context) {
 	Current.ParseDurationFlag(ctx, FlagPaymentsBCTimeout)
 	Current.ParseFloat64Flag(ctx, FlagPaymentsAccountantPromiseSettleThreshold)
 	Current.ParseDurationFlag(ctx, FlagPaymentsAccountantPromiseSettleTimeout)
+	Current.ParseFloat64Flag(ctx, FlagPaymentsSettleMaxFeeRatio)
+	Current.ParseDurationFlag(ctx, FlagPaymentsSettleInterval)
+	Current.ParseUInt64Flag(ctx, FlagPaymentsSettleMinAmount)
+	Current.ParseUInt64Flag(ctx, FlagPaymentsSettleReserve)
 	Current.ParseStringFlag(ctx, FlagPaymentsMystSCAddress)
 	Current.ParseUInt64Flag(ctx, FlagPaymentsMaxRRecovery)
 	Current.ParseBoolFlag(ctx, FlagPaymentsDisable)
 	Current.ParseUInt64Flag(ctx, FlagPaymentsConsumerUpperPriceBound)
 	Current.ParseUInt64Flag(ctx, FlagPaymentsConsumerLowerPriceBound)
+	Current.ParseStringFlag(ctx, FlagPaymentsDisplayCurrency)
+	Current.ParseStringFlag(ctx, FlagPaymentsDisplayRatesFile)
+	Current.ParseDurationFlag(ctx, FlagPaymentsPackageDuration)
+	Current.ParseUInt64Flag(ctx, FlagPaymentsPackageBytes)
+	Current.ParseUInt64Flag(ctx, FlagPaymentsPackagePrice)
+	Current.ParseDurationFlag(ctx, FlagPaymentsTrialDuration)
+	Current.ParseUInt64Flag(ctx, FlagPaymentsTrialBytes)
 }
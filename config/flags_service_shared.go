@@ -30,6 +30,7 @@ type ServicesOptions struct {
 	AccessPolicyList          []string
 	AccessPolicyFetchInterval time.Duration
 	ShaperEnabled             bool
+	ServicesFile              string
 }
 
 var (
@@ -73,6 +74,12 @@ var (
 		Name:  "shaper.enabled",
 		Usage: "Limit service bandwidth",
 	}
+	// FlagServicesFile declarative services file.
+	FlagServicesFile = cli.StringFlag{
+		Name:  "services.file",
+		Usage: "Path of TOML file listing services to keep running. It is reloaded on SIGHUP or when changed",
+		Value: "",
+	}
 )
 
 // RegisterFlagsServiceShared registers shared service CLI flags
@@ -97,4 +104,5 @@ func ParseFlagsServiceShared(ctx *cli.Context) {
 	Current.ParseStringFlag(ctx, FlagAccessPolicyList)
 	Current.ParseDurationFlag(ctx, FlagAccessPolicyFetchInterval)
 	Current.ParseBoolFlag(ctx, FlagShaperEnabled)
+	Current.ParseStringFlag(ctx, FlagServicesFile)
 }
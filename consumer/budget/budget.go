@@ -0,0 +1,275 @@
+/*
+ * Copyright (C) 2020 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package budget
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/mysteriumnetwork/node/eventbus"
+	"github.com/mysteriumnetwork/node/identity"
+	"github.com/pkg/errors"
+)
+
+// ErrLimitReached indicates that spending would exceed the consumer budget.
+var ErrLimitReached = errors.New("spending limit reached")
+
+// AppTopicSpendingAlert represents the topic to which spending alerts are published.
+const AppTopicSpendingAlert = "spending_alert"
+
+// DefaultAlertThresholds are the budget usage percents alerted about if budget does not specify its own.
+var DefaultAlertThresholds = []int{80}
+
+const (
+	budgetBucketName   = "consumer_budgets"
+	spendingBucketName = "consumer_spendings"
+	errBoltNotFound    = "not found"
+)
+
+// Period represents the period budget limits spending in
+type Period string
+
+const (
+	// PeriodSession limits spending in a single session
+	PeriodSession = Period("session")
+	// PeriodDay limits spending in a calendar day
+	PeriodDay = Period("day")
+	// PeriodMonth limits spending in a calendar month
+	PeriodMonth = Period("month")
+)
+
+// Budget describes how much consumer allows to spend, zero limit means unlimited spending.
+type Budget struct {
+	PerSession      uint64 `json:"perSession"`
+	PerDay          uint64 `json:"perDay"`
+	PerMonth        uint64 `json:"perMonth"`
+	AlertThresholds []int  `json:"alertThresholds"`
+}
+
+// Validate checks whether the budget is valid.
+func (b Budget) Validate() error {
+	for _, threshold := range b.AlertThresholds {
+		if threshold == 0 || threshold >= 100 {
+			return fmt.Errorf("alert threshold %d%% is out of range, expected 1-99", threshold)
+		}
+	}
+	return nil
+}
+
+func (b Budget) thresholds() []int {
+	if len(b.AlertThresholds) == 0 {
+		return DefaultAlertThresholds
+	}
+	return b.AlertThresholds
+}
+
+// Spending represents the amount consumer spent in the current day and month.
+type Spending struct {
+	Day   uint64 `json:"day"`
+	Month uint64 `json:"month"`
+}
+
+// AlertEvent is published once consumer spends the given percent of the budget in the period.
+// Threshold of 100 means that the limit is reached and spending is refused.
+type AlertEvent struct {
+	Identity  identity.Identity `json:"identity"`
+	Period    Period            `json:"period"`
+	Threshold int               `json:"threshold"`
+	Limit     uint64            `json:"limit"`
+	Spent     uint64            `json:"spent"`
+}
+
+type persistentStorage interface {
+	GetValue(bucket string, key interface{}, to interface{}) error
+	SetValue(bucket string, key interface{}, to interface{}) error
+}
+
+// Limiter keeps track of consumer spending and enforces consumer budgets.
+type Limiter struct {
+	storage   persistentStorage
+	publisher eventbus.Publisher
+	clock     func() time.Time
+	lock      sync.Mutex
+}
+
+// NewLimiter returns a new instance of spending limiter.
+func NewLimiter(storage persistentStorage, publisher eventbus.Publisher) *Limiter {
+	return &Limiter{
+		storage:   storage,
+		publisher: publisher,
+		clock:     time.Now,
+	}
+}
+
+// Budget returns the budget of the given consumer.
+func (l *Limiter) Budget(id identity.Identity) (Budget, error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	return l.getBudget(id)
+}
+
+// SetBudget replaces the budget of the given consumer.
+func (l *Limiter) SetBudget(id identity.Identity, budget Budget) error {
+	if err := budget.Validate(); err != nil {
+		return err
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	return errors.Wrap(l.storage.SetValue(budgetBucketName, id.Address, budget), "could not store budget")
+}
+
+// Spending returns the amount spent by the given consumer in the current day and month.
+func (l *Limiter) Spending(id identity.Identity) (Spending, error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	return l.getSpending(id, l.clock())
+}
+
+// CanStart checks whether the given consumer is allowed to start a new session.
+func (l *Limiter) CanStart(id identity.Identity) error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	budget, err := l.getBudget(id)
+	if err != nil {
+		return err
+	}
+	spending, err := l.getSpending(id, l.clock())
+	if err != nil {
+		return err
+	}
+	if reached(budget.PerDay, spending.Day) || reached(budget.PerMonth, spending.Month) {
+		return ErrLimitReached
+	}
+	return nil
+}
+
+// Spend records the amount consumer is about to spend in the session.
+// Session spent is the amount already spent in the session.
+// Returns ErrLimitReached without recording the amount if it exceeds any of the consumer limits.
+func (l *Limiter) Spend(id identity.Identity, sessionSpent, amount uint64) error {
+	alerts, err := l.spend(id, sessionSpent, amount)
+	for _, alert := range alerts {
+		l.publisher.Publish(AppTopicSpendingAlert, alert)
+	}
+	return err
+}
+
+func (l *Limiter) spend(id identity.Identity, sessionSpent, amount uint64) ([]AlertEvent, error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	budget, err := l.getBudget(id)
+	if err != nil {
+		return nil, err
+	}
+	now := l.clock()
+	spending, err := l.getSpending(id, now)
+	if err != nil {
+		return nil, err
+	}
+
+	usages := []AlertEvent{
+		{Identity: id, Period: PeriodSession, Limit: budget.PerSession, Spent: sessionSpent},
+		{Identity: id, Period: PeriodDay, Limit: budget.PerDay, Spent: spending.Day},
+		{Identity: id, Period: PeriodMonth, Limit: budget.PerMonth, Spent: spending.Month},
+	}
+	for _, usage := range usages {
+		if usage.Limit > 0 && usage.Spent+amount > usage.Limit {
+			usage.Threshold = 100
+			return []AlertEvent{usage}, ErrLimitReached
+		}
+	}
+
+	if err := l.storeSpending(id, now, Spending{Day: spending.Day + amount, Month: spending.Month + amount}); err != nil {
+		return nil, err
+	}
+
+	var alerts []AlertEvent
+	for _, usage := range usages {
+		for _, threshold := range crossedThresholds(budget.thresholds(), usage.Limit, usage.Spent, usage.Spent+amount) {
+			alert := usage
+			alert.Threshold = threshold
+			alert.Spent += amount
+			alerts = append(alerts, alert)
+		}
+	}
+	return alerts, nil
+}
+
+func (l *Limiter) getBudget(id identity.Identity) (Budget, error) {
+	var budget Budget
+	err := l.storage.GetValue(budgetBucketName, id.Address, &budget)
+	if err != nil && err.Error() != errBoltNotFound {
+		return Budget{}, errors.Wrap(err, "could not get budget")
+	}
+	return budget, nil
+}
+
+func (l *Limiter) getSpending(id identity.Identity, now time.Time) (spending Spending, err error) {
+	if spending.Day, err = l.getSpent(dayKey(id, now)); err != nil {
+		return spending, err
+	}
+	spending.Month, err = l.getSpent(monthKey(id, now))
+	return spending, err
+}
+
+func (l *Limiter) getSpent(key string) (uint64, error) {
+	var spent uint64
+	err := l.storage.GetValue(spendingBucketName, key, &spent)
+	if err != nil && err.Error() != errBoltNotFound {
+		return 0, errors.Wrap(err, "could not get spending")
+	}
+	return spent, nil
+}
+
+func (l *Limiter) storeSpending(id identity.Identity, now time.Time, spending Spending) error {
+	if err := l.storage.SetValue(spendingBucketName, dayKey(id, now), spending.Day); err != nil {
+		return errors.Wrap(err, "could not store spending")
+	}
+	return errors.Wrap(l.storage.SetValue(spendingBucketName, monthKey(id, now), spending.Month), "could not store spending")
+}
+
+func dayKey(id identity.Identity, now time.Time) string {
+	return id.Address + "/" + now.UTC().Format("2006-01-02")
+}
+
+func monthKey(id identity.Identity, now time.Time) string {
+	return id.Address + "/" + now.UTC().Format("2006-01")
+}
+
+func reached(limit, spent uint64) bool {
+	return limit > 0 && spent >= limit
+}
+
+// crossedThresholds returns the thresholds passed by spending from before to after.
+func crossedThresholds(thresholds []int, limit, before, after uint64) []int {
+	if limit == 0 {
+		return nil
+	}
+	var crossed []int
+	for _, threshold := range thresholds {
+		mark := limit * uint64(threshold) / 100
+		if before < mark && after >= mark {
+			crossed = append(crossed, threshold)
+		}
+	}
+	return crossed
+}
@@ -0,0 +1,138 @@
+/*
+ * Copyright (C) 2020 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package budget
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/mysteriumnetwork/node/core/storage/boltdb"
+	"github.com/mysteriumnetwork/node/identity"
+	"github.com/mysteriumnetwork/node/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+var consumerID = identity.FromAddress("0x1")
+
+type mockPublisher struct {
+	alerts []AlertEvent
+}
+
+func (mp *mockPublisher) Publish(topic string, data interface{}) {
+	if topic == AppTopicSpendingAlert {
+		mp.alerts = append(mp.alerts, data.(AlertEvent))
+	}
+}
+
+func newTestLimiter(t *testing.T) (*Limiter, *mockPublisher, *utils.SettableClock, func()) {
+	dir, err := ioutil.TempDir("", "budgetTest")
+	assert.NoError(t, err)
+	bolt, err := boltdb.NewStorage(dir)
+	assert.NoError(t, err)
+
+	publisher := &mockPublisher{}
+	clock := &utils.SettableClock{}
+	clock.SetTime(time.Date(2020, 2, 28, 12, 0, 0, 0, time.UTC))
+	limiter := NewLimiter(bolt, publisher)
+	limiter.clock = clock.GetTime
+
+	return limiter, publisher, clock, func() {
+		bolt.Close()
+		os.RemoveAll(dir)
+	}
+}
+
+func TestLimiter_Budget(t *testing.T) {
+	limiter, _, _, cleanup := newTestLimiter(t)
+	defer cleanup()
+
+	budget, err := limiter.Budget(consumerID)
+	assert.NoError(t, err)
+	assert.Equal(t, Budget{}, budget)
+
+	budget = Budget{PerSession: 10, PerDay: 100, PerMonth: 1000, AlertThresholds: []int{50, 90}}
+	assert.NoError(t, limiter.SetBudget(consumerID, budget))
+	stored, err := limiter.Budget(consumerID)
+	assert.NoError(t, err)
+	assert.Equal(t, budget, stored)
+
+	assert.Error(t, limiter.SetBudget(consumerID, Budget{AlertThresholds: []int{100}}))
+}
+
+func TestLimiter_SpendWithoutBudget(t *testing.T) {
+	limiter, publisher, _, cleanup := newTestLimiter(t)
+	defer cleanup()
+
+	assert.NoError(t, limiter.Spend(consumerID, 0, 1000))
+	assert.NoError(t, limiter.CanStart(consumerID))
+	spending, err := limiter.Spending(consumerID)
+	assert.NoError(t, err)
+	assert.Equal(t, Spending{Day: 1000, Month: 1000}, spending)
+	assert.Len(t, publisher.alerts, 0)
+}
+
+func TestLimiter_SessionLimit(t *testing.T) {
+	limiter, publisher, _, cleanup := newTestLimiter(t)
+	defer cleanup()
+	assert.NoError(t, limiter.SetBudget(consumerID, Budget{PerSession: 100}))
+
+	assert.NoError(t, limiter.Spend(consumerID, 0, 60))
+	assert.NoError(t, limiter.Spend(consumerID, 60, 30))
+	assert.Equal(t, []AlertEvent{
+		{Identity: consumerID, Period: PeriodSession, Threshold: 80, Limit: 100, Spent: 90},
+	}, publisher.alerts)
+
+	assert.Equal(t, ErrLimitReached, limiter.Spend(consumerID, 90, 20))
+	assert.Equal(t, AlertEvent{Identity: consumerID, Period: PeriodSession, Threshold: 100, Limit: 100, Spent: 90}, publisher.alerts[1])
+
+	// session limit does not prevent new sessions
+	assert.NoError(t, limiter.CanStart(consumerID))
+	spending, err := limiter.Spending(consumerID)
+	assert.NoError(t, err)
+	assert.Equal(t, Spending{Day: 90, Month: 90}, spending)
+}
+
+func TestLimiter_DayAndMonthLimits(t *testing.T) {
+	limiter, publisher, clock, cleanup := newTestLimiter(t)
+	defer cleanup()
+	assert.NoError(t, limiter.SetBudget(consumerID, Budget{PerDay: 100, PerMonth: 150, AlertThresholds: []int{50}}))
+
+	assert.NoError(t, limiter.Spend(consumerID, 0, 100))
+	assert.Equal(t, []AlertEvent{
+		{Identity: consumerID, Period: PeriodDay, Threshold: 50, Limit: 100, Spent: 100},
+		{Identity: consumerID, Period: PeriodMonth, Threshold: 50, Limit: 150, Spent: 100},
+	}, publisher.alerts)
+	assert.Equal(t, ErrLimitReached, limiter.CanStart(consumerID))
+
+	// next day spending starts over, but month limit still applies
+	clock.AddTime(24 * time.Hour)
+	assert.NoError(t, limiter.CanStart(consumerID))
+	assert.Equal(t, ErrLimitReached, limiter.Spend(consumerID, 0, 60))
+	assert.Equal(t, AlertEvent{Identity: consumerID, Period: PeriodMonth, Threshold: 100, Limit: 150, Spent: 100}, publisher.alerts[2])
+	assert.NoError(t, limiter.Spend(consumerID, 0, 50))
+	assert.Equal(t, ErrLimitReached, limiter.CanStart(consumerID))
+
+	// the 29th of February 2020 was followed by a new month
+	clock.AddTime(24 * time.Hour)
+	assert.NoError(t, limiter.CanStart(consumerID))
+	spending, err := limiter.Spending(consumerID)
+	assert.NoError(t, err)
+	assert.Equal(t, Spending{}, spending)
+}
@@ -0,0 +1,148 @@
+/*
+ * Copyright (C) 2020 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package audit
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const bucketName = "tequilapi_audit"
+
+var errBoltNotFound = "not found"
+
+// Record is a single API request recorded in the audit log.
+type Record struct {
+	ID       int `storm:"id,increment"`
+	Time     time.Time
+	Username string
+	Role     string
+	// RemoteAddr is the address the request came from, empty for requests via unix domain socket.
+	RemoteAddr string
+	Method     string
+	Path       string
+	// Params are query and body parameters of the request with the secrets redacted.
+	Params   map[string]interface{}
+	Status   int
+	Duration time.Duration
+}
+
+// Filter narrows down the audit records, zero values match everything.
+type Filter struct {
+	Username   string
+	Method     string
+	PathPrefix string
+	From       time.Time
+	To         time.Time
+	// Limit returns only the given number of latest records if positive.
+	Limit int
+}
+
+// Matches checks if the record satisfies the filter.
+func (f Filter) Matches(r Record) bool {
+	if f.Username != "" && f.Username != r.Username {
+		return false
+	}
+	if f.Method != "" && !strings.EqualFold(f.Method, r.Method) {
+		return false
+	}
+	if f.PathPrefix != "" && !strings.HasPrefix(r.Path, f.PathPrefix) {
+		return false
+	}
+	if !f.From.IsZero() && r.Time.Before(f.From) {
+		return false
+	}
+	if !f.To.IsZero() && !r.Time.Before(f.To) {
+		return false
+	}
+	return true
+}
+
+type storage interface {
+	Store(bucket string, data interface{}) error
+	GetAllFrom(bucket string, data interface{}) error
+	Delete(bucket string, data interface{}) error
+}
+
+// Log keeps the latest API requests, removing the oldest record once the limit is reached.
+type Log struct {
+	storage    storage
+	maxRecords int
+	clock      func() time.Time
+	lock       sync.Mutex
+}
+
+// NewLog returns a new audit log keeping up to maxRecords latest records in the given storage.
+func NewLog(storage storage, maxRecords int) *Log {
+	return &Log{
+		storage:    storage,
+		maxRecords: maxRecords,
+		clock:      time.Now,
+	}
+}
+
+// Record appends the record to the log.
+func (l *Log) Record(record Record) error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	record.ID = 0
+	if record.Time.IsZero() {
+		record.Time = l.clock()
+	}
+	record.Time = record.Time.UTC()
+	if err := l.storage.Store(bucketName, &record); err != nil {
+		return errors.Wrap(err, "could not record audit record")
+	}
+
+	// IDs are sequential, so the record which fell out of the limit is removed
+	if expired := record.ID - l.maxRecords; l.maxRecords > 0 && expired > 0 {
+		err := l.storage.Delete(bucketName, &Record{ID: expired})
+		if err != nil && err.Error() != errBoltNotFound {
+			return errors.Wrap(err, "could not remove expired audit record")
+		}
+	}
+	return nil
+}
+
+// Records returns the records matching the filter, latest first.
+func (l *Log) Records(filter Filter) ([]Record, error) {
+	var all []Record
+	err := l.storage.GetAllFrom(bucketName, &all)
+	if err != nil && err.Error() != errBoltNotFound {
+		return nil, errors.Wrap(err, "could not get audit records")
+	}
+
+	result := make([]Record, 0, len(all))
+	for _, r := range all {
+		if filter.Matches(r) {
+			result = append(result, r)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].ID > result[j].ID
+	})
+	if filter.Limit > 0 && len(result) > filter.Limit {
+		result = result[:filter.Limit]
+	}
+	return result, nil
+}
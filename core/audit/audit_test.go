@@ -0,0 +1,119 @@
+/*
+ * Copyright (C) 2020 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package audit
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/mysteriumnetwork/node/core/storage/boltdb"
+	"github.com/mysteriumnetwork/node/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestLog(t *testing.T, maxRecords int) (*Log, *utils.SettableClock, func()) {
+	dir, err := ioutil.TempDir("", "auditTest")
+	assert.NoError(t, err)
+	bolt, err := boltdb.NewStorage(dir)
+	assert.NoError(t, err)
+
+	clock := &utils.SettableClock{}
+	clock.SetTime(time.Date(2020, 3, 1, 12, 0, 0, 0, time.UTC))
+	log := NewLog(bolt, maxRecords)
+	log.clock = clock.GetTime
+
+	return log, clock, func() {
+		bolt.Close()
+		os.RemoveAll(dir)
+	}
+}
+
+func TestLog_Empty(t *testing.T) {
+	log, _, cleanup := newTestLog(t, 10)
+	defer cleanup()
+
+	records, err := log.Records(Filter{})
+	assert.NoError(t, err)
+	assert.Len(t, records, 0)
+}
+
+func TestLog_RecordsLatestFirst(t *testing.T) {
+	log, clock, cleanup := newTestLog(t, 10)
+	defer cleanup()
+
+	assert.NoError(t, log.Record(Record{Username: "myst", Method: "POST", Path: "/services", Status: 201}))
+	clock.AddTime(time.Minute)
+	assert.NoError(t, log.Record(Record{Username: "myst", Method: "DELETE", Path: "/services/1", Status: 202}))
+
+	records, err := log.Records(Filter{})
+	assert.NoError(t, err)
+	assert.Len(t, records, 2)
+	assert.Equal(t, "/services/1", records[0].Path)
+	assert.Equal(t, time.Date(2020, 3, 1, 12, 1, 0, 0, time.UTC), records[0].Time)
+	assert.Equal(t, "/services", records[1].Path)
+	assert.Equal(t, time.Date(2020, 3, 1, 12, 0, 0, 0, time.UTC), records[1].Time)
+}
+
+func TestLog_RemovesOldestRecords(t *testing.T) {
+	log, _, cleanup := newTestLog(t, 3)
+	defer cleanup()
+
+	for _, path := range []string{"/1", "/2", "/3", "/4", "/5"} {
+		assert.NoError(t, log.Record(Record{Method: "POST", Path: path}))
+	}
+
+	records, err := log.Records(Filter{})
+	assert.NoError(t, err)
+	assert.Len(t, records, 3)
+	assert.Equal(t, "/5", records[0].Path)
+	assert.Equal(t, "/3", records[2].Path)
+}
+
+func TestLog_Filter(t *testing.T) {
+	log, clock, cleanup := newTestLog(t, 10)
+	defer cleanup()
+
+	assert.NoError(t, log.Record(Record{Username: "myst", Method: "POST", Path: "/services"}))
+	clock.AddTime(time.Hour)
+	assert.NoError(t, log.Record(Record{Username: "admin", Method: "PUT", Path: "/access-policies"}))
+	clock.AddTime(time.Hour)
+	assert.NoError(t, log.Record(Record{Username: "myst", Method: "DELETE", Path: "/services/1"}))
+
+	tests := []struct {
+		filter Filter
+		paths  []string
+	}{
+		{Filter{Username: "myst"}, []string{"/services/1", "/services"}},
+		{Filter{Method: "put"}, []string{"/access-policies"}},
+		{Filter{PathPrefix: "/services"}, []string{"/services/1", "/services"}},
+		{Filter{From: time.Date(2020, 3, 1, 13, 0, 0, 0, time.UTC)}, []string{"/services/1", "/access-policies"}},
+		{Filter{To: time.Date(2020, 3, 1, 13, 0, 0, 0, time.UTC)}, []string{"/services"}},
+		{Filter{Limit: 1}, []string{"/services/1"}},
+	}
+	for _, tt := range tests {
+		records, err := log.Records(tt.filter)
+		assert.NoError(t, err)
+		paths := make([]string, len(records))
+		for i, r := range records {
+			paths[i] = r.Path
+		}
+		assert.Equal(t, tt.paths, paths, "filter %+v", tt.filter)
+	}
+}
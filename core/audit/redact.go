@@ -0,0 +1,62 @@
+/*
+ * Copyright (C) 2020 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package audit
+
+import (
+	"strings"
+)
+
+// Redacted replaces values of the secret parameters.
+const Redacted = "[REDACTED]"
+
+// secretParams are parts of parameter names which hold secrets, compared case insensitively
+var secretParams = []string{"pass", "secret", "token", "key", "blob", "signature"}
+
+// IsSecret checks if the parameter of the given name holds a secret.
+func IsSecret(name string) bool {
+	name = strings.ToLower(name)
+	for _, secret := range secretParams {
+		if strings.Contains(name, secret) {
+			return true
+		}
+	}
+	return false
+}
+
+// Redact returns a copy of the decoded JSON value with the values of secret parameters replaced, nested objects included.
+func Redact(value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(value))
+		for name, item := range value {
+			if IsSecret(name) {
+				result[name] = Redacted
+			} else {
+				result[name] = Redact(item)
+			}
+		}
+		return result
+	case []interface{}:
+		result := make([]interface{}, len(value))
+		for i, item := range value {
+			result[i] = Redact(item)
+		}
+		return result
+	}
+	return value
+}
@@ -0,0 +1,60 @@
+/*
+ * Copyright (C) 2020 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package audit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsSecret(t *testing.T) {
+	assert.True(t, IsSecret("passphrase"))
+	assert.True(t, IsSecret("newPassword"))
+	assert.True(t, IsSecret("API_TOKEN"))
+	assert.True(t, IsSecret("privateKey"))
+	assert.False(t, IsSecret("providerId"))
+	assert.False(t, IsSecret("type"))
+}
+
+func TestRedact(t *testing.T) {
+	value := map[string]interface{}{
+		"providerId": "0x1",
+		"passphrase": "secret",
+		"options": map[string]interface{}{
+			"token": "abc",
+			"port":  float64(1194),
+		},
+		"users": []interface{}{
+			map[string]interface{}{"username": "myst", "password": "mystberry"},
+		},
+	}
+
+	assert.Equal(t, map[string]interface{}{
+		"providerId": "0x1",
+		"passphrase": Redacted,
+		"options": map[string]interface{}{
+			"token": Redacted,
+			"port":  float64(1194),
+		},
+		"users": []interface{}{
+			map[string]interface{}{"username": "myst", "password": Redacted},
+		},
+	}, Redact(value))
+	assert.Equal(t, "secret", value["passphrase"])
+}
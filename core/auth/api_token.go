@@ -0,0 +1,225 @@
+/*
+ * Copyright (C) 2020 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package auth
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mysteriumnetwork/node/core/storage"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	apiTokensDBBucket = "api-tokens"
+	// APITokenPrefix distinguishes API tokens from session JWT tokens
+	APITokenPrefix = "myst_"
+)
+
+var (
+	// ErrAPITokenNotFound API token does not exist
+	ErrAPITokenNotFound = errors.New("API token not found")
+	// ErrAPITokenExpired API token is expired
+	ErrAPITokenExpired = errors.New("API token expired")
+	// ErrAPITokenScope API token scope exceeds the role of its owner
+	ErrAPITokenScope = errors.New("API token scope exceeds the role of its owner")
+	// ErrAPITokenOwner API token is not owned by any user
+	ErrAPITokenOwner = errors.New("API token has to be owned by a user")
+)
+
+// APIToken is a long-lived, named and revocable token for headless access to Tequilapi.
+// Only the hash of the token secret is stored.
+type APIToken struct {
+	ID         string `storm:"id"`
+	Name       string
+	Owner      string
+	Scope      Role
+	SecretHash string
+	CreatedAt  time.Time
+	ExpiresAt  time.Time
+}
+
+// Expired checks if the token is expired at the given time, tokens without expiry never expire
+func (t APIToken) Expired(at time.Time) bool {
+	return !t.ExpiresAt.IsZero() && !at.Before(t.ExpiresAt)
+}
+
+// APITokens creates, lists, revokes and authenticates API tokens
+type APITokens struct {
+	storage UserStorage
+	users   userGetter
+	now     func() time.Time
+	lock    sync.Mutex
+}
+
+type userGetter interface {
+	User(username string) (User, error)
+}
+
+// NewAPITokens creates API token manager, token owners are looked up in the given users
+func NewAPITokens(storage UserStorage, users userGetter) *APITokens {
+	return &APITokens{
+		storage: storage,
+		users:   users,
+		now:     time.Now,
+	}
+}
+
+// Create issues a new token with the scope not exceeding the role of owner.
+// Returned token string is the only chance to see it, as only its hash is kept.
+func (a *APITokens) Create(name string, owner User, scope Role, expiresAt time.Time) (string, APIToken, error) {
+	if !scope.Valid() {
+		return "", APIToken{}, ErrInvalidRole
+	}
+	if owner.Username == "" {
+		return "", APIToken{}, ErrAPITokenOwner
+	}
+	if !owner.Role.Covers(scope) {
+		return "", APIToken{}, ErrAPITokenScope
+	}
+
+	id, err := generateRandomBytes(8)
+	if err != nil {
+		return "", APIToken{}, errors.Wrap(err, "failed to generate API token ID")
+	}
+	secret, err := generateRandomBytes(32)
+	if err != nil {
+		return "", APIToken{}, errors.Wrap(err, "failed to generate API token secret")
+	}
+
+	token := APIToken{
+		ID:         hex.EncodeToString(id),
+		Name:       name,
+		Owner:      owner.Username,
+		Scope:      scope,
+		SecretHash: hashSecret(hex.EncodeToString(secret)),
+		CreatedAt:  a.now().UTC(),
+	}
+	if !expiresAt.IsZero() {
+		token.ExpiresAt = expiresAt.UTC()
+	}
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	if err := a.storage.Store(apiTokensDBBucket, &token); err != nil {
+		return "", APIToken{}, errors.Wrap(err, "unable to store API token")
+	}
+	log.Info().Msgf("API token %q (%s) created with scope %s", name, token.ID, scope)
+	return APITokenPrefix + token.ID + "_" + hex.EncodeToString(secret), token, nil
+}
+
+// List returns all tokens
+func (a *APITokens) List() ([]APIToken, error) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	var tokens []APIToken
+	err := a.storage.GetAllFrom(apiTokensDBBucket, &tokens)
+	if err == storage.ErrNotFound {
+		return nil, nil
+	}
+	return tokens, errors.Wrap(err, "unable to load API tokens")
+}
+
+// Get returns the token with the given ID
+func (a *APITokens) Get(id string) (APIToken, error) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	return a.get(id)
+}
+
+// Revoke deletes the token with the given ID, so that it can't be used anymore
+func (a *APITokens) Revoke(id string) error {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	token, err := a.get(id)
+	if err != nil {
+		return err
+	}
+	if err := a.storage.Delete(apiTokensDBBucket, &token); err != nil {
+		return errors.Wrap(err, "unable to delete API token")
+	}
+	log.Info().Msgf("API token %q (%s) revoked", token.Name, token.ID)
+	return nil
+}
+
+// AuthenticateAPIToken checks the token and returns the user it acts as.
+// Token acts with its scope, as long as its owner still exists and is permitted to grant that scope.
+func (a *APITokens) AuthenticateAPIToken(tokenString string) (User, error) {
+	id, secret, err := parseAPIToken(tokenString)
+	if err != nil {
+		return User{}, err
+	}
+
+	token, err := a.Get(id)
+	if err != nil {
+		return User{}, err
+	}
+	if subtle.ConstantTimeCompare([]byte(token.SecretHash), []byte(hashSecret(secret))) != 1 {
+		return User{}, ErrUnauthorized
+	}
+	if token.Expired(a.now()) {
+		return User{}, ErrAPITokenExpired
+	}
+
+	if token.Owner == "" {
+		return User{}, ErrAPITokenOwner
+	}
+	owner, err := a.users.User(token.Owner)
+	if err != nil {
+		return User{}, errors.Wrap(err, "API token owner is not valid")
+	}
+	if !owner.Role.Covers(token.Scope) {
+		return User{}, ErrAPITokenScope
+	}
+	return User{Username: owner.Username, Role: token.Scope}, nil
+}
+
+// IsAPIToken checks if the given token looks like an API token rather than a session JWT token
+func IsAPIToken(token string) bool {
+	return strings.HasPrefix(token, APITokenPrefix)
+}
+
+func (a *APITokens) get(id string) (APIToken, error) {
+	var token APIToken
+	err := a.storage.GetOneByField(apiTokensDBBucket, "ID", id, &token)
+	if err == storage.ErrNotFound {
+		return APIToken{}, ErrAPITokenNotFound
+	}
+	return token, errors.Wrap(err, "unable to load API token")
+}
+
+func parseAPIToken(token string) (id, secret string, err error) {
+	parts := strings.Split(strings.TrimPrefix(token, APITokenPrefix), "_")
+	if !IsAPIToken(token) || len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", errors.New("malformed API token")
+	}
+	return parts[0], parts[1], nil
+}
+
+func hashSecret(secret string) string {
+	hash := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(hash[:])
+}
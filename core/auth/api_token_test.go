@@ -0,0 +1,115 @@
+/*
+ * Copyright (C) 2020 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPITokens_CreateAndAuthenticate(t *testing.T) {
+	authenticator, db, cleanup := newTestAuthenticator(t)
+	defer cleanup()
+	tokens := NewAPITokens(db, authenticator)
+
+	owner, err := authenticator.User("myst")
+	assert.NoError(t, err)
+	secret, token, err := tokens.Create("dashboard", owner, RoleMonitor, time.Time{})
+	assert.NoError(t, err)
+	assert.True(t, IsAPIToken(secret))
+	assert.Equal(t, "myst", token.Owner)
+	assert.NotContains(t, token.SecretHash, secret)
+
+	user, err := tokens.AuthenticateAPIToken(secret)
+	assert.NoError(t, err)
+	assert.Equal(t, User{Username: "myst", Role: RoleMonitor}, user)
+
+	_, err = tokens.AuthenticateAPIToken(secret[:len(secret)-1] + "0")
+	assert.Error(t, err)
+	_, err = tokens.AuthenticateAPIToken("myst_broken")
+	assert.Error(t, err)
+
+	list, err := tokens.List()
+	assert.NoError(t, err)
+	assert.Len(t, list, 1)
+
+	assert.NoError(t, tokens.Revoke(token.ID))
+	_, err = tokens.AuthenticateAPIToken(secret)
+	assert.Equal(t, ErrAPITokenNotFound, err)
+	assert.Equal(t, ErrAPITokenNotFound, tokens.Revoke(token.ID))
+}
+
+func TestAPITokens_Expire(t *testing.T) {
+	authenticator, db, cleanup := newTestAuthenticator(t)
+	defer cleanup()
+	tokens := NewAPITokens(db, authenticator)
+
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	tokens.now = func() time.Time { return now }
+	secret, _, err := tokens.Create("script", User{Username: "myst", Role: RoleAdmin}, RoleConsumer, now.Add(time.Hour))
+	assert.NoError(t, err)
+
+	user, err := tokens.AuthenticateAPIToken(secret)
+	assert.NoError(t, err)
+	assert.Equal(t, RoleConsumer, user.Role)
+
+	now = now.Add(time.Hour)
+	_, err = tokens.AuthenticateAPIToken(secret)
+	assert.Equal(t, ErrAPITokenExpired, err)
+}
+
+func TestAPITokens_ScopeIsLimitedByOwner(t *testing.T) {
+	authenticator, db, cleanup := newTestAuthenticator(t)
+	defer cleanup()
+	tokens := NewAPITokens(db, authenticator)
+
+	owner, err := authenticator.CreateUser("operator", "secret", RoleProvider)
+	assert.NoError(t, err)
+	_, _, err = tokens.Create("script", owner, RoleConsumer, time.Time{})
+	assert.Equal(t, ErrAPITokenScope, err)
+
+	secret, _, err := tokens.Create("script", owner, RoleProvider, time.Time{})
+	assert.NoError(t, err)
+
+	_, err = authenticator.UpdateUser("operator", "", RoleMonitor)
+	assert.NoError(t, err)
+	_, err = tokens.AuthenticateAPIToken(secret)
+	assert.Equal(t, ErrAPITokenScope, err)
+
+	assert.NoError(t, authenticator.DeleteUser("operator"))
+	_, err = tokens.AuthenticateAPIToken(secret)
+	assert.Error(t, err)
+}
+
+func TestAPITokens_RequireOwner(t *testing.T) {
+	authenticator, db, cleanup := newTestAuthenticator(t)
+	defer cleanup()
+	tokens := NewAPITokens(db, authenticator)
+
+	_, _, err := tokens.Create("script", User{Role: RoleAdmin}, RoleAdmin, time.Time{})
+	assert.Equal(t, ErrAPITokenOwner, err)
+
+	secret, token, err := tokens.Create("script", User{Username: "myst", Role: RoleAdmin}, RoleAdmin, time.Time{})
+	assert.NoError(t, err)
+	token.Owner = ""
+	assert.NoError(t, db.Store(apiTokensDBBucket, &token))
+	_, err = tokens.AuthenticateAPIToken(secret)
+	assert.Equal(t, ErrAPITokenOwner, err)
+}
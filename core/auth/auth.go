@@ -17,15 +17,42 @@
 
 package auth
 
-import "github.com/rs/zerolog/log"
+import (
+	"sync"
+
+	"github.com/mysteriumnetwork/node/core/storage"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+const usersDBBucket = "app-users"
+
+// UserStorage for users and their credentials
+type UserStorage interface {
+	Storage
+	Store(bucket string, data interface{}) error
+	GetAllFrom(bucket string, data interface{}) error
+	GetOneByField(bucket string, fieldName string, key interface{}, to interface{}) error
+	Delete(bucket string, data interface{}) error
+}
+
+// User is allowed to access Tequilapi actions permitted by the role
+type User struct {
+	Username string `storm:"id" json:"username"`
+	Role     Role   `json:"role"`
+	// PasswordGeneration is increased on each password change to revoke the tokens issued with the previous password
+	PasswordGeneration int `json:"password_generation,omitempty"`
+}
 
 // Authenticator provides an authentication method for builtin UI.
 type Authenticator struct {
-	storage Storage
+	storage     UserStorage
+	lock        sync.Mutex
+	initialized bool
 }
 
 // NewAuthenticator creates an authenticator
-func NewAuthenticator(storage Storage) *Authenticator {
+func NewAuthenticator(storage UserStorage) *Authenticator {
 	return &Authenticator{
 		storage: storage,
 	}
@@ -33,17 +60,28 @@ func NewAuthenticator(storage Storage) *Authenticator {
 
 // CheckCredentials authenticates user by password
 func (a *Authenticator) CheckCredentials(username, password string) error {
+	if _, err := a.User(username); err != nil {
+		return errors.Wrap(err, "bad credentials")
+	}
 	return NewCredentials(username, password, a.storage).Validate()
 }
 
 // ChangePassword changes user password
 func (a *Authenticator) ChangePassword(username, oldPassword, newPassword string) (err error) {
-	err = NewCredentials(username, oldPassword, a.storage).Validate()
+	err = a.CheckCredentials(username, oldPassword)
 	if err != nil {
 		log.Info().Err(err).Msg("Bad credentials for changing password")
 		return ErrUnauthorized
 	}
-	err = NewCredentials(username, newPassword, a.storage).Set()
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	user, err := a.user(username)
+	if err != nil {
+		return err
+	}
+	err = a.setPassword(&user, newPassword)
 	if err != nil {
 		log.Info().Err(err).Msg("Error changing password")
 		return err
@@ -51,3 +89,185 @@ func (a *Authenticator) ChangePassword(username, oldPassword, newPassword string
 	log.Info().Msgf("%q user password changed successfully", username)
 	return nil
 }
+
+// User returns the user with the given username
+func (a *Authenticator) User(username string) (User, error) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	return a.user(username)
+}
+
+// Users returns all users
+func (a *Authenticator) Users() ([]User, error) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	return a.users()
+}
+
+// CreateUser creates a user with the given password and role
+func (a *Authenticator) CreateUser(username, password string, role Role) (User, error) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	if !role.Valid() {
+		return User{}, ErrInvalidRole
+	}
+	if _, err := a.user(username); err == nil {
+		return User{}, ErrUserExists
+	} else if err != ErrUserNotFound {
+		return User{}, err
+	}
+
+	if err := NewCredentials(username, password, a.storage).Set(); err != nil {
+		return User{}, err
+	}
+	user := User{Username: username, Role: role}
+	if err := a.storage.Store(usersDBBucket, &user); err != nil {
+		return User{}, errors.Wrap(err, "unable to store user")
+	}
+	log.Info().Msgf("%q user created with role %s", username, role)
+	return user, nil
+}
+
+// UpdateUser changes the role of user and the password if it is not empty
+func (a *Authenticator) UpdateUser(username, password string, role Role) (User, error) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	if !role.Valid() {
+		return User{}, ErrInvalidRole
+	}
+	user, err := a.user(username)
+	if err != nil {
+		return User{}, err
+	}
+	if user.Role == RoleAdmin && role != RoleAdmin {
+		if err := a.checkNotLastAdmin(); err != nil {
+			return User{}, err
+		}
+	}
+
+	if password != "" {
+		if err := a.setPassword(&user, password); err != nil {
+			return User{}, err
+		}
+	}
+	user.Role = role
+	if err := a.storage.Store(usersDBBucket, &user); err != nil {
+		return User{}, errors.Wrap(err, "unable to store user")
+	}
+	log.Info().Msgf("%q user updated with role %s", username, role)
+	return user, nil
+}
+
+// DeleteUser deletes the user and its credentials
+func (a *Authenticator) DeleteUser(username string) error {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	user, err := a.user(username)
+	if err != nil {
+		return err
+	}
+	if user.Role == RoleAdmin {
+		if err := a.checkNotLastAdmin(); err != nil {
+			return err
+		}
+	}
+
+	if err := NewCredentials(username, "", a.storage).clear(); err != nil {
+		return err
+	}
+	if err := a.storage.Delete(usersDBBucket, &user); err != nil {
+		return errors.Wrap(err, "unable to delete user")
+	}
+	log.Info().Msgf("%q user deleted", username)
+	return nil
+}
+
+func (a *Authenticator) user(username string) (User, error) {
+	if err := a.initialize(); err != nil {
+		return User{}, err
+	}
+
+	var user User
+	err := a.storage.GetOneByField(usersDBBucket, "Username", username, &user)
+	if err == storage.ErrNotFound {
+		return User{}, ErrUserNotFound
+	}
+	return user, errors.Wrap(err, "unable to load user")
+}
+
+// setPassword stores the new password of user and revokes the tokens issued with the previous one
+func (a *Authenticator) setPassword(user *User, password string) error {
+	if err := NewCredentials(user.Username, password, a.storage).Set(); err != nil {
+		return err
+	}
+	user.PasswordGeneration++
+	return errors.Wrap(a.storage.Store(usersDBBucket, user), "unable to store user")
+}
+
+func (a *Authenticator) users() ([]User, error) {
+	if err := a.initialize(); err != nil {
+		return nil, err
+	}
+
+	var users []User
+	err := a.storage.GetAllFrom(usersDBBucket, &users)
+	return users, errors.Wrap(err, "unable to load users")
+}
+
+func (a *Authenticator) checkNotLastAdmin() error {
+	users, err := a.users()
+	if err != nil {
+		return err
+	}
+
+	admins := 0
+	for _, user := range users {
+		if user.Role == RoleAdmin {
+			admins++
+		}
+	}
+	if admins <= 1 {
+		return ErrLastAdmin
+	}
+	return nil
+}
+
+// initialize creates the default admin user when there are no users yet.
+// Password of the default user is kept if it was changed before the users were introduced.
+func (a *Authenticator) initialize() error {
+	if a.initialized {
+		return nil
+	}
+
+	var users []User
+	err := a.storage.GetAllFrom(usersDBBucket, &users)
+	if err != nil && err != storage.ErrNotFound {
+		return errors.Wrap(err, "unable to load users")
+	}
+	if len(users) > 0 {
+		a.initialized = true
+		return nil
+	}
+
+	credentials := NewCredentials(defaultUsername, initialPassword, a.storage)
+	exists, err := credentials.exists()
+	if err != nil {
+		return errors.Wrap(err, "could not load credentials")
+	}
+	if !exists {
+		log.Info().Msg("Credentials not found, initializing to default")
+		if err := credentials.Set(); err != nil {
+			return errors.Wrap(err, "failed to set initial credentials")
+		}
+	}
+	if err := a.storage.Store(usersDBBucket, &User{Username: defaultUsername, Role: RoleAdmin}); err != nil {
+		return errors.Wrap(err, "failed to store initial user")
+	}
+	a.initialized = true
+	return nil
+}
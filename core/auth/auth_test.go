@@ -0,0 +1,112 @@
+/*
+ * Copyright (C) 2020 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package auth
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/mysteriumnetwork/node/core/storage/boltdb"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestAuthenticator(t *testing.T) (*Authenticator, *boltdb.Bolt, func()) {
+	dir, err := ioutil.TempDir("", "authTest")
+	assert.NoError(t, err)
+	db, err := boltdb.NewStorage(dir)
+	assert.NoError(t, err)
+
+	return NewAuthenticator(db), db, func() {
+		db.Close()
+		os.RemoveAll(dir)
+	}
+}
+
+func TestAuthenticator_DefaultUserIsAdmin(t *testing.T) {
+	authenticator, _, cleanup := newTestAuthenticator(t)
+	defer cleanup()
+
+	assert.NoError(t, authenticator.CheckCredentials("myst", "mystberry"))
+	assert.Error(t, authenticator.CheckCredentials("myst", "wrong"))
+	assert.Error(t, authenticator.CheckCredentials("other", "mystberry"))
+
+	users, err := authenticator.Users()
+	assert.NoError(t, err)
+	assert.Equal(t, []User{{Username: "myst", Role: RoleAdmin}}, users)
+}
+
+func TestAuthenticator_DefaultUserKeepsChangedPassword(t *testing.T) {
+	authenticator, db, cleanup := newTestAuthenticator(t)
+	defer cleanup()
+	assert.NoError(t, NewCredentials("myst", "changed", db).Set())
+
+	assert.NoError(t, authenticator.CheckCredentials("myst", "changed"))
+	assert.Error(t, authenticator.CheckCredentials("myst", "mystberry"))
+}
+
+func TestAuthenticator_ManagesUsers(t *testing.T) {
+	authenticator, _, cleanup := newTestAuthenticator(t)
+	defer cleanup()
+
+	user, err := authenticator.CreateUser("noc", "secret", RoleMonitor)
+	assert.NoError(t, err)
+	assert.Equal(t, User{Username: "noc", Role: RoleMonitor}, user)
+	assert.NoError(t, authenticator.CheckCredentials("noc", "secret"))
+
+	_, err = authenticator.CreateUser("noc", "secret", RoleMonitor)
+	assert.Equal(t, ErrUserExists, err)
+	_, err = authenticator.CreateUser("bad", "secret", Role("root"))
+	assert.Equal(t, ErrInvalidRole, err)
+
+	user, err = authenticator.UpdateUser("noc", "", RoleProvider)
+	assert.NoError(t, err)
+	assert.Equal(t, RoleProvider, user.Role)
+	assert.NoError(t, authenticator.CheckCredentials("noc", "secret"))
+
+	user, err = authenticator.UpdateUser("noc", "changed", RoleProvider)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, user.PasswordGeneration)
+	assert.NoError(t, authenticator.CheckCredentials("noc", "changed"))
+
+	assert.NoError(t, authenticator.ChangePassword("noc", "changed", "again"))
+	assert.NoError(t, authenticator.CheckCredentials("noc", "again"))
+	user, err = authenticator.User("noc")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, user.PasswordGeneration)
+
+	assert.NoError(t, authenticator.DeleteUser("noc"))
+	assert.Error(t, authenticator.CheckCredentials("noc", "again"))
+	_, err = authenticator.User("noc")
+	assert.Equal(t, ErrUserNotFound, err)
+	assert.Equal(t, ErrUserNotFound, authenticator.DeleteUser("noc"))
+}
+
+func TestAuthenticator_KeepsLastAdmin(t *testing.T) {
+	authenticator, _, cleanup := newTestAuthenticator(t)
+	defer cleanup()
+
+	assert.Equal(t, ErrLastAdmin, authenticator.DeleteUser("myst"))
+	_, err := authenticator.UpdateUser("myst", "", RoleMonitor)
+	assert.Equal(t, ErrLastAdmin, err)
+
+	_, err = authenticator.CreateUser("root", "secret", RoleAdmin)
+	assert.NoError(t, err)
+	assert.NoError(t, authenticator.DeleteUser("myst"))
+	assert.Error(t, authenticator.CheckCredentials("myst", "mystberry"), "deleted default user must not be restored")
+}
@@ -20,7 +20,6 @@ package auth
 import (
 	"github.com/mysteriumnetwork/node/core/storage"
 	"github.com/pkg/errors"
-	"github.com/rs/zerolog/log"
 	"golang.org/x/crypto/bcrypt"
 )
 
@@ -31,12 +30,12 @@ type Storage interface {
 }
 
 const (
-	username            = "myst"
+	defaultUsername     = "myst"
 	initialPassword     = "mystberry"
 	credentialsDBBucket = "app-credentials"
 )
 
-// Credentials verifies/sets user credentials for web UI
+// Credentials verifies/sets user credentials for Tequilapi and web UI
 type Credentials struct {
 	username, password string
 	db                 Storage
@@ -54,13 +53,13 @@ func NewCredentials(username, password string, db Storage) *Credentials {
 // Validate username and password against stored Credentials
 func (credentials *Credentials) Validate() (err error) {
 	var storedHash string
-	storedHash, err = credentials.loadOrInitialize()
+	err = credentials.db.GetValue(credentialsDBBucket, credentials.username, &storedHash)
+	if err == storage.ErrNotFound || (err == nil && storedHash == "") {
+		return errors.New("bad credentials")
+	}
 	if err != nil {
 		return errors.Wrap(err, "could not load credentials")
 	}
-	if credentials.username != username {
-		return errors.New("bad credentials")
-	}
 	err = bcrypt.CompareHashAndPassword([]byte(storedHash), []byte(credentials.password))
 	if err != nil {
 		return errors.Wrap(err, "bad credentials")
@@ -68,18 +67,19 @@ func (credentials *Credentials) Validate() (err error) {
 	return nil
 }
 
-func (credentials *Credentials) loadOrInitialize() (s string, err error) {
+// exists checks if credentials of the user are stored
+func (credentials *Credentials) exists() (bool, error) {
 	var storedHash string
-	err = credentials.db.GetValue(credentialsDBBucket, username, &storedHash)
+	err := credentials.db.GetValue(credentialsDBBucket, credentials.username, &storedHash)
 	if err == storage.ErrNotFound {
-		log.Info().Msg("Credentials not found, initializing to default")
-		err = NewCredentials(username, initialPassword, credentials.db).Set()
-		if err != nil {
-			return "", errors.Wrap(err, "failed to set initial credentials")
-		}
-		err = credentials.db.GetValue(credentialsDBBucket, username, &storedHash)
+		return false, nil
 	}
-	return storedHash, err
+	return err == nil && storedHash != "", err
+}
+
+// clear removes the stored password hash, so that the credentials can't be validated anymore
+func (credentials *Credentials) clear() error {
+	return errors.Wrap(credentials.db.SetValue(credentialsDBBucket, credentials.username, ""), "unable to clear credentials")
 }
 
 // Set new credentials
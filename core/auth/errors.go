@@ -22,4 +22,12 @@ import "github.com/pkg/errors"
 var (
 	// ErrUnauthorized unauthorized
 	ErrUnauthorized = errors.New("unauthorized")
+	// ErrUserNotFound user does not exist
+	ErrUserNotFound = errors.New("user not found")
+	// ErrUserExists user with the same username already exists
+	ErrUserExists = errors.New("user already exists")
+	// ErrInvalidRole role is unknown
+	ErrInvalidRole = errors.New("invalid role")
+	// ErrLastAdmin the only admin can't be removed or demoted
+	ErrLastAdmin = errors.New("at least one admin user is required")
 )
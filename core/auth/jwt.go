@@ -42,7 +42,9 @@ type JWTEncryptionKey []byte
 const JWTCookieName string = "token"
 
 type jwtClaims struct {
-	Username string `json:"username"`
+	Username           string `json:"username"`
+	Role               Role   `json:"role"`
+	PasswordGeneration int    `json:"pwd_gen,omitempty"`
 	jwt.StandardClaims
 }
 
@@ -57,11 +59,13 @@ func NewJWTAuthenticator(encryptionKey JWTEncryptionKey) *JWTAuthenticator {
 	return auth
 }
 
-// CreateToken creates a new JWT token
-func (jwtAuth *JWTAuthenticator) CreateToken(username string) (JWT, error) {
+// CreateToken creates a new JWT token carrying the role and password generation of user
+func (jwtAuth *JWTAuthenticator) CreateToken(user User) (JWT, error) {
 	expirationTime := jwtAuth.getExpirationTime()
 	claims := &jwtClaims{
-		Username: username,
+		Username:           user.Username,
+		Role:               user.Role,
+		PasswordGeneration: user.PasswordGeneration,
 		StandardClaims: jwt.StandardClaims{
 			ExpiresAt: expirationTime.Unix(),
 		},
@@ -78,20 +82,29 @@ func (jwtAuth *JWTAuthenticator) CreateToken(username string) (JWT, error) {
 
 // ValidateToken validates a JWT token
 func (jwtAuth *JWTAuthenticator) ValidateToken(token string) (bool, error) {
+	if _, err := jwtAuth.ParseToken(token); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// ParseToken validates a JWT token and returns the user it was issued to
+func (jwtAuth *JWTAuthenticator) ParseToken(token string) (User, error) {
 	claims := &jwtClaims{}
 
 	tkn, err := jwt.ParseWithClaims(token, claims, func(token *jwt.Token) (interface{}, error) {
 		return jwtAuth.encryptionKey, nil
 	})
 	if err != nil {
-		return false, err
+		return User{}, err
 	}
 
 	if tkn == nil || !tkn.Valid {
-		return false, errors.New("invalid JWT token")
+		return User{}, errors.New("invalid JWT token")
 	}
 
-	return true, nil
+	return User{Username: claims.Username, Role: claims.Role, PasswordGeneration: claims.PasswordGeneration}, nil
 }
 
 func (jwtAuth *JWTAuthenticator) getExpirationTime() time.Time {
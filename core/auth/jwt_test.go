@@ -0,0 +1,49 @@
+/*
+ * Copyright (C) 2020 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package auth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJWTAuthenticator_TokenCarriesRole(t *testing.T) {
+	jwtAuth := NewJWTAuthenticator([]byte("key"))
+
+	token, err := jwtAuth.CreateToken(User{Username: "noc", Role: RoleMonitor, PasswordGeneration: 2})
+	assert.NoError(t, err)
+
+	user, err := jwtAuth.ParseToken(token.Token)
+	assert.NoError(t, err)
+	assert.Equal(t, User{Username: "noc", Role: RoleMonitor, PasswordGeneration: 2}, user)
+
+	_, err = NewJWTAuthenticator([]byte("other key")).ParseToken(token.Token)
+	assert.Error(t, err)
+}
+
+func TestRole_Allows(t *testing.T) {
+	assert.True(t, RoleMonitor.Allows(PermissionRead))
+	assert.False(t, RoleMonitor.Allows(PermissionConsume))
+	assert.True(t, RoleConsumer.Allows(PermissionConsume))
+	assert.False(t, RoleConsumer.Allows(PermissionProvide))
+	assert.True(t, RoleProvider.Allows(PermissionProvide))
+	assert.False(t, RoleProvider.Allows(PermissionAdmin))
+	assert.True(t, RoleAdmin.Allows(PermissionAdmin))
+	assert.False(t, Role("root").Allows(PermissionRead))
+}
@@ -0,0 +1,84 @@
+/*
+ * Copyright (C) 2020 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package auth
+
+// Role determines which Tequilapi actions the user is allowed to perform
+type Role string
+
+const (
+	// RoleMonitor can only read the state of the node, e.g. for monitoring dashboards
+	RoleMonitor Role = "monitor"
+	// RoleConsumer can manage consumer connections
+	RoleConsumer Role = "consumer"
+	// RoleProvider can manage provided services and their earnings
+	RoleProvider Role = "provider"
+	// RoleAdmin can perform any action, including user management
+	RoleAdmin Role = "admin"
+)
+
+// Permission represents a group of Tequilapi actions
+type Permission string
+
+const (
+	// PermissionRead allows reading the state of the node
+	PermissionRead Permission = "read"
+	// PermissionConsume allows managing consumer connections
+	PermissionConsume Permission = "consume"
+	// PermissionProvide allows managing provided services
+	PermissionProvide Permission = "provide"
+	// PermissionAdmin allows managing identities, configuration and users
+	PermissionAdmin Permission = "admin"
+)
+
+var rolePermissions = map[Role][]Permission{
+	RoleMonitor:  {PermissionRead},
+	RoleConsumer: {PermissionRead, PermissionConsume},
+	RoleProvider: {PermissionRead, PermissionProvide},
+	RoleAdmin:    {PermissionRead, PermissionConsume, PermissionProvide, PermissionAdmin},
+}
+
+// Roles returns all known roles
+func Roles() []Role {
+	return []Role{RoleMonitor, RoleConsumer, RoleProvider, RoleAdmin}
+}
+
+// Valid checks if role is known
+func (r Role) Valid() bool {
+	_, ok := rolePermissions[r]
+	return ok
+}
+
+// Allows checks if role grants the given permission
+func (r Role) Allows(permission Permission) bool {
+	for _, p := range rolePermissions[r] {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// Covers checks if role grants all the permissions of the other role
+func (r Role) Covers(other Role) bool {
+	for _, p := range rolePermissions[other] {
+		if !r.Allows(p) {
+			return false
+		}
+	}
+	return other.Valid()
+}
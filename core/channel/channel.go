@@ -0,0 +1,51 @@
+/*
+ * Copyright (C) 2020 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package channel
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/mysteriumnetwork/node/identity"
+)
+
+// Status describes the state of the identity's payment channel with the accountant.
+type Status struct {
+	Identity     identity.Identity
+	AccountantID identity.Identity
+	// Address is the on-chain address of the consumer channel.
+	Address common.Address
+	// Balance is the amount of tokens the consumer channel holds.
+	Balance uint64
+	// Settled is the amount the provider has settled with the accountant.
+	Settled uint64
+	// GrandTotalPromised is the total amount the consumer has promised through the accountant.
+	GrandTotalPromised uint64
+	// LastPromise is the last promise the provider got from the accountant, nil if there's none.
+	LastPromise *Promise
+	// Registered indicates if the registry sees the identity as registered.
+	Registered bool
+}
+
+// Promise describes a promise issued by the accountant.
+type Promise struct {
+	ChannelID   []byte
+	Amount      uint64
+	Fee         uint64
+	Hashlock    []byte
+	AgreementID uint64
+	Revealed    bool
+}
@@ -94,7 +94,21 @@ type PaymentIssuer interface {
 // PaymentEngineFactory creates a new payment issuer from the given params
 type PaymentEngineFactory func(paymentInfo *promise.PaymentInfo,
 	dialog communication.Dialog,
-	consumer, provider, accountant identity.Identity, proposal market.ServiceProposal) (PaymentIssuer, error)
+	consumer, provider, accountant identity.Identity, proposal market.ServiceProposal, sessionID session.ID) (PaymentIssuer, error)
+
+// AccountantSelector picks the accountant to pay the provider through for the given proposal
+type AccountantSelector interface {
+	Select(consumerID, requested identity.Identity, proposal market.ServiceProposal) (identity.Identity, error)
+}
+
+// SpendingLimiter tells whether the consumer is allowed to start a new session within the budget
+type SpendingLimiter interface {
+	CanStart(id identity.Identity) error
+}
+
+// CountryResolver returns the country the consumer connects from, empty if unknown.
+// The country is shared with providers only if the resolver is given.
+type CountryResolver func() string
 
 type connectionManager struct {
 	// These are passed on creation.
@@ -105,6 +119,9 @@ type connectionManager struct {
 	connectivityStatusSender connectivity.StatusSender
 	ipResolver               ip.Resolver
 	ipCheckParams            IPCheckParams
+	originCountry            CountryResolver
+	accountantSelector       AccountantSelector
+	spendingLimiter          SpendingLimiter
 
 	// These are populated by Connect at runtime.
 	ctx                    context.Context
@@ -129,6 +146,9 @@ func NewManager(
 	connectivityStatusSender connectivity.StatusSender,
 	ipResolver ip.Resolver,
 	ipCheckParams IPCheckParams,
+	originCountry CountryResolver,
+	accountantSelector AccountantSelector,
+	spendingLimiter SpendingLimiter,
 	disablePayments bool,
 ) *connectionManager {
 	return &connectionManager{
@@ -141,6 +161,9 @@ func NewManager(
 		cleanup:                  make([]func() error, 0),
 		ipResolver:               ipResolver,
 		ipCheckParams:            ipCheckParams,
+		originCountry:            originCountry,
+		accountantSelector:       accountantSelector,
+		spendingLimiter:          spendingLimiter,
 		disablePayments:          disablePayments,
 	}
 }
@@ -159,6 +182,16 @@ func (manager *connectionManager) Connect(consumerID, accountantID identity.Iden
 		}
 	}()
 
+	if !manager.disablePayments {
+		accountantID, err = manager.accountantSelector.Select(consumerID, accountantID, proposal)
+		if err != nil {
+			return err
+		}
+		if err = manager.spendingLimiter.CanStart(consumerID); err != nil {
+			return err
+		}
+	}
+
 	providerID := identity.FromAddress(proposal.ProviderID)
 	dialog, err := manager.createDialog(consumerID, providerID, proposal.ProviderContacts[0])
 	if err != nil {
@@ -176,7 +209,7 @@ func (manager *connectionManager) Connect(consumerID, accountantID identity.Iden
 		return err
 	}
 
-	err = manager.launchPayments(paymentInfo, dialog, consumerID, providerID, accountantID, proposal)
+	err = manager.launchPayments(paymentInfo, dialog, consumerID, providerID, accountantID, proposal, sessionDTO.ID)
 	if err != nil {
 		manager.sendSessionStatus(dialog, sessionDTO.ID, connectivity.StatusSessionPaymentsFailed, err)
 		return err
@@ -258,8 +291,8 @@ func (manager *connectionManager) getPublicIP() string {
 	return currentPublicIP
 }
 
-func (manager *connectionManager) launchPayments(paymentInfo *promise.PaymentInfo, dialog communication.Dialog, consumerID, providerID, accountantID identity.Identity, proposal market.ServiceProposal) error {
-	payments, err := manager.paymentEngineFactory(paymentInfo, dialog, consumerID, providerID, accountantID, proposal)
+func (manager *connectionManager) launchPayments(paymentInfo *promise.PaymentInfo, dialog communication.Dialog, consumerID, providerID, accountantID identity.Identity, proposal market.ServiceProposal, sessionID session.ID) error {
+	payments, err := manager.paymentEngineFactory(paymentInfo, dialog, consumerID, providerID, accountantID, proposal, sessionID)
 	if err != nil {
 		return err
 	}
@@ -328,6 +361,12 @@ func (manager *connectionManager) createSession(c Connection, dialog communicati
 		AccountantID:   accountantID,
 		PaymentVersion: paymentVersion,
 	}
+	if manager.originCountry != nil {
+		consumerInfo.Country = manager.originCountry()
+	}
+	if method, ok := proposal.PaymentMethod.(market.PricedPaymentMethod); ok {
+		consumerInfo.Price = &market.Price{PerMinute: method.GetPrice(), PerGB: method.GetPricePerGB()}
+	}
 
 	s, paymentInfo, err := session.RequestSessionCreate(dialog, proposal.ID, sessionCreateConfig, consumerInfo)
 	if err != nil {
@@ -28,6 +28,7 @@ import (
 	"github.com/mysteriumnetwork/node/core/ip"
 	"github.com/mysteriumnetwork/node/identity"
 	"github.com/mysteriumnetwork/node/market"
+	"github.com/mysteriumnetwork/node/money"
 	"github.com/mysteriumnetwork/node/services/openvpn/discovery/dto"
 	"github.com/mysteriumnetwork/node/session"
 	"github.com/mysteriumnetwork/node/session/connectivity"
@@ -47,6 +48,8 @@ type testContext struct {
 	fakeResolver          ip.Resolver
 	ipCheckParams         IPCheckParams
 	statusSender          *mockStatusSender
+	accountantSelector    *mockAccountantSelector
+	spendingLimiter       *mockSpendingLimiter
 	sync.RWMutex
 }
 
@@ -113,12 +116,14 @@ func (tc *testContext) SetupTest() {
 
 	tc.statusSender = &mockStatusSender{}
 	tc.fakeResolver = ip.NewResolverMock("ip")
+	tc.accountantSelector = &mockAccountantSelector{}
+	tc.spendingLimiter = &mockSpendingLimiter{}
 
 	tc.connManager = NewManager(
 		dialogCreator,
 		func(paymentInfo *promise.PaymentInfo,
 			dialog communication.Dialog,
-			consumer, provider, accountant identity.Identity, proposal market.ServiceProposal) (PaymentIssuer, error) {
+			consumer, provider, accountant identity.Identity, proposal market.ServiceProposal, sessionID session.ID) (PaymentIssuer, error) {
 			if paymentInfo == nil {
 				paymentInfo = &promise.PaymentInfo{}
 			}
@@ -134,6 +139,9 @@ func (tc *testContext) SetupTest() {
 		tc.statusSender,
 		tc.fakeResolver,
 		tc.ipCheckParams,
+		nil,
+		tc.accountantSelector,
+		tc.spendingLimiter,
 		false,
 	)
 }
@@ -155,6 +163,53 @@ func (tc *testContext) TestWhenManagerMadeConnectionStatusReturnsConnectedStateA
 	assert.Equal(tc.T(), statusConnected(establishedSessionID, activeProposal), tc.connManager.Status())
 }
 
+func (tc *testContext) TestCountryIsSharedOnlyIfResolverIsGiven() {
+	err := tc.connManager.Connect(consumerID, accountantID, activeProposal, ConnectParams{})
+	assert.NoError(tc.T(), err)
+	assert.NoError(tc.T(), tc.connManager.Disconnect())
+	assert.Len(tc.T(), tc.mockDialog.createRequests, 1)
+	assert.Equal(tc.T(), "", tc.mockDialog.createRequests[0].ConsumerInfo.Country)
+
+	tc.connManager.originCountry = func() string { return "LT" }
+	err = tc.connManager.Connect(consumerID, accountantID, activeProposal, ConnectParams{})
+	assert.NoError(tc.T(), err)
+	assert.Len(tc.T(), tc.mockDialog.createRequests, 1)
+	assert.Equal(tc.T(), "LT", tc.mockDialog.createRequests[0].ConsumerInfo.Country)
+}
+
+func (tc *testContext) TestSessionCreateCarriesAgreedPrice() {
+	proposal := activeProposal
+	proposal.PaymentMethod = dto.PaymentRate{Price: money.NewMoney(10, money.CurrencyMyst), Duration: time.Minute}
+
+	err := tc.connManager.Connect(consumerID, accountantID, proposal, ConnectParams{})
+	assert.NoError(tc.T(), err)
+	assert.Len(tc.T(), tc.mockDialog.createRequests, 1)
+	assert.Equal(
+		tc.T(),
+		&market.Price{PerMinute: money.NewMoney(10, money.CurrencyMyst), PerGB: money.Money{}},
+		tc.mockDialog.createRequests[0].ConsumerInfo.Price,
+	)
+}
+
+func (tc *testContext) TestConnectFailsWhenNoAccountantCanBeSelected() {
+	tc.accountantSelector.err = errors.New("no common accountant")
+
+	err := tc.connManager.Connect(consumerID, accountantID, activeProposal, ConnectParams{})
+	assert.EqualError(tc.T(), err, "no common accountant")
+	assert.Equal(tc.T(), statusNotConnected(), tc.connManager.Status())
+}
+
+func (tc *testContext) TestConnectFailsWithoutSessionCreateWhenBudgetIsExhausted() {
+	tc.spendingLimiter.err = errors.New("spending limit reached")
+	tc.mockDialog = nil
+
+	err := tc.connManager.Connect(consumerID, accountantID, activeProposal, ConnectParams{})
+	assert.EqualError(tc.T(), err, "spending limit reached")
+	assert.Equal(tc.T(), statusNotConnected(), tc.connManager.Status())
+	// no dialog is opened, so no session-create is requested
+	assert.Nil(tc.T(), tc.mockDialog)
+}
+
 func (tc *testContext) TestStatusReportsConnectingWhenConnectionIsInProgress() {
 	tc.fakeConnectionFactory.mockConnection.onStartReportStates = []fakeState{}
 
@@ -507,6 +562,22 @@ func (mpm *MockPaymentIssuer) Stop() {
 	close(mpm.stopChan)
 }
 
+type mockAccountantSelector struct {
+	err error
+}
+
+func (mas *mockAccountantSelector) Select(consumerID, requested identity.Identity, proposal market.ServiceProposal) (identity.Identity, error) {
+	return requested, mas.err
+}
+
+type mockSpendingLimiter struct {
+	err error
+}
+
+func (msl *mockSpendingLimiter) CanStart(id identity.Identity) error {
+	return msl.err
+}
+
 type mockStatusSender struct {
 	sentMsg *connectivity.StatusMessage
 	sync.Mutex
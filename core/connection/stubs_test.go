@@ -206,10 +206,11 @@ func (foc *connectionMock) StateCallback(callback func(state fakeState)) {
 }
 
 type mockDialog struct {
-	peerID      identity.Identity
-	sessionID   session.ID
-	paymentInfo *promise.PaymentInfo
-	closed      bool
+	peerID         identity.Identity
+	sessionID      session.ID
+	paymentInfo    *promise.PaymentInfo
+	closed         bool
+	createRequests []*session.CreateRequest
 	sync.RWMutex
 }
 
@@ -271,6 +272,9 @@ func (md *mockDialog) Request(producer communication.RequestProducer) (responseP
 	}
 
 	if producer.GetRequestEndpoint() == communication.RequestEndpoint("session-create") {
+		md.Lock()
+		md.createRequests = append(md.createRequests, producer.Produce().(*session.CreateRequest))
+		md.Unlock()
 		return &session.CreateResponse{
 				Success: true,
 				Session: session.SessionDto{
@@ -110,6 +110,18 @@ func (d *Discovery) Start(ownIdentity identity.Identity, proposal market.Service
 	go d.mainDiscoveryLoop()
 }
 
+// Update replaces the announced proposal, re-registering it immediately if it is already announced
+func (d *Discovery) Update(proposal market.ServiceProposal) {
+	d.mu.Lock()
+	d.proposal = proposal
+	announced := d.status == PingProposal
+	d.mu.Unlock()
+
+	if announced {
+		go d.reregisterProposal()
+	}
+}
+
 // Wait wait for proposal announcements to stop / unregister
 func (d *Discovery) Wait() {
 	d.proposalAnnouncementStopped.Wait()
@@ -189,29 +201,46 @@ func (d *Discovery) registerIdentity() {
 }
 
 func (d *Discovery) registerProposal() {
-	err := d.proposalRegistry.RegisterProposal(d.proposal, d.signer)
+	proposal := d.currentProposal()
+	err := d.proposalRegistry.RegisterProposal(proposal, d.signer)
 	if err != nil {
 		log.Error().Err(err).Msg("Failed to register proposal, retrying after 1 min")
 		time.Sleep(1 * time.Minute)
 		d.changeStatus(RegisterProposal)
 		return
 	}
-	d.eventBus.Publish(AppTopicProposalAnnounce, d.proposal)
+	d.eventBus.Publish(AppTopicProposalAnnounce, proposal)
 	d.changeStatus(PingProposal)
 }
 
+func (d *Discovery) reregisterProposal() {
+	proposal := d.currentProposal()
+	if err := d.proposalRegistry.RegisterProposal(proposal, d.signer); err != nil {
+		log.Error().Err(err).Msg("Failed to re-register updated proposal, it will be announced on next ping")
+		return
+	}
+	d.eventBus.Publish(AppTopicProposalAnnounce, proposal)
+}
+
 func (d *Discovery) pingProposal() {
 	time.Sleep(d.proposalPingTTL)
-	err := d.proposalRegistry.PingProposal(d.proposal, d.signer)
+	proposal := d.currentProposal()
+	err := d.proposalRegistry.PingProposal(proposal, d.signer)
 	if err != nil {
 		log.Error().Err(err).Msg("Failed to ping proposal")
 	}
-	d.eventBus.Publish(AppTopicProposalAnnounce, d.proposal)
+	d.eventBus.Publish(AppTopicProposalAnnounce, proposal)
 	d.changeStatus(PingProposal)
 }
 
+func (d *Discovery) currentProposal() market.ServiceProposal {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.proposal
+}
+
 func (d *Discovery) unregisterProposal() {
-	err := d.proposalRegistry.UnregisterProposal(d.proposal, d.signer)
+	err := d.proposalRegistry.UnregisterProposal(d.currentProposal(), d.signer)
 	if err != nil {
 		log.Error().Err(err).Msg("Failed to unregister proposal: ")
 		d.changeStatus(UnregisterProposalFailed)
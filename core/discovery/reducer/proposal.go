@@ -18,6 +18,8 @@
 package reducer
 
 import (
+	"time"
+
 	"github.com/mysteriumnetwork/node/market"
 )
 
@@ -68,6 +70,10 @@ func Price(lowerBound, upperBound uint64) func(market.ServiceProposal) bool {
 	return func(proposal market.ServiceProposal) bool {
 		if proposal.PaymentMethod != nil {
 			price := proposal.PaymentMethod.GetPrice().Amount
+			// prices of prepaid periods longer than a minute are compared per minute
+			if perTime := proposal.PaymentMethod.GetRate().PerTime; perTime > time.Minute {
+				price = uint64(float64(price) * float64(time.Minute) / float64(perTime))
+			}
 			return price >= lowerBound && price <= upperBound
 		}
 
@@ -19,7 +19,10 @@ package reducer
 
 import (
 	"testing"
+	"time"
 
+	"github.com/mysteriumnetwork/node/market"
+	"github.com/mysteriumnetwork/node/money"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -94,3 +97,21 @@ func Test_Price_FiltersByPrice(t *testing.T) {
 	assert.False(t, match(proposalCheap))
 	assert.True(t, match(proposalExact))
 }
+
+func Test_Price_ComparesLongPeriodsPerMinute(t *testing.T) {
+	match := Price(100, 1000000)
+
+	hourly := market.ServiceProposal{
+		PaymentMethod: &mockPaymentMethod{
+			price: money.NewMoney(60000000, money.CurrencyMyst),
+			rate:  market.PaymentRate{PerTime: time.Hour},
+		},
+	}
+	assert.True(t, match(hourly))
+
+	hourly.PaymentMethod = &mockPaymentMethod{
+		price: money.NewMoney(60000060, money.CurrencyMyst),
+		rate:  market.PaymentRate{PerTime: time.Hour},
+	}
+	assert.False(t, match(hourly))
+}
@@ -0,0 +1,183 @@
+/*
+ * Copyright (C) 2020 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package ledger
+
+import (
+	"sort"
+	"time"
+)
+
+// Period is the length of the time span earnings are grouped by
+type Period string
+
+const (
+	// PeriodDay groups earnings by calendar day
+	PeriodDay = Period("day")
+	// PeriodWeek groups earnings by calendar week starting on Monday
+	PeriodWeek = Period("week")
+	// PeriodMonth groups earnings by calendar month
+	PeriodMonth = Period("month")
+)
+
+// IsValid checks if the period is known.
+func (p Period) IsValid() bool {
+	return p == PeriodDay || p == PeriodWeek || p == PeriodMonth
+}
+
+// Start returns the beginning of the period the given time falls into.
+func (p Period) Start(t time.Time) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	switch p {
+	case PeriodWeek:
+		sinceMonday := (int(day.Weekday()) + 6) % 7
+		return day.AddDate(0, 0, -sinceMonday)
+	case PeriodMonth:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return day
+	}
+}
+
+// SessionEarnings holds the amounts invoiced and paid during a single session.
+type SessionEarnings struct {
+	SessionID   string
+	ServiceType string
+	Country     string
+	Consumer    string
+	Started     time.Time
+	Invoiced    uint64
+	Paid        uint64
+}
+
+// EarningsGroup holds the amount earned by a group of sessions.
+type EarningsGroup struct {
+	Key      string
+	Earned   uint64
+	Sessions int
+}
+
+// Earnings holds the provider earnings aggregated in different ways.
+type Earnings struct {
+	Earned       uint64
+	Sessions     []SessionEarnings
+	Periods      []EarningsGroup
+	ServiceTypes []EarningsGroup
+	Countries    []EarningsGroup
+}
+
+// UnknownCountry is used to group earnings from the consumers which did not tell their country.
+const UnknownCountry = "unknown"
+
+// AggregateEarnings aggregates the incoming exchange messages and the outgoing invoices of the provider.
+// Earnings are attributed to the period the consumer paid in.
+func AggregateEarnings(entries []Entry, period Period) Earnings {
+	var earnings Earnings
+	sessions := make(map[string]*SessionEarnings)
+	earned := make(map[string]uint64)
+	var sessionOrder []string
+	periods := newGrouping()
+	serviceTypes := newGrouping()
+	countries := newGrouping()
+
+	for _, e := range entries {
+		isInvoice := e.Kind == KindInvoice && e.Direction == DirectionOutgoing
+		isPayment := e.Kind == KindExchangeMessage && e.Direction == DirectionIncoming
+		if !isInvoice && !isPayment {
+			continue
+		}
+
+		s, ok := sessions[e.SessionID]
+		if !ok {
+			s = &SessionEarnings{SessionID: e.SessionID, Consumer: e.Counterparty.Address, Started: e.Time}
+			sessions[e.SessionID] = s
+			sessionOrder = append(sessionOrder, e.SessionID)
+		}
+		if e.ServiceType != "" {
+			s.ServiceType = e.ServiceType
+		}
+		if e.Country != "" {
+			s.Country = e.Country
+		}
+
+		if isInvoice {
+			if e.Total > s.Invoiced {
+				s.Invoiced = e.Total
+			}
+			continue
+		}
+		if e.Total > s.Paid {
+			s.Paid = e.Total
+		}
+		earned[e.SessionID] += e.Amount
+		earnings.Earned += e.Amount
+		periods.add(e.Period(period), e.SessionID, e.Amount)
+	}
+
+	for _, id := range sessionOrder {
+		s := sessions[id]
+		country := s.Country
+		if country == "" {
+			country = UnknownCountry
+		}
+		serviceTypes.add(s.ServiceType, id, earned[id])
+		countries.add(country, id, earned[id])
+		earnings.Sessions = append(earnings.Sessions, *s)
+	}
+
+	earnings.Periods = periods.groups()
+	earnings.ServiceTypes = serviceTypes.groups()
+	earnings.Countries = countries.groups()
+	return earnings
+}
+
+// Period returns the formatted beginning of the period the entry was recorded in.
+func (e Entry) Period(period Period) string {
+	return period.Start(e.Time).Format("2006-01-02")
+}
+
+type grouping struct {
+	earned   map[string]uint64
+	sessions map[string]map[string]bool
+}
+
+func newGrouping() grouping {
+	return grouping{
+		earned:   make(map[string]uint64),
+		sessions: make(map[string]map[string]bool),
+	}
+}
+
+func (g grouping) add(key, sessionID string, amount uint64) {
+	g.earned[key] += amount
+	if g.sessions[key] == nil {
+		g.sessions[key] = make(map[string]bool)
+	}
+	g.sessions[key][sessionID] = true
+}
+
+func (g grouping) groups() []EarningsGroup {
+	result := make([]EarningsGroup, 0, len(g.earned))
+	for key, earned := range g.earned {
+		result = append(result, EarningsGroup{Key: key, Earned: earned, Sessions: len(g.sessions[key])})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Key < result[j].Key
+	})
+	return result
+}
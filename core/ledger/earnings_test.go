@@ -0,0 +1,74 @@
+/*
+ * Copyright (C) 2020 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package ledger
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPeriod_Start(t *testing.T) {
+	// Thursday
+	moment := time.Date(2020, 3, 5, 15, 30, 0, 0, time.UTC)
+
+	assert.Equal(t, time.Date(2020, 3, 5, 0, 0, 0, 0, time.UTC), PeriodDay.Start(moment))
+	assert.Equal(t, time.Date(2020, 3, 2, 0, 0, 0, 0, time.UTC), PeriodWeek.Start(moment))
+	assert.Equal(t, time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC), PeriodMonth.Start(moment))
+
+	sunday := time.Date(2020, 3, 8, 23, 0, 0, 0, time.UTC)
+	assert.Equal(t, time.Date(2020, 3, 2, 0, 0, 0, 0, time.UTC), PeriodWeek.Start(sunday))
+}
+
+func TestAggregateEarnings(t *testing.T) {
+	day1 := time.Date(2020, 3, 1, 12, 0, 0, 0, time.UTC)
+	day2 := day1.AddDate(0, 0, 1)
+	entries := []Entry{
+		{Kind: KindInvoice, Direction: DirectionOutgoing, Time: day1, SessionID: "s1", Counterparty: consumerID, ServiceType: "openvpn", Country: "LT", Total: 10},
+		{Kind: KindExchangeMessage, Direction: DirectionIncoming, Time: day1, SessionID: "s1", Counterparty: consumerID, ServiceType: "openvpn", Country: "LT", Amount: 10, Total: 10},
+		{Kind: KindInvoice, Direction: DirectionOutgoing, Time: day2, SessionID: "s1", Counterparty: consumerID, ServiceType: "openvpn", Country: "LT", Total: 25},
+		{Kind: KindExchangeMessage, Direction: DirectionIncoming, Time: day2, SessionID: "s1", Counterparty: consumerID, ServiceType: "openvpn", Country: "LT", Amount: 15, Total: 25},
+		{Kind: KindAccountantPromise, Direction: DirectionIncoming, Time: day2, SessionID: "s1", Amount: 15, Total: 25},
+		{Kind: KindInvoice, Direction: DirectionOutgoing, Time: day2, SessionID: "s2", Counterparty: consumerID, ServiceType: "wireguard", Total: 7},
+		{Kind: KindExchangeMessage, Direction: DirectionIncoming, Time: day2, SessionID: "s2", Counterparty: consumerID, ServiceType: "wireguard", Amount: 5, Total: 5},
+	}
+
+	earnings := AggregateEarnings(entries, PeriodDay)
+
+	assert.Equal(t, uint64(30), earnings.Earned)
+	assert.Equal(t, []SessionEarnings{
+		{SessionID: "s1", ServiceType: "openvpn", Country: "LT", Consumer: consumerID.Address, Started: day1, Invoiced: 25, Paid: 25},
+		{SessionID: "s2", ServiceType: "wireguard", Consumer: consumerID.Address, Started: day2, Invoiced: 7, Paid: 5},
+	}, earnings.Sessions)
+	assert.Equal(t, []EarningsGroup{
+		{Key: "2020-03-01", Earned: 10, Sessions: 1},
+		{Key: "2020-03-02", Earned: 20, Sessions: 2},
+	}, earnings.Periods)
+	assert.Equal(t, []EarningsGroup{
+		{Key: "openvpn", Earned: 25, Sessions: 1},
+		{Key: "wireguard", Earned: 5, Sessions: 1},
+	}, earnings.ServiceTypes)
+	assert.Equal(t, []EarningsGroup{
+		{Key: "LT", Earned: 25, Sessions: 1},
+		{Key: UnknownCountry, Earned: 5, Sessions: 1},
+	}, earnings.Countries)
+
+	monthly := AggregateEarnings(entries, PeriodMonth)
+	assert.Equal(t, []EarningsGroup{{Key: "2020-03-01", Earned: 30, Sessions: 2}}, monthly.Periods)
+}
@@ -0,0 +1,176 @@
+/*
+ * Copyright (C) 2020 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package ledger
+
+import (
+	"time"
+
+	"github.com/asdine/storm"
+	"github.com/asdine/storm/q"
+	"github.com/mysteriumnetwork/node/identity"
+	"github.com/pkg/errors"
+)
+
+const bucketName = "payment_ledger"
+
+const errBoltNotFound = "not found"
+
+// Kind is the kind of the payment transaction
+type Kind string
+
+const (
+	// KindInvoice is an invoice sent by the provider to the consumer
+	KindInvoice = Kind("invoice")
+	// KindExchangeMessage is an exchange message sent by the consumer to pay the invoice
+	KindExchangeMessage = Kind("exchange_message")
+	// KindAccountantPromise is a promise issued by the accountant to the provider
+	KindAccountantPromise = Kind("accountant_promise")
+	// KindSettlement is an accountant promise settled on the blockchain
+	KindSettlement = Kind("settlement")
+)
+
+// IsValid checks if the kind is known.
+func (k Kind) IsValid() bool {
+	switch k {
+	case KindInvoice, KindExchangeMessage, KindAccountantPromise, KindSettlement:
+		return true
+	}
+	return false
+}
+
+// Direction tells whether the transaction was sent or received by the identity
+type Direction string
+
+const (
+	// DirectionIncoming means the identity received the transaction
+	DirectionIncoming = Direction("incoming")
+	// DirectionOutgoing means the identity sent the transaction
+	DirectionOutgoing = Direction("outgoing")
+)
+
+// Entry is a single payment transaction recorded in the ledger.
+type Entry struct {
+	ID           int `storm:"id,increment"`
+	Kind         Kind
+	Direction    Direction
+	Time         time.Time
+	SessionID    string
+	Identity     identity.Identity
+	Counterparty identity.Identity
+	AccountantID identity.Identity
+	ServiceType  string
+	// Country is the country of the counterparty, empty if unknown.
+	Country string
+	// Amount is the value carried by this transaction alone.
+	Amount uint64
+	// Total is the cumulative amount the transaction states, e.g. agreement total of an exchange message.
+	Total    uint64
+	Fee      uint64
+	Hashlock string
+	TxHash   string
+}
+
+// Filter narrows down the ledger entries, zero values match everything.
+type Filter struct {
+	Identity  identity.Identity
+	SessionID string
+	Kind      Kind
+	Direction Direction
+	From      time.Time
+	To        time.Time
+	// Offset skips the given number of the oldest matching entries.
+	Offset int
+	// Limit caps the number of returned entries, zero means no limit.
+	Limit int
+}
+
+func (f Filter) matchers() []q.Matcher {
+	var matchers []q.Matcher
+	if f.Identity.Address != "" {
+		matchers = append(matchers, q.Eq("Identity", f.Identity))
+	}
+	if f.SessionID != "" {
+		matchers = append(matchers, q.Eq("SessionID", f.SessionID))
+	}
+	if f.Kind != "" {
+		matchers = append(matchers, q.Eq("Kind", f.Kind))
+	}
+	if f.Direction != "" {
+		matchers = append(matchers, q.Eq("Direction", f.Direction))
+	}
+	if !f.From.IsZero() {
+		matchers = append(matchers, q.Gte("Time", f.From.UTC()))
+	}
+	if !f.To.IsZero() {
+		matchers = append(matchers, q.Lt("Time", f.To.UTC()))
+	}
+	return matchers
+}
+
+type storage interface {
+	Store(bucket string, data interface{}) error
+	Select(bucket string, matchers ...q.Matcher) storm.Query
+}
+
+// Ledger is an append-only history of payment transactions.
+type Ledger struct {
+	storage storage
+	clock   func() time.Time
+}
+
+// NewLedger returns a new ledger backed by the given storage.
+func NewLedger(storage storage) *Ledger {
+	return &Ledger{
+		storage: storage,
+		clock:   time.Now,
+	}
+}
+
+// Record appends the entry to the ledger.
+func (l *Ledger) Record(entry Entry) error {
+	if !entry.Kind.IsValid() {
+		return errors.Errorf("unknown transaction kind %q", entry.Kind)
+	}
+	entry.ID = 0
+	if entry.Time.IsZero() {
+		entry.Time = l.clock()
+	}
+	entry.Time = entry.Time.UTC()
+	return errors.Wrap(l.storage.Store(bucketName, &entry), "could not record transaction")
+}
+
+// Entries returns the entries matching the filter, oldest first.
+func (l *Ledger) Entries(filter Filter) ([]Entry, error) {
+	query := l.storage.Select(bucketName, filter.matchers()...).OrderBy("Time", "ID")
+	if filter.Offset > 0 {
+		query = query.Skip(filter.Offset)
+	}
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+
+	var result []Entry
+	err := query.Find(&result)
+	if err != nil && err.Error() != errBoltNotFound {
+		return nil, errors.Wrap(err, "could not get transactions")
+	}
+	if result == nil {
+		result = []Entry{}
+	}
+	return result, nil
+}
@@ -0,0 +1,145 @@
+/*
+ * Copyright (C) 2020 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package ledger
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/mysteriumnetwork/node/core/storage/boltdb"
+	"github.com/mysteriumnetwork/node/identity"
+	"github.com/mysteriumnetwork/node/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	providerID = identity.FromAddress("0x1")
+	consumerID = identity.FromAddress("0x2")
+)
+
+func newTestLedger(t *testing.T) (*Ledger, *utils.SettableClock, func()) {
+	dir, err := ioutil.TempDir("", "ledgerTest")
+	assert.NoError(t, err)
+	bolt, err := boltdb.NewStorage(dir)
+	assert.NoError(t, err)
+
+	clock := &utils.SettableClock{}
+	clock.SetTime(time.Date(2020, 3, 1, 12, 0, 0, 0, time.UTC))
+	ledger := NewLedger(bolt)
+	ledger.clock = clock.GetTime
+
+	return ledger, clock, func() {
+		bolt.Close()
+		os.RemoveAll(dir)
+	}
+}
+
+func TestLedger_Empty(t *testing.T) {
+	ledger, _, cleanup := newTestLedger(t)
+	defer cleanup()
+
+	entries, err := ledger.Entries(Filter{})
+	assert.NoError(t, err)
+	assert.Len(t, entries, 0)
+}
+
+func TestLedger_RecordAppends(t *testing.T) {
+	ledger, clock, cleanup := newTestLedger(t)
+	defer cleanup()
+
+	entry := Entry{Kind: KindInvoice, Direction: DirectionOutgoing, SessionID: "s1", Identity: providerID, Counterparty: consumerID, Amount: 10, Total: 10}
+	assert.NoError(t, ledger.Record(entry))
+	clock.AddTime(time.Minute)
+	entry.Amount, entry.Total = 5, 15
+	assert.NoError(t, ledger.Record(entry))
+
+	entries, err := ledger.Entries(Filter{})
+	assert.NoError(t, err)
+	assert.Len(t, entries, 2)
+	assert.NotEqual(t, entries[0].ID, entries[1].ID)
+	assert.Equal(t, uint64(10), entries[0].Amount)
+	assert.Equal(t, time.Date(2020, 3, 1, 12, 0, 0, 0, time.UTC), entries[0].Time)
+	assert.Equal(t, uint64(15), entries[1].Total)
+	assert.Equal(t, time.Date(2020, 3, 1, 12, 1, 0, 0, time.UTC), entries[1].Time)
+}
+
+func TestLedger_RecordRejectsUnknownKind(t *testing.T) {
+	ledger, _, cleanup := newTestLedger(t)
+	defer cleanup()
+
+	assert.Error(t, ledger.Record(Entry{Kind: "refund"}))
+}
+
+func TestLedger_EntriesFiltered(t *testing.T) {
+	ledger, clock, cleanup := newTestLedger(t)
+	defer cleanup()
+
+	start := clock.GetTime()
+	assert.NoError(t, ledger.Record(Entry{Kind: KindInvoice, Direction: DirectionOutgoing, SessionID: "s1", Identity: providerID}))
+	clock.AddTime(time.Hour)
+	assert.NoError(t, ledger.Record(Entry{Kind: KindExchangeMessage, Direction: DirectionOutgoing, SessionID: "s2", Identity: consumerID}))
+	clock.AddTime(time.Hour)
+	assert.NoError(t, ledger.Record(Entry{Kind: KindSettlement, Direction: DirectionIncoming, Identity: providerID}))
+
+	tests := map[string]struct {
+		filter Filter
+		kinds  []Kind
+	}{
+		"identity": {
+			filter: Filter{Identity: providerID},
+			kinds:  []Kind{KindInvoice, KindSettlement},
+		},
+		"session": {
+			filter: Filter{SessionID: "s2"},
+			kinds:  []Kind{KindExchangeMessage},
+		},
+		"kind": {
+			filter: Filter{Kind: KindSettlement},
+			kinds:  []Kind{KindSettlement},
+		},
+		"direction": {
+			filter: Filter{Direction: DirectionOutgoing},
+			kinds:  []Kind{KindInvoice, KindExchangeMessage},
+		},
+		"time range": {
+			filter: Filter{From: start.Add(time.Hour), To: start.Add(2 * time.Hour)},
+			kinds:  []Kind{KindExchangeMessage},
+		},
+		"page": {
+			filter: Filter{Offset: 1, Limit: 1},
+			kinds:  []Kind{KindExchangeMessage},
+		},
+		"page of identity": {
+			filter: Filter{Identity: providerID, Offset: 1, Limit: 5},
+			kinds:  []Kind{KindSettlement},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			entries, err := ledger.Entries(test.filter)
+			assert.NoError(t, err)
+			var kinds []Kind
+			for _, e := range entries {
+				kinds = append(kinds, e.Kind)
+			}
+			assert.Equal(t, test.kinds, kinds)
+		})
+	}
+}
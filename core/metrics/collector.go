@@ -0,0 +1,278 @@
+/*
+ * Copyright (C) 2020 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package metrics
+
+import (
+	"io"
+	"sync"
+
+	"github.com/mysteriumnetwork/node/communication/nats"
+	"github.com/mysteriumnetwork/node/core/connection"
+	"github.com/mysteriumnetwork/node/core/discovery"
+	stateEvent "github.com/mysteriumnetwork/node/core/state/event"
+	"github.com/mysteriumnetwork/node/eventbus"
+	"github.com/mysteriumnetwork/node/market"
+	sessionEvent "github.com/mysteriumnetwork/node/session/event"
+	"github.com/mysteriumnetwork/node/session/pingpong"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Collector keeps the node metrics up to date by consuming the events
+type Collector struct {
+	registry *prometheus.Registry
+
+	connectionState         *gaugeSet
+	connectionBytesSent     prometheus.Gauge
+	connectionBytesReceived prometheus.Gauge
+	serviceSessions         *gaugeSet
+	serviceBytes            *prometheus.CounterVec
+	natStatus               *gaugeSet
+	paymentsPromised        prometheus.Counter
+	paymentsEarned          *prometheus.GaugeVec
+	settlements             *prometheus.CounterVec
+	settledAmount           prometheus.Counter
+	proposals               *gaugeSet
+	brokerConnections       *gaugeSet
+	brokerDisconnects       prometheus.Counter
+
+	lock             sync.Mutex
+	sessionTransfers map[string]sessionEvent.DataTransferEventPayload
+	proposalTypes    map[market.ProposalID]string
+	brokerConnStatus map[string]nats.ConnectionStatus
+}
+
+// NewCollector returns a new collector with all the metrics registered
+func NewCollector() *Collector {
+	c := &Collector{
+		registry: prometheus.NewRegistry(),
+
+		connectionState: newGaugeSet("myst_connection_state", "Consumer connection state, 1 for the current state", "state"),
+		connectionBytesSent: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "myst_connection_bytes_sent",
+			Help: "Bytes sent during the current consumer session",
+		}),
+		connectionBytesReceived: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "myst_connection_bytes_received",
+			Help: "Bytes received during the current consumer session",
+		}),
+		serviceSessions: newGaugeSet("myst_service_sessions", "Active provider sessions per service type", "service_type"),
+		serviceBytes: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "myst_service_bytes_total",
+			Help: "Bytes transferred in provider sessions per direction",
+		}, []string{"direction"}),
+		natStatus: newGaugeSet("myst_nat_status", "NAT traversal status, 1 for the current status", "status"),
+		paymentsPromised: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "myst_payments_promised_total",
+			Help: "Amount promised to providers by consumer",
+		}),
+		paymentsEarned: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "myst_payments_earned",
+			Help: "Amount promised by accountant to provider in total",
+		}, []string{"provider_id", "accountant_id"}),
+		settlements: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "myst_settlements_total",
+			Help: "Settlement attempts per result",
+		}, []string{"result"}),
+		settledAmount: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "myst_settled_amount_total",
+			Help: "Amount settled on the blockchain",
+		}),
+		proposals:         newGaugeSet("myst_discovery_proposals", "Proposals discovered via broker per service type", "service_type"),
+		brokerConnections: newGaugeSet("myst_broker_connections", "Broker connections per status", "status"),
+		brokerDisconnects: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "myst_broker_disconnects_total",
+			Help: "Broker disconnects",
+		}),
+
+		sessionTransfers: make(map[string]sessionEvent.DataTransferEventPayload),
+		proposalTypes:    make(map[market.ProposalID]string),
+		brokerConnStatus: make(map[string]nats.ConnectionStatus),
+	}
+	c.registry.MustRegister(
+		c.connectionState,
+		c.connectionBytesSent,
+		c.connectionBytesReceived,
+		c.serviceSessions,
+		c.serviceBytes,
+		c.natStatus,
+		c.paymentsPromised,
+		c.paymentsEarned,
+		c.settlements,
+		c.settledAmount,
+		c.proposals,
+		c.brokerConnections,
+		c.brokerDisconnects,
+	)
+	c.connectionState.Replace(map[string]float64{string(connection.NotConnected): 1})
+	return c
+}
+
+// Subscribe subscribes the collector to the events the metrics are fed from
+func (c *Collector) Subscribe(bus eventbus.Subscriber) error {
+	subscriptions := map[string]interface{}{
+		connection.AppTopicConsumerConnectionState: c.consumeConnectionState,
+		connection.AppTopicConsumerStatistics:      c.consumeConnectionStatistics,
+		stateEvent.AppTopicState:                   c.consumeStateEvent,
+		sessionEvent.AppTopicSession:               c.consumeSessionEvent,
+		sessionEvent.AppTopicDataTransfered:        c.consumeDataTransfer,
+		pingpong.AppTopicExchangeMessage:           c.consumeExchangeMessage,
+		pingpong.AppTopicAccountantPromise:         c.consumeAccountantPromise,
+		pingpong.AppTopicSettlement:                c.consumeSettlement,
+		discovery.AppTopicProposalAdded:            c.consumeProposalAnnounced,
+		discovery.AppTopicProposalUpdated:          c.consumeProposalAnnounced,
+		discovery.AppTopicProposalRemoved:          c.consumeProposalRemoved,
+		nats.AppTopicConnectionStatus:              c.consumeBrokerStatus,
+	}
+	for topic, fn := range subscriptions {
+		if err := bus.Subscribe(topic, fn); err != nil {
+			return errors.Wrapf(err, "could not subscribe metrics to %q", topic)
+		}
+	}
+	return nil
+}
+
+// Write writes the metrics in Prometheus text exposition format
+func (c *Collector) Write(w io.Writer) error {
+	return writeText(c.registry, w)
+}
+
+func (c *Collector) consumeConnectionState(e connection.StateEvent) {
+	c.connectionState.Replace(map[string]float64{string(e.State): 1})
+	if e.State == connection.Connecting {
+		c.connectionBytesSent.Set(0)
+		c.connectionBytesReceived.Set(0)
+	}
+}
+
+func (c *Collector) consumeConnectionStatistics(e connection.SessionStatsEvent) {
+	c.connectionBytesSent.Set(float64(e.Stats.BytesSent))
+	c.connectionBytesReceived.Set(float64(e.Stats.BytesReceived))
+}
+
+func (c *Collector) consumeStateEvent(e stateEvent.State) {
+	natStatus := make(map[string]float64)
+	if e.NATStatus.Status != "" {
+		natStatus[e.NATStatus.Status] = 1
+	}
+	c.natStatus.Replace(natStatus)
+
+	serviceTypes := make(map[string]string, len(e.Services))
+	counts := make(map[string]float64)
+	for _, service := range e.Services {
+		serviceTypes[service.ID] = service.Type
+		// services without sessions are reported with zero
+		if _, ok := counts[service.Type]; !ok {
+			counts[service.Type] = 0
+		}
+	}
+	for _, session := range e.Sessions {
+		counts[serviceTypes[session.ServiceID]]++
+	}
+	c.serviceSessions.Replace(counts)
+}
+
+func (c *Collector) consumeSessionEvent(e sessionEvent.Payload) {
+	if e.Action != sessionEvent.Removed {
+		return
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	delete(c.sessionTransfers, e.ID)
+}
+
+// consumeDataTransfer counts the bytes transferred since the previous event of the session, the events carry session totals
+func (c *Collector) consumeDataTransfer(e sessionEvent.DataTransferEventPayload) {
+	c.lock.Lock()
+	previous := c.sessionTransfers[e.ID]
+	c.sessionTransfers[e.ID] = e
+	c.lock.Unlock()
+
+	c.serviceBytes.WithLabelValues("up").Add(float64(amountSince(previous.Up, e.Up)))
+	c.serviceBytes.WithLabelValues("down").Add(float64(amountSince(previous.Down, e.Down)))
+}
+
+func (c *Collector) consumeExchangeMessage(e pingpong.ExchangeMessageEventPayload) {
+	c.paymentsPromised.Add(float64(e.AmountPromised))
+}
+
+func (c *Collector) consumeAccountantPromise(e pingpong.AccountantPromiseEventPayload) {
+	c.paymentsEarned.WithLabelValues(e.ProviderID.Address, e.AccountantID.Address).Set(float64(e.Promise.Amount))
+}
+
+func (c *Collector) consumeSettlement(e pingpong.SettlementEventPayload) {
+	result := "failure"
+	if e.Successful {
+		result = "success"
+	}
+	c.settlements.WithLabelValues(result).Inc()
+	c.settledAmount.Add(float64(e.Amount))
+}
+
+func (c *Collector) consumeProposalAnnounced(proposal market.ServiceProposal) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.proposalTypes[proposal.UniqueID()] = proposal.ServiceType
+	c.updateProposals()
+}
+
+func (c *Collector) consumeProposalRemoved(proposal market.ServiceProposal) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	delete(c.proposalTypes, proposal.UniqueID())
+	c.updateProposals()
+}
+
+func (c *Collector) updateProposals() {
+	counts := make(map[string]float64)
+	for _, serviceType := range c.proposalTypes {
+		counts[serviceType]++
+	}
+	c.proposals.Replace(counts)
+}
+
+func (c *Collector) consumeBrokerStatus(e nats.ConnectionStatusEvent) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	switch e.Status {
+	case nats.ConnectionStatusClosed:
+		delete(c.brokerConnStatus, e.ConnectionID)
+	case nats.ConnectionStatusDisconnected:
+		c.brokerConnStatus[e.ConnectionID] = e.Status
+		c.brokerDisconnects.Inc()
+	default:
+		c.brokerConnStatus[e.ConnectionID] = nats.ConnectionStatusConnected
+	}
+
+	counts := map[string]float64{
+		string(nats.ConnectionStatusConnected):    0,
+		string(nats.ConnectionStatusDisconnected): 0,
+	}
+	for _, status := range c.brokerConnStatus {
+		counts[string(status)]++
+	}
+	c.brokerConnections.Replace(counts)
+}
+
+func amountSince(previous, current uint64) uint64 {
+	if current < previous {
+		return current
+	}
+	return current - previous
+}
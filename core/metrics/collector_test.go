@@ -0,0 +1,107 @@
+/*
+ * Copyright (C) 2020 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package metrics
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/mysteriumnetwork/node/communication/nats"
+	"github.com/mysteriumnetwork/node/consumer"
+	"github.com/mysteriumnetwork/node/core/connection"
+	"github.com/mysteriumnetwork/node/core/discovery"
+	stateEvent "github.com/mysteriumnetwork/node/core/state/event"
+	"github.com/mysteriumnetwork/node/eventbus"
+	"github.com/mysteriumnetwork/node/identity"
+	"github.com/mysteriumnetwork/node/market"
+	sessionEvent "github.com/mysteriumnetwork/node/session/event"
+	"github.com/mysteriumnetwork/node/session/pingpong"
+	"github.com/mysteriumnetwork/payments/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+func collect(t *testing.T, c *Collector) string {
+	var out bytes.Buffer
+	assert.NoError(t, c.Write(&out))
+	return out.String()
+}
+
+func TestCollector_ConsumesEvents(t *testing.T) {
+	bus := eventbus.New()
+	c := NewCollector()
+	assert.NoError(t, c.Subscribe(bus))
+	assert.Contains(t, collect(t, c), `myst_connection_state{state="NotConnected"} 1`)
+
+	bus.Publish(connection.AppTopicConsumerConnectionState, connection.StateEvent{State: connection.Connected})
+	bus.Publish(connection.AppTopicConsumerStatistics, connection.SessionStatsEvent{
+		Stats: consumer.SessionStatistics{BytesSent: 10, BytesReceived: 20},
+	})
+	bus.Publish(stateEvent.AppTopicState, stateEvent.State{
+		NATStatus: stateEvent.NATStatus{Status: "successful"},
+		Services: []stateEvent.ServiceInfo{
+			{ID: "service1", Type: "wireguard"},
+			{ID: "service2", Type: "openvpn"},
+		},
+		Sessions: []stateEvent.ServiceSession{
+			{ID: "session1", ServiceID: "service1"},
+			{ID: "session2", ServiceID: "service1"},
+		},
+	})
+	bus.Publish(sessionEvent.AppTopicDataTransfered, sessionEvent.DataTransferEventPayload{ID: "session1", Up: 100, Down: 200})
+	bus.Publish(sessionEvent.AppTopicDataTransfered, sessionEvent.DataTransferEventPayload{ID: "session1", Up: 150, Down: 300})
+	bus.Publish(pingpong.AppTopicExchangeMessage, pingpong.ExchangeMessageEventPayload{AmountPromised: 5})
+	bus.Publish(pingpong.AppTopicExchangeMessage, pingpong.ExchangeMessageEventPayload{AmountPromised: 7})
+	bus.Publish(pingpong.AppTopicAccountantPromise, pingpong.AccountantPromiseEventPayload{
+		ProviderID:   identity.FromAddress("0x1"),
+		AccountantID: identity.FromAddress("0x2"),
+		Promise:      crypto.Promise{Amount: 500},
+	})
+	bus.Publish(pingpong.AppTopicSettlement, pingpong.SettlementEventPayload{Amount: 400, Successful: true})
+	bus.Publish(pingpong.AppTopicSettlement, pingpong.SettlementEventPayload{})
+	bus.Publish(discovery.AppTopicProposalAdded, market.ServiceProposal{ProviderID: "0x1", ServiceType: "wireguard"})
+	bus.Publish(discovery.AppTopicProposalAdded, market.ServiceProposal{ProviderID: "0x2", ServiceType: "wireguard"})
+	bus.Publish(discovery.AppTopicProposalUpdated, market.ServiceProposal{ProviderID: "0x2", ServiceType: "wireguard"})
+	bus.Publish(discovery.AppTopicProposalRemoved, market.ServiceProposal{ProviderID: "0x1", ServiceType: "wireguard"})
+	bus.Publish(nats.AppTopicConnectionStatus, nats.ConnectionStatusEvent{ConnectionID: "1", Status: nats.ConnectionStatusConnected})
+	bus.Publish(nats.AppTopicConnectionStatus, nats.ConnectionStatusEvent{ConnectionID: "2", Status: nats.ConnectionStatusConnected})
+	bus.Publish(nats.AppTopicConnectionStatus, nats.ConnectionStatusEvent{ConnectionID: "2", Status: nats.ConnectionStatusDisconnected})
+
+	metrics := collect(t, c)
+	for _, sample := range []string{
+		`myst_connection_state{state="Connected"} 1`,
+		`myst_connection_bytes_sent 10`,
+		`myst_connection_bytes_received 20`,
+		`myst_service_sessions{service_type="openvpn"} 0`,
+		`myst_service_sessions{service_type="wireguard"} 2`,
+		`myst_service_bytes_total{direction="up"} 150`,
+		`myst_service_bytes_total{direction="down"} 300`,
+		`myst_nat_status{status="successful"} 1`,
+		`myst_payments_promised_total 12`,
+		`myst_payments_earned{accountant_id="0x2",provider_id="0x1"} 500`,
+		`myst_settlements_total{result="success"} 1`,
+		`myst_settlements_total{result="failure"} 1`,
+		`myst_settled_amount_total 400`,
+		`myst_discovery_proposals{service_type="wireguard"} 1`,
+		`myst_broker_connections{status="Connected"} 1`,
+		`myst_broker_connections{status="Disconnected"} 1`,
+		`myst_broker_disconnects_total 1`,
+	} {
+		assert.Contains(t, metrics, sample+"\n")
+	}
+	assert.NotContains(t, metrics, `state="NotConnected"`)
+}
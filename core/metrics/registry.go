@@ -0,0 +1,78 @@
+/*
+ * Copyright (C) 2020 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package metrics
+
+import (
+	"io"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/expfmt"
+)
+
+// gaugeSet is a gauge with a single label which samples are replaced all at once,
+// so that a scrape never sees the samples half updated.
+type gaugeSet struct {
+	desc *prometheus.Desc
+
+	lock   sync.Mutex
+	values map[string]float64
+}
+
+func newGaugeSet(name, help, label string) *gaugeSet {
+	return &gaugeSet{
+		desc:   prometheus.NewDesc(name, help, []string{label}, nil),
+		values: make(map[string]float64),
+	}
+}
+
+// Replace replaces all the samples with the given ones, keyed by label value
+func (g *gaugeSet) Replace(values map[string]float64) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	g.values = values
+}
+
+// Describe implements prometheus.Collector
+func (g *gaugeSet) Describe(ch chan<- *prometheus.Desc) {
+	ch <- g.desc
+}
+
+// Collect implements prometheus.Collector
+func (g *gaugeSet) Collect(ch chan<- prometheus.Metric) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	for label, value := range g.values {
+		ch <- prometheus.MustNewConstMetric(g.desc, prometheus.GaugeValue, value, label)
+	}
+}
+
+// writeText writes the metrics gathered by the registry in Prometheus text exposition format
+func writeText(registry *prometheus.Registry, w io.Writer) error {
+	families, err := registry.Gather()
+	if err != nil {
+		return err
+	}
+	encoder := expfmt.NewEncoder(w, expfmt.FmtText)
+	for _, family := range families {
+		if err := encoder.Encode(family); err != nil {
+			return err
+		}
+	}
+	return nil
+}
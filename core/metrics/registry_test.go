@@ -0,0 +1,70 @@
+/*
+ * Copyright (C) 2020 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package metrics
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGaugeSet_ReplacesSamples(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	state := newGaugeSet("myst_connection_state", "State", "state")
+	registry.MustRegister(state)
+
+	state.Replace(map[string]float64{"Connecting": 1})
+	state.Replace(map[string]float64{"Connected": 1})
+
+	var out bytes.Buffer
+	assert.NoError(t, writeText(registry, &out))
+	assert.Equal(t, `# HELP myst_connection_state State
+# TYPE myst_connection_state gauge
+myst_connection_state{state="Connected"} 1
+`, out.String())
+}
+
+func TestGaugeSet_ScrapeNeverSeesPartialReplace(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	state := newGaugeSet("myst_connection_state", "State", "state")
+	registry.MustRegister(state)
+	state.Replace(map[string]float64{"NotConnected": 1})
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 1000; i++ {
+			state.Replace(map[string]float64{"Connecting": 1})
+			state.Replace(map[string]float64{"Connected": 1})
+		}
+	}()
+
+	for scraping := true; scraping; {
+		select {
+		case <-done:
+			scraping = false
+		default:
+		}
+		var out bytes.Buffer
+		assert.NoError(t, writeText(registry, &out))
+		assert.Equal(t, 1, strings.Count(out.String(), "myst_connection_state{"))
+	}
+}
@@ -19,6 +19,7 @@ package node
 
 import (
 	"path"
+	"time"
 
 	"github.com/mysteriumnetwork/node/config"
 	"github.com/mysteriumnetwork/node/logconfig"
@@ -56,6 +57,9 @@ type Options struct {
 	TequilapiAddress string
 	TequilapiPort    int
 	TequilapiEnabled bool
+	TequilapiTLS     OptionsTequilapiTLS
+	TequilapiSocket  OptionsTequilapiSocket
+	TequilapiAudit   OptionsTequilapiAudit
 	BindAddress      string
 	UI               OptionsUI
 	FeedbackURL      string
@@ -79,11 +83,15 @@ type Options struct {
 
 // GetOptions retrieves node options from the app configuration.
 func GetOptions() *Options {
+	directories := GetOptionsDirectory()
 	return &Options{
-		Directories:      *GetOptionsDirectory(),
+		Directories:      *directories,
 		TequilapiAddress: config.GetString(config.FlagTequilapiAddress),
 		TequilapiPort:    config.GetInt(config.FlagTequilapiPort),
 		TequilapiEnabled: true,
+		TequilapiTLS:     GetOptionsTequilapiTLS(directories.Data),
+		TequilapiSocket:  GetOptionsTequilapiSocket(),
+		TequilapiAudit:   GetOptionsTequilapiAudit(),
 		BindAddress:      config.GetString(config.FlagBindAddress),
 		UI: OptionsUI{
 			UIEnabled: config.GetBool(config.FlagUIEnable),
@@ -92,6 +100,8 @@ func GetOptions() *Options {
 		FeedbackURL: config.GetString(config.FlagFeedbackURL),
 		Keystore: OptionsKeystore{
 			UseLightweight: config.GetBool(config.FlagKeystoreLightweight),
+			Signer:         config.GetString(config.FlagKeystoreSigner),
+			SignerTimeout:  config.GetDuration(config.FlagKeystoreSignerTimeout),
 		},
 		LogOptions: *GetLogOptions(),
 		OptionsNetwork: OptionsNetwork{
@@ -119,6 +129,7 @@ func GetOptions() *Options {
 			Country:       config.GetString(config.FlagLocationCountry),
 			City:          config.GetString(config.FlagLocationCity),
 			NodeType:      config.GetString(config.FlagLocationNodeType),
+			ShareCountry:  config.GetBool(config.FlagLocationShareCountry),
 		},
 		Transactor: OptionsTransactor{
 			TransactorEndpointAddress:       config.GetString(config.FlagTransactorAddress),
@@ -133,15 +144,28 @@ func GetOptions() *Options {
 			BCTimeout:                          config.GetDuration(config.FlagPaymentsBCTimeout),
 			AccountantPromiseSettlingThreshold: config.GetFloat64(config.FlagPaymentsAccountantPromiseSettleThreshold),
 			SettlementTimeout:                  config.GetDuration(config.FlagPaymentsAccountantPromiseSettleTimeout),
+			SettlementMaxFeeRatio:              config.GetFloat64(config.FlagPaymentsSettleMaxFeeRatio),
+			SettlementInterval:                 config.GetDuration(config.FlagPaymentsSettleInterval),
+			SettlementMinAmount:                config.GetUInt64(config.FlagPaymentsSettleMinAmount),
+			SettlementReserve:                  config.GetUInt64(config.FlagPaymentsSettleReserve),
 			MystSCAddress:                      config.GetString(config.FlagPaymentsMystSCAddress),
 			MaxRRecoveryLength:                 config.GetUInt64(config.FlagPaymentsMaxRRecovery),
 			PaymentsDisabled:                   config.GetBool(config.FlagPaymentsDisable),
 			ConsumerUpperPriceBound:            config.GetUInt64(config.FlagPaymentsConsumerUpperPriceBound),
 			ConsumerLowerPriceBound:            config.GetUInt64(config.FlagPaymentsConsumerLowerPriceBound),
+			DisplayCurrency:                    config.GetString(config.FlagPaymentsDisplayCurrency),
+			DisplayRatesFile:                   config.GetString(config.FlagPaymentsDisplayRatesFile),
+			PackageDuration:                    config.GetDuration(config.FlagPaymentsPackageDuration),
+			PackageBytes:                       config.GetUInt64(config.FlagPaymentsPackageBytes),
+			PackagePrice:                       config.GetUInt64(config.FlagPaymentsPackagePrice),
+			TrialDuration:                      config.GetDuration(config.FlagPaymentsTrialDuration),
+			TrialBytes:                         config.GetUInt64(config.FlagPaymentsTrialBytes),
 		},
 		Accountant: OptionsAccountant{
 			AccountantID:              config.GetString(config.FlagAccountantID),
 			AccountantEndpointAddress: config.GetString(config.FlagAccountantAddress),
+			Accepted:                  parseAccountants(config.GetStringSlice(config.FlagAccountantAccepted), config.GetString(config.FlagAccountantAddress)),
+			SelectionPolicy:           config.GetString(config.FlagAccountantSelection),
 		},
 		Openvpn: wrapper{nodeOptions: openvpn_core.NodeOptions{
 			BinaryPath: config.GetString(config.FlagOpenvpnBinary),
@@ -189,4 +213,7 @@ func GetDiscoveryOptions() *OptionsDiscovery {
 // OptionsKeystore stores the keystore configuration
 type OptionsKeystore struct {
 	UseLightweight bool
+	// Signer is a socket of external signer holding the keys, local keystore is used when empty
+	Signer        string
+	SignerTimeout time.Duration
 }
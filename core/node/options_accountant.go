@@ -17,8 +17,60 @@
 
 package node
 
+import "strings"
+
 // OptionsAccountant describes possible parameters for interaction with Accountant
 type OptionsAccountant struct {
 	AccountantEndpointAddress string
 	AccountantID              string
+	// Accepted lists the accountants accepted in addition to the default one.
+	Accepted []AccountantDefinition
+	// SelectionPolicy determines how consumer picks the accountant for a session.
+	SelectionPolicy string
+}
+
+// AccountantDefinition describes an accountant and the location of its API.
+type AccountantDefinition struct {
+	ID              string
+	EndpointAddress string
+}
+
+// Accountants returns all the accepted accountants, the default one goes first.
+func (o OptionsAccountant) Accountants() []AccountantDefinition {
+	result := []AccountantDefinition{{ID: o.AccountantID, EndpointAddress: o.AccountantEndpointAddress}}
+	for _, a := range o.Accepted {
+		if strings.EqualFold(a.ID, o.AccountantID) {
+			continue
+		}
+		result = append(result, a)
+	}
+	return result
+}
+
+// AccountantIDs returns the IDs of all the accepted accountants, the default one goes first.
+func (o OptionsAccountant) AccountantIDs() []string {
+	accountants := o.Accountants()
+	result := make([]string, len(accountants))
+	for i, a := range accountants {
+		result[i] = a.ID
+	}
+	return result
+}
+
+// parseAccountants parses accountants given as <accountant ID>[@<accountant URL>].
+func parseAccountants(values []string, defaultEndpointAddress string) []AccountantDefinition {
+	result := make([]AccountantDefinition, 0, len(values))
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+		parts := strings.SplitN(value, "@", 2)
+		definition := AccountantDefinition{ID: parts[0], EndpointAddress: defaultEndpointAddress}
+		if len(parts) == 2 && parts[1] != "" {
+			definition.EndpointAddress = parts[1]
+		}
+		result = append(result, definition)
+	}
+	return result
 }
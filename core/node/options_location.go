@@ -40,4 +40,7 @@ type OptionsLocation struct {
 	Country  string
 	City     string
 	NodeType string
+
+	// ShareCountry allows sharing the country consumer connects from with the providers.
+	ShareCountry bool
 }
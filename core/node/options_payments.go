@@ -25,9 +25,20 @@ type OptionsPayments struct {
 	BCTimeout                          time.Duration
 	AccountantPromiseSettlingThreshold float64
 	SettlementTimeout                  time.Duration
+	SettlementMaxFeeRatio              float64
+	SettlementInterval                 time.Duration
+	SettlementMinAmount                uint64
+	SettlementReserve                  uint64
 	MystSCAddress                      string
 	MaxRRecoveryLength                 uint64
 	PaymentsDisabled                   bool
 	ConsumerUpperPriceBound            uint64
 	ConsumerLowerPriceBound            uint64
+	DisplayCurrency                    string
+	DisplayRatesFile                   string
+	PackageDuration                    time.Duration
+	PackageBytes                       uint64
+	PackagePrice                       uint64
+	TrialDuration                      time.Duration
+	TrialBytes                         uint64
 }
@@ -0,0 +1,88 @@
+/*
+ * Copyright (C) 2020 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package node
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/mysteriumnetwork/node/config"
+	"github.com/pkg/errors"
+)
+
+// OptionsTequilapiTLS describes TLS configuration of tequilapi
+type OptionsTequilapiTLS struct {
+	Enabled  bool
+	CertFile string
+	KeyFile  string
+}
+
+// OptionsTequilapiSocket describes unix domain socket configuration of tequilapi
+type OptionsTequilapiSocket struct {
+	// Path of the socket, socket listener is disabled when empty
+	Path string
+	// Mode is file permissions of the socket in octal notation
+	Mode string
+}
+
+// FileMode returns socket file permissions.
+func (options OptionsTequilapiSocket) FileMode() (os.FileMode, error) {
+	mode, err := strconv.ParseUint(options.Mode, 8, 32)
+	if err != nil {
+		return 0, errors.Wrapf(err, "invalid socket mode %q", options.Mode)
+	}
+	return os.FileMode(mode), nil
+}
+
+// OptionsTequilapiAudit describes audit log configuration of tequilapi
+type OptionsTequilapiAudit struct {
+	// MaxRecords is the number of latest requests kept in the audit log, auditing is disabled when zero
+	MaxRecords int
+}
+
+// GetOptionsTequilapiTLS retrieves tequilapi TLS configuration from app configuration.
+func GetOptionsTequilapiTLS(dataDir string) OptionsTequilapiTLS {
+	options := OptionsTequilapiTLS{
+		Enabled:  config.GetBool(config.FlagTequilapiTLS),
+		CertFile: config.GetString(config.FlagTequilapiTLSCert),
+		KeyFile:  config.GetString(config.FlagTequilapiTLSKey),
+	}
+	if options.CertFile == "" {
+		options.CertFile = filepath.Join(dataDir, "tequilapi.crt")
+	}
+	if options.KeyFile == "" {
+		options.KeyFile = filepath.Join(dataDir, "tequilapi.key")
+	}
+	return options
+}
+
+// GetOptionsTequilapiSocket retrieves tequilapi socket configuration from app configuration.
+func GetOptionsTequilapiSocket() OptionsTequilapiSocket {
+	return OptionsTequilapiSocket{
+		Path: config.GetString(config.FlagTequilapiSocket),
+		Mode: config.GetString(config.FlagTequilapiSocketMode),
+	}
+}
+
+// GetOptionsTequilapiAudit retrieves tequilapi audit log configuration from app configuration.
+func GetOptionsTequilapiAudit() OptionsTequilapiAudit {
+	return OptionsTequilapiAudit{
+		MaxRecords: config.GetInt(config.FlagTequilapiAuditMaxRecords),
+	}
+}
@@ -24,9 +24,13 @@ import (
 	"github.com/mysteriumnetwork/node/market"
 )
 
+// PoliciesProvider returns access policies which are currently applied to the service
+type PoliciesProvider func() *[]market.AccessPolicy
+
 // ValidateAllowedIdentity checks if given identity is allowed by given policies
-func ValidateAllowedIdentity(repository *Repository, policies *[]market.AccessPolicy) func(identity.Identity) error {
+func ValidateAllowedIdentity(repository *Repository, policiesProvider PoliciesProvider) func(identity.Identity) error {
 	return func(peerID identity.Identity) error {
+		policies := policiesProvider()
 		if policies == nil {
 			return nil
 		}
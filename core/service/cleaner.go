@@ -27,9 +27,9 @@ type SessionStorage interface {
 	RemoveForService(serviceID string)
 }
 
-// HandleServiceStatus removes sessions of stopped service
+// HandleServiceStatus removes sessions of stopped or restarting service
 func (cleaner *Cleaner) HandleServiceStatus(event EventPayload) {
-	if event.Status == string(NotRunning) {
+	if event.Status == string(NotRunning) || event.Status == string(Restarting) {
 		cleaner.SessionStorage.RemoveForService(event.ID)
 	}
 }
@@ -0,0 +1,307 @@
+/*
+ * Copyright (C) 2020 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package declarative
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/mysteriumnetwork/node/core/service"
+	"github.com/mysteriumnetwork/node/core/service/pricing"
+	"github.com/mysteriumnetwork/node/market"
+	"github.com/mysteriumnetwork/node/money"
+	"github.com/pkg/errors"
+)
+
+// File describes the services which should be kept running by the node.
+//
+// Example:
+//
+//	shaper = true
+//
+//	[[service]]
+//	identity = "0x..."
+//	passphrase-file = "identity.pass"
+//	type = "openvpn"
+//	access-policies = ["mysterium"]
+//	  [service.options]
+//	  port = 1194
+//	  [service.restart-policy]
+//	  type = "on-failure"
+//	  max-restarts = 5
+//	  [service.price]
+//	  per-minute = 50000
+//	  per-gb = 100000
+//	  [[service.pricing-rule]]
+//	  type = "time-window"
+//	  from = "18:00"
+//	  to = "23:00"
+//	  per-minute = 80000
+//	  per-gb = 150000
+//	  [[service.pricing-rule]]
+//	  type = "load"
+//	  sessions = 20
+//	  per-minute = 100000
+//	  per-gb = 200000
+type File struct {
+	// Shaper enables or disables bandwidth limitation, it is applied node wide.
+	Shaper   *bool   `toml:"shaper"`
+	Services []Entry `toml:"service"`
+}
+
+// Entry describes a single service which should be running.
+// The identity is unlocked with the passphrase read from the passphrase file, relative to the services file,
+// or with the identity passphrase of the node if no passphrase file is given.
+type Entry struct {
+	Identity       string                 `toml:"identity"`
+	PassphraseFile string                 `toml:"passphrase-file"`
+	Type           string                 `toml:"type"`
+	Options        map[string]interface{} `toml:"options"`
+	AccessPolicies []string               `toml:"access-policies"`
+	RestartPolicy  *RestartPolicy         `toml:"restart-policy"`
+	Price          *Price                 `toml:"price"`
+	PricingRules   []PricingRule          `toml:"pricing-rule"`
+
+	// passphrase is read from the passphrase file, so that it is never kept in the services file itself.
+	passphrase *string
+}
+
+// RestartPolicy describes how the service should be restarted after failures.
+type RestartPolicy struct {
+	Type        string `toml:"type"`
+	MaxRestarts int    `toml:"max-restarts"`
+}
+
+// Price describes the price of the service in the smallest MYST units.
+type Price struct {
+	PerMinute uint64 `toml:"per-minute"`
+	PerGB     uint64 `toml:"per-gb"`
+}
+
+// PricingRuleType is the kind of dynamic pricing rule
+type PricingRuleType string
+
+const (
+	// PricingRuleTimeWindow charges the rule price during the time of day window
+	PricingRuleTimeWindow = PricingRuleType("time-window")
+	// PricingRuleLoad charges the rule price once service has at least the given number of sessions
+	PricingRuleLoad = PricingRuleType("load")
+)
+
+// PricingRule describes when the service should be charged at a different price.
+// Rules are applied in order, so later rules take precedence over earlier ones.
+type PricingRule struct {
+	Type      PricingRuleType `toml:"type"`
+	From      string          `toml:"from"`
+	To        string          `toml:"to"`
+	Sessions  int             `toml:"sessions"`
+	PerMinute uint64          `toml:"per-minute"`
+	PerGB     uint64          `toml:"per-gb"`
+}
+
+// strategy returns the pricing strategy of the rule.
+func (r PricingRule) strategy() (pricing.Strategy, error) {
+	charge := toMarketPrice(Price{PerMinute: r.PerMinute, PerGB: r.PerGB})
+	switch r.Type {
+	case PricingRuleTimeWindow:
+		from, err := parseTimeOfDay(r.From)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid window start")
+		}
+		to, err := parseTimeOfDay(r.To)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid window end")
+		}
+		return pricing.TimeWindow{From: from, To: to, Charge: charge}, nil
+	case PricingRuleLoad:
+		if r.Sessions <= 0 {
+			return nil, errors.New("sessions must be positive")
+		}
+		return pricing.Load{Sessions: r.Sessions, Charge: charge}, nil
+	default:
+		return nil, fmt.Errorf("unknown pricing rule type %q", r.Type)
+	}
+}
+
+// parseTimeOfDay parses the time of day given in HH:MM format.
+func parseTimeOfDay(value string) (time.Duration, error) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// Key uniquely identifies the entry within the file.
+func (e Entry) Key() string {
+	return e.Identity + "/" + e.Type
+}
+
+// String returns human readable representation of the entry.
+func (e Entry) String() string {
+	return fmt.Sprintf("service %s of %s", e.Type, e.Identity)
+}
+
+// JSONOptions returns entry options encoded the same way as tequilapi service start request options.
+func (e Entry) JSONOptions() (*json.RawMessage, error) {
+	if len(e.Options) == 0 {
+		return nil, nil
+	}
+	encoded, err := json.Marshal(e.Options)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to encode options of "+e.String())
+	}
+	raw := json.RawMessage(encoded)
+	return &raw, nil
+}
+
+// ServiceRestartPolicy returns the restart policy which should be applied to the service.
+func (e Entry) ServiceRestartPolicy() service.RestartPolicy {
+	policy := service.DefaultRestartPolicy()
+	if e.RestartPolicy != nil {
+		policy.Type = service.RestartPolicyType(e.RestartPolicy.Type)
+		policy.MaxRestarts = e.RestartPolicy.MaxRestarts
+	}
+	return policy
+}
+
+// ServicePrice returns the price which should be applied to the service, nil means the default price.
+func (e Entry) ServicePrice() *market.Price {
+	if e.Price == nil {
+		return nil
+	}
+	price := toMarketPrice(*e.Price)
+	return &price
+}
+
+// PricingStrategy returns the dynamic pricing strategy of the service, nil means the service is priced statically.
+func (e Entry) PricingStrategy() (pricing.Strategy, error) {
+	if len(e.PricingRules) == 0 {
+		return nil, nil
+	}
+	chain := make(pricing.Chain, len(e.PricingRules))
+	for i, rule := range e.PricingRules {
+		strategy, err := rule.strategy()
+		if err != nil {
+			return nil, errors.Wrapf(err, "pricing rule #%d", i+1)
+		}
+		chain[i] = strategy
+	}
+	return chain, nil
+}
+
+func toMarketPrice(price Price) market.Price {
+	return market.Price{
+		PerMinute: money.NewMoney(price.PerMinute, money.CurrencyMyst),
+		PerGB:     money.NewMoney(price.PerGB, money.CurrencyMyst),
+	}
+}
+
+// Passphrase returns the passphrase of the entry identity, or the given default one if the entry has no passphrase file.
+func (e Entry) Passphrase(defaultPassphrase string) string {
+	if e.passphrase == nil {
+		return defaultPassphrase
+	}
+	return *e.passphrase
+}
+
+// requiresRestart checks whether changing from the given entry to this one requires the service to be restarted.
+func (e Entry) requiresRestart(applied Entry) bool {
+	return !reflect.DeepEqual(e.passphrase, applied.passphrase) ||
+		!reflect.DeepEqual(e.Options, applied.Options) ||
+		e.ServiceRestartPolicy() != applied.ServiceRestartPolicy()
+}
+
+// Load reads and validates the services file.
+func Load(path string) (File, error) {
+	var file File
+	if _, err := toml.DecodeFile(path, &file); err != nil {
+		return File{}, errors.Wrap(err, "failed to decode services file")
+	}
+	if err := file.validate(); err != nil {
+		return File{}, errors.Wrap(err, "invalid services file")
+	}
+	for i := range file.Services {
+		if err := file.Services[i].readPassphrase(filepath.Dir(path)); err != nil {
+			return File{}, errors.Wrapf(err, "service #%d", i+1)
+		}
+	}
+	return file, nil
+}
+
+// readPassphrase reads the passphrase file of the entry, refusing files readable by other users.
+func (e *Entry) readPassphrase(dir string) error {
+	if e.PassphraseFile == "" {
+		return nil
+	}
+	path := e.PassphraseFile
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(dir, path)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return errors.Wrap(err, "failed to read passphrase file")
+	}
+	if runtime.GOOS != "windows" && info.Mode().Perm()&0077 != 0 {
+		return fmt.Errorf("passphrase file %s must not be accessible by group or others, permissions are %v", path, info.Mode().Perm())
+	}
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return errors.Wrap(err, "failed to read passphrase file")
+	}
+	passphrase := strings.TrimRight(string(content), "\r\n")
+	e.passphrase = &passphrase
+	return nil
+}
+
+func (f File) validate() error {
+	keys := make(map[string]bool, len(f.Services))
+	for i, entry := range f.Services {
+		if entry.Identity == "" {
+			return fmt.Errorf("service #%d: identity is required", i+1)
+		}
+		if entry.Type == "" {
+			return fmt.Errorf("service #%d: type is required", i+1)
+		}
+		if keys[entry.Key()] {
+			return fmt.Errorf("service #%d: %s is listed more than once", i+1, entry)
+		}
+		keys[entry.Key()] = true
+
+		policy := entry.ServiceRestartPolicy()
+		if !policy.IsValid() {
+			return fmt.Errorf("service #%d: invalid restart policy %q", i+1, policy.Type)
+		}
+		if policy.MaxRestarts < 0 {
+			return fmt.Errorf("service #%d: max restarts can not be negative", i+1)
+		}
+		if _, err := entry.PricingStrategy(); err != nil {
+			return fmt.Errorf("service #%d: %v", i+1, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,201 @@
+/*
+ * Copyright (C) 2020 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package declarative
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/mysteriumnetwork/node/core/service"
+	"github.com/mysteriumnetwork/node/core/service/pricing"
+	"github.com/stretchr/testify/assert"
+)
+
+func writeServicesFile(t *testing.T, content string) string {
+	file, err := ioutil.TempFile("", "services*.toml")
+	assert.NoError(t, err)
+	defer file.Close()
+
+	_, err = file.WriteString(content)
+	assert.NoError(t, err)
+	return file.Name()
+}
+
+func writePassphraseFile(t *testing.T, passphrase string, mode os.FileMode) string {
+	file, err := ioutil.TempFile("", "passphrase")
+	assert.NoError(t, err)
+	defer file.Close()
+
+	_, err = file.WriteString(passphrase)
+	assert.NoError(t, err)
+	assert.NoError(t, file.Chmod(mode))
+	return file.Name()
+}
+
+func TestLoad(t *testing.T) {
+	passphraseFile := writePassphraseFile(t, "secret\n", 0600)
+	defer os.Remove(passphraseFile)
+
+	path := writeServicesFile(t, `
+shaper = true
+
+[[service]]
+identity = "0x1"
+passphrase-file = "`+filepath.Base(passphraseFile)+`"
+type = "openvpn"
+access-policies = ["mysterium"]
+  [service.options]
+  port = 1194
+  protocol = "udp"
+  [service.restart-policy]
+  type = "on-failure"
+  max-restarts = 3
+
+[[service]]
+identity = "0x1"
+type = "wireguard"
+`)
+
+	defer os.Remove(path)
+
+	file, err := Load(path)
+	assert.NoError(t, err)
+	assert.True(t, *file.Shaper)
+	assert.Len(t, file.Services, 2)
+
+	openvpn := file.Services[0]
+	assert.Equal(t, "0x1", openvpn.Identity)
+	assert.Equal(t, "secret", openvpn.Passphrase("default"))
+	assert.Equal(t, []string{"mysterium"}, openvpn.AccessPolicies)
+	assert.Equal(t, service.RestartOnFailure, openvpn.ServiceRestartPolicy().Type)
+	assert.Equal(t, 3, openvpn.ServiceRestartPolicy().MaxRestarts)
+	options, err := openvpn.JSONOptions()
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"port": 1194, "protocol": "udp"}`, string(*options))
+
+	wireguard := file.Services[1]
+	assert.Equal(t, "default", wireguard.Passphrase("default"))
+	assert.Equal(t, service.DefaultRestartPolicy(), wireguard.ServiceRestartPolicy())
+	options, err = wireguard.JSONOptions()
+	assert.NoError(t, err)
+	assert.Nil(t, options)
+}
+
+func TestLoad_PassphraseFileAccessibleByOthers(t *testing.T) {
+	passphraseFile := writePassphraseFile(t, "secret", 0644)
+	defer os.Remove(passphraseFile)
+
+	path := writeServicesFile(t, `
+[[service]]
+identity = "0x1"
+passphrase-file = "`+passphraseFile+`"
+type = "openvpn"
+`)
+	defer os.Remove(path)
+
+	_, err := Load(path)
+	assert.EqualError(t, err, "service #1: passphrase file "+passphraseFile+" must not be accessible by group or others, permissions are -rw-r--r--")
+}
+
+func TestLoad_PricingRules(t *testing.T) {
+	path := writeServicesFile(t, `
+[[service]]
+identity = "0x1"
+type = "openvpn"
+  [service.price]
+  per-minute = 100
+  [[service.pricing-rule]]
+  type = "time-window"
+  from = "18:00"
+  to = "23:30"
+  per-minute = 200
+  [[service.pricing-rule]]
+  type = "load"
+  sessions = 5
+  per-minute = 300
+  per-gb = 1000
+`)
+	defer os.Remove(path)
+
+	file, err := Load(path)
+	assert.NoError(t, err)
+
+	strategy, err := file.Services[0].PricingStrategy()
+	assert.NoError(t, err)
+	assert.Equal(t, pricing.Chain{
+		pricing.TimeWindow{From: 18 * time.Hour, To: 23*time.Hour + 30*time.Minute, Charge: toMarketPrice(Price{PerMinute: 200})},
+		pricing.Load{Sessions: 5, Charge: toMarketPrice(Price{PerMinute: 300, PerGB: 1000})},
+	}, strategy)
+}
+
+func TestLoad_Invalid(t *testing.T) {
+	tests := map[string]string{
+		"missing identity": `
+[[service]]
+type = "openvpn"
+`,
+		"missing type": `
+[[service]]
+identity = "0x1"
+`,
+		"duplicate service": `
+[[service]]
+identity = "0x1"
+type = "openvpn"
+[[service]]
+identity = "0x1"
+type = "openvpn"
+`,
+		"unknown restart policy": `
+[[service]]
+identity = "0x1"
+type = "openvpn"
+  [service.restart-policy]
+  type = "sometimes"
+`,
+		"unknown pricing rule": `
+[[service]]
+identity = "0x1"
+type = "openvpn"
+  [[service.pricing-rule]]
+  type = "weather"
+`,
+		"malformed pricing window": `
+[[service]]
+identity = "0x1"
+type = "openvpn"
+  [[service.pricing-rule]]
+  type = "time-window"
+  from = "6pm"
+  to = "23:00"
+`,
+		"malformed toml": `[[service]`,
+	}
+	for name, content := range tests {
+		t.Run(name, func(t *testing.T) {
+			path := writeServicesFile(t, content)
+			defer os.Remove(path)
+
+			_, err := Load(path)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,401 @@
+/*
+ * Copyright (C) 2020 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package declarative
+
+import (
+	"encoding/json"
+	"os"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/mysteriumnetwork/node/config"
+	"github.com/mysteriumnetwork/node/core/service"
+	"github.com/mysteriumnetwork/node/core/service/pricing"
+	"github.com/mysteriumnetwork/node/identity"
+	"github.com/mysteriumnetwork/node/market"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+// AppTopicServicesDrift represents the topic to which drift between the services file and running services is published.
+const AppTopicServicesDrift = "Services file drift"
+
+const watchInterval = 10 * time.Second
+
+// DriftKind describes the difference between the services file and running services
+type DriftKind string
+
+const (
+	// DriftMissing means that service listed in the file is not running
+	DriftMissing = DriftKind("missing")
+	// DriftChanged means that service definition was changed in the file
+	DriftChanged = DriftKind("changed")
+	// DriftAccessPolicies means that access policies of running service differ from the file
+	DriftAccessPolicies = DriftKind("access-policies")
+	// DriftPrice means that price of running service differs from the file
+	DriftPrice = DriftKind("price")
+	// DriftPricingRules means that dynamic pricing rules were changed in the file
+	DriftPricingRules = DriftKind("pricing-rules")
+	// DriftShaper means that bandwidth limitation of running services differs from the file
+	DriftShaper = DriftKind("shaper")
+	// DriftRemoved means that running service is no longer listed in the file
+	DriftRemoved = DriftKind("removed")
+)
+
+// Drift is published every time the reconciler has to correct running services.
+type Drift struct {
+	Kind       DriftKind `json:"kind"`
+	ProviderID string    `json:"providerId"`
+	Type       string    `json:"type"`
+	ServiceID  string    `json:"serviceId,omitempty"`
+}
+
+// ServiceManager manages running services.
+type ServiceManager interface {
+	Start(providerID identity.Identity, serviceType string, policyIDs []string, options service.Options, restartPolicy service.RestartPolicy, price *market.Price) (service.ID, error)
+	Stop(id service.ID) error
+	Service(id service.ID) *service.Instance
+	UpdateAccessPolicies(id service.ID, policyIDs []string) error
+	UpdatePrice(id service.ID, price market.Price) error
+	SetPricingStrategy(id service.ID, strategy pricing.Strategy) error
+}
+
+// OptionsParser parses service options from JSON, falling back to configured options for missing values.
+type OptionsParser func(*json.RawMessage) (service.Options, error)
+
+type identityUnlocker interface {
+	Unlock(address string, passphrase string) error
+}
+
+type publisher interface {
+	Publish(topic string, data interface{})
+}
+
+type managedService struct {
+	id    service.ID
+	entry Entry
+}
+
+// Reconciler keeps running services in line with the services file.
+// Services started by other means (e.g. tequilapi) are left untouched.
+type Reconciler struct {
+	path          string
+	watchInterval time.Duration
+	manager       ServiceManager
+	unlocker      identityUnlocker
+	passphrase    string
+	parsers       map[string]OptionsParser
+	publisher     publisher
+
+	lock    sync.Mutex
+	desired File
+	modTime time.Time
+	managed map[string]managedService
+	// overriddenShaper is the bandwidth limitation from the file which could not be applied, because of the CLI flag.
+	overriddenShaper *bool
+
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewReconciler creates a reconciler for the given services file.
+// Identities of the services without a passphrase file are unlocked with the given passphrase.
+func NewReconciler(
+	path string,
+	manager ServiceManager,
+	unlocker identityUnlocker,
+	passphrase string,
+	parsers map[string]OptionsParser,
+	publisher publisher,
+) *Reconciler {
+	return &Reconciler{
+		path:          path,
+		watchInterval: watchInterval,
+		manager:       manager,
+		unlocker:      unlocker,
+		passphrase:    passphrase,
+		parsers:       parsers,
+		publisher:     publisher,
+		managed:       make(map[string]managedService),
+		stop:          make(chan struct{}),
+	}
+}
+
+// Start applies the services file and keeps reconciling running services with it in the background.
+func (r *Reconciler) Start() error {
+	if err := r.Reload(); err != nil {
+		return err
+	}
+	go r.watch()
+	return nil
+}
+
+// Stop stops watching the services file. Running services are left running.
+func (r *Reconciler) Stop() {
+	r.stopOnce.Do(func() {
+		close(r.stop)
+	})
+}
+
+// Reload reads the services file again and reconciles running services with it.
+// If the file can not be loaded, running services are left as they are.
+func (r *Reconciler) Reload() error {
+	info, err := os.Stat(r.path)
+	if err != nil {
+		return errors.Wrap(err, "failed to read services file")
+	}
+	file, err := Load(r.path)
+	if err != nil {
+		return err
+	}
+	log.Info().Msgf("Services file loaded: %s, services: %d", r.path, len(file.Services))
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.desired = file
+	r.modTime = info.ModTime()
+	r.reconcile()
+	return nil
+}
+
+func (r *Reconciler) watch() {
+	ticker := time.NewTicker(r.watchInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.stop:
+			return
+		case <-ticker.C:
+			if r.fileChanged() {
+				if err := r.Reload(); err != nil {
+					log.Error().Err(err).Msg("Failed to reload services file")
+				}
+				continue
+			}
+
+			r.lock.Lock()
+			r.reconcile()
+			r.lock.Unlock()
+		}
+	}
+}
+
+func (r *Reconciler) fileChanged() bool {
+	info, err := os.Stat(r.path)
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to check services file")
+		return false
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return !info.ModTime().Equal(r.modTime)
+}
+
+func (r *Reconciler) reconcile() {
+	listed := make(map[string]bool, len(r.desired.Services))
+	for _, entry := range r.desired.Services {
+		listed[entry.Key()] = true
+	}
+
+	for key, managed := range r.managed {
+		if listed[key] {
+			continue
+		}
+		r.report(DriftRemoved, managed.entry, managed.id)
+		if err := r.manager.Stop(managed.id); err != nil {
+			log.Warn().Err(err).Msgf("Failed to stop %s", managed.entry)
+		}
+		delete(r.managed, key)
+	}
+
+	r.reconcileShaper()
+
+	for _, entry := range r.desired.Services {
+		r.reconcileEntry(entry)
+	}
+}
+
+func (r *Reconciler) reconcileEntry(entry Entry) {
+	if managed, ok := r.managed[entry.Key()]; ok {
+		instance := r.manager.Service(managed.id)
+		switch {
+		case instance == nil:
+			r.report(DriftMissing, entry, managed.id)
+		case entry.requiresRestart(managed.entry):
+			r.report(DriftChanged, entry, managed.id)
+			if err := r.manager.Stop(managed.id); err != nil {
+				log.Warn().Err(err).Msgf("Failed to stop %s", entry)
+			}
+		default:
+			if !samePolicies(instance.AccessPolicies(), entry.AccessPolicies) {
+				r.report(DriftAccessPolicies, entry, managed.id)
+				if err := r.manager.UpdateAccessPolicies(managed.id, entry.AccessPolicies); err != nil {
+					log.Error().Err(err).Msgf("Failed to update access policies of %s", entry)
+				}
+			}
+			if price := entry.ServicePrice(); price != nil && !r.samePrice(instance, entry, managed.entry) {
+				r.report(DriftPrice, entry, managed.id)
+				if err := r.manager.UpdatePrice(managed.id, *price); err != nil {
+					log.Error().Err(err).Msgf("Failed to update price of %s", entry)
+				}
+			}
+			if !reflect.DeepEqual(entry.PricingRules, managed.entry.PricingRules) {
+				r.report(DriftPricingRules, entry, managed.id)
+				if err := r.applyPricingRules(managed.id, entry); err != nil {
+					log.Error().Err(err).Msgf("Failed to update pricing rules of %s", entry)
+				}
+			}
+			r.managed[entry.Key()] = managedService{id: managed.id, entry: entry}
+			return
+		}
+		delete(r.managed, entry.Key())
+	}
+
+	id, err := r.start(entry)
+	if err != nil {
+		log.Error().Err(err).Msgf("Failed to start %s", entry)
+		return
+	}
+	log.Info().Msgf("Started %s, service ID: %s", entry, id)
+	r.managed[entry.Key()] = managedService{id: id, entry: entry}
+}
+
+func (r *Reconciler) start(entry Entry) (service.ID, error) {
+	parse, ok := r.parsers[entry.Type]
+	if !ok {
+		return "", service.ErrUnsupportedServiceType
+	}
+	rawOptions, err := entry.JSONOptions()
+	if err != nil {
+		return "", err
+	}
+	options, err := parse(rawOptions)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to parse options")
+	}
+	if err := r.unlocker.Unlock(entry.Identity, entry.Passphrase(r.passphrase)); err != nil {
+		return "", errors.Wrap(err, "failed to unlock identity")
+	}
+
+	id, err := r.manager.Start(
+		identity.FromAddress(entry.Identity),
+		entry.Type,
+		entry.AccessPolicies,
+		options,
+		entry.ServiceRestartPolicy(),
+		entry.ServicePrice(),
+	)
+	if err != nil {
+		return id, err
+	}
+	if len(entry.PricingRules) > 0 {
+		if err := r.applyPricingRules(id, entry); err != nil {
+			log.Error().Err(err).Msgf("Failed to apply pricing rules of %s", entry)
+		}
+	}
+	return id, nil
+}
+
+func (r *Reconciler) applyPricingRules(id service.ID, entry Entry) error {
+	strategy, err := entry.PricingStrategy()
+	if err != nil {
+		return err
+	}
+	return r.manager.SetPricingStrategy(id, strategy)
+}
+
+// samePrice checks whether the running service is charged at the price from the file.
+// Dynamically priced service announces the price decided by its pricing rules, so its price is compared to the applied file instead.
+func (r *Reconciler) samePrice(instance *service.Instance, entry, applied Entry) bool {
+	if len(applied.PricingRules) > 0 {
+		return reflect.DeepEqual(entry.Price, applied.Price)
+	}
+	return samePrice(instance.Proposal().PaymentMethod, *entry.ServicePrice())
+}
+
+// reconcileShaper applies bandwidth limitation from the file node wide.
+// Services set up shaping when started, so running ones are stopped to be started again with the new limitation.
+func (r *Reconciler) reconcileShaper() {
+	shaper := r.desired.Shaper
+	if shaper == nil || config.GetBool(config.FlagShaperEnabled) == *shaper {
+		return
+	}
+	if r.overriddenShaper != nil && *r.overriddenShaper == *shaper {
+		return
+	}
+
+	log.Info().Msgf("Applying bandwidth limitation from services file: %v", *shaper)
+	config.Current.SetUser(config.FlagShaperEnabled.Name, *shaper)
+	if config.GetBool(config.FlagShaperEnabled) != *shaper {
+		log.Warn().Msgf("Bandwidth limitation from services file is overridden by flag %q", config.FlagShaperEnabled.Name)
+		r.overriddenShaper = shaper
+		return
+	}
+	r.overriddenShaper = nil
+
+	for _, entry := range r.desired.Services {
+		managed, ok := r.managed[entry.Key()]
+		if !ok {
+			continue
+		}
+		r.report(DriftShaper, entry, managed.id)
+		if err := r.manager.Stop(managed.id); err != nil {
+			log.Warn().Err(err).Msgf("Failed to stop %s", entry)
+		}
+		delete(r.managed, entry.Key())
+	}
+}
+
+func (r *Reconciler) report(kind DriftKind, entry Entry, id service.ID) {
+	log.Warn().Msgf("Services file drift (%s): %s, service ID: %s", kind, entry, id)
+	r.publisher.Publish(AppTopicServicesDrift, Drift{
+		Kind:       kind,
+		ProviderID: entry.Identity,
+		Type:       entry.Type,
+		ServiceID:  string(id),
+	})
+}
+
+func samePolicies(policies *[]market.AccessPolicy, policyIDs []string) bool {
+	var running []market.AccessPolicy
+	if policies != nil {
+		running = *policies
+	}
+	if len(running) != len(policyIDs) {
+		return false
+	}
+	for i := range running {
+		if running[i].ID != policyIDs[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func samePrice(method market.PaymentMethod, price market.Price) bool {
+	priced, ok := method.(market.PricedPaymentMethod)
+	if !ok {
+		// price can not be applied, nothing to reconcile
+		return true
+	}
+	return priced.GetPrice().Amount == price.PerMinute.Amount && priced.GetPricePerGB().Amount == price.PerGB.Amount
+}
@@ -0,0 +1,361 @@
+/*
+ * Copyright (C) 2020 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package declarative
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mysteriumnetwork/node/config"
+	"github.com/mysteriumnetwork/node/core/service"
+	"github.com/mysteriumnetwork/node/core/service/pricing"
+	"github.com/mysteriumnetwork/node/identity"
+	"github.com/mysteriumnetwork/node/market"
+	"github.com/mysteriumnetwork/node/money"
+	"github.com/mysteriumnetwork/node/services/openvpn/discovery/dto"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockServiceManager struct {
+	lastID     int
+	instances  map[service.ID]*service.Instance
+	started    []string
+	stopped    []service.ID
+	updated    map[service.ID][]string
+	prices     map[service.ID]market.Price
+	strategies map[service.ID]pricing.Strategy
+}
+
+func newMockServiceManager() *mockServiceManager {
+	return &mockServiceManager{
+		instances:  make(map[service.ID]*service.Instance),
+		updated:    make(map[service.ID][]string),
+		prices:     make(map[service.ID]market.Price),
+		strategies: make(map[service.ID]pricing.Strategy),
+	}
+}
+
+func (m *mockServiceManager) Start(providerID identity.Identity, serviceType string, policyIDs []string, options service.Options, _ service.RestartPolicy, price *market.Price) (service.ID, error) {
+	m.lastID++
+	id := service.ID(fmt.Sprint(m.lastID))
+	paymentMethod := dto.PaymentRate{Duration: time.Minute}.WithPrice(market.Price{})
+	if price != nil {
+		paymentMethod = paymentMethod.(market.PricedPaymentMethod).WithPrice(*price)
+	}
+	m.instances[id] = service.NewInstance(options, service.Running, nil, market.ServiceProposal{
+		ProviderID:     providerID.Address,
+		ServiceType:    serviceType,
+		AccessPolicies: toPolicies(policyIDs),
+		PaymentMethod:  paymentMethod,
+	}, nil, nil)
+	m.started = append(m.started, providerID.Address+"/"+serviceType)
+	return id, nil
+}
+
+func (m *mockServiceManager) Stop(id service.ID) error {
+	delete(m.instances, id)
+	m.stopped = append(m.stopped, id)
+	return nil
+}
+
+func (m *mockServiceManager) Service(id service.ID) *service.Instance {
+	return m.instances[id]
+}
+
+func (m *mockServiceManager) UpdateAccessPolicies(id service.ID, policyIDs []string) error {
+	m.updated[id] = policyIDs
+	return nil
+}
+
+func (m *mockServiceManager) UpdatePrice(id service.ID, price market.Price) error {
+	m.prices[id] = price
+	return nil
+}
+
+func (m *mockServiceManager) SetPricingStrategy(id service.ID, strategy pricing.Strategy) error {
+	m.strategies[id] = strategy
+	return nil
+}
+
+func toPolicies(policyIDs []string) *[]market.AccessPolicy {
+	if len(policyIDs) == 0 {
+		return nil
+	}
+	policies := make([]market.AccessPolicy, len(policyIDs))
+	for i, id := range policyIDs {
+		policies[i] = market.AccessPolicy{ID: id}
+	}
+	return &policies
+}
+
+type mockUnlocker struct {
+	unlocked []string
+}
+
+func (m *mockUnlocker) Unlock(address string, _ string) error {
+	m.unlocked = append(m.unlocked, address)
+	return nil
+}
+
+type mockPublisher struct {
+	lock   sync.Mutex
+	drifts []Drift
+}
+
+func (m *mockPublisher) Publish(_ string, data interface{}) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.drifts = append(m.drifts, data.(Drift))
+}
+
+func parseOptions(request *json.RawMessage) (service.Options, error) {
+	if request == nil {
+		return nil, nil
+	}
+	var options map[string]interface{}
+	err := json.Unmarshal(*request, &options)
+	return options, err
+}
+
+func newTestReconciler(t *testing.T, content string) (*Reconciler, *mockServiceManager, *mockPublisher) {
+	manager := newMockServiceManager()
+	publisher := &mockPublisher{}
+	reconciler := NewReconciler(
+		writeServicesFile(t, content),
+		manager,
+		&mockUnlocker{},
+		"",
+		map[string]OptionsParser{"openvpn": parseOptions, "wireguard": parseOptions},
+		publisher,
+	)
+	return reconciler, manager, publisher
+}
+
+func rewriteServicesFile(t *testing.T, path, content string) {
+	assert.NoError(t, ioutil.WriteFile(path, []byte(content), 0600))
+}
+
+func TestReconciler_StartsListedServices(t *testing.T) {
+	reconciler, manager, publisher := newTestReconciler(t, `
+[[service]]
+identity = "0x1"
+type = "openvpn"
+[[service]]
+identity = "0x1"
+type = "wireguard"
+`)
+	defer os.Remove(reconciler.path)
+
+	assert.NoError(t, reconciler.Reload())
+	assert.Equal(t, []string{"0x1/openvpn", "0x1/wireguard"}, manager.started)
+	assert.Len(t, publisher.drifts, 0)
+
+	assert.NoError(t, reconciler.Reload())
+	assert.Len(t, manager.started, 2)
+}
+
+func TestReconciler_AppliesFileChanges(t *testing.T) {
+	reconciler, manager, publisher := newTestReconciler(t, `
+[[service]]
+identity = "0x1"
+type = "openvpn"
+[[service]]
+identity = "0x1"
+type = "wireguard"
+`)
+	defer os.Remove(reconciler.path)
+	assert.NoError(t, reconciler.Reload())
+
+	rewriteServicesFile(t, reconciler.path, `
+[[service]]
+identity = "0x1"
+type = "openvpn"
+access-policies = ["mysterium"]
+`)
+	assert.NoError(t, reconciler.Reload())
+
+	assert.Equal(t, []service.ID{"2"}, manager.stopped)
+	assert.Equal(t, []string{"mysterium"}, manager.updated["1"])
+	assert.Equal(t, []Drift{
+		{Kind: DriftRemoved, ProviderID: "0x1", Type: "wireguard", ServiceID: "2"},
+		{Kind: DriftAccessPolicies, ProviderID: "0x1", Type: "openvpn", ServiceID: "1"},
+	}, publisher.drifts)
+
+	rewriteServicesFile(t, reconciler.path, `
+[[service]]
+identity = "0x1"
+type = "openvpn"
+access-policies = ["mysterium"]
+  [service.options]
+  port = 1194
+`)
+	assert.NoError(t, reconciler.Reload())
+
+	assert.Equal(t, []service.ID{"2", "1"}, manager.stopped)
+	assert.Equal(t, []string{"0x1/openvpn", "0x1/wireguard", "0x1/openvpn"}, manager.started)
+	assert.Equal(t, map[string]interface{}{"port": float64(1194)}, manager.instances["3"].Options())
+}
+
+func TestReconciler_UpdatesPrice(t *testing.T) {
+	reconciler, manager, publisher := newTestReconciler(t, `
+[[service]]
+identity = "0x1"
+type = "openvpn"
+  [service.price]
+  per-minute = 100
+`)
+	defer os.Remove(reconciler.path)
+	assert.NoError(t, reconciler.Reload())
+	assert.Len(t, publisher.drifts, 0)
+
+	rewriteServicesFile(t, reconciler.path, `
+[[service]]
+identity = "0x1"
+type = "openvpn"
+  [service.price]
+  per-minute = 100
+  per-gb = 2000
+`)
+	assert.NoError(t, reconciler.Reload())
+
+	assert.Len(t, manager.stopped, 0)
+	assert.Equal(t, market.Price{
+		PerMinute: money.NewMoney(100, money.CurrencyMyst),
+		PerGB:     money.NewMoney(2000, money.CurrencyMyst),
+	}, manager.prices["1"])
+	assert.Equal(t, []Drift{{Kind: DriftPrice, ProviderID: "0x1", Type: "openvpn", ServiceID: "1"}}, publisher.drifts)
+}
+
+func TestReconciler_AppliesPricingRules(t *testing.T) {
+	reconciler, manager, publisher := newTestReconciler(t, `
+[[service]]
+identity = "0x1"
+type = "openvpn"
+  [service.price]
+  per-minute = 100
+  [[service.pricing-rule]]
+  type = "load"
+  sessions = 5
+  per-minute = 300
+`)
+	defer os.Remove(reconciler.path)
+	assert.NoError(t, reconciler.Reload())
+	assert.Equal(t, pricing.Chain{pricing.Load{Sessions: 5, Charge: toMarketPrice(Price{PerMinute: 300})}}, manager.strategies["1"])
+
+	assert.NoError(t, reconciler.Reload())
+	assert.Len(t, publisher.drifts, 0)
+
+	rewriteServicesFile(t, reconciler.path, `
+[[service]]
+identity = "0x1"
+type = "openvpn"
+  [service.price]
+  per-minute = 100
+`)
+	assert.NoError(t, reconciler.Reload())
+	assert.Nil(t, manager.strategies["1"])
+	assert.Equal(t, []Drift{{Kind: DriftPricingRules, ProviderID: "0x1", Type: "openvpn", ServiceID: "1"}}, publisher.drifts)
+}
+
+func TestReconciler_AppliesShaper(t *testing.T) {
+	defer config.Current.RemoveUser(config.FlagShaperEnabled.Name)
+	reconciler, manager, publisher := newTestReconciler(t, `
+[[service]]
+identity = "0x1"
+type = "openvpn"
+`)
+	defer os.Remove(reconciler.path)
+	assert.NoError(t, reconciler.Reload())
+
+	rewriteServicesFile(t, reconciler.path, `
+shaper = true
+[[service]]
+identity = "0x1"
+type = "openvpn"
+`)
+	assert.NoError(t, reconciler.Reload())
+	assert.True(t, config.GetBool(config.FlagShaperEnabled))
+	assert.Equal(t, []service.ID{"1"}, manager.stopped)
+	assert.Equal(t, []string{"0x1/openvpn", "0x1/openvpn"}, manager.started)
+	assert.Equal(t, []Drift{{Kind: DriftShaper, ProviderID: "0x1", Type: "openvpn", ServiceID: "1"}}, publisher.drifts)
+
+	reconciler.reconcile()
+	assert.Len(t, publisher.drifts, 1)
+
+	config.Current.SetUser(config.FlagShaperEnabled.Name, false)
+	reconciler.reconcile()
+	assert.True(t, config.GetBool(config.FlagShaperEnabled))
+	assert.Equal(t, []service.ID{"1", "2"}, manager.stopped)
+	assert.Equal(t, Drift{Kind: DriftShaper, ProviderID: "0x1", Type: "openvpn", ServiceID: "2"}, publisher.drifts[1])
+}
+
+func TestReconciler_KeepsShaperOverriddenByFlag(t *testing.T) {
+	config.Current.SetCLI(config.FlagShaperEnabled.Name, false)
+	defer config.Current.RemoveCLI(config.FlagShaperEnabled.Name)
+	defer config.Current.RemoveUser(config.FlagShaperEnabled.Name)
+	reconciler, manager, publisher := newTestReconciler(t, `
+shaper = true
+[[service]]
+identity = "0x1"
+type = "openvpn"
+`)
+	defer os.Remove(reconciler.path)
+	assert.NoError(t, reconciler.Reload())
+	reconciler.reconcile()
+
+	assert.False(t, config.GetBool(config.FlagShaperEnabled))
+	assert.Len(t, manager.stopped, 0)
+	assert.Len(t, publisher.drifts, 0)
+}
+
+func TestReconciler_RestartsMissingServices(t *testing.T) {
+	reconciler, manager, publisher := newTestReconciler(t, `
+[[service]]
+identity = "0x1"
+type = "openvpn"
+`)
+	defer os.Remove(reconciler.path)
+	assert.NoError(t, reconciler.Reload())
+
+	assert.NoError(t, manager.Stop("1"))
+	reconciler.reconcile()
+
+	assert.Equal(t, []string{"0x1/openvpn", "0x1/openvpn"}, manager.started)
+	assert.Equal(t, []Drift{{Kind: DriftMissing, ProviderID: "0x1", Type: "openvpn", ServiceID: "1"}}, publisher.drifts)
+}
+
+func TestReconciler_KeepsServicesWhenFileIsInvalid(t *testing.T) {
+	reconciler, manager, _ := newTestReconciler(t, `
+[[service]]
+identity = "0x1"
+type = "openvpn"
+`)
+	defer os.Remove(reconciler.path)
+	assert.NoError(t, reconciler.Reload())
+
+	rewriteServicesFile(t, reconciler.path, `[[service]`)
+	assert.Error(t, reconciler.Reload())
+
+	assert.Len(t, manager.stopped, 0)
+	assert.NotNil(t, manager.Service("1"))
+}
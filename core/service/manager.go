@@ -19,12 +19,15 @@ package service
 
 import (
 	"encoding/json"
+	"time"
 
 	"github.com/gofrs/uuid"
 	"github.com/mysteriumnetwork/node/communication"
 	"github.com/mysteriumnetwork/node/core/policy"
+	"github.com/mysteriumnetwork/node/core/service/pricing"
 	"github.com/mysteriumnetwork/node/identity"
 	"github.com/mysteriumnetwork/node/market"
+	"github.com/mysteriumnetwork/node/money"
 	"github.com/mysteriumnetwork/node/session"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog/log"
@@ -37,6 +40,10 @@ var (
 	ErrUnsupportedServiceType = errors.New("unsupported service type")
 	// ErrUnsupportedAccessPolicy indicates that manager tried to create service with unsupported access policy
 	ErrUnsupportedAccessPolicy = errors.New("unsupported access policy")
+	// ErrUnsupportedPricing indicates that service payment method does not allow provider to set the price
+	ErrUnsupportedPricing = errors.New("service does not support custom pricing")
+	// ErrDataTransferNotReported indicates that service can't be charged by transferred data, as it does not report the traffic of sessions
+	ErrDataTransferNotReported = errors.New("service does not report transferred data")
 )
 
 // Service interface represents pluggable Mysterium service
@@ -46,11 +53,26 @@ type Service interface {
 	ProvideConfig(sessionConfig json.RawMessage) (*session.ConfigParams, error)
 }
 
+// DataTransferReporter is implemented by services publishing the provider side traffic of sessions.
+// Only such services can charge sessions by the transferred data.
+type DataTransferReporter interface {
+	ReportsDataTransfer() bool
+}
+
 // DialogWaiterFactory initiates communication channel which waits for incoming dialogs
-type DialogWaiterFactory func(providerID identity.Identity, serviceType string, policies *[]market.AccessPolicy) (communication.DialogWaiter, error)
+type DialogWaiterFactory func(providerID identity.Identity, serviceType string, policies policy.PoliciesProvider) (communication.DialogWaiter, error)
+
+// ProposalProvider returns the current proposal of the service
+type ProposalProvider func() market.ServiceProposal
+
+// PriceQuoter returns the current price quote of the service
+type PriceQuoter func() pricing.Quote
+
+// SessionCounter returns the number of active sessions of the service
+type SessionCounter func(id ID) int
 
 // DialogHandlerFactory initiates instance which is able to handle incoming dialogs
-type DialogHandlerFactory func(market.ServiceProposal, session.ConfigProvider, string) (communication.DialogHandler, error)
+type DialogHandlerFactory func(ProposalProvider, PriceQuoter, session.ConfigProvider, string) (communication.DialogHandler, error)
 
 // DiscoveryFactory initiates instance which is able announce service discoverability
 type DiscoveryFactory func() Discovery
@@ -58,10 +80,18 @@ type DiscoveryFactory func() Discovery
 // Discovery registers the service to the discovery api periodically
 type Discovery interface {
 	Start(ownIdentity identity.Identity, proposal market.ServiceProposal)
+	Update(proposal market.ServiceProposal)
 	Stop()
 	Wait()
 }
 
+const (
+	// livenessProbeInterval is the interval between liveness probes of the running services
+	livenessProbeInterval = 30 * time.Second
+	// repricingInterval is the interval between re-evaluations of dynamically priced services
+	repricingInterval = time.Minute
+)
+
 // WaitForNATHole blocks until NAT hole is punched towards consumer through local NAT or until hole punching failed
 type WaitForNATHole func() error
 
@@ -73,6 +103,7 @@ func NewManager(
 	discoveryFactory DiscoveryFactory,
 	eventPublisher Publisher,
 	policyRepo *policy.Repository,
+	sessionCounter SessionCounter,
 ) *Manager {
 	return &Manager{
 		serviceRegistry:      serviceRegistry,
@@ -82,6 +113,11 @@ func NewManager(
 		discoveryFactory:     discoveryFactory,
 		eventPublisher:       eventPublisher,
 		policyRepo:           policyRepo,
+		sessionCounter:       sessionCounter,
+		clock:                time.Now,
+
+		livenessProbeInterval: livenessProbeInterval,
+		repricingInterval:     repricingInterval,
 	}
 }
 
@@ -96,78 +132,386 @@ type Manager struct {
 	discoveryFactory DiscoveryFactory
 	eventPublisher   Publisher
 	policyRepo       *policy.Repository
+	sessionCounter   SessionCounter
+	clock            func() time.Time
+	accountants      []string
+	paymentPackage   *PackageOptions
+	trial            *market.Trial
+
+	livenessProbeInterval time.Duration
+	repricingInterval     time.Duration
+}
+
+// SetAccountants sets the accountants advertised in proposals of services started afterwards.
+func (manager *Manager) SetAccountants(ids []string) {
+	manager.accountants = ids
+}
+
+// PackageOptions describes prepaid packages the services are sold in.
+type PackageOptions struct {
+	// Service duration included in the package, zero if package is not limited by time
+	Duration time.Duration
+	// Bytes transferred included in the package, zero if package is not limited by data
+	Bytes uint64
+	// Price of the package, zero if package is priced at what its duration and data cost at the service price
+	Price uint64
+}
+
+// SetPaymentPackage makes services started afterwards sold in prepaid packages, nil sells them at the usual rate.
+func (manager *Manager) SetPaymentPackage(pkg *PackageOptions) {
+	manager.paymentPackage = pkg
+}
+
+// SetTrial sets the free trial offered to new consumers by services started afterwards, nil offers none.
+func (manager *Manager) SetTrial(trial *market.Trial) {
+	manager.trial = trial
 }
 
 // Start starts an instance of the given service type if knows one in service registry.
 // It passes the options to the start method of the service.
 // If an error occurs in the underlying service, the error is then returned.
-func (manager *Manager) Start(providerID identity.Identity, serviceType string, policyIDs []string, options Options) (id ID, err error) {
+// Once service exits, it is restarted according to the given restart policy.
+// If price is not given, service is charged at the default price of its payment method.
+func (manager *Manager) Start(providerID identity.Identity, serviceType string, policyIDs []string, options Options, restartPolicy RestartPolicy, price *market.Price) (id ID, err error) {
 	service, proposal, err := manager.serviceRegistry.Create(serviceType, options)
 	if err != nil {
 		return id, err
 	}
+	reportsData := reportsDataTransfer(service)
+	if manager.trial != nil && manager.trial.Bytes > 0 && !reportsData {
+		return id, ErrDataTransferNotReported
+	}
+
+	if price != nil {
+		if err := validatePrice(proposal, *price, reportsData); err != nil {
+			return id, err
+		}
+		if proposal, err = applyPrice(proposal, *price); err != nil {
+			return id, err
+		}
+	}
 
-	var policies *[]market.AccessPolicy = nil
-	if len(policyIDs) > 0 {
-		policies = manager.policyRepo.Policies(policyIDs)
-		if err = manager.policyRepo.AddPolicies(*policies); err != nil {
-			return id, ErrUnsupportedAccessPolicy
+	if manager.paymentPackage != nil {
+		if proposal, err = applyPackage(proposal, *manager.paymentPackage, reportsData); err != nil {
+			return id, err
 		}
 	}
-	proposal.SetAccessPolicies(policies)
 
-	dialogWaiter, err := manager.dialogWaiterFactory(providerID, serviceType, policies)
+	policies, err := manager.resolvePolicies(policyIDs)
 	if err != nil {
 		return id, err
 	}
-	proposal.SetProviderContact(providerID, dialogWaiter.GetContact())
+	proposal.SetAccessPolicies(policies)
+	proposal.SetAccountants(manager.accountants)
+	proposal.SetTrial(manager.trial)
 
 	id, err = generateID()
 	if err != nil {
 		return id, err
 	}
-	dialogHandler, err := manager.dialogHandlerFactory(proposal, service, string(id))
-	if err != nil {
-		return id, err
-	}
-	if err = dialogWaiter.Start(dialogHandler); err != nil {
-		return id, err
-	}
 
-	discovery := manager.discoveryFactory()
-	discovery.Start(providerID, proposal)
-
-	instance := Instance{
+	instance := &Instance{
 		id:             id,
 		state:          Starting,
 		options:        options,
-		service:        service,
 		proposal:       proposal,
-		dialogWaiter:   dialogWaiter,
-		discovery:      discovery,
+		restartPolicy:  restartPolicy,
 		eventPublisher: manager.eventPublisher,
+		reportsData:    reportsData,
 	}
 
-	manager.servicePool.Add(&instance)
+	supervised, dialogWaiter, err := manager.startComponents(instance, providerID, serviceType, service)
+	if err != nil {
+		return id, err
+	}
+	instance.service = supervised
+	instance.dialogWaiter = dialogWaiter
+
+	discovery := manager.discoveryFactory()
+	discovery.Start(providerID, instance.Proposal())
+	instance.discovery = discovery
 
-	go func() {
+	manager.servicePool.Add(instance)
+
+	go manager.supervise(instance, providerID, serviceType, supervised)
+
+	return id, nil
+}
+
+func (manager *Manager) startComponents(instance *Instance, providerID identity.Identity, serviceType string, service Service) (*supervisedService, communication.DialogWaiter, error) {
+	supervised := &supervisedService{Service: service}
+
+	dialogWaiter, err := manager.dialogWaiterFactory(providerID, serviceType, instance.AccessPolicies)
+	if err != nil {
+		return nil, nil, err
+	}
+	instance.setProviderContact(providerID, dialogWaiter.GetContact())
+
+	dialogHandler, err := manager.dialogHandlerFactory(instance.Proposal, instance.Quote, supervised, string(instance.id))
+	if err != nil {
+		return nil, nil, err
+	}
+	if err = dialogWaiter.Start(dialogHandler); err != nil {
+		return nil, nil, err
+	}
+
+	return supervised, dialogWaiter, nil
+}
+
+// supervise serves the service and restarts it once it exits, until restart policy gives up or service is stopped by the user.
+func (manager *Manager) supervise(instance *Instance, providerID identity.Identity, serviceType string, service *supervisedService) {
+	for service != nil {
 		instance.setState(Running)
 
-		serveErr := service.Serve(providerID)
-		if serveErr != nil {
-			log.Error().Err(serveErr).Msg("Service serve failed")
+		stopProbing := probeLiveness(service, manager.livenessProbeInterval)
+		exitErr := service.Serve(providerID)
+		if probeErr := stopProbing(); probeErr != nil {
+			exitErr = probeErr
+		}
+		if exitErr != nil {
+			log.Error().Err(exitErr).Msg("Service serve failed")
 		}
 
-		// TODO: fix https://github.com/mysteriumnetwork/node/issues/855
-		stopErr := manager.servicePool.Stop(id)
-		if stopErr != nil {
-			log.Error().Err(stopErr).Msg("Service stop failed")
+		if manager.servicePool.Instance(instance.id) == nil {
+			// service was stopped by the user
+			instance.discovery.Wait()
+			return
 		}
 
-		discovery.Wait()
-	}()
+		service = manager.restart(instance, providerID, serviceType, exitErr)
+	}
 
-	return id, nil
+	// TODO: fix https://github.com/mysteriumnetwork/node/issues/855
+	stopErr := manager.servicePool.Stop(instance.id)
+	if stopErr != nil && stopErr != ErrNoSuchInstance {
+		log.Error().Err(stopErr).Msg("Service stop failed")
+	}
+
+	instance.discovery.Wait()
+}
+
+// restart brings exited service back according to the instance restart policy.
+// Returns nil if service should not be restarted anymore or if it was stopped while restarting.
+func (manager *Manager) restart(instance *Instance, providerID identity.Identity, serviceType string, exitErr error) *supervisedService {
+	for {
+		if exitErr != nil {
+			instance.recordFailure(exitErr)
+		}
+
+		restarts := instance.RestartInfo().Count
+		if !instance.restartPolicy.shouldRestart(exitErr, restarts) {
+			return nil
+		}
+
+		err := manager.servicePool.detach(instance.id)
+		if err == ErrNoSuchInstance {
+			return nil
+		} else if err != nil {
+			log.Warn().Err(err).Msg("Failed to stop service components before restart")
+		}
+
+		time.Sleep(instance.restartPolicy.backoff(restarts))
+		instance.incrementRestarts()
+		log.Info().Msgf("Restarting service %s, attempt %d", instance.id, restarts+1)
+
+		var service *supervisedService
+		var dialogWaiter communication.DialogWaiter
+		created, _, err := manager.serviceRegistry.Create(serviceType, instance.options)
+		if err == nil {
+			service, dialogWaiter, err = manager.startComponents(instance, providerID, serviceType, created)
+		}
+		if err != nil {
+			log.Error().Err(err).Msg("Service restart failed")
+			exitErr = err
+			continue
+		}
+
+		if !manager.servicePool.attach(instance.id, service, dialogWaiter) {
+			// service was stopped by the user while restarting
+			service.Stop()
+			dialogWaiter.Stop()
+			return nil
+		}
+		return service
+	}
+}
+
+// UpdateAccessPolicies replaces access policies of the running service.
+// Service proposal is re-announced and consumers of existing sessions are validated against the new policies.
+func (manager *Manager) UpdateAccessPolicies(id ID, policyIDs []string) error {
+	instance := manager.servicePool.Instance(id)
+	if instance == nil {
+		return ErrNoSuchInstance
+	}
+
+	policies, err := manager.resolvePolicies(policyIDs)
+	if err != nil {
+		return err
+	}
+
+	proposal := instance.setAccessPolicies(policies)
+	if instance.discovery != nil {
+		instance.discovery.Update(proposal)
+	}
+
+	manager.eventPublisher.Publish(AppTopicServiceAccessPolicies, AccessPoliciesEventPayload{
+		ID:             string(id),
+		ProviderID:     proposal.ProviderID,
+		Type:           proposal.ServiceType,
+		AccessPolicies: policies,
+	})
+	return nil
+}
+
+// UpdatePrice changes the price of the running service and re-announces its proposal.
+// If service is priced dynamically, the given price is charged when pricing strategy does not decide otherwise.
+// Existing sessions keep being charged at the price they were started with.
+// Price is validated before anything is changed, so that invalid price leaves the service intact.
+func (manager *Manager) UpdatePrice(id ID, price market.Price) error {
+	instance := manager.servicePool.Instance(id)
+	if instance == nil {
+		return ErrNoSuchInstance
+	}
+	if err := validatePrice(instance.Proposal(), price, instance.reportsData); err != nil {
+		return err
+	}
+
+	if pricer := instance.getPricer(); pricer != nil {
+		pricer.SetBase(price)
+		return manager.reprice(instance)
+	}
+
+	proposal, err := instance.setPrice(price)
+	if err != nil {
+		return err
+	}
+	if instance.discovery != nil {
+		instance.discovery.Update(proposal)
+	}
+	return nil
+}
+
+// SetPricingStrategy prices the running service dynamically according to the given strategy.
+// Service is re-priced periodically and its proposal is re-announced every time the price changes.
+// Existing sessions keep being charged at the price they were started with.
+// Nil strategy returns the service back to the static price.
+func (manager *Manager) SetPricingStrategy(id ID, strategy pricing.Strategy) error {
+	instance := manager.servicePool.Instance(id)
+	if instance == nil {
+		return ErrNoSuchInstance
+	}
+
+	base, err := instance.basePrice()
+	if err != nil {
+		return err
+	}
+
+	if strategy == nil {
+		if instance.setPricer(nil) {
+			proposal, err := instance.setPrice(base)
+			if err != nil {
+				return err
+			}
+			if instance.discovery != nil {
+				instance.discovery.Update(proposal)
+			}
+		}
+		return nil
+	}
+
+	activeSessions := func() int {
+		if manager.sessionCounter == nil {
+			return 0
+		}
+		return manager.sessionCounter(id)
+	}
+	if !instance.setPricer(pricing.NewPricer(base, strategy, manager.clock, activeSessions)) {
+		go manager.repriceRegularly(instance)
+	}
+	return manager.reprice(instance)
+}
+
+// repriceRegularly re-evaluates the price of dynamically priced service until it is stopped or priced statically.
+func (manager *Manager) repriceRegularly(instance *Instance) {
+	ticker := time.NewTicker(manager.repricingInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if manager.servicePool.Instance(instance.id) == nil || instance.getPricer() == nil {
+			return
+		}
+		if err := manager.reprice(instance); err != nil {
+			log.Error().Err(err).Msgf("Failed to reprice service %s", instance.id)
+		}
+	}
+}
+
+// reprice applies the current quote to the service proposal and re-announces it if the price changed.
+func (manager *Manager) reprice(instance *Instance) error {
+	proposal, changed, err := instance.applyQuote()
+	if err != nil || !changed {
+		return err
+	}
+
+	quote := instance.Quote()
+	log.Info().Msgf("Service %s repriced to %v per minute, %v per GB (version %d)", instance.id, quote.Price.PerMinute.Amount, quote.Price.PerGB.Amount, quote.Version)
+	if instance.discovery != nil {
+		instance.discovery.Update(proposal)
+	}
+	return nil
+}
+
+// validatePrice checks that the service allows to set the given price
+func validatePrice(proposal market.ServiceProposal, price market.Price, reportsData bool) error {
+	if _, ok := proposal.PaymentMethod.(market.PricedPaymentMethod); !ok {
+		return ErrUnsupportedPricing
+	}
+	if price.PerGB.Amount > 0 && !reportsData {
+		return ErrDataTransferNotReported
+	}
+	return nil
+}
+
+func reportsDataTransfer(service Service) bool {
+	reporter, ok := service.(DataTransferReporter)
+	return ok && reporter.ReportsDataTransfer()
+}
+
+func applyPrice(proposal market.ServiceProposal, price market.Price) (market.ServiceProposal, error) {
+	method, ok := proposal.PaymentMethod.(market.PricedPaymentMethod)
+	if !ok {
+		return proposal, ErrUnsupportedPricing
+	}
+	proposal.PaymentMethod = method.WithPrice(price)
+	return proposal, nil
+}
+
+func applyPackage(proposal market.ServiceProposal, options PackageOptions, reportsData bool) (market.ServiceProposal, error) {
+	method, ok := proposal.PaymentMethod.(market.PricedPaymentMethod)
+	if !ok {
+		return proposal, ErrUnsupportedPricing
+	}
+	if options.Bytes > 0 && !reportsData {
+		return proposal, ErrDataTransferNotReported
+	}
+	pkg := market.NewPaymentPackage(method, options.Duration, options.Bytes)
+	if options.Price > 0 {
+		pkg.Price = money.NewMoney(options.Price, money.CurrencyMyst)
+	}
+	proposal.PaymentMethod = pkg
+	return proposal, nil
+}
+
+func (manager *Manager) resolvePolicies(policyIDs []string) (*[]market.AccessPolicy, error) {
+	if len(policyIDs) == 0 {
+		return nil, nil
+	}
+
+	policies := manager.policyRepo.Policies(policyIDs)
+	if err := manager.policyRepo.AddPolicies(*policies); err != nil {
+		return nil, ErrUnsupportedAccessPolicy
+	}
+	return policies, nil
 }
 
 func generateID() (ID, error) {
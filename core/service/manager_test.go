@@ -23,9 +23,12 @@ import (
 	"time"
 
 	"github.com/mysteriumnetwork/node/core/policy"
+	"github.com/mysteriumnetwork/node/core/service/pricing"
 	"github.com/mysteriumnetwork/node/identity"
 	"github.com/mysteriumnetwork/node/market"
+	"github.com/mysteriumnetwork/node/money"
 	"github.com/mysteriumnetwork/node/requests"
+	"github.com/mysteriumnetwork/node/utils"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -51,8 +54,9 @@ func TestManager_StartRemovesServiceFromPoolIfServiceCrashes(t *testing.T) {
 		discoveryFactory,
 		&mockPublisher{},
 		mockPolicy,
+		nil,
 	)
-	_, err := manager.Start(identity.FromAddress(proposalMock.ProviderID), serviceType, nil, struct{}{})
+	_, err := manager.Start(identity.FromAddress(proposalMock.ProviderID), serviceType, nil, struct{}{}, DefaultRestartPolicy(), nil)
 	assert.Nil(t, err)
 
 	discovery.Wait()
@@ -76,8 +80,9 @@ func TestManager_StartDoesNotCrashIfStoppedByUser(t *testing.T) {
 		discoveryFactory,
 		&mockPublisher{},
 		mockPolicy,
+		nil,
 	)
-	id, err := manager.Start(identity.FromAddress(proposalMock.ProviderID), serviceType, nil, struct{}{})
+	id, err := manager.Start(identity.FromAddress(proposalMock.ProviderID), serviceType, nil, struct{}{}, DefaultRestartPolicy(), nil)
 	assert.Nil(t, err)
 	err = manager.Stop(id)
 	assert.Nil(t, err)
@@ -103,9 +108,10 @@ func TestManager_StopSendsEvent_SucceedsAndPublishesEvent(t *testing.T) {
 		discoveryFactory,
 		eventBus,
 		mockPolicy,
+		nil,
 	)
 
-	id, err := manager.Start(identity.FromAddress(proposalMock.ProviderID), serviceType, nil, struct{}{})
+	id, err := manager.Start(identity.FromAddress(proposalMock.ProviderID), serviceType, nil, struct{}{}, DefaultRestartPolicy(), nil)
 	assert.NoError(t, err)
 
 	services := manager.servicePool.List()
@@ -139,3 +145,316 @@ func TestManager_StopSendsEvent_SucceedsAndPublishesEvent(t *testing.T) {
 	}
 	assert.True(t, matchFound)
 }
+
+type pricedPaymentMethodFake struct {
+	price market.Price
+}
+
+func (m pricedPaymentMethodFake) GetPrice() money.Money      { return m.price.PerMinute }
+func (m pricedPaymentMethodFake) GetPricePerGB() money.Money { return m.price.PerGB }
+func (m pricedPaymentMethodFake) GetType() string            { return "FAKE" }
+func (m pricedPaymentMethodFake) GetRate() market.PaymentRate {
+	return market.PaymentRate{PerTime: time.Minute}
+}
+func (m pricedPaymentMethodFake) WithPrice(price market.Price) market.PaymentMethod {
+	return pricedPaymentMethodFake{price: price}
+}
+
+func TestManager_StartAppliesPrice(t *testing.T) {
+	registry := NewRegistry()
+	mockCopy := *serviceMock
+	mockCopy.mockProcess = make(chan struct{})
+	registry.Register(serviceType, func(options Options) (Service, market.ServiceProposal, error) {
+		return &dataReportingServiceFake{serviceFake: mockCopy}, market.ServiceProposal{PaymentMethod: pricedPaymentMethodFake{}}, nil
+	})
+	registry.Register("unpriced", func(options Options) (Service, market.ServiceProposal, error) {
+		return &mockCopy, proposalMock, nil
+	})
+	registry.Register("unreported", func(options Options) (Service, market.ServiceProposal, error) {
+		return &mockCopy, market.ServiceProposal{PaymentMethod: pricedPaymentMethodFake{}}, nil
+	})
+
+	discovery := mockDiscovery{}
+	manager := NewManager(
+		registry,
+		MockDialogWaiterFactory,
+		MockDialogHandlerFactory,
+		MockDiscoveryFactoryFunc(&discovery),
+		&mockPublisher{},
+		mockPolicy,
+		nil,
+	)
+
+	price := market.Price{
+		PerMinute: money.NewMoney(10, money.CurrencyMyst),
+		PerGB:     money.NewMoney(1000, money.CurrencyMyst),
+	}
+	id, err := manager.Start(identity.FromAddress(proposalMock.ProviderID), serviceType, nil, struct{}{}, DefaultRestartPolicy(), &price)
+	assert.NoError(t, err)
+	assert.Equal(t, pricedPaymentMethodFake{price: price}, manager.Service(id).Proposal().PaymentMethod)
+	assert.Equal(t, pricedPaymentMethodFake{price: price}, discovery.proposal.PaymentMethod)
+	assert.NoError(t, manager.Stop(id))
+
+	_, err = manager.Start(identity.FromAddress(proposalMock.ProviderID), "unpriced", nil, struct{}{}, DefaultRestartPolicy(), &price)
+	assert.Equal(t, ErrUnsupportedPricing, err)
+
+	_, err = manager.Start(identity.FromAddress(proposalMock.ProviderID), "unreported", nil, struct{}{}, DefaultRestartPolicy(), &price)
+	assert.Equal(t, ErrDataTransferNotReported, err)
+}
+
+func TestManager_StartAdvertisesAccountantsAndTrial(t *testing.T) {
+	registry := NewRegistry()
+	mockCopy := *serviceMock
+	mockCopy.mockProcess = make(chan struct{})
+	registry.Register(serviceType, func(options Options) (Service, market.ServiceProposal, error) {
+		return &mockCopy, proposalMock, nil
+	})
+
+	discovery := mockDiscovery{}
+	manager := NewManager(
+		registry,
+		MockDialogWaiterFactory,
+		MockDialogHandlerFactory,
+		MockDiscoveryFactoryFunc(&discovery),
+		&mockPublisher{},
+		mockPolicy,
+		nil,
+	)
+	manager.SetAccountants([]string{"0x1", "0x2"})
+	manager.SetTrial(&market.Trial{Duration: 10 * time.Minute})
+
+	id, err := manager.Start(identity.FromAddress(proposalMock.ProviderID), serviceType, nil, struct{}{}, DefaultRestartPolicy(), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"0x1", "0x2"}, manager.Service(id).Proposal().AccountantIDs)
+	assert.Equal(t, []string{"0x1", "0x2"}, discovery.proposal.AccountantIDs)
+	assert.Equal(t, &market.Trial{Duration: 10 * time.Minute}, discovery.proposal.Trial)
+	assert.NoError(t, manager.Stop(id))
+
+	manager.SetTrial(&market.Trial{Bytes: 1000})
+	_, err = manager.Start(identity.FromAddress(proposalMock.ProviderID), serviceType, nil, struct{}{}, DefaultRestartPolicy(), nil)
+	assert.Equal(t, ErrDataTransferNotReported, err)
+}
+
+func TestManager_StartSellsPackages(t *testing.T) {
+	registry := NewRegistry()
+	mockCopy := *serviceMock
+	mockCopy.mockProcess = make(chan struct{})
+	registry.Register(serviceType, func(options Options) (Service, market.ServiceProposal, error) {
+		return &mockCopy, market.ServiceProposal{PaymentMethod: pricedPaymentMethodFake{}}, nil
+	})
+
+	discovery := mockDiscovery{}
+	manager := NewManager(
+		registry,
+		MockDialogWaiterFactory,
+		MockDialogHandlerFactory,
+		MockDiscoveryFactoryFunc(&discovery),
+		&mockPublisher{},
+		mockPolicy,
+		nil,
+	)
+	manager.SetPaymentPackage(&PackageOptions{Duration: time.Hour})
+
+	price := market.Price{PerMinute: money.NewMoney(20, money.CurrencyMyst)}
+	id, err := manager.Start(identity.FromAddress(proposalMock.ProviderID), serviceType, nil, struct{}{}, DefaultRestartPolicy(), &price)
+	assert.NoError(t, err)
+	expected := market.PaymentPackage{Price: money.NewMoney(1200, money.CurrencyMyst), Duration: time.Hour}
+	assert.Equal(t, expected, manager.Service(id).Proposal().PaymentMethod)
+	assert.Equal(t, expected, discovery.proposal.PaymentMethod)
+	assert.NoError(t, manager.Stop(id))
+}
+
+func Test_applyPackage(t *testing.T) {
+	proposal := market.ServiceProposal{PaymentMethod: pricedPaymentMethodFake{price: market.Price{PerMinute: money.NewMoney(20, money.CurrencyMyst)}}}
+
+	packaged, err := applyPackage(proposal, PackageOptions{Duration: time.Hour, Price: 1000}, false)
+	assert.NoError(t, err)
+	assert.Equal(t, market.PaymentPackage{Price: money.NewMoney(1000, money.CurrencyMyst), Duration: time.Hour}, packaged.PaymentMethod)
+
+	packaged, err = applyPackage(proposal, PackageOptions{Bytes: 1000, Price: 1000}, true)
+	assert.NoError(t, err)
+	assert.Equal(t, market.PaymentPackage{Price: money.NewMoney(1000, money.CurrencyMyst), Bytes: 1000}, packaged.PaymentMethod)
+
+	_, err = applyPackage(proposal, PackageOptions{Bytes: 1000}, false)
+	assert.Equal(t, ErrDataTransferNotReported, err)
+
+	_, err = applyPackage(packaged, PackageOptions{Duration: time.Hour}, true)
+	assert.Equal(t, ErrUnsupportedPricing, err)
+}
+
+func TestManager_UpdatePrice_ReannouncesProposal(t *testing.T) {
+	registry := NewRegistry()
+	mockCopy := *serviceMock
+	mockCopy.mockProcess = make(chan struct{})
+	registry.Register(serviceType, func(options Options) (Service, market.ServiceProposal, error) {
+		return &mockCopy, market.ServiceProposal{PaymentMethod: pricedPaymentMethodFake{}}, nil
+	})
+
+	discovery := mockDiscovery{}
+	manager := NewManager(
+		registry,
+		MockDialogWaiterFactory,
+		MockDialogHandlerFactory,
+		MockDiscoveryFactoryFunc(&discovery),
+		&mockPublisher{},
+		mockPolicy,
+		nil,
+	)
+
+	id, err := manager.Start(identity.FromAddress(proposalMock.ProviderID), serviceType, nil, struct{}{}, DefaultRestartPolicy(), nil)
+	assert.NoError(t, err)
+
+	price := market.Price{PerMinute: money.NewMoney(20, money.CurrencyMyst)}
+	err = manager.UpdatePrice(id, price)
+	assert.NoError(t, err)
+	assert.Equal(t, pricedPaymentMethodFake{price: price}, manager.Service(id).Proposal().PaymentMethod)
+	assert.Equal(t, pricedPaymentMethodFake{price: price}, discovery.proposal.PaymentMethod)
+
+	err = manager.UpdatePrice(ID("unknown"), price)
+	assert.Equal(t, ErrNoSuchInstance, err)
+
+	err = manager.UpdatePrice(id, market.Price{PerGB: money.NewMoney(1000, money.CurrencyMyst)})
+	assert.Equal(t, ErrDataTransferNotReported, err)
+	assert.Equal(t, pricedPaymentMethodFake{price: price}, manager.Service(id).Proposal().PaymentMethod)
+
+	assert.NoError(t, manager.Stop(id))
+}
+
+func TestManager_SetPricingStrategy_RepricesService(t *testing.T) {
+	registry := NewRegistry()
+	mockCopy := *serviceMock
+	mockCopy.mockProcess = make(chan struct{})
+	registry.Register(serviceType, func(options Options) (Service, market.ServiceProposal, error) {
+		return &mockCopy, market.ServiceProposal{PaymentMethod: pricedPaymentMethodFake{}}, nil
+	})
+
+	discovery := mockDiscovery{}
+	sessions := 0
+	manager := NewManager(
+		registry,
+		MockDialogWaiterFactory,
+		MockDialogHandlerFactory,
+		MockDiscoveryFactoryFunc(&discovery),
+		&mockPublisher{},
+		mockPolicy,
+		func(ID) int { return sessions },
+	)
+	clock := &utils.SettableClock{}
+	clock.SetTime(time.Date(2020, 2, 1, 12, 0, 0, 0, time.UTC))
+	manager.clock = clock.GetTime
+	manager.repricingInterval = time.Hour
+
+	basePrice := market.Price{PerMinute: money.NewMoney(10, money.CurrencyMyst)}
+	peakPrice := market.Price{PerMinute: money.NewMoney(20, money.CurrencyMyst)}
+	loadedPrice := market.Price{PerMinute: money.NewMoney(30, money.CurrencyMyst)}
+	id, err := manager.Start(identity.FromAddress(proposalMock.ProviderID), serviceType, nil, struct{}{}, DefaultRestartPolicy(), &basePrice)
+	assert.NoError(t, err)
+	instance := manager.Service(id)
+	assert.Equal(t, pricing.Quote{}, instance.Quote())
+
+	err = manager.SetPricingStrategy(id, pricing.Chain{
+		pricing.TimeWindow{From: 18 * time.Hour, To: 22 * time.Hour, Charge: peakPrice},
+		pricing.Load{Sessions: 2, Charge: loadedPrice},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, pricing.Quote{Version: 1, Price: basePrice}, instance.Quote())
+
+	clock.AddTime(7 * time.Hour)
+	assert.NoError(t, manager.reprice(instance))
+	assert.Equal(t, pricing.Quote{Version: 2, Price: peakPrice}, instance.Quote())
+	assert.Equal(t, pricedPaymentMethodFake{price: peakPrice}, discovery.proposal.PaymentMethod)
+
+	sessions = 2
+	assert.NoError(t, manager.reprice(instance))
+	assert.Equal(t, pricing.Quote{Version: 3, Price: loadedPrice}, instance.Quote())
+	assert.Equal(t, pricedPaymentMethodFake{price: loadedPrice}, discovery.proposal.PaymentMethod)
+
+	sessions = 0
+	clock.AddTime(4 * time.Hour)
+	newBase := market.Price{PerMinute: money.NewMoney(15, money.CurrencyMyst)}
+	assert.NoError(t, manager.UpdatePrice(id, newBase))
+	assert.Equal(t, pricing.Quote{Version: 4, Price: newBase}, instance.Quote())
+	assert.Equal(t, pricedPaymentMethodFake{price: newBase}, discovery.proposal.PaymentMethod)
+
+	assert.NoError(t, manager.SetPricingStrategy(id, nil))
+	assert.Equal(t, pricing.Quote{}, instance.Quote())
+	assert.Equal(t, pricedPaymentMethodFake{price: newBase}, discovery.proposal.PaymentMethod)
+
+	assert.Equal(t, ErrNoSuchInstance, manager.SetPricingStrategy(ID("unknown"), pricing.Chain{}))
+	assert.NoError(t, manager.Stop(id))
+}
+
+func TestManager_UpdateAccessPolicies_ReannouncesProposal(t *testing.T) {
+	registry := NewRegistry()
+	mockCopy := *serviceMock
+	mockCopy.mockProcess = make(chan struct{})
+	registry.Register(serviceType, func(options Options) (Service, market.ServiceProposal, error) {
+		return &mockCopy, proposalMock, nil
+	})
+
+	discovery := mockDiscovery{}
+	eventBus := &mockPublisher{}
+	manager := NewManager(
+		registry,
+		MockDialogWaiterFactory,
+		MockDialogHandlerFactory,
+		MockDiscoveryFactoryFunc(&discovery),
+		eventBus,
+		mockPolicy,
+		nil,
+	)
+
+	id, err := manager.Start(identity.FromAddress(proposalMock.ProviderID), serviceType, nil, struct{}{}, DefaultRestartPolicy(), nil)
+	assert.NoError(t, err)
+	assert.Nil(t, manager.Service(id).AccessPolicies())
+
+	err = manager.UpdateAccessPolicies(id, nil)
+	assert.NoError(t, err)
+	assert.Nil(t, discovery.proposal.AccessPolicies)
+
+	eventBus.lock.Lock()
+	var published bool
+	for i := range eventBus.publishedData {
+		if e, ok := eventBus.publishedData[i].(AccessPoliciesEventPayload); ok && e.ID == string(id) {
+			published = true
+		}
+	}
+	eventBus.lock.Unlock()
+	assert.True(t, published)
+
+	err = manager.UpdateAccessPolicies(ID("unknown"), nil)
+	assert.Equal(t, ErrNoSuchInstance, err)
+
+	assert.NoError(t, manager.Stop(id))
+	discovery.Wait()
+}
+
+func TestManager_StartRestartsFailedServiceAccordingToPolicy(t *testing.T) {
+	registry := NewRegistry()
+	mockCopy := *serviceMock
+	mockCopy.onStartReturnError = errors.New("some error")
+	registry.Register(serviceType, func(options Options) (Service, market.ServiceProposal, error) {
+		return &mockCopy, proposalMock, nil
+	})
+
+	discovery := mockDiscovery{}
+	manager := NewManager(
+		registry,
+		MockDialogWaiterFactory,
+		MockDialogHandlerFactory,
+		MockDiscoveryFactoryFunc(&discovery),
+		&mockPublisher{},
+		mockPolicy,
+		nil,
+	)
+	restartPolicy := RestartPolicy{Type: RestartOnFailure, MaxRestarts: 2, Backoff: time.Millisecond}
+	id, err := manager.Start(identity.FromAddress(proposalMock.ProviderID), serviceType, nil, struct{}{}, restartPolicy, nil)
+	assert.NoError(t, err)
+	instance := manager.Service(id)
+
+	discovery.Wait()
+	assert.Len(t, manager.servicePool.List(), 0)
+	assert.Equal(t, 2, instance.RestartInfo().Count)
+	assert.Equal(t, "some error", instance.RestartInfo().LastFailure)
+	assert.Equal(t, NotRunning, instance.State())
+}
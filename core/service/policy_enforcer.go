@@ -0,0 +1,55 @@
+/*
+ * Copyright (C) 2020 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package service
+
+import (
+	"github.com/mysteriumnetwork/node/core/policy"
+	"github.com/mysteriumnetwork/node/market"
+	"github.com/mysteriumnetwork/node/session"
+	"github.com/rs/zerolog/log"
+)
+
+// PolicyEnforcer terminates sessions of consumers which are no longer allowed by updated service access policies
+type PolicyEnforcer struct {
+	SessionStorage   SessionTerminator
+	PolicyRepository *policy.Repository
+}
+
+// SessionTerminator keeps sessions and allows terminating them
+type SessionTerminator interface {
+	GetAll() []session.Session
+	Terminate(id session.ID) bool
+}
+
+// HandleAccessPolicies validates consumers of service sessions against updated access policies
+func (enforcer *PolicyEnforcer) HandleAccessPolicies(event AccessPoliciesEventPayload) {
+	validate := policy.ValidateAllowedIdentity(enforcer.PolicyRepository, func() *[]market.AccessPolicy {
+		return event.AccessPolicies
+	})
+
+	for _, sessionInstance := range enforcer.SessionStorage.GetAll() {
+		if sessionInstance.ServiceID != event.ID {
+			continue
+		}
+
+		if err := validate(sessionInstance.ConsumerID); err != nil {
+			log.Info().Err(err).Msgf("Terminating session %s of consumer %s", sessionInstance.ID, sessionInstance.ConsumerID.Address)
+			enforcer.SessionStorage.Terminate(sessionInstance.ID)
+		}
+	}
+}
@@ -19,8 +19,11 @@ package service
 
 import (
 	"sync"
+	"time"
 
 	"github.com/mysteriumnetwork/node/communication"
+	"github.com/mysteriumnetwork/node/core/service/pricing"
+	"github.com/mysteriumnetwork/node/identity"
 	"github.com/mysteriumnetwork/node/market"
 	"github.com/mysteriumnetwork/node/utils"
 	"github.com/pkg/errors"
@@ -107,6 +110,47 @@ func (p *Pool) stop(id ID) error {
 	return errStop.Errorf("ErrorCollection(%s)", ", ")
 }
 
+// detach stops service and dialog waiter of the instance which is going to be restarted, keeping the instance in the pool
+func (p *Pool) detach(id ID) error {
+	p.Lock()
+	defer p.Unlock()
+
+	instance, ok := p.instances[id]
+	if !ok {
+		return ErrNoSuchInstance
+	}
+
+	errStop := utils.ErrorCollection{}
+	if instance.dialogWaiter != nil {
+		errStop.Add(instance.dialogWaiter.Stop())
+	}
+	if instance.service != nil {
+		errStop.Add(instance.service.Stop())
+	}
+	instance.dialogWaiter = nil
+	instance.service = nil
+
+	instance.setState(Restarting)
+
+	return errStop.Errorf("ErrorCollection(%s)", ", ")
+}
+
+// attach sets restarted service and dialog waiter of the instance.
+// Returns false if instance was stopped while restarting.
+func (p *Pool) attach(id ID, service RunnableService, dialogWaiter communication.DialogWaiter) bool {
+	p.Lock()
+	defer p.Unlock()
+
+	instance, ok := p.instances[id]
+	if !ok {
+		return false
+	}
+
+	instance.service = service
+	instance.dialogWaiter = dialogWaiter
+	return true
+}
+
 // StopAll kills all running instances
 func (p *Pool) StopAll() error {
 	p.Lock()
@@ -143,12 +187,13 @@ func NewInstance(
 	discovery Discovery,
 ) *Instance {
 	return &Instance{
-		options:      options,
-		state:        state,
-		service:      service,
-		proposal:     proposal,
-		dialogWaiter: dialog,
-		discovery:    discovery,
+		options:       options,
+		state:         state,
+		service:       service,
+		proposal:      proposal,
+		dialogWaiter:  dialog,
+		discovery:     discovery,
+		restartPolicy: DefaultRestartPolicy(),
 	}
 }
 
@@ -162,6 +207,11 @@ type Instance struct {
 	dialogWaiter   communication.DialogWaiter
 	discovery      Discovery
 	eventPublisher Publisher
+	restartPolicy  RestartPolicy
+	restartInfo    RestartInfo
+	pricer         *pricing.Pricer
+	quote          pricing.Quote
+	reportsData    bool
 
 	stateLock sync.RWMutex
 }
@@ -173,9 +223,130 @@ func (i *Instance) Options() Options {
 
 // Proposal returns service proposal of the running service instance.
 func (i *Instance) Proposal() market.ServiceProposal {
+	i.stateLock.RLock()
+	defer i.stateLock.RUnlock()
 	return i.proposal
 }
 
+// RestartPolicy returns restart policy of the service instance.
+func (i *Instance) RestartPolicy() RestartPolicy {
+	return i.restartPolicy
+}
+
+// RestartInfo returns restart history of the service instance.
+func (i *Instance) RestartInfo() RestartInfo {
+	i.stateLock.RLock()
+	defer i.stateLock.RUnlock()
+	return i.restartInfo
+}
+
+func (i *Instance) recordFailure(err error) {
+	i.stateLock.Lock()
+	defer i.stateLock.Unlock()
+	i.restartInfo.LastFailure = err.Error()
+	i.restartInfo.LastFailureAt = time.Now().UTC()
+}
+
+func (i *Instance) incrementRestarts() {
+	i.stateLock.Lock()
+	defer i.stateLock.Unlock()
+	i.restartInfo.Count++
+}
+
+func (i *Instance) setProviderContact(providerID identity.Identity, contact market.Contact) {
+	i.stateLock.Lock()
+	defer i.stateLock.Unlock()
+	i.proposal.SetProviderContact(providerID, contact)
+}
+
+// AccessPolicies returns access policies currently applied to the service instance.
+func (i *Instance) AccessPolicies() *[]market.AccessPolicy {
+	i.stateLock.RLock()
+	defer i.stateLock.RUnlock()
+	return i.proposal.AccessPolicies
+}
+
+func (i *Instance) setAccessPolicies(policies *[]market.AccessPolicy) market.ServiceProposal {
+	i.stateLock.Lock()
+	defer i.stateLock.Unlock()
+	i.proposal.SetAccessPolicies(policies)
+	return i.proposal
+}
+
+func (i *Instance) setPrice(price market.Price) (market.ServiceProposal, error) {
+	i.stateLock.Lock()
+	defer i.stateLock.Unlock()
+	proposal, err := applyPrice(i.proposal, price)
+	if err != nil {
+		return proposal, err
+	}
+	i.proposal = proposal
+	return i.proposal, nil
+}
+
+// Quote returns the price quote currently announced in the service instance proposal.
+// Zero quote is returned if service is not priced dynamically.
+func (i *Instance) Quote() pricing.Quote {
+	i.stateLock.RLock()
+	defer i.stateLock.RUnlock()
+	return i.quote
+}
+
+func (i *Instance) getPricer() *pricing.Pricer {
+	i.stateLock.RLock()
+	defer i.stateLock.RUnlock()
+	return i.pricer
+}
+
+// setPricer replaces the pricer of the instance and returns whether the instance was already priced dynamically.
+func (i *Instance) setPricer(pricer *pricing.Pricer) bool {
+	i.stateLock.Lock()
+	defer i.stateLock.Unlock()
+	priced := i.pricer != nil
+	i.pricer = pricer
+	if pricer == nil {
+		i.quote = pricing.Quote{}
+	}
+	return priced
+}
+
+// basePrice returns the price charged when pricing strategy does not decide otherwise.
+func (i *Instance) basePrice() (market.Price, error) {
+	i.stateLock.RLock()
+	defer i.stateLock.RUnlock()
+	method, ok := i.proposal.PaymentMethod.(market.PricedPaymentMethod)
+	if !ok {
+		return market.Price{}, ErrUnsupportedPricing
+	}
+	if i.pricer != nil {
+		return i.pricer.Base(), nil
+	}
+	return market.Price{PerMinute: method.GetPrice(), PerGB: method.GetPricePerGB()}, nil
+}
+
+// applyQuote sets the current price to the instance proposal and bumps the quote version if the price changed.
+// Announced quote version keeps increasing even if the pricing strategy is replaced.
+// Returns whether the price changed since it was last applied.
+func (i *Instance) applyQuote() (market.ServiceProposal, bool, error) {
+	i.stateLock.Lock()
+	defer i.stateLock.Unlock()
+	if i.pricer == nil {
+		return i.proposal, false, nil
+	}
+
+	price := i.pricer.Price()
+	if i.quote.Version != 0 && pricing.SamePrice(i.quote.Price, price) {
+		return i.proposal, false, nil
+	}
+	proposal, err := applyPrice(i.proposal, price)
+	if err != nil {
+		return proposal, false, err
+	}
+	i.proposal = proposal
+	i.quote = pricing.Quote{Version: i.quote.Version + 1, Price: price}
+	return i.proposal, true, nil
+}
+
 // State returns the service instance state.
 func (i *Instance) State() State {
 	i.stateLock.RLock()
@@ -194,9 +365,11 @@ func (i *Instance) setState(newState State) {
 // toEvent returns an event representation of the instance
 func (i *Instance) toEvent() EventPayload {
 	return EventPayload{
-		ID:         string(i.id),
-		ProviderID: i.proposal.ProviderID,
-		Type:       i.proposal.ServiceType,
-		Status:     string(i.state),
+		ID:           string(i.id),
+		ProviderID:   i.proposal.ProviderID,
+		Type:         i.proposal.ServiceType,
+		Status:       string(i.state),
+		RestartCount: i.restartInfo.Count,
+		LastFailure:  i.restartInfo.LastFailure,
 	}
 }
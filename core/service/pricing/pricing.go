@@ -0,0 +1,149 @@
+/*
+ * Copyright (C) 2020 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package pricing
+
+import (
+	"sync"
+	"time"
+
+	"github.com/mysteriumnetwork/node/market"
+)
+
+// Conditions describe the state of the service at the moment of pricing
+type Conditions struct {
+	Time           time.Time
+	ActiveSessions int
+}
+
+// Strategy decides on the price of the service under the given conditions
+type Strategy interface {
+	// Price returns the price of the service, base is the price decided so far
+	Price(base market.Price, conditions Conditions) market.Price
+}
+
+// TimeWindow charges the given price during the time of day window.
+// Window wraps around midnight if it ends before it starts.
+type TimeWindow struct {
+	From   time.Duration
+	To     time.Duration
+	Charge market.Price
+}
+
+// Price returns the window price if conditions time falls into the window, base price otherwise
+func (tw TimeWindow) Price(base market.Price, conditions Conditions) market.Price {
+	if tw.contains(timeOfDay(conditions.Time)) {
+		return tw.Charge
+	}
+	return base
+}
+
+func (tw TimeWindow) contains(t time.Duration) bool {
+	if tw.From <= tw.To {
+		return t >= tw.From && t < tw.To
+	}
+	return t >= tw.From || t < tw.To
+}
+
+func timeOfDay(t time.Time) time.Duration {
+	year, month, day := t.Date()
+	return t.Sub(time.Date(year, month, day, 0, 0, 0, 0, t.Location()))
+}
+
+// Load charges the given price once the service has at least the given number of active sessions
+type Load struct {
+	Sessions int
+	Charge   market.Price
+}
+
+// Price returns the load price if service is loaded enough, base price otherwise
+func (l Load) Price(base market.Price, conditions Conditions) market.Price {
+	if conditions.ActiveSessions >= l.Sessions {
+		return l.Charge
+	}
+	return base
+}
+
+// Chain applies strategies in order, so later strategies take precedence over earlier ones
+type Chain []Strategy
+
+// Price returns the price decided by the last matching strategy
+func (c Chain) Price(base market.Price, conditions Conditions) market.Price {
+	price := base
+	for _, strategy := range c {
+		price = strategy.Price(price, conditions)
+	}
+	return price
+}
+
+// Quote is a versioned price of the service announced to consumers.
+// Version changes every time the price changes, zero version means service is not priced dynamically.
+type Quote struct {
+	Version uint64
+	Price   market.Price
+}
+
+// Pricer prices the service according to the pricing strategy
+type Pricer struct {
+	strategy       Strategy
+	clock          func() time.Time
+	activeSessions func() int
+
+	lock sync.Mutex
+	base market.Price
+}
+
+// NewPricer returns a new pricer of the service charging base price unless strategy decides otherwise
+func NewPricer(base market.Price, strategy Strategy, clock func() time.Time, activeSessions func() int) *Pricer {
+	return &Pricer{
+		base:           base,
+		strategy:       strategy,
+		clock:          clock,
+		activeSessions: activeSessions,
+	}
+}
+
+// SetBase changes the price charged when strategy does not decide otherwise
+func (p *Pricer) SetBase(base market.Price) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.base = base
+}
+
+// Base returns the price charged when strategy does not decide otherwise
+func (p *Pricer) Base() market.Price {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return p.base
+}
+
+// Price returns the current price of the service.
+func (p *Pricer) Price() market.Price {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	conditions := Conditions{Time: p.clock()}
+	if p.activeSessions != nil {
+		conditions.ActiveSessions = p.activeSessions()
+	}
+	return p.strategy.Price(p.base, conditions)
+}
+
+// SamePrice tells whether both prices charge the same amounts.
+func SamePrice(a, b market.Price) bool {
+	return a.PerMinute.Amount == b.PerMinute.Amount && a.PerGB.Amount == b.PerGB.Amount
+}
@@ -0,0 +1,145 @@
+/*
+ * Copyright (C) 2020 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package pricing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mysteriumnetwork/node/market"
+	"github.com/mysteriumnetwork/node/money"
+	"github.com/mysteriumnetwork/node/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	basePrice    = perMinute(100)
+	peakPrice    = perMinute(200)
+	loadedPrice  = perMinute(300)
+	midnightTime = time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC)
+)
+
+func perMinute(amount uint64) market.Price {
+	return market.Price{
+		PerMinute: money.NewMoney(amount, money.CurrencyMyst),
+		PerGB:     money.NewMoney(0, money.CurrencyMyst),
+	}
+}
+
+func TestTimeWindow_Price(t *testing.T) {
+	tests := []struct {
+		name   string
+		window TimeWindow
+		at     time.Duration
+		want   market.Price
+	}{
+		{
+			name:   "inside window",
+			window: TimeWindow{From: 18 * time.Hour, To: 22 * time.Hour, Charge: peakPrice},
+			at:     20 * time.Hour,
+			want:   peakPrice,
+		},
+		{
+			name:   "window start is inclusive",
+			window: TimeWindow{From: 18 * time.Hour, To: 22 * time.Hour, Charge: peakPrice},
+			at:     18 * time.Hour,
+			want:   peakPrice,
+		},
+		{
+			name:   "window end is exclusive",
+			window: TimeWindow{From: 18 * time.Hour, To: 22 * time.Hour, Charge: peakPrice},
+			at:     22 * time.Hour,
+			want:   basePrice,
+		},
+		{
+			name:   "window wrapping midnight before midnight",
+			window: TimeWindow{From: 22 * time.Hour, To: 2 * time.Hour, Charge: peakPrice},
+			at:     23 * time.Hour,
+			want:   peakPrice,
+		},
+		{
+			name:   "window wrapping midnight after midnight",
+			window: TimeWindow{From: 22 * time.Hour, To: 2 * time.Hour, Charge: peakPrice},
+			at:     time.Hour,
+			want:   peakPrice,
+		},
+		{
+			name:   "outside window wrapping midnight",
+			window: TimeWindow{From: 22 * time.Hour, To: 2 * time.Hour, Charge: peakPrice},
+			at:     12 * time.Hour,
+			want:   basePrice,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			price := tt.window.Price(basePrice, Conditions{Time: midnightTime.Add(tt.at)})
+			assert.Equal(t, tt.want, price)
+		})
+	}
+}
+
+func TestLoad_Price(t *testing.T) {
+	load := Load{Sessions: 3, Charge: loadedPrice}
+	assert.Equal(t, basePrice, load.Price(basePrice, Conditions{ActiveSessions: 2}))
+	assert.Equal(t, loadedPrice, load.Price(basePrice, Conditions{ActiveSessions: 3}))
+	assert.Equal(t, loadedPrice, load.Price(basePrice, Conditions{ActiveSessions: 10}))
+}
+
+func TestChain_LaterStrategyTakesPrecedence(t *testing.T) {
+	chain := Chain{
+		TimeWindow{From: 18 * time.Hour, To: 22 * time.Hour, Charge: peakPrice},
+		Load{Sessions: 3, Charge: loadedPrice},
+	}
+	peak := midnightTime.Add(20 * time.Hour)
+
+	assert.Equal(t, basePrice, chain.Price(basePrice, Conditions{Time: midnightTime}))
+	assert.Equal(t, peakPrice, chain.Price(basePrice, Conditions{Time: peak}))
+	assert.Equal(t, loadedPrice, chain.Price(basePrice, Conditions{Time: midnightTime, ActiveSessions: 3}))
+	assert.Equal(t, loadedPrice, chain.Price(basePrice, Conditions{Time: peak, ActiveSessions: 3}))
+}
+
+func TestPricer_Price(t *testing.T) {
+	clock := &utils.SettableClock{}
+	clock.SetTime(midnightTime.Add(17 * time.Hour))
+	sessions := 0
+	pricer := NewPricer(basePrice, Chain{
+		TimeWindow{From: 18 * time.Hour, To: 22 * time.Hour, Charge: peakPrice},
+		Load{Sessions: 3, Charge: loadedPrice},
+	}, clock.GetTime, func() int { return sessions })
+
+	assert.Equal(t, basePrice, pricer.Price())
+
+	clock.AddTime(time.Hour)
+	assert.Equal(t, peakPrice, pricer.Price())
+
+	sessions = 3
+	assert.Equal(t, loadedPrice, pricer.Price())
+
+	sessions = 0
+	clock.AddTime(4 * time.Hour)
+	assert.Equal(t, basePrice, pricer.Price())
+
+	pricer.SetBase(perMinute(150))
+	assert.Equal(t, perMinute(150), pricer.Price())
+}
+
+func TestSamePrice(t *testing.T) {
+	assert.True(t, SamePrice(perMinute(100), perMinute(100)))
+	assert.False(t, SamePrice(perMinute(100), perMinute(150)))
+	assert.False(t, SamePrice(perMinute(100), market.Price{PerMinute: money.NewMoney(100, money.CurrencyMyst), PerGB: money.NewMoney(1, money.CurrencyMyst)}))
+}
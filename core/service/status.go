@@ -17,15 +17,30 @@
 
 package service
 
+import "github.com/mysteriumnetwork/node/market"
+
 // AppTopicServiceStatus is used in event bus to announce the service status
 const AppTopicServiceStatus = "Service status"
 
+// AppTopicServiceAccessPolicies is used in event bus to announce the change of service access policies
+const AppTopicServiceAccessPolicies = "Service access policies"
+
 // EventPayload represents the service event related information
 type EventPayload struct {
-	ID         string `json:"id"`
-	ProviderID string `json:"providerId"`
-	Type       string `json:"type"`
-	Status     string `json:"status"`
+	ID           string `json:"id"`
+	ProviderID   string `json:"providerId"`
+	Type         string `json:"type"`
+	Status       string `json:"status"`
+	RestartCount int    `json:"restartCount"`
+	LastFailure  string `json:"lastFailure,omitempty"`
+}
+
+// AccessPoliciesEventPayload represents the service access policies change related information
+type AccessPoliciesEventPayload struct {
+	ID             string                 `json:"id"`
+	ProviderID     string                 `json:"providerId"`
+	Type           string                 `json:"type"`
+	AccessPolicies *[]market.AccessPolicy `json:"accessPolicies"`
 }
 
 // State represents list of possible service states
@@ -38,4 +53,6 @@ const (
 	Starting = State("Starting")
 	// Running means that fully established service exists
 	Running = State("Running")
+	// Restarting means that service has exited and is being restarted according to its restart policy
+	Restarting = State("Restarting")
 )
@@ -22,6 +22,7 @@ import (
 	"sync"
 
 	"github.com/mysteriumnetwork/node/communication"
+	"github.com/mysteriumnetwork/node/core/policy"
 	"github.com/mysteriumnetwork/node/identity"
 	"github.com/mysteriumnetwork/node/market"
 	"github.com/mysteriumnetwork/node/nat/traversal"
@@ -58,6 +59,15 @@ func (service *serviceFake) ProvideConfig(sessionConfig json.RawMessage) (*sessi
 	return &session.ConfigParams{TraversalParams: &traversal.Params{}}, nil
 }
 
+// dataReportingServiceFake is a service reporting the traffic of its sessions
+type dataReportingServiceFake struct {
+	serviceFake
+}
+
+func (service *dataReportingServiceFake) ReportsDataTransfer() bool {
+	return true
+}
+
 type mockDialogWaiter struct {
 	contact  market.Contact
 	stopErr  error
@@ -77,7 +87,7 @@ func (mdw *mockDialogWaiter) Start(_ communication.DialogHandler) error {
 }
 
 // MockDialogWaiterFactory returns a new instance of communication dialog waiter.
-func MockDialogWaiterFactory(providerID identity.Identity, serviceType string, policies *[]market.AccessPolicy) (communication.DialogWaiter, error) {
+func MockDialogWaiterFactory(providerID identity.Identity, serviceType string, policies policy.PoliciesProvider) (communication.DialogWaiter, error) {
 	return &mockDialogWaiter{}, nil
 }
 
@@ -89,17 +99,23 @@ func (mdh *mockDialogHandler) Handle(communication.Dialog) error {
 }
 
 // MockDialogHandlerFactory creates a new mock dialog handler
-func MockDialogHandlerFactory(market.ServiceProposal, session.ConfigProvider, string) (communication.DialogHandler, error) {
+func MockDialogHandlerFactory(ProposalProvider, PriceQuoter, session.ConfigProvider, string) (communication.DialogHandler, error) {
 	return &mockDialogHandler{}, nil
 }
 
 type mockDiscovery struct {
-	wg sync.WaitGroup
+	wg       sync.WaitGroup
+	proposal market.ServiceProposal
 }
 
 func (mds *mockDiscovery) Start(ownIdentity identity.Identity, proposal market.ServiceProposal) {
+	mds.proposal = proposal
 	mds.wg.Add(1)
 }
+
+func (mds *mockDiscovery) Update(proposal market.ServiceProposal) {
+	mds.proposal = proposal
+}
 func (mds *mockDiscovery) Stop() {
 	mds.wg.Done()
 }
@@ -0,0 +1,159 @@
+/*
+ * Copyright (C) 2020 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package service
+
+import (
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// RestartPolicyType determines whether service is restarted once it exits
+type RestartPolicyType string
+
+const (
+	// RestartNever means that service is never restarted
+	RestartNever = RestartPolicyType("never")
+	// RestartOnFailure means that service is restarted only if it exits with an error or fails liveness probe
+	RestartOnFailure = RestartPolicyType("on-failure")
+	// RestartAlways means that service is restarted whenever it exits, unless stopped by the user
+	RestartAlways = RestartPolicyType("always")
+)
+
+// ErrLivenessProbeFailed indicates that service was stopped because it failed liveness probe
+var ErrLivenessProbeFailed = errors.New("liveness probe failed")
+
+// RestartPolicy describes how the service is restarted once it exits
+type RestartPolicy struct {
+	Type RestartPolicyType
+	// MaxRestarts limits the number of restarts, zero means unlimited
+	MaxRestarts int
+	// Backoff is the delay before the first restart, it is doubled on every consecutive restart
+	Backoff time.Duration
+	// MaxBackoff limits the delay between restarts
+	MaxBackoff time.Duration
+}
+
+// DefaultRestartPolicy returns restart policy of the services which do not set one
+func DefaultRestartPolicy() RestartPolicy {
+	return RestartPolicy{
+		Type:       RestartNever,
+		Backoff:    5 * time.Second,
+		MaxBackoff: 5 * time.Minute,
+	}
+}
+
+// IsValid checks if restart policy type is known
+func (rp RestartPolicy) IsValid() bool {
+	switch rp.Type {
+	case RestartNever, RestartOnFailure, RestartAlways:
+		return true
+	}
+	return false
+}
+
+func (rp RestartPolicy) shouldRestart(serveErr error, restarts int) bool {
+	if rp.MaxRestarts > 0 && restarts >= rp.MaxRestarts {
+		return false
+	}
+
+	switch rp.Type {
+	case RestartAlways:
+		return true
+	case RestartOnFailure:
+		return serveErr != nil
+	default:
+		return false
+	}
+}
+
+func (rp RestartPolicy) backoff(restarts int) time.Duration {
+	delay := rp.Backoff
+	for i := 0; i < restarts && delay < rp.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if rp.MaxBackoff > 0 && delay > rp.MaxBackoff {
+		delay = rp.MaxBackoff
+	}
+	return delay
+}
+
+// LivenessProber is implemented by services which are able to tell if they are still healthy
+type LivenessProber interface {
+	Alive() error
+}
+
+// RestartInfo represents restart history of the service instance
+type RestartInfo struct {
+	Count         int
+	LastFailure   string
+	LastFailureAt time.Time
+}
+
+// supervisedService makes sure that the underlying service is stopped only once,
+// no matter whether it is stopped by the user, by restart or by the failed liveness probe.
+type supervisedService struct {
+	Service
+
+	once    sync.Once
+	stopErr error
+}
+
+func (s *supervisedService) Stop() error {
+	s.once.Do(func() {
+		s.stopErr = s.Service.Stop()
+	})
+	return s.stopErr
+}
+
+// probeLiveness periodically probes the service and stops it once it reports that it is not alive.
+// Returns a function which ends probing and reports the probe failure, if any.
+func probeLiveness(service *supervisedService, interval time.Duration) func() error {
+	prober, ok := service.Service.(LivenessProber)
+	if !ok || interval <= 0 {
+		return func() error { return nil }
+	}
+
+	done := make(chan struct{})
+	failed := make(chan error, 1)
+	go func() {
+		for {
+			select {
+			case <-done:
+				return
+			case <-time.After(interval):
+				if err := prober.Alive(); err != nil {
+					failed <- errors.Wrap(ErrLivenessProbeFailed, err.Error())
+					service.Stop()
+					return
+				}
+			}
+		}
+	}()
+
+	return func() error {
+		close(done)
+		select {
+		case err := <-failed:
+			return err
+		default:
+			return nil
+		}
+	}
+}
@@ -0,0 +1,89 @@
+/*
+ * Copyright (C) 2020 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/mysteriumnetwork/node/identity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRestartPolicy_ShouldRestart(t *testing.T) {
+	serveErr := errors.New("serve failed")
+
+	tests := []struct {
+		name     string
+		policy   RestartPolicy
+		serveErr error
+		restarts int
+		want     bool
+	}{
+		{"never restarts on failure", RestartPolicy{Type: RestartNever}, serveErr, 0, false},
+		{"on-failure restarts on failure", RestartPolicy{Type: RestartOnFailure}, serveErr, 0, true},
+		{"on-failure does not restart on clean exit", RestartPolicy{Type: RestartOnFailure}, nil, 0, false},
+		{"always restarts on clean exit", RestartPolicy{Type: RestartAlways}, nil, 0, true},
+		{"max restarts reached", RestartPolicy{Type: RestartAlways, MaxRestarts: 3}, serveErr, 3, false},
+		{"max restarts not reached", RestartPolicy{Type: RestartAlways, MaxRestarts: 3}, serveErr, 2, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.policy.shouldRestart(tt.serveErr, tt.restarts))
+		})
+	}
+}
+
+func TestRestartPolicy_Backoff(t *testing.T) {
+	policy := RestartPolicy{Backoff: time.Second, MaxBackoff: 5 * time.Second}
+
+	assert.Equal(t, time.Second, policy.backoff(0))
+	assert.Equal(t, 2*time.Second, policy.backoff(1))
+	assert.Equal(t, 4*time.Second, policy.backoff(2))
+	assert.Equal(t, 5*time.Second, policy.backoff(3))
+	assert.Equal(t, 5*time.Second, policy.backoff(10))
+}
+
+func TestRestartPolicy_IsValid(t *testing.T) {
+	assert.True(t, DefaultRestartPolicy().IsValid())
+	assert.True(t, RestartPolicy{Type: RestartOnFailure}.IsValid())
+	assert.False(t, RestartPolicy{Type: "sometimes"}.IsValid())
+}
+
+type probedServiceFake struct {
+	serviceFake
+	aliveErr error
+}
+
+func (service *probedServiceFake) Alive() error {
+	return service.aliveErr
+}
+
+func TestProbeLiveness_StopsServiceWhenProbeFails(t *testing.T) {
+	fake := &probedServiceFake{aliveErr: errors.New("process exited")}
+	fake.mockProcess = make(chan struct{})
+	service := &supervisedService{Service: fake}
+
+	stopProbing := probeLiveness(service, time.Millisecond)
+	err := service.Serve(identity.FromAddress("0x1"))
+
+	assert.NoError(t, err)
+	assert.Error(t, stopProbing())
+}
@@ -57,6 +57,8 @@ type ServiceInfo struct {
 	AccessPolicies       *[]market.AccessPolicy `json:"accessPolicies,omitempty"`
 	Sessions             []ServiceSession       `json:"serviceSession,omitempty"`
 	ConnectionStatistics ConnectionStatistics   `json:"connectionStatistics"`
+	RestartCount         int                    `json:"restartCount"`
+	LastFailure          string                 `json:"lastFailure,omitempty"`
 }
 
 // ServiceSession represents the session object
@@ -141,6 +141,7 @@ func (k *Keeper) updateServices() {
 	i := 0
 	for key, v := range services {
 		proposal := v.Proposal()
+		restartInfo := v.RestartInfo()
 
 		// merge in the connection statistics
 		match, _ := k.getServiceByID(string(key))
@@ -153,6 +154,8 @@ func (k *Keeper) updateServices() {
 			Status:               string(v.State()),
 			Proposal:             proposal,
 			ConnectionStatistics: match.ConnectionStatistics,
+			RestartCount:         restartInfo.Count,
+			LastFailure:          restartInfo.LastFailure,
 		}
 		i++
 	}
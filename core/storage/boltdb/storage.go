@@ -21,6 +21,7 @@ import (
 	"path/filepath"
 
 	"github.com/asdine/storm"
+	"github.com/asdine/storm/q"
 	"github.com/pkg/errors"
 )
 
@@ -60,6 +61,11 @@ func (b *Bolt) GetAllFrom(bucket string, data interface{}) error {
 	return b.db.From(bucket).All(data)
 }
 
+// Select returns a query over the structs in the given bucket matching all the given matchers
+func (b *Bolt) Select(bucket string, matchers ...q.Matcher) storm.Query {
+	return b.db.From(bucket).Select(matchers...)
+}
+
 // Delete removes the given struct from the given bucket
 func (b *Bolt) Delete(bucket string, data interface{}) error {
 	return b.db.From(bucket).DeleteStruct(data)
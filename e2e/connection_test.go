@@ -32,11 +32,15 @@ var (
 	consumerPassphrase = "localconsumer"
 	providerID         = "0xd1a23227bd5ad77f36ba62badcb78a410a1db6c5"
 	providerPassphrase = "localprovider"
+	tequilapiUsername  = "myst"
+	tequilapiPassword  = "mystberry"
 	accountantID       = "0xf2e2c77D2e7207d8341106E6EfA469d1940FD0d8"
 )
 
 func TestConsumerConnectsToProvider(t *testing.T) {
 	tequilapiConsumer := newTequilapiConsumer()
+	_, err := tequilapiConsumer.AuthLogin(tequilapiUsername, tequilapiPassword)
+	assert.NoError(t, err)
 
 	var consumerID string
 	// no need to register provider, as he will auto-register
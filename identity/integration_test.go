@@ -18,8 +18,12 @@
 package identity
 
 import (
+	"io/ioutil"
+	"os"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/mysteriumnetwork/node/eventbus"
@@ -44,3 +48,48 @@ func Test_UnlockAndSignAndVerify(t *testing.T) {
 	verifier := NewVerifierIdentity(FromAddress("0x53a835143c0ef3bbcbfa796d7eb738ca7dd28f68"))
 	assert.True(t, verifier.Verify([]byte("Boop!"), signature))
 }
+
+func Test_ExportAndImport(t *testing.T) {
+	dir, err := ioutil.TempDir("", "identityImportTest")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	exporter := NewIdentityManager(NewKeystoreFilesystem("test_data", true), eventbus.New())
+	keyJSON, err := exporter.ExportIdentity("0x53a835143c0ef3bbcbfa796d7eb738ca7dd28f68", "", "backup")
+	assert.NoError(t, err)
+
+	importer := NewIdentityManager(NewKeystoreFilesystem(dir, true), eventbus.New())
+	_, err = importer.ImportIdentity(keyJSON, "wrong", "new")
+	assert.Error(t, err)
+
+	id, err := importer.ImportIdentity(keyJSON, "backup", "new")
+	assert.NoError(t, err)
+	assert.Equal(t, FromAddress("0x53a835143c0ef3bbcbfa796d7eb738ca7dd28f68"), id)
+	assert.NoError(t, importer.Unlock(id.Address, "new"))
+
+	_, err = importer.ImportIdentity(keyJSON, "backup", "new")
+	assert.Equal(t, ErrIdentityExists, err)
+}
+
+func Test_ChangePassphrase(t *testing.T) {
+	dir, err := ioutil.TempDir("", "identityPassphraseTest")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	manager := NewIdentityManager(NewKeystoreFilesystem(dir, true), eventbus.New())
+	id, err := manager.CreateNewIdentity("old")
+	assert.NoError(t, err)
+
+	err = manager.ChangePassphrase(id.Address, "wrong", "new")
+	assert.Equal(t, keystore.ErrDecrypt, errors.Cause(err))
+
+	assert.NoError(t, manager.ChangePassphrase(id.Address, "old", "new"))
+
+	files, err := ioutil.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Len(t, files, 1, "temporary key file has to be renamed over the old one")
+
+	reloaded := NewIdentityManager(NewKeystoreFilesystem(dir, true), eventbus.New())
+	assert.Error(t, reloaded.Unlock(id.Address, "old"))
+	assert.NoError(t, reloaded.Unlock(id.Address, "new"))
+}
@@ -0,0 +1,218 @@
+/*
+ * Copyright (C) 2020 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package identity
+
+import (
+	"encoding/json"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+)
+
+// ErrUnsupportedByExternalSigner represents an error when the action is not available for keys held by an external signer
+var ErrUnsupportedByExternalSigner = errors.New("not supported by external signer")
+
+// ErrPassphraseManagedBySigner represents an error when passphrase is given for a key held by an external signer
+var ErrPassphraseManagedBySigner = errors.New("passphrase is managed by external signer, unlock with an empty passphrase")
+
+const (
+	signerMethodList     = "account_list"
+	signerMethodNew      = "account_new"
+	signerMethodSignData = "account_signData"
+
+	// signerContentText is the content type Clef signs as EIP-191 personal message
+	signerContentText = "text/plain"
+)
+
+// ExternalKeystore delegates signing to an external signer process listening on a local socket,
+// talking to it in JSON-RPC 2.0 the same way as Clef does.
+// Clef never signs raw hashes, so the hash is signed as EIP-191 personal message instead, see SignHash.
+type ExternalKeystore struct {
+	network, address string
+	timeout          time.Duration
+	requestID        uint64
+
+	// passphrases are held by the signer, so accounts are unlocked on the signer side
+	unlockedMu sync.Mutex
+	unlocked   map[common.Address]bool
+}
+
+// NewKeystoreExternal creates new keystore, which keeps keys in external signer listening on the given unix socket
+func NewKeystoreExternal(socket string, timeout time.Duration) *ExternalKeystore {
+	return &ExternalKeystore{
+		network:  "unix",
+		address:  socket,
+		timeout:  timeout,
+		unlocked: make(map[common.Address]bool),
+	}
+}
+
+// Accounts returns the accounts held by the signer
+func (ks *ExternalKeystore) Accounts() []accounts.Account {
+	var addresses []common.Address
+	if err := ks.call(signerMethodList, &addresses); err != nil {
+		return nil
+	}
+
+	list := make([]accounts.Account, len(addresses))
+	for i, address := range addresses {
+		list[i] = accounts.Account{Address: address}
+	}
+	return list
+}
+
+// NewAccount asks the signer to create a new key, passphrase is managed by the signer
+func (ks *ExternalKeystore) NewAccount(_ string) (accounts.Account, error) {
+	var address common.Address
+	if err := ks.call(signerMethodNew, &address); err != nil {
+		return accounts.Account{}, err
+	}
+	return accounts.Account{Address: address}, nil
+}
+
+// Find checks if the signer holds the key of the given account
+func (ks *ExternalKeystore) Find(a accounts.Account) (accounts.Account, error) {
+	var addresses []common.Address
+	if err := ks.call(signerMethodList, &addresses); err != nil {
+		return accounts.Account{}, err
+	}
+
+	for _, address := range addresses {
+		if address == a.Address {
+			return accounts.Account{Address: address}, nil
+		}
+	}
+	return accounts.Account{}, errors.New("account not found")
+}
+
+// Unlock checks that the signer holds the key of the given account, the key itself is unlocked on the signer side.
+// Passphrase has to be empty, since it is never passed to the signer.
+func (ks *ExternalKeystore) Unlock(a accounts.Account, passphrase string) error {
+	if passphrase != "" {
+		return ErrPassphraseManagedBySigner
+	}
+	if _, err := ks.Find(a); err != nil {
+		return err
+	}
+
+	ks.unlockedMu.Lock()
+	defer ks.unlockedMu.Unlock()
+	ks.unlocked[a.Address] = true
+	return nil
+}
+
+// SignHash asks the signer to sign the given hash with the key of unlocked account.
+// Signer signs the hash as EIP-191 personal message, i.e. the signature is of accounts.TextHash(hash) rather than of the hash itself.
+// V of the signature is returned as 0 or 1, same as the go-ethereum keystore returns.
+func (ks *ExternalKeystore) SignHash(a accounts.Account, hash []byte) ([]byte, error) {
+	ks.unlockedMu.Lock()
+	unlocked := ks.unlocked[a.Address]
+	ks.unlockedMu.Unlock()
+	if !unlocked {
+		return nil, errors.New("authentication needed: password or unlock")
+	}
+
+	var signature hexutil.Bytes
+	if err := ks.call(signerMethodSignData, &signature, signerContentText, a.Address, hexutil.Bytes(hash)); err != nil {
+		return nil, err
+	}
+	if len(signature) != 65 {
+		return nil, errors.Errorf("external signer returned signature of invalid length %d", len(signature))
+	}
+	// Clef returns V as 27 or 28
+	if signature[64] >= 27 {
+		signature[64] -= 27
+	}
+	return signature, nil
+}
+
+// Export is not supported, keys never leave the signer
+func (ks *ExternalKeystore) Export(_ accounts.Account, _, _ string) ([]byte, error) {
+	return nil, ErrUnsupportedByExternalSigner
+}
+
+// Import is not supported, keys have to be imported to the signer directly
+func (ks *ExternalKeystore) Import(_ []byte, _, _ string) (accounts.Account, error) {
+	return accounts.Account{}, ErrUnsupportedByExternalSigner
+}
+
+// Update is not supported, passphrases are managed by the signer
+func (ks *ExternalKeystore) Update(_ accounts.Account, _, _ string) error {
+	return ErrUnsupportedByExternalSigner
+}
+
+type signerRequest struct {
+	Version string        `json:"jsonrpc"`
+	ID      uint64        `json:"id"`
+	Method  string        `json:"method"`
+	Params  []interface{} `json:"params"`
+}
+
+type signerResponse struct {
+	Version string          `json:"jsonrpc"`
+	ID      uint64          `json:"id"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *signerError    `json:"error,omitempty"`
+}
+
+type signerError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (ks *ExternalKeystore) call(method string, result interface{}, params ...interface{}) error {
+	conn, err := net.DialTimeout(ks.network, ks.address, ks.timeout)
+	if err != nil {
+		return errors.Wrap(err, "could not connect to external signer")
+	}
+	defer conn.Close()
+	if err := conn.SetDeadline(time.Now().Add(ks.timeout)); err != nil {
+		return errors.Wrap(err, "could not set external signer deadline")
+	}
+
+	if params == nil {
+		params = []interface{}{}
+	}
+	request := signerRequest{
+		Version: "2.0",
+		ID:      atomic.AddUint64(&ks.requestID, 1),
+		Method:  method,
+		Params:  params,
+	}
+	if err := json.NewEncoder(conn).Encode(request); err != nil {
+		return errors.Wrapf(err, "could not send %s to external signer", method)
+	}
+
+	var response signerResponse
+	if err := json.NewDecoder(conn).Decode(&response); err != nil {
+		return errors.Wrapf(err, "could not read %s response of external signer", method)
+	}
+	if response.Error != nil {
+		return errors.Errorf("external signer failed %s: %s", method, response.Error.Message)
+	}
+	if response.ID != request.ID {
+		return errors.Errorf("external signer responded to request %d instead of %d", response.ID, request.ID)
+	}
+	return errors.Wrapf(json.Unmarshal(response.Result, result), "could not parse %s response of external signer", method)
+}
@@ -0,0 +1,119 @@
+/*
+ * Copyright (C) 2020 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package identity
+
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mysteriumnetwork/node/eventbus"
+)
+
+func startLocalSigner(t *testing.T, keystore Keystore, passphrase string) (socket string, stop func()) {
+	dir, err := ioutil.TempDir("", "externalSignerTest")
+	assert.NoError(t, err)
+
+	socket = filepath.Join(dir, "signer.ipc")
+	listener, err := net.Listen("unix", socket)
+	assert.NoError(t, err)
+
+	signer := newLocalSigner(keystore, passphrase)
+	go signer.Serve(listener)
+
+	return socket, func() {
+		signer.Stop()
+		os.RemoveAll(dir)
+	}
+}
+
+func Test_ExternalKeystoreSignsAsPersonalMessage(t *testing.T) {
+	fileKeystore := NewKeystoreFilesystem("test_data", true)
+	socket, stop := startLocalSigner(t, fileKeystore, "")
+	defer stop()
+
+	ks := NewKeystoreExternal(socket, 5*time.Second)
+	manager := NewIdentityManager(ks, eventbus.New())
+	assert.Contains(t, manager.GetIdentities(), FromAddress("0x53a835143c0ef3bbcbfa796d7eb738ca7dd28f68"))
+
+	signer := NewSigner(ks, FromAddress("0x53a835143c0ef3bbcbfa796d7eb738ca7dd28f68"))
+	_, err := signer.Sign([]byte("Boop!"))
+	assert.Error(t, err, "account has to be unlocked before signing")
+
+	assert.NoError(t, manager.Unlock("0x53a835143c0ef3bbcbfa796d7eb738ca7dd28f68", ""))
+	signature, err := signer.Sign([]byte("Boop!"))
+	assert.NoError(t, err)
+
+	account := accounts.Account{Address: common.HexToAddress("0x53a835143c0ef3bbcbfa796d7eb738ca7dd28f68")}
+	assert.NoError(t, fileKeystore.Unlock(account, ""))
+	expected, err := fileKeystore.SignHash(account, accounts.TextHash(messageHash([]byte("Boop!"))))
+	assert.NoError(t, err)
+	assert.Exactly(t, SignatureBytes(expected), signature)
+
+	assert.True(t, NewVerifierIdentity(FromAddress("0x53a835143c0ef3bbcbfa796d7eb738ca7dd28f68")).Verify([]byte("Boop!"), signature))
+	assert.False(t, NewVerifierIdentity(FromAddress("0x1")).Verify([]byte("Boop!"), signature))
+}
+
+func Test_ExternalKeystoreCreatesAccounts(t *testing.T) {
+	dir, err := ioutil.TempDir("", "externalSignerKeys")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	socket, stop := startLocalSigner(t, NewKeystoreFilesystem(dir, true), "secret")
+	defer stop()
+
+	manager := NewIdentityManager(NewKeystoreExternal(socket, 5*time.Second), eventbus.New())
+	id, err := manager.CreateNewIdentity("ignored")
+	assert.NoError(t, err)
+	assert.Equal(t, []Identity{id}, manager.GetIdentities())
+	assert.Equal(t, ErrPassphraseManagedBySigner, errors.Cause(manager.Unlock(id.Address, "secret")))
+	assert.NoError(t, manager.Unlock(id.Address, ""))
+
+	_, err = manager.ExportIdentity(id.Address, "ignored", "backup")
+	assert.Equal(t, ErrUnsupportedByExternalSigner, errors.Cause(err))
+
+	err = manager.ChangePassphrase(id.Address, "ignored", "new")
+	assert.Equal(t, ErrUnsupportedByExternalSigner, errors.Cause(err))
+}
+
+func Test_ExternalKeystoreFailures(t *testing.T) {
+	ks := NewKeystoreExternal(filepath.Join(os.TempDir(), "missing-signer.ipc"), 5*time.Second)
+	assert.Empty(t, ks.Accounts())
+	_, err := ks.NewAccount("")
+	assert.Error(t, err)
+
+	socket, stop := startLocalSigner(t, NewKeystoreFilesystem("test_data", true), "wrong")
+	defer stop()
+
+	ks = NewKeystoreExternal(socket, 5*time.Second)
+	account := accounts.Account{Address: common.HexToAddress("0x53a835143c0ef3bbcbfa796d7eb738ca7dd28f68")}
+	assert.NoError(t, ks.Unlock(account, ""))
+	_, err = ks.SignHash(account, messageHash([]byte("Boop!")))
+	assert.EqualError(t, err, "external signer failed account_signData: could not decrypt key with given password")
+
+	assert.Error(t, ks.Unlock(accounts.Account{Address: common.HexToAddress("0x1")}, ""))
+	assert.Error(t, ks.call("account_unknown", nil))
+}
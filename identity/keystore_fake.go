@@ -27,6 +27,7 @@ type keyStoreFake struct {
 	AccountsMock []accounts.Account
 	ErrorMock    error
 	LastHash     []byte
+	ImportMock   accounts.Account
 }
 
 func (keyStore *keyStoreFake) Accounts() []accounts.Account {
@@ -76,3 +77,32 @@ func (keyStore *keyStoreFake) Find(a accounts.Account) (accounts.Account, error)
 
 	return a, errors.New("account not found")
 }
+
+func (keyStore *keyStoreFake) Export(a accounts.Account, passphrase, newPassphrase string) ([]byte, error) {
+	if keyStore.ErrorMock != nil {
+		return nil, keyStore.ErrorMock
+	}
+
+	if _, err := keyStore.Find(a); err != nil {
+		return nil, err
+	}
+	return []byte(`{"address":"` + a.Address.Hex() + `"}`), nil
+}
+
+func (keyStore *keyStoreFake) Import(keyJSON []byte, passphrase, newPassphrase string) (accounts.Account, error) {
+	if keyStore.ErrorMock != nil {
+		return accounts.Account{}, keyStore.ErrorMock
+	}
+
+	keyStore.AccountsMock = append(keyStore.AccountsMock, keyStore.ImportMock)
+	return keyStore.ImportMock, nil
+}
+
+func (keyStore *keyStoreFake) Update(a accounts.Account, passphrase, newPassphrase string) error {
+	if keyStore.ErrorMock != nil {
+		return keyStore.ErrorMock
+	}
+
+	_, err := keyStore.Find(a)
+	return err
+}
@@ -18,17 +18,93 @@
 package identity
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/pkg/errors"
 	"github.com/rs/zerolog/log"
 )
 
+// KeystoreFilesystem keeps keys in filesystem, re-encrypting them without risking to lose the key file
+type KeystoreFilesystem struct {
+	*keystore.KeyStore
+}
+
 // NewKeystoreFilesystem create new keystore, which keeps keys in filesystem
-func NewKeystoreFilesystem(directory string, lightweight bool) *keystore.KeyStore {
+func NewKeystoreFilesystem(directory string, lightweight bool) *KeystoreFilesystem {
 	if lightweight {
 		log.Debug().Msg("Using lightweight keystore")
-		return keystore.NewKeyStore(directory, keystore.LightScryptN, keystore.LightScryptP)
+		return &KeystoreFilesystem{keystore.NewKeyStore(directory, keystore.LightScryptN, keystore.LightScryptP)}
 	}
 
 	log.Debug().Msg("using heavyweight keystore")
-	return keystore.NewKeyStore(directory, keystore.StandardScryptN, keystore.StandardScryptP)
+	return &KeystoreFilesystem{keystore.NewKeyStore(directory, keystore.StandardScryptN, keystore.StandardScryptP)}
+}
+
+// Update re-encrypts the key file of account with the new passphrase.
+// New key file is written and synced aside, checked and only then renamed over the old one,
+// so the key file is either old or new one even if the node crashes in between.
+func (ks *KeystoreFilesystem) Update(a accounts.Account, passphrase, newPassphrase string) error {
+	a, err := ks.Find(a)
+	if err != nil {
+		return err
+	}
+
+	keyJSON, err := ks.Export(a, passphrase, newPassphrase)
+	if err != nil {
+		return err
+	}
+
+	return replaceKeyFile(a.URL.Path, keyJSON, newPassphrase)
+}
+
+func replaceKeyFile(path string, keyJSON []byte, passphrase string) error {
+	dir := filepath.Dir(path)
+	tmp, err := ioutil.TempFile(dir, "."+filepath.Base(path)+".tmp")
+	if err != nil {
+		return errors.Wrap(err, "could not create temporary key file")
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(keyJSON); err != nil {
+		tmp.Close()
+		return errors.Wrap(err, "could not write temporary key file")
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return errors.Wrap(err, "could not sync temporary key file")
+	}
+	if err := tmp.Close(); err != nil {
+		return errors.Wrap(err, "could not close temporary key file")
+	}
+
+	written, err := ioutil.ReadFile(tmp.Name())
+	if err != nil {
+		return errors.Wrap(err, "could not read temporary key file")
+	}
+	if _, err := keystore.DecryptKey(written, passphrase); err != nil {
+		return errors.Wrap(err, "temporary key file is corrupted")
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return errors.Wrap(err, "could not replace key file")
+	}
+	return syncDir(dir)
+}
+
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return errors.Wrap(err, "could not open keystore directory")
+	}
+	defer d.Close()
+
+	// directories can't be synced on some platforms (e.g. windows), rename has been done anyway
+	if err := d.Sync(); err != nil {
+		log.Warn().Err(err).Msg("Could not sync keystore directory")
+	}
+	return nil
 }
@@ -19,11 +19,14 @@ package identity
 
 import "github.com/ethereum/go-ethereum/accounts"
 
-// Keystore allows actions with accounts (listing, creating, unlocking, signing)
+// Keystore allows actions with accounts (listing, creating, unlocking, signing, re-encrypting)
 type Keystore interface {
 	Accounts() []accounts.Account
 	NewAccount(passphrase string) (accounts.Account, error)
 	Find(a accounts.Account) (accounts.Account, error)
 	Unlock(a accounts.Account, passphrase string) error
 	SignHash(a accounts.Account, hash []byte) ([]byte, error)
+	Export(a accounts.Account, passphrase, newPassphrase string) ([]byte, error)
+	Import(keyJSON []byte, passphrase, newPassphrase string) (accounts.Account, error)
+	Update(a accounts.Account, passphrase, newPassphrase string) error
 }
@@ -21,6 +21,7 @@
 package identity
 
 import (
+	"encoding/json"
 	"sync"
 
 	"github.com/ethereum/go-ethereum/accounts"
@@ -34,6 +35,9 @@ import (
 // AppTopicIdentityUnlock is the channel name for identity unlock event
 const AppTopicIdentityUnlock = "identity-unlocked"
 
+// ErrIdentityExists represents an error when imported identity is already stored in keystore
+var ErrIdentityExists = errors.New("identity already exists")
+
 type identityManager struct {
 	keystoreManager Keystore
 	unlocked        map[string]bool // Currently unlocked addresses
@@ -125,6 +129,53 @@ func (idm *identityManager) Unlock(address string, passphrase string) error {
 	return nil
 }
 
+// ExportIdentity returns the key of identity as JSON keystore blob, encrypted with the export passphrase.
+func (idm *identityManager) ExportIdentity(address, passphrase, exportPassphrase string) ([]byte, error) {
+	account, err := idm.findAccount(address)
+	if err != nil {
+		return nil, err
+	}
+
+	keyJSON, err := idm.keystoreManager.Export(account, passphrase, exportPassphrase)
+	if err != nil {
+		return nil, errors.Wrapf(err, "keystore failed to export identity: %s", address)
+	}
+	return keyJSON, nil
+}
+
+// ImportIdentity stores the key of identity given as JSON keystore blob, encrypting it with the new passphrase.
+func (idm *identityManager) ImportIdentity(keyJSON []byte, passphrase, newPassphrase string) (Identity, error) {
+	var key struct {
+		Address string `json:"address"`
+	}
+	if err := json.Unmarshal(keyJSON, &key); err != nil {
+		return Identity{}, errors.Wrap(err, "invalid keystore blob")
+	}
+	if key.Address != "" && idm.HasIdentity(key.Address) {
+		return Identity{}, ErrIdentityExists
+	}
+
+	account, err := idm.keystoreManager.Import(keyJSON, passphrase, newPassphrase)
+	if err != nil {
+		return Identity{}, errors.Wrap(err, "keystore failed to import identity")
+	}
+
+	return accountToIdentity(account), nil
+}
+
+// ChangePassphrase re-encrypts the key of identity with the new passphrase.
+func (idm *identityManager) ChangePassphrase(address, passphrase, newPassphrase string) error {
+	account, err := idm.findAccount(address)
+	if err != nil {
+		return err
+	}
+
+	if err := idm.keystoreManager.Update(account, passphrase, newPassphrase); err != nil {
+		return errors.Wrapf(err, "keystore failed to change passphrase of identity: %s", address)
+	}
+	return nil
+}
+
 func (idm *identityManager) findAccount(address string) (accounts.Account, error) {
 	account, err := idm.keystoreManager.Find(addressToAccount(address))
 	if err != nil {
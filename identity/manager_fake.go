@@ -17,7 +17,10 @@
 
 package identity
 
-import "github.com/pkg/errors"
+import (
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/pkg/errors"
+)
 
 type idmFake struct {
 	LastUnlockAddress    string
@@ -63,3 +66,25 @@ func (fakeIdm *idmFake) Unlock(address string, passphrase string) error {
 	}
 	return nil
 }
+
+func (fakeIdm *idmFake) ExportIdentity(address, _, _ string) ([]byte, error) {
+	if _, err := fakeIdm.GetIdentity(address); err != nil {
+		return nil, err
+	}
+	return []byte(`{"address":"` + address + `"}`), nil
+}
+
+func (fakeIdm *idmFake) ImportIdentity(_ []byte, _, _ string) (Identity, error) {
+	fakeIdm.existingIdentities = append(fakeIdm.existingIdentities, fakeIdm.newIdentity)
+	return fakeIdm.newIdentity, nil
+}
+
+func (fakeIdm *idmFake) ChangePassphrase(address, passphrase, _ string) error {
+	fakeIdm.LastUnlockAddress = address
+	fakeIdm.LastUnlockPassphrase = passphrase
+	if fakeIdm.unlockFails {
+		return errors.Wrap(keystore.ErrDecrypt, "Unlock failed")
+	}
+	_, err := fakeIdm.GetIdentity(address)
+	return err
+}
@@ -25,4 +25,7 @@ type Manager interface {
 	GetIdentity(address string) (Identity, error)
 	HasIdentity(address string) bool
 	Unlock(address string, passphrase string) error
+	ExportIdentity(address, passphrase, exportPassphrase string) ([]byte, error)
+	ImportIdentity(keyJSON []byte, passphrase, newPassphrase string) (Identity, error)
+	ChangePassphrase(address, passphrase, newPassphrase string) error
 }
@@ -99,3 +99,43 @@ func TestManager_HasIdentity(t *testing.T) {
 	assert.True(t, manager.HasIdentity("0x000000000000000000000000000000000000000a"))
 	assert.False(t, manager.HasIdentity("0x000000000000000000000000000000000000000B"))
 }
+
+func TestManager_ExportIdentity(t *testing.T) {
+	manager := newManager("0x000000000000000000000000000000000000000A", eventbus.New())
+
+	keyJSON, err := manager.ExportIdentity("0x000000000000000000000000000000000000000A", "", "export")
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"address":"0x000000000000000000000000000000000000000A"}`, string(keyJSON))
+
+	_, err = manager.ExportIdentity("0x000000000000000000000000000000000000000B", "", "export")
+	assert.EqualError(t, err, "identity not found: 0x000000000000000000000000000000000000000B")
+}
+
+func TestManager_ImportIdentity(t *testing.T) {
+	manager := newManager("0x000000000000000000000000000000000000000A", eventbus.New())
+	manager.keystoreManager.(*keyStoreFake).ImportMock = addressToAccount("0x000000000000000000000000000000000000000B")
+
+	identity, err := manager.ImportIdentity([]byte(`{}`), "export", "")
+	assert.NoError(t, err)
+	assert.Equal(t, Identity{"0x000000000000000000000000000000000000000b"}, identity)
+	assert.True(t, manager.HasIdentity("0x000000000000000000000000000000000000000B"))
+
+	_, err = manager.ImportIdentity([]byte(`{"address":"000000000000000000000000000000000000000b"}`), "export", "")
+	assert.Equal(t, ErrIdentityExists, err)
+}
+
+func TestManager_ImportIdentityError(t *testing.T) {
+	im := newManagerWithError(errors.New("wrong passphrase"))
+
+	_, err := im.ImportIdentity([]byte(`{}`), "export", "")
+	assert.EqualError(t, err, "keystore failed to import identity: wrong passphrase")
+}
+
+func TestManager_ChangePassphrase(t *testing.T) {
+	manager := newManager("0x000000000000000000000000000000000000000A", eventbus.New())
+
+	assert.NoError(t, manager.ChangePassphrase("0x000000000000000000000000000000000000000A", "old", "new"))
+
+	err := manager.ChangePassphrase("0x000000000000000000000000000000000000000B", "old", "new")
+	assert.EqualError(t, err, "identity not found: 0x000000000000000000000000000000000000000B")
+}
@@ -26,4 +26,5 @@ import (
 type IdentityRegistry interface {
 	Subscribe(eventbus.Subscriber) error
 	GetRegistrationStatus(id identity.Identity) (RegistrationStatus, error)
+	RestoreRegistrationStatus(id identity.Identity) (RegistrationStatus, error)
 }
@@ -127,6 +127,20 @@ func (registry *contractRegistry) GetRegistrationStatus(id identity.Identity) (R
 	return statusBC, errors.Wrap(err, "could not store registration status")
 }
 
+// RestoreRegistrationStatus checks the registration status of the provided identity on blockchain and caches it locally,
+// replacing the status cached before. Used for identities brought from other nodes, as the cached status is missing or outdated.
+func (registry *contractRegistry) RestoreRegistrationStatus(id identity.Identity) (RegistrationStatus, error) {
+	statusBC, err := registry.isRegisteredInBC(id)
+	if err != nil {
+		return Unregistered, errors.Wrap(err, "could not check identity registration status on blockchain")
+	}
+	err = registry.storage.Store(StoredRegistrationStatus{
+		Identity:           id,
+		RegistrationStatus: statusBC,
+	})
+	return statusBC, errors.Wrap(err, "could not store registration status")
+}
+
 func (registry *contractRegistry) handleNodeEvent(ev event.Payload) {
 	log.Debug().Msgf("event received %v", ev)
 	if ev.Status == event.StatusStarted {
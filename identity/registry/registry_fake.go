@@ -33,6 +33,11 @@ func (registry *FakeRegistry) GetRegistrationStatus(id identity.Identity) (Regis
 	return registry.RegistrationStatus, registry.RegistrationCheckError
 }
 
+// RestoreRegistrationStatus returns fake identity registration status within payments contract
+func (registry *FakeRegistry) RestoreRegistrationStatus(id identity.Identity) (RegistrationStatus, error) {
+	return registry.RegistrationStatus, registry.RegistrationCheckError
+}
+
 // Subscribe does nothing
 func (registry *FakeRegistry) Subscribe(eventbus.Subscriber) error {
 	return nil
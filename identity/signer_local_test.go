@@ -0,0 +1,178 @@
+/*
+ * Copyright (C) 2020 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package identity
+
+import (
+	"bufio"
+	"encoding/json"
+	"net"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	signerErrorInvalidRequest = -32600
+	signerErrorUnknownMethod  = -32601
+	signerErrorInvalidParams  = -32602
+	signerErrorInternal       = -32000
+)
+
+// localSigner is a stand-in for Clef serving keys of the given keystore in tests.
+// It implements the subset of Clef API ExternalKeystore uses.
+type localSigner struct {
+	keystore   Keystore
+	passphrase string
+
+	mu       sync.Mutex
+	unlocked map[common.Address]bool
+	listener net.Listener
+}
+
+// newLocalSigner creates a signer serving keys of the given keystore, all keys are unlocked with the given passphrase
+func newLocalSigner(keystore Keystore, passphrase string) *localSigner {
+	return &localSigner{
+		keystore:   keystore,
+		passphrase: passphrase,
+		unlocked:   make(map[common.Address]bool),
+	}
+}
+
+// Serve accepts connections on the given listener until Stop is called
+func (ls *localSigner) Serve(listener net.Listener) error {
+	ls.mu.Lock()
+	ls.listener = listener
+	ls.mu.Unlock()
+
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			return errors.Wrap(err, "local signer stopped accepting connections")
+		}
+		go ls.serveConn(conn)
+	}
+}
+
+// Stop stops accepting new connections
+func (ls *localSigner) Stop() error {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+
+	if ls.listener == nil {
+		return nil
+	}
+	return ls.listener.Close()
+}
+
+func (ls *localSigner) serveConn(conn net.Conn) {
+	defer conn.Close()
+
+	decoder := json.NewDecoder(bufio.NewReader(conn))
+	encoder := json.NewEncoder(conn)
+	for {
+		var request struct {
+			ID     uint64            `json:"id"`
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+		if err := decoder.Decode(&request); err != nil {
+			return
+		}
+
+		response := signerResponse{Version: "2.0", ID: request.ID}
+		result, rpcErr := ls.handle(request.Method, request.Params)
+		if rpcErr != nil {
+			response.Error = rpcErr
+		} else if response.Result, rpcErr = marshalSignerResult(result); rpcErr != nil {
+			response.Error = rpcErr
+		}
+
+		if err := encoder.Encode(response); err != nil {
+			log.Warn().Err(err).Msg("Local signer failed to respond")
+			return
+		}
+	}
+}
+
+func (ls *localSigner) handle(method string, params []json.RawMessage) (interface{}, *signerError) {
+	switch method {
+	case signerMethodList:
+		list := ls.keystore.Accounts()
+		addresses := make([]common.Address, len(list))
+		for i, account := range list {
+			addresses[i] = account.Address
+		}
+		return addresses, nil
+	case signerMethodNew:
+		account, err := ls.keystore.NewAccount(ls.passphrase)
+		if err != nil {
+			return nil, &signerError{Code: signerErrorInternal, Message: err.Error()}
+		}
+		return account.Address, nil
+	case signerMethodSignData:
+		var contentType string
+		var address common.Address
+		var data hexutil.Bytes
+		if len(params) != 3 || json.Unmarshal(params[0], &contentType) != nil || json.Unmarshal(params[1], &address) != nil || json.Unmarshal(params[2], &data) != nil {
+			return nil, &signerError{Code: signerErrorInvalidParams, Message: "expected content type, address and data"}
+		}
+		if contentType != signerContentText {
+			return nil, &signerError{Code: signerErrorInvalidParams, Message: "unsupported content type " + contentType}
+		}
+		signature, err := ls.signHash(accounts.Account{Address: address}, accounts.TextHash(data))
+		if err != nil {
+			return nil, &signerError{Code: signerErrorInternal, Message: err.Error()}
+		}
+		// same as Clef, V is transformed to 27 or 28
+		signature[64] += 27
+		return hexutil.Bytes(signature), nil
+	case "":
+		return nil, &signerError{Code: signerErrorInvalidRequest, Message: "method is missing"}
+	default:
+		return nil, &signerError{Code: signerErrorUnknownMethod, Message: "the method " + method + " does not exist"}
+	}
+}
+
+func (ls *localSigner) signHash(account accounts.Account, hash []byte) ([]byte, error) {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+
+	if !ls.unlocked[account.Address] {
+		account, err := ls.keystore.Find(account)
+		if err != nil {
+			return nil, err
+		}
+		if err := ls.keystore.Unlock(account, ls.passphrase); err != nil {
+			return nil, err
+		}
+		ls.unlocked[account.Address] = true
+	}
+	return ls.keystore.SignHash(account, hash)
+}
+
+func marshalSignerResult(result interface{}) (json.RawMessage, *signerError) {
+	data, err := json.Marshal(result)
+	if err != nil {
+		return nil, &signerError{Code: signerErrorInternal, Message: err.Error()}
+	}
+	return data, nil
+}
@@ -32,7 +32,7 @@ func NewVerifierSigned() *verifierSigned {
 // NewVerifierIdentity constructs Verifier which:
 //   - checks signature's sanity
 //   - checks if message was unchanged by middleman
-//   - checks if message is from exact identity
+//   - checks if message is from exact identity, either signed by keystore or by external signer
 func NewVerifierIdentity(peerID Identity) *verifierIdentity {
 	return &verifierIdentity{NewExtractor(), peerID}
 }
@@ -56,6 +56,10 @@ func (verifier *verifierIdentity) Verify(message []byte, signature Signature) bo
 	if err != nil {
 		return false
 	}
+	if identity == verifier.peerID {
+		return true
+	}
 
-	return identity == verifier.peerID
+	identity, err = extractText(message, signature)
+	return err == nil && identity == verifier.peerID
 }
@@ -38,6 +38,21 @@ type PaymentRate struct {
 	PerByte uint64
 }
 
+// Price represents the price provider asks for the service
+type Price struct {
+	PerMinute money.Money `json:"perMinute"`
+	PerGB     money.Money `json:"perGB"`
+}
+
+// PricedPaymentMethod is a payment method which price can be set by the provider
+type PricedPaymentMethod interface {
+	PaymentMethod
+	// Service price per gigabyte of transferred data
+	GetPricePerGB() money.Money
+	// WithPrice returns a copy of payment method charging the given price
+	WithPrice(price Price) PaymentMethod
+}
+
 // UnsupportedPaymentMethod represents payment method which is unknown to node (i.e. not registered)
 type UnsupportedPaymentMethod struct {
 }
@@ -67,7 +82,9 @@ type PaymentMethodUnserializer func(*json.RawMessage) (PaymentMethod, error)
 
 // service payment method unserializer registry
 //TODO same idea as for contact global map
-var paymentMethodMap = make(map[string]PaymentMethodUnserializer)
+var paymentMethodMap = map[string]PaymentMethodUnserializer{
+	PaymentMethodPackage: unserializePaymentPackage,
+}
 
 // RegisterPaymentMethodUnserializer registers unserializer for specified payment method type
 func RegisterPaymentMethodUnserializer(paymentMethod string, unserializer func(*json.RawMessage) (PaymentMethod, error)) {
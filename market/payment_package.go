@@ -0,0 +1,96 @@
+/*
+ * Copyright (C) 2020 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package market
+
+import (
+	"encoding/json"
+	"math"
+	"time"
+
+	"github.com/mysteriumnetwork/node/datasize"
+	"github.com/mysteriumnetwork/node/money"
+)
+
+// PaymentMethodPackage indicates payment method for prepaid packages of service
+const PaymentMethodPackage = "PACKAGE"
+
+// PaymentPackage structure describes the price of a package consumer pays for up front.
+// Package is used up once either its duration passes or its data is transferred, whichever comes first.
+type PaymentPackage struct {
+	Price money.Money `json:"price"`
+
+	// Service duration included in the package, zero if package is not limited by time
+	Duration time.Duration `json:"duration,omitempty"`
+
+	// Bytes transferred included in the package, zero if package is not limited by data
+	Bytes uint64 `json:"bytes,omitempty"`
+}
+
+// NewPaymentPackage returns a package priced at what its whole duration and data would cost at the given rate.
+func NewPaymentPackage(rate PricedPaymentMethod, duration time.Duration, bytes uint64) PaymentPackage {
+	var amount float64
+	if perTime := rate.GetRate().PerTime; perTime > 0 {
+		amount += float64(duration) / float64(perTime) * float64(rate.GetPrice().Amount)
+	}
+	transferred := datasize.BitSize(bytes) * datasize.Byte
+	amount += transferred.Gigabytes() * float64(rate.GetPricePerGB().Amount)
+
+	return PaymentPackage{
+		Price:    money.NewMoney(uint64(math.Round(amount)), money.CurrencyMyst),
+		Duration: duration,
+		Bytes:    bytes,
+	}
+}
+
+// GetPrice returns price of a single package
+func (method PaymentPackage) GetPrice() money.Money {
+	return method.Price
+}
+
+// GetType returns PACKAGE
+func (method PaymentPackage) GetType() string {
+	return PaymentMethodPackage
+}
+
+// GetRate returns the duration and data included in a package
+func (method PaymentPackage) GetRate() PaymentRate {
+	return PaymentRate{
+		PerTime: method.Duration,
+		PerByte: method.Bytes,
+	}
+}
+
+// PackagesUsed returns how many packages were used up by the given usage of the service.
+func (method PaymentPackage) PackagesUsed(elapsed time.Duration, transferred uint64) uint64 {
+	var used uint64
+	if method.Duration > 0 {
+		used = uint64(elapsed / method.Duration)
+	}
+	if method.Bytes > 0 {
+		if byData := transferred / method.Bytes; byData > used {
+			used = byData
+		}
+	}
+	return used
+}
+
+func unserializePaymentPackage(rawDefinition *json.RawMessage) (PaymentMethod, error) {
+	var method PaymentPackage
+	err := json.Unmarshal(*rawDefinition, &method)
+	return method, err
+}
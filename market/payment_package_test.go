@@ -0,0 +1,95 @@
+/*
+ * Copyright (C) 2020 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package market
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/mysteriumnetwork/node/datasize"
+	"github.com/mysteriumnetwork/node/money"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPaymentPackageSerialize(t *testing.T) {
+	method := PaymentPackage{
+		Price:    money.NewMoney(3000000, money.CurrencyMyst),
+		Duration: time.Hour,
+	}
+
+	jsonBytes, err := json.Marshal(method)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"price": {"amount": 3000000, "currency": "MYST"}, "duration": 3600000000000}`, string(jsonBytes))
+
+	var unserialized PaymentPackage
+	assert.NoError(t, json.Unmarshal(jsonBytes, &unserialized))
+	assert.Equal(t, method, unserialized)
+}
+
+func TestNewPaymentPackage(t *testing.T) {
+	perGB := money.NewMoney(2000000, money.CurrencyMyst)
+	rate := mockPricedPaymentMethod{
+		price: Price{PerMinute: money.NewMoney(50000, money.CurrencyMyst), PerGB: perGB},
+	}
+	gigabyte := uint64(datasize.GB.Bytes())
+
+	assert.Equal(t, money.NewMoney(3000000, money.CurrencyMyst), NewPaymentPackage(rate, time.Hour, 0).Price)
+	assert.Equal(t, money.NewMoney(2000000, money.CurrencyMyst), NewPaymentPackage(rate, 0, gigabyte).Price)
+	assert.Equal(t, money.NewMoney(5000000, money.CurrencyMyst), NewPaymentPackage(rate, time.Hour, gigabyte).Price)
+}
+
+func TestPaymentPackage_PackagesUsed(t *testing.T) {
+	method := PaymentPackage{Duration: time.Hour, Bytes: 1000}
+
+	assert.Equal(t, uint64(0), method.PackagesUsed(59*time.Minute, 999))
+	assert.Equal(t, uint64(1), method.PackagesUsed(time.Hour, 0))
+	assert.Equal(t, uint64(2), method.PackagesUsed(time.Hour, 2500))
+	assert.Equal(t, uint64(0), PaymentPackage{Bytes: 1000}.PackagesUsed(100*time.Hour, 10))
+}
+
+func TestPaymentPackageUnserialize(t *testing.T) {
+	raw := json.RawMessage(`{"price": {"amount": 3000000, "currency": "MYST"}, "bytes": 1000}`)
+
+	method := unserializePaymentMethod(PaymentMethodPackage, &raw)
+	assert.Equal(t, PaymentPackage{Price: money.NewMoney(3000000, money.CurrencyMyst), Bytes: 1000}, method)
+}
+
+type mockPricedPaymentMethod struct {
+	price Price
+}
+
+func (method mockPricedPaymentMethod) GetPrice() money.Money {
+	return method.price.PerMinute
+}
+
+func (method mockPricedPaymentMethod) GetPricePerGB() money.Money {
+	return method.price.PerGB
+}
+
+func (method mockPricedPaymentMethod) GetType() string {
+	return "mock_priced"
+}
+
+func (method mockPricedPaymentMethod) GetRate() PaymentRate {
+	return PaymentRate{PerTime: time.Minute}
+}
+
+func (method mockPricedPaymentMethod) WithPrice(price Price) PaymentMethod {
+	return mockPricedPaymentMethod{price: price}
+}
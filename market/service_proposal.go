@@ -19,6 +19,7 @@ package market
 
 import (
 	"encoding/json"
+	"strings"
 
 	"github.com/mysteriumnetwork/node/identity"
 )
@@ -57,6 +58,12 @@ type ServiceProposal struct {
 
 	// AccessPolicies represents the access controls for proposal
 	AccessPolicies *[]AccessPolicy `json:"access_policies,omitempty"`
+
+	// AccountantIDs lists the accountants provider accepts payments through, in the order of preference
+	AccountantIDs []string `json:"accountant_ids,omitempty"`
+
+	// Trial describes the free usage offered to new consumers
+	Trial *Trial `json:"trial,omitempty"`
 }
 
 // UniqueID returns unique proposal composite ID
@@ -79,6 +86,8 @@ func (proposal *ServiceProposal) UnmarshalJSON(data []byte) error {
 		PaymentMethod     *json.RawMessage `json:"payment_method"`
 		ProviderContacts  *json.RawMessage `json:"provider_contacts"`
 		AccessPolicies    *[]AccessPolicy  `json:"access_policies,omitempty"`
+		AccountantIDs     []string         `json:"accountant_ids,omitempty"`
+		Trial             *Trial           `json:"trial,omitempty"`
 	}
 	if err := json.Unmarshal(data, &jsonData); err != nil {
 		return err
@@ -106,6 +115,8 @@ func (proposal *ServiceProposal) UnmarshalJSON(data []byte) error {
 	proposal.ProviderContacts = unserializeContacts(jsonData.ProviderContacts)
 
 	proposal.AccessPolicies = jsonData.AccessPolicies
+	proposal.AccountantIDs = jsonData.AccountantIDs
+	proposal.Trial = jsonData.Trial
 	return nil
 }
 
@@ -123,6 +134,33 @@ func (proposal *ServiceProposal) SetAccessPolicies(ap *[]AccessPolicy) {
 	proposal.AccessPolicies = ap
 }
 
+// SetAccountants updates service proposal with the accountants provider accepts
+func (proposal *ServiceProposal) SetAccountants(accountantIDs []string) {
+	proposal.AccountantIDs = accountantIDs
+}
+
+// SetTrial updates service proposal with the free trial offered to new consumers, nil or empty trial offers none
+func (proposal *ServiceProposal) SetTrial(trial *Trial) {
+	if trial.IsEmpty() {
+		trial = nil
+	}
+	proposal.Trial = trial
+}
+
+// AcceptsAccountant checks if provider accepts payments through the given accountant.
+// Proposals not listing any accountants do not restrict them.
+func (proposal *ServiceProposal) AcceptsAccountant(accountantID identity.Identity) bool {
+	if len(proposal.AccountantIDs) == 0 {
+		return true
+	}
+	for _, id := range proposal.AccountantIDs {
+		if strings.EqualFold(id, accountantID.Address) {
+			return true
+		}
+	}
+	return false
+}
+
 // IsSupported returns true if this service proposal can be used for connections by service consumer
 // can be used as a filter to filter out all proposals which are unsupported for any reason
 func (proposal *ServiceProposal) IsSupported() bool {
@@ -257,3 +257,50 @@ func Test_ServiceProposal_UnserializeAccessPolicy(t *testing.T) {
 	assert.Equal(t, expected, actual)
 	assert.True(t, actual.IsSupported())
 }
+
+func Test_ServiceProposal_UnserializeAccountants(t *testing.T) {
+	jsonData := []byte(`{
+		"service_type": "mock_service",
+		"payment_method_type": "mock_payment",
+		"provider_id": "node",
+		"accountant_ids": ["0x1", "0x2"]
+	}`)
+
+	var actual ServiceProposal
+	err := json.Unmarshal(jsonData, &actual)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"0x1", "0x2"}, actual.AccountantIDs)
+}
+
+func Test_ServiceProposal_UnserializeTrial(t *testing.T) {
+	jsonData := []byte(`{
+		"service_type": "mock_service",
+		"payment_method_type": "mock_payment",
+		"provider_id": "node",
+		"trial": {"duration": 600000000000, "bytes": 100000000}
+	}`)
+
+	var actual ServiceProposal
+	err := json.Unmarshal(jsonData, &actual)
+	assert.NoError(t, err)
+	assert.Equal(t, &Trial{Duration: 10 * time.Minute, Bytes: 100000000}, actual.Trial)
+}
+
+func Test_ServiceProposal_SetTrial(t *testing.T) {
+	proposal := ServiceProposal{}
+	proposal.SetTrial(&Trial{})
+	assert.Nil(t, proposal.Trial)
+
+	proposal.SetTrial(&Trial{Bytes: 100})
+	assert.Equal(t, &Trial{Bytes: 100}, proposal.Trial)
+}
+
+func Test_ServiceProposal_AcceptsAccountant(t *testing.T) {
+	proposal := ServiceProposal{}
+	assert.True(t, proposal.AcceptsAccountant(identity.FromAddress("0x1")))
+
+	proposal.SetAccountants([]string{"0xAB", "0x2"})
+	assert.True(t, proposal.AcceptsAccountant(identity.FromAddress("0xab")))
+	assert.True(t, proposal.AcceptsAccountant(identity.FromAddress("0x2")))
+	assert.False(t, proposal.AcceptsAccountant(identity.FromAddress("0x3")))
+}
@@ -0,0 +1,35 @@
+/*
+ * Copyright (C) 2020 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package market
+
+import "time"
+
+// Trial describes the free usage provider offers to consumers using the service for the first time.
+// Trial ends once either its duration passes or its data is transferred, whichever comes first.
+type Trial struct {
+	// Service duration free of charge, zero if trial is not limited by time
+	Duration time.Duration `json:"duration,omitempty"`
+
+	// Bytes transferred free of charge, zero if trial is not limited by data
+	Bytes uint64 `json:"bytes,omitempty"`
+}
+
+// IsEmpty checks if trial does not include any free usage.
+func (trial *Trial) IsEmpty() bool {
+	return trial == nil || (trial.Duration == 0 && trial.Bytes == 0)
+}
@@ -42,6 +42,7 @@ import (
 	"github.com/mysteriumnetwork/node/logconfig"
 	"github.com/mysteriumnetwork/node/market"
 	"github.com/mysteriumnetwork/node/metadata"
+	"github.com/mysteriumnetwork/node/money"
 	"github.com/mysteriumnetwork/node/services/openvpn"
 	"github.com/mysteriumnetwork/node/services/wireguard"
 	"github.com/rs/zerolog"
@@ -69,6 +70,7 @@ type MobileNode struct {
 	consumerBalanceTracker       *pingpong.ConsumerBalanceTracker
 	registryAddress              string
 	channelImplementationAddress string
+	moneyConverter               *money.Converter
 }
 
 // MobileNetworkOptions alias for node.OptionsNetwork to be visible from mobile framework
@@ -154,6 +156,7 @@ func NewNode(appPath string, optionsNetwork *MobileNetworkOptions) (*MobileNode,
 			MystSCAddress:                      "0x7753cfAD258eFbC52A9A1452e42fFbce9bE486cb",
 			ConsumerUpperPriceBound:            1000000,
 			ConsumerLowerPriceBound:            0,
+			DisplayCurrency:                    "USD",
 		},
 	}
 
@@ -177,6 +180,7 @@ func NewNode(appPath string, optionsNetwork *MobileNetworkOptions) (*MobileNode,
 		accountant:                   identity.FromAddress(options.Accountant.AccountantID),
 		feedbackReporter:             di.Reporter,
 		transactor:                   di.Transactor,
+		moneyConverter:               di.MoneyConverter,
 		identityRegistry:             di.IdentityRegistry,
 		consumerBalanceTracker:       di.ConsumerBalanceTracker,
 		channelImplementationAddress: options.Transactor.ChannelImplementation,
@@ -185,6 +189,7 @@ func NewNode(appPath string, optionsNetwork *MobileNetworkOptions) (*MobileNode,
 			di.ProposalRepository,
 			di.MysteriumAPI,
 			di.QualityClient,
+			di.MoneyConverter,
 			options.Payments.ConsumerLowerPriceBound,
 			options.Payments.ConsumerUpperPriceBound,
 		),
@@ -404,6 +409,10 @@ func (mb *MobileNode) GetIdentity() (*GetIdentityResponse, error) {
 // GetIdentityRegistrationFeesResponse represents identity registration fees result.
 type GetIdentityRegistrationFeesResponse struct {
 	Fee int64
+	// FeeDisplay is the human readable fee, e.g. "0.5 MYST".
+	FeeDisplay string
+	// FeeFiat is the approximate fee in the display currency, empty if the exchange rate is unknown.
+	FeeFiat string
 }
 
 // GetIdentityRegistrationFees returns identity registration fees.
@@ -412,7 +421,12 @@ func (mb *MobileNode) GetIdentityRegistrationFees() (*GetIdentityRegistrationFee
 	if err != nil {
 		return nil, errors.Wrap(err, "could not get registration fees")
 	}
-	return &GetIdentityRegistrationFeesResponse{Fee: int64(fees.Fee)}, nil
+	fee := money.NewMoney(fees.Fee, money.CurrencyMyst)
+	return &GetIdentityRegistrationFeesResponse{
+		Fee:        int64(fees.Fee),
+		FeeDisplay: fee.Format(),
+		FeeFiat:    mb.moneyConverter.FormatFiat(fee),
+	}, nil
 }
 
 // RegisterIdentityRequest represents identity registration request.
@@ -24,6 +24,7 @@ import (
 	"github.com/mysteriumnetwork/node/core/quality"
 	"github.com/mysteriumnetwork/node/market"
 	"github.com/mysteriumnetwork/node/market/mysterium"
+	"github.com/mysteriumnetwork/node/money"
 	"github.com/mysteriumnetwork/node/services/openvpn"
 	"github.com/mysteriumnetwork/node/services/wireguard"
 )
@@ -61,6 +62,14 @@ type proposalDTO struct {
 	ServiceType  string               `json:"serviceType"`
 	CountryCode  string               `json:"countryCode"`
 	QualityLevel proposalQualityLevel `json:"qualityLevel"`
+
+	// Price per minute in MYST and its approximate value in the display currency.
+	Price     string `json:"price,omitempty"`
+	PriceFiat string `json:"priceFiat,omitempty"`
+
+	// Price per gigabyte in MYST and its approximate value in the display currency.
+	PricePerGB     string `json:"pricePerGB,omitempty"`
+	PricePerGBFiat string `json:"pricePerGBFiat,omitempty"`
 }
 
 type getProposalsResponse struct {
@@ -79,16 +88,22 @@ type qualityFinder interface {
 	ProposalsMetrics() []quality.ConnectMetric
 }
 
+type moneyFormatter interface {
+	FormatFiat(value money.Money) string
+}
+
 func newProposalsManager(
 	repository proposal.Repository,
 	mysteriumAPI mysteriumAPI,
 	qualityFinder qualityFinder,
+	moneyFormatter moneyFormatter,
 	lowerPriceBound, upperPriceBound uint64,
 ) *proposalsManager {
 	return &proposalsManager{
 		repository:      repository,
 		mysteriumAPI:    mysteriumAPI,
 		qualityFinder:   qualityFinder,
+		moneyFormatter:  moneyFormatter,
 		upperPriceBound: upperPriceBound,
 		lowerPriceBound: lowerPriceBound,
 	}
@@ -99,6 +114,7 @@ type proposalsManager struct {
 	cache           []market.ServiceProposal
 	mysteriumAPI    mysteriumAPI
 	qualityFinder   qualityFinder
+	moneyFormatter  moneyFormatter
 	upperPriceBound uint64
 	lowerPriceBound uint64
 }
@@ -167,12 +183,7 @@ func (m *proposalsManager) addToCache(proposals []market.ServiceProposal) {
 func (m *proposalsManager) mapToProposalsResponse(serviceProposals []market.ServiceProposal) ([]byte, error) {
 	var proposals []*proposalDTO
 	for _, p := range serviceProposals {
-		proposals = append(proposals, &proposalDTO{
-			ID:          p.ID,
-			ProviderID:  p.ProviderID,
-			ServiceType: p.ServiceType,
-			CountryCode: m.getServiceCountryCode(&p),
-		})
+		proposals = append(proposals, m.mapToProposalDTO(&p))
 	}
 
 	m.addQualityData(proposals)
@@ -186,18 +197,37 @@ func (m *proposalsManager) mapToProposalsResponse(serviceProposals []market.Serv
 }
 
 func (m *proposalsManager) mapToProposalResponse(p *market.ServiceProposal) ([]byte, error) {
+	res := &getProposalResponse{Proposal: m.mapToProposalDTO(p)}
+	bytes, err := json.Marshal(res)
+	if err != nil {
+		return nil, err
+	}
+	return bytes, nil
+}
+
+func (m *proposalsManager) mapToProposalDTO(p *market.ServiceProposal) *proposalDTO {
 	dto := &proposalDTO{
 		ID:          p.ID,
 		ProviderID:  p.ProviderID,
 		ServiceType: p.ServiceType,
 		CountryCode: m.getServiceCountryCode(p),
 	}
-	res := &getProposalResponse{Proposal: dto}
-	bytes, err := json.Marshal(res)
-	if err != nil {
-		return nil, err
+	if method, ok := p.PaymentMethod.(market.PricedPaymentMethod); ok {
+		dto.Price = method.GetPrice().Format()
+		dto.PriceFiat = m.formatFiat(method.GetPrice())
+		if perGB := method.GetPricePerGB(); perGB.Amount > 0 {
+			dto.PricePerGB = perGB.Format()
+			dto.PricePerGBFiat = m.formatFiat(perGB)
+		}
 	}
-	return bytes, nil
+	return dto
+}
+
+func (m *proposalsManager) formatFiat(value money.Money) string {
+	if m.moneyFormatter == nil {
+		return ""
+	}
+	return m.moneyFormatter.FormatFiat(value)
 }
 
 func (m *proposalsManager) getServiceCountryCode(p *market.ServiceProposal) string {
@@ -24,6 +24,8 @@ import (
 	"github.com/mysteriumnetwork/node/core/quality"
 	"github.com/mysteriumnetwork/node/market"
 	"github.com/mysteriumnetwork/node/market/mysterium"
+	"github.com/mysteriumnetwork/node/money"
+	"github.com/mysteriumnetwork/node/services/wireguard"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
 )
@@ -47,6 +49,7 @@ func (s *proposalManagerTestSuite) SetupTest() {
 		s.repository,
 		s.mysteriumAPI,
 		s.qualityFinder,
+		nil,
 		0, 1000000,
 	)
 }
@@ -109,6 +112,23 @@ func (s *proposalManagerTestSuite) TestGetSingleProposal() {
 	assert.Equal(s.T(), "{\"proposal\":{\"id\":0,\"providerId\":\"p1\",\"serviceType\":\"wireguard\",\"countryCode\":\"\",\"qualityLevel\":0}}", string(bytes))
 }
 
+func (s *proposalManagerTestSuite) TestGetSingleProposalWithPrice() {
+	s.repository.data = []market.ServiceProposal{
+		{ProviderID: "p1", ServiceType: "wireguard", PaymentMethod: wireguard.Payment{}.WithPrice(market.Price{
+			PerMinute: money.NewMoney(50000000, money.CurrencyMyst),
+			PerGB:     money.NewMoney(400000000, money.CurrencyMyst),
+		})},
+	}
+	s.proposalsManager.moneyFormatter = money.NewConverter(money.StaticRates{"USD": 0.2}, "USD")
+	bytes, err := s.proposalsManager.getProposal(&GetProposalRequest{
+		ProviderID:  "p1",
+		ServiceType: "wireguard",
+	})
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "{\"proposal\":{\"id\":0,\"providerId\":\"p1\",\"serviceType\":\"wireguard\",\"countryCode\":\"\",\"qualityLevel\":0,\"price\":\"0.5 MYST\",\"priceFiat\":\"~0.10 USD\",\"pricePerGB\":\"4 MYST\",\"pricePerGBFiat\":\"~0.80 USD\"}}", string(bytes))
+}
+
 func TestProposalManagerSuite(t *testing.T) {
 	suite.Run(t, new(proposalManagerTestSuite))
 }
@@ -0,0 +1,50 @@
+/*
+ * Copyright (C) 2020 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package money
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MystDecimals is the number of decimal places myst amounts are expanded by.
+const MystDecimals = 8
+
+// mystUnit is the amount of one whole myst.
+const mystUnit uint64 = 100000000
+
+// Myst returns the amount in whole myst tokens.
+func (value Money) Myst() float64 {
+	return float64(value.Amount) / float64(mystUnit)
+}
+
+// Format returns the human readable amount, e.g. "0.5 MYST".
+func (value Money) Format() string {
+	currency := value.Currency
+	if currency == "" {
+		currency = CurrencyMyst
+	}
+
+	whole := value.Amount / mystUnit
+	fraction := value.Amount % mystUnit
+	if fraction == 0 {
+		return fmt.Sprintf("%d %s", whole, currency)
+	}
+	decimals := strings.TrimRight(fmt.Sprintf("%0*d", MystDecimals, fraction), "0")
+	return fmt.Sprintf("%d.%s %s", whole, decimals, currency)
+}
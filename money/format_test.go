@@ -0,0 +1,39 @@
+/*
+ * Copyright (C) 2020 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package money
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMoney_Format(t *testing.T) {
+	tests := []struct {
+		value Money
+		want  string
+	}{
+		{value: Money{}, want: "0 MYST"},
+		{value: NewMoney(100000000, CurrencyMyst), want: "1 MYST"},
+		{value: NewMoney(150000000, CurrencyMyst), want: "1.5 MYST"},
+		{value: NewMoney(12345, CurrencyMyst), want: "0.00012345 MYST"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, tt.value.Format())
+	}
+}
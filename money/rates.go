@@ -0,0 +1,123 @@
+/*
+ * Copyright (C) 2020 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package money
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// ErrUnknownRate is returned when the exchange rate of the currency is not known.
+var ErrUnknownRate = errors.New("unknown exchange rate")
+
+// RateSource provides the price of one myst in other currencies.
+type RateSource interface {
+	Rate(currency Currency) (float64, error)
+}
+
+// StaticRates is a rate source backed by a fixed set of rates.
+type StaticRates map[Currency]float64
+
+// Rate returns the price of one myst in the given currency.
+func (sr StaticRates) Rate(currency Currency) (float64, error) {
+	rate, ok := sr[Currency(strings.ToUpper(string(currency)))]
+	if !ok {
+		return 0, errors.Wrapf(ErrUnknownRate, "no rate for %v", currency)
+	}
+	return rate, nil
+}
+
+// NewStaticRatesFromFile loads the rates from a JSON file which maps currency codes
+// to the price of one myst, e.g. {"USD": 0.25, "EUR": 0.22}.
+func NewStaticRatesFromFile(path string) (StaticRates, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read exchange rates file")
+	}
+
+	var rates map[string]float64
+	if err := json.Unmarshal(data, &rates); err != nil {
+		return nil, errors.Wrap(err, "could not parse exchange rates file")
+	}
+
+	res := make(StaticRates, len(rates))
+	for currency, rate := range rates {
+		res[Currency(strings.ToUpper(currency))] = rate
+	}
+	return res, nil
+}
+
+// Converter converts myst amounts to the display currency.
+type Converter struct {
+	source   RateSource
+	currency Currency
+}
+
+// NewConverter returns a converter to the given currency using the rate source.
+func NewConverter(source RateSource, currency Currency) *Converter {
+	return &Converter{
+		source:   source,
+		currency: currency,
+	}
+}
+
+// Currency returns the display currency.
+func (c *Converter) Currency() Currency {
+	return c.currency
+}
+
+// Convert returns the value of the given amount in the display currency.
+func (c *Converter) Convert(value Money) (float64, error) {
+	if c == nil || c.source == nil {
+		return 0, ErrUnknownRate
+	}
+	rate, err := c.source.Rate(c.currency)
+	if err != nil {
+		return 0, err
+	}
+	return value.Myst() * rate, nil
+}
+
+// FormatFiat returns the approximate value in the display currency, e.g. "~0.13 USD".
+// Empty string is returned if the exchange rate is not known.
+func (c *Converter) FormatFiat(value Money) string {
+	fiat, err := c.Convert(value)
+	if err != nil {
+		return ""
+	}
+	return fmt.Sprintf("~%.*f %s", fiatPrecision(fiat), fiat, c.currency)
+}
+
+// fiatPrecision keeps two significant digits of small amounts which would be rounded to zero otherwise.
+func fiatPrecision(value float64) int {
+	precision := 2
+	if value > 0 {
+		if p := int(-math.Floor(math.Log10(value))) + 1; p > precision {
+			precision = p
+		}
+	}
+	if precision > MystDecimals {
+		precision = MystDecimals
+	}
+	return precision
+}
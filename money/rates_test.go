@@ -0,0 +1,59 @@
+/*
+ * Copyright (C) 2020 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package money
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewStaticRatesFromFile(t *testing.T) {
+	file, err := ioutil.TempFile("", "rates")
+	assert.NoError(t, err)
+	defer os.Remove(file.Name())
+	_, err = file.WriteString(`{"usd": 0.25, "EUR": 0.2}`)
+	assert.NoError(t, err)
+	assert.NoError(t, file.Close())
+
+	rates, err := NewStaticRatesFromFile(file.Name())
+	assert.NoError(t, err)
+
+	rate, err := rates.Rate("USD")
+	assert.NoError(t, err)
+	assert.Equal(t, 0.25, rate)
+
+	_, err = rates.Rate("GBP")
+	assert.Equal(t, ErrUnknownRate, errors.Cause(err))
+}
+
+func TestConverter_FormatFiat(t *testing.T) {
+	converter := NewConverter(StaticRates{"USD": 0.25}, "USD")
+
+	assert.Equal(t, "~0.38 USD", converter.FormatFiat(NewMoney(150000000, CurrencyMyst)))
+	assert.Equal(t, "~0.000025 USD", converter.FormatFiat(NewMoney(10000, CurrencyMyst)))
+	assert.Equal(t, "~0.00 USD", converter.FormatFiat(Money{}))
+	assert.Equal(t, "", NewConverter(StaticRates{}, "USD").FormatFiat(NewMoney(1, CurrencyMyst)))
+	assert.Equal(t, "", NewConverter(nil, "USD").FormatFiat(NewMoney(1, CurrencyMyst)))
+
+	var noConverter *Converter
+	assert.Equal(t, "", noConverter.FormatFiat(NewMoney(1, CurrencyMyst)))
+}
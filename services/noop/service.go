@@ -64,6 +64,11 @@ func (manager *Manager) Stop() error {
 	return nil
 }
 
+// Alive reports the service as always alive, as Noop service has no process or device which could fail
+func (manager *Manager) Alive() error {
+	return nil
+}
+
 // GetProposal returns the proposal for NOOP service for given country
 func GetProposal(location location.Location) market.ServiceProposal {
 	return market.ServiceProposal{
@@ -34,6 +34,7 @@ var (
 )
 
 var _ service.Service = NewManager()
+var _ service.LivenessProber = NewManager()
 
 func Test_GetProposal(t *testing.T) {
 	country := "LT"
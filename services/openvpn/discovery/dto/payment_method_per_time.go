@@ -33,6 +33,9 @@ type PaymentRate struct {
 
 	// Service duration provided for paid price
 	Duration time.Duration `json:"duration"`
+
+	// Price of a gigabyte of transferred data, charged in addition to the price per duration
+	PricePerGB *money.Money `json:"pricePerGB,omitempty"`
 }
 
 // GetPrice returns price of payment per time
@@ -51,3 +54,24 @@ func (method PaymentRate) GetRate() market.PaymentRate {
 		PerTime: method.Duration,
 	}
 }
+
+// GetPricePerGB returns price of a gigabyte of transferred data
+func (method PaymentRate) GetPricePerGB() money.Money {
+	if method.PricePerGB == nil {
+		return money.Money{}
+	}
+	return *method.PricePerGB
+}
+
+// WithPrice returns payment rate charging the given price per minute and per gigabyte
+func (method PaymentRate) WithPrice(price market.Price) market.PaymentMethod {
+	rate := PaymentRate{
+		Price:    price.PerMinute,
+		Duration: time.Minute,
+	}
+	if price.PerGB.Amount > 0 {
+		perGB := price.PerGB
+		rate.PricePerGB = &perGB
+	}
+	return rate
+}
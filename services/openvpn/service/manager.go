@@ -20,6 +20,7 @@ package service
 import (
 	"encoding/json"
 	"net"
+	"sync"
 
 	"github.com/mysteriumnetwork/go-openvpn/openvpn"
 	"github.com/mysteriumnetwork/go-openvpn/openvpn/tls"
@@ -92,6 +93,8 @@ type Manager struct {
 	vpnServiceConfigProvider ConfigNegotiator
 	processLauncher          *processLauncher
 	openvpnProcess           openvpn.Process
+	processExited            chan struct{}
+	processLock              sync.RWMutex
 
 	location       location.ServiceLocationInfo
 	serviceOptions Options
@@ -213,6 +216,28 @@ func (m *Manager) Stop() error {
 	return nil
 }
 
+// ReportsDataTransfer tells that the traffic of sessions is published, so that sessions can be charged by data
+func (m *Manager) ReportsDataTransfer() bool {
+	return true
+}
+
+// Alive checks if OpenVPN server process is still running
+func (m *Manager) Alive() error {
+	m.processLock.RLock()
+	processExited := m.processExited
+	m.processLock.RUnlock()
+	if processExited == nil {
+		return nil
+	}
+
+	select {
+	case <-processExited:
+		return errors.New("OpenVPN process exited")
+	default:
+		return nil
+	}
+}
+
 // ProvideConfig takes session creation config from end consumer and provides the service configuration to the end consumer
 func (m *Manager) ProvideConfig(sessionConfig json.RawMessage) (*session.ConfigParams, error) {
 	if m.vpnServiceConfigProvider == nil {
@@ -280,13 +305,22 @@ func (m *Manager) startServer(server openvpn.Process, stateChannel chan openvpn.
 	}
 
 	// Consume server states
+	processExited := make(chan struct{})
+	m.processLock.Lock()
+	m.processExited = processExited
+	m.processLock.Unlock()
 	go func() {
+		var exitOnce sync.Once
+		markExited := func() { exitOnce.Do(func() { close(processExited) }) }
+		defer markExited()
+
 		for state := range stateChannel {
 			switch state {
 			case openvpn.ProcessStarted:
 				log.Info().Msg("OpenVPN service booting up")
 			case openvpn.ProcessExited:
 				log.Info().Msg("OpenVPN service exited")
+				markExited()
 			}
 		}
 	}()
@@ -37,5 +37,6 @@ func SharedConfiguredOptions() config.ServicesOptions {
 		AccessPolicyList:          policies,
 		AccessPolicyFetchInterval: config.GetDuration(config.FlagAccessPolicyFetchInterval),
 		ShaperEnabled:             config.GetBool(config.FlagShaperEnabled),
+		ServicesFile:              config.GetString(config.FlagServicesFile),
 	}
 }
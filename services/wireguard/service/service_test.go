@@ -18,17 +18,23 @@
 package service
 
 import (
+	"bytes"
 	"net"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/mysteriumnetwork/node/core/ip"
 	"github.com/mysteriumnetwork/node/core/location"
+	"github.com/mysteriumnetwork/node/core/metrics"
+	"github.com/mysteriumnetwork/node/eventbus"
 	"github.com/mysteriumnetwork/node/identity"
 	"github.com/mysteriumnetwork/node/market"
 	"github.com/mysteriumnetwork/node/nat"
 	wg "github.com/mysteriumnetwork/node/services/wireguard"
+	sessionEvent "github.com/mysteriumnetwork/node/session/event"
 	"github.com/mysteriumnetwork/node/session/pingpong"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -81,12 +87,108 @@ func Test_Manager_ProviderConfig_FailsWhenSessionConfigIsInvalid(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func Test_Manager_Alive(t *testing.T) {
+	manager := newManagerStub(pubIP, outIP, country)
+	assert.NoError(t, manager.Alive(), "service without sessions is alive")
+
+	healthy := &mockConnectionEndpoint{}
+	healthyDestroyed := 0
+	manager.trackConnection(healthy, func() { healthyDestroyed++ })
+	broken := &mockConnectionEndpoint{}
+	brokenDestroyed := 0
+	manager.trackConnection(broken, func() {
+		brokenDestroyed++
+		manager.untrackConnection(broken)
+	})
+	assert.NoError(t, manager.Alive())
+	assert.Equal(t, 0, healthyDestroyed+brokenDestroyed)
+
+	broken.statsErr = errors.New("device not found")
+	assert.NoError(t, manager.Alive(), "broken session does not fail the service")
+	assert.Equal(t, 1, brokenDestroyed)
+	assert.Equal(t, 0, healthyDestroyed)
+
+	manager.connEndpointFactory = func() (wg.ConnectionEndpoint, error) {
+		return nil, errors.New("no wireguard")
+	}
+	_, err := manager.startNewConnection(52820)
+	assert.Error(t, err)
+	assert.EqualError(t, manager.Alive(), "could not create WireGuard connection endpoint: no wireguard")
+	assert.Equal(t, 0, healthyDestroyed)
+
+	manager.connEndpointFactory = func() (wg.ConnectionEndpoint, error) {
+		return connectionEndpointStub, nil
+	}
+	_, err = manager.startNewConnection(52820)
+	assert.NoError(t, err)
+	assert.NoError(t, manager.Alive())
+}
+
+func Test_Manager_AliveDestroysSessionWithoutInterface(t *testing.T) {
+	manager := newManagerStub(pubIP, outIP, country)
+	conn := &mockConnectionEndpoint{}
+	destroyed := make(chan struct{}, 1)
+	manager.trackConnection(conn, func() { destroyed <- struct{}{} })
+
+	manager.interfaceByName = func(name string) (*net.Interface, error) {
+		return nil, errors.New("no such network interface")
+	}
+	assert.NoError(t, manager.Alive())
+	select {
+	case <-destroyed:
+	default:
+		assert.Fail(t, "session without interface was not destroyed")
+	}
+}
+
+func Test_Manager_PublishesSessionStats(t *testing.T) {
+	publisher := &mockPublisher{published: make(chan interface{}, 1)}
+	manager := newManagerStub(pubIP, outIP, country)
+	manager.publisher = publisher
+	manager.statsInterval = time.Millisecond
+
+	done := make(chan struct{})
+	defer close(done)
+	go manager.publishStats("session-id", &mockConnectionEndpoint{stats: wg.Stats{BytesSent: 10, BytesReceived: 20}}, done)
+
+	select {
+	case payload := <-publisher.published:
+		assert.Equal(t, sessionEvent.DataTransferEventPayload{ID: "session-id", Up: 10, Down: 20}, payload)
+	case <-time.After(time.Second):
+		assert.Fail(t, "session stats were not published")
+	}
+}
+
+func Test_Manager_SessionStatsReachServiceBytesMetric(t *testing.T) {
+	bus := eventbus.New()
+	collector := metrics.NewCollector()
+	assert.NoError(t, collector.Subscribe(bus))
+
+	manager := newManagerStub(pubIP, outIP, country)
+	manager.publisher = bus
+	manager.statsInterval = time.Millisecond
+
+	done := make(chan struct{})
+	defer close(done)
+	go manager.publishStats("session-id", &mockConnectionEndpoint{stats: wg.Stats{BytesSent: 10, BytesReceived: 20}}, done)
+
+	assert.Eventually(t, func() bool {
+		var out bytes.Buffer
+		assert.NoError(t, collector.Write(&out))
+		return strings.Contains(out.String(), `myst_service_bytes_total{direction="up"} 10`+"\n") &&
+			strings.Contains(out.String(), `myst_service_bytes_total{direction="down"} 20`+"\n")
+	}, time.Second, 10*time.Millisecond)
+}
+
 // usually time.Sleep call gives a chance for other goroutines to kick in important when testing async code
 func waitABit() {
 	time.Sleep(10 * time.Millisecond)
 }
 
-type mockConnectionEndpoint struct{}
+type mockConnectionEndpoint struct {
+	stats    wg.Stats
+	statsErr error
+}
 
 func (mce *mockConnectionEndpoint) StartConsumerMode(config wg.ConsumerModeConfig) error { return nil }
 func (mce *mockConnectionEndpoint) StartProviderMode(config wg.ProviderModeConfig) error { return nil }
@@ -97,7 +199,22 @@ func (mce *mockConnectionEndpoint) AddPeer(_ string, _ wg.Peer) error
 func (mce *mockConnectionEndpoint) RemovePeer(_ string) error                            { return nil }
 func (mce *mockConnectionEndpoint) ConfigureRoutes(_ net.IP) error                       { return nil }
 func (mce *mockConnectionEndpoint) PeerStats() (*wg.Stats, error) {
-	return &wg.Stats{LastHandshake: time.Now()}, nil
+	stats := mce.stats
+	stats.LastHandshake = time.Now()
+	return &stats, mce.statsErr
+}
+
+type mockPublisher struct {
+	published chan interface{}
+}
+
+func (mp *mockPublisher) Publish(topic string, data interface{}) {
+	if topic == sessionEvent.AppTopicDataTransfered {
+		select {
+		case mp.published <- data:
+		default:
+		}
+	}
 }
 
 func newManagerStub(pub, out, country string) *Manager {
@@ -108,6 +225,10 @@ func newManagerStub(pub, out, country string) *Manager {
 		connEndpointFactory: func() (wg.ConnectionEndpoint, error) {
 			return connectionEndpointStub, nil
 		},
+		connections: make(map[wg.ConnectionEndpoint]func()),
+		interfaceByName: func(name string) (*net.Interface, error) {
+			return &net.Interface{Name: name}, nil
+		},
 	}
 }
 
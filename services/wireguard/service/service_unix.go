@@ -1,4 +1,5 @@
-//+build !windows
+//go:build !windows
+// +build !windows
 
 /*
  * Copyright (C) 2019 The "MysteriumNetwork/node" Authors.
@@ -24,6 +25,7 @@ import (
 	"fmt"
 	"net"
 	"sync"
+	"time"
 
 	"github.com/mysteriumnetwork/node/core/ip"
 	"github.com/mysteriumnetwork/node/core/location"
@@ -39,11 +41,15 @@ import (
 	"github.com/mysteriumnetwork/node/services/wireguard/endpoint"
 	"github.com/mysteriumnetwork/node/services/wireguard/resources"
 	"github.com/mysteriumnetwork/node/session"
+	sessionEvent "github.com/mysteriumnetwork/node/session/event"
 	"github.com/mysteriumnetwork/node/utils/netutil"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog/log"
 )
 
+// statsReportingInterval is how often traffic of the session is reported, the same as in OpenVPN service
+const statsReportingInterval = 30 * time.Second
+
 // NATPinger defined Pinger interface for Provider
 type NATPinger interface {
 	BindServicePort(key string, port int)
@@ -83,7 +89,10 @@ func NewManager(
 		connEndpointFactory: func() (wg.ConnectionEndpoint, error) {
 			return endpoint.NewConnectionEndpoint(&location, resourcesAllocator, options.ConnectDelay)
 		},
-		location: location,
+		connections:     make(map[wg.ConnectionEndpoint]func()),
+		interfaceByName: net.InterfaceByName,
+		statsInterval:   statsReportingInterval,
+		location:        location,
 	}
 }
 
@@ -106,6 +115,11 @@ type Manager struct {
 	dnsProxyMu sync.Mutex
 
 	connEndpointFactory func() (wg.ConnectionEndpoint, error)
+	connEndpointErr     error
+	connections         map[wg.ConnectionEndpoint]func()
+	connectionsMu       sync.Mutex
+	interfaceByName     func(name string) (*net.Interface, error)
+	statsInterval       time.Duration
 
 	ipResolver ip.Resolver
 	location   location.ServiceLocationInfo
@@ -175,22 +189,101 @@ func (m *Manager) ProvideConfig(sessionConfig json.RawMessage) (*session.ConfigP
 		return nil, errors.Wrap(err, "failed to setup NAT/firewall rules")
 	}
 
+	statsDone := make(chan struct{})
+	start := func(sessionID session.ID) {
+		go m.publishStats(sessionID, conn, statsDone)
+	}
+
+	// destroy is called by the session manager, or by the liveness probe once the connection breaks
+	var destroyOnce sync.Once
 	destroy := func() {
-		if releasePortMapping != nil {
-			log.Trace().Msg("Deleting port mapping")
-			releasePortMapping()
+		destroyOnce.Do(func() {
+			close(statsDone)
+			if releasePortMapping != nil {
+				log.Trace().Msg("Deleting port mapping")
+				releasePortMapping()
+			}
+			log.Trace().Msg("Deleting nat rules")
+			if err := m.natService.Del(natRules); err != nil {
+				log.Error().Err(err).Msg("Failed to delete NAT rules")
+			}
+			m.untrackConnection(conn)
+			log.Trace().Msg("Stopping connection endpoint")
+			if err := conn.Stop(); err != nil {
+				log.Error().Err(err).Msg("Failed to stop connection endpoint")
+			}
+		})
+	}
+	m.trackConnection(conn, destroy)
+
+	return &session.ConfigParams{SessionServiceConfig: config, SessionStartCallback: start, SessionDestroyCallback: destroy, TraversalParams: &traversalParams}, nil
+}
+
+// publishStats periodically reports the traffic of session peer until the session is destroyed
+func (m *Manager) publishStats(sessionID session.ID, conn wg.ConnectionEndpoint, done <-chan struct{}) {
+	ticker := time.NewTicker(m.statsInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			stats, err := conn.PeerStats()
+			if err != nil {
+				log.Warn().Err(err).Msgf("Could not get traffic stats of session %s", sessionID)
+				continue
+			}
+			m.publisher.Publish(sessionEvent.AppTopicDataTransfered, sessionEvent.DataTransferEventPayload{
+				ID:   string(sessionID),
+				Up:   stats.BytesSent,
+				Down: stats.BytesReceived,
+			})
 		}
-		log.Trace().Msg("Deleting nat rules")
-		if err := m.natService.Del(natRules); err != nil {
-			log.Error().Err(err).Msg("Failed to delete NAT rules")
+	}
+}
+
+func (m *Manager) trackConnection(conn wg.ConnectionEndpoint, destroy func()) {
+	m.connectionsMu.Lock()
+	defer m.connectionsMu.Unlock()
+	m.connections[conn] = destroy
+}
+
+func (m *Manager) untrackConnection(conn wg.ConnectionEndpoint) {
+	m.connectionsMu.Lock()
+	defer m.connectionsMu.Unlock()
+	delete(m.connections, conn)
+}
+
+// ReportsDataTransfer tells that the traffic of sessions is published, so that sessions can be charged by data
+func (m *Manager) ReportsDataTransfer() bool {
+	return true
+}
+
+// Alive reports whether new WireGuard connection endpoints can be created.
+// Sessions whose interface or device is gone are destroyed one by one, without failing the whole service.
+func (m *Manager) Alive() error {
+	m.connectionsMu.Lock()
+	var broken []func()
+	for conn, destroy := range m.connections {
+		iface := conn.InterfaceName()
+		if _, err := m.interfaceByName(iface); err != nil {
+			log.Warn().Err(err).Msgf("WireGuard interface %s not found, destroying its session", iface)
+			broken = append(broken, destroy)
+			continue
 		}
-		log.Trace().Msg("Stopping connection endpoint")
-		if err := conn.Stop(); err != nil {
-			log.Error().Err(err).Msg("Failed to stop connection endpoint")
+		if _, err := conn.PeerStats(); err != nil {
+			log.Warn().Err(err).Msgf("WireGuard device %s is not available, destroying its session", iface)
+			broken = append(broken, destroy)
 		}
 	}
+	err := m.connEndpointErr
+	m.connectionsMu.Unlock()
 
-	return &session.ConfigParams{SessionServiceConfig: config, SessionDestroyCallback: destroy, TraversalParams: &traversalParams}, nil
+	for _, destroy := range broken {
+		destroy()
+	}
+	return err
 }
 
 func (m *Manager) tryAddPortMapping(port int) (release func(), ok bool) {
@@ -208,6 +301,11 @@ func (m *Manager) tryAddPortMapping(port int) (release func(), ok bool) {
 
 func (m *Manager) startNewConnection(port int) (wg.ConnectionEndpoint, error) {
 	connEndpoint, err := m.connEndpointFactory()
+
+	m.connectionsMu.Lock()
+	m.connEndpointErr = errors.Wrap(err, "could not create WireGuard connection endpoint")
+	m.connectionsMu.Unlock()
+
 	if err != nil {
 		return nil, errors.Wrap(err, "could not run conn endpoint factory")
 	}
@@ -54,6 +54,9 @@ const PaymentMethod = "WG"
 // Payment structure describes price for Wireguard service payment
 type Payment struct {
 	Price money.Money `json:"price"`
+
+	// Price of a gigabyte of transferred data, charged in addition to the price per minute
+	PricePerGB *money.Money `json:"pricePerGB,omitempty"`
 }
 
 // GetPrice returns price of payment per time
@@ -73,6 +76,24 @@ func (method Payment) GetRate() market.PaymentRate {
 	}
 }
 
+// GetPricePerGB returns price of a gigabyte of transferred data
+func (method Payment) GetPricePerGB() money.Money {
+	if method.PricePerGB == nil {
+		return money.Money{}
+	}
+	return *method.PricePerGB
+}
+
+// WithPrice returns payment charging the given price per minute and per gigabyte
+func (method Payment) WithPrice(price market.Price) market.PaymentMethod {
+	payment := Payment{Price: price.PerMinute}
+	if price.PerGB.Amount > 0 {
+		perGB := price.PerGB
+		payment.PricePerGB = &perGB
+	}
+	return payment
+}
+
 // EndpointFactory creates new connection endpoint.
 type EndpointFactory func() (ConnectionEndpoint, error)
 
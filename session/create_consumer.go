@@ -83,6 +83,9 @@ func (consumer *createConsumer) Consume(requestPtr interface{}) (response interf
 	sessionInstance, err := consumer.sessionCreator.Create(consumer.peerID, *request.ConsumerInfo, request.ProposalID, sessionConfigParams.SessionServiceConfig, sessionConfigParams.TraversalParams)
 	switch err {
 	case nil:
+		if sessionConfigParams.SessionStartCallback != nil {
+			sessionConfigParams.SessionStartCallback(sessionInstance.ID)
+		}
 		if sessionConfigParams.SessionDestroyCallback != nil {
 			go func() {
 				<-sessionInstance.done
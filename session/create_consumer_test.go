@@ -40,10 +40,11 @@ func TestConsumer_Success(t *testing.T) {
 			ConsumerID: identity.FromAddress("123"),
 		},
 	}
+	var startedID ID
 	consumer := createConsumer{
 		sessionCreator:         mockManager,
 		peerID:                 identity.FromAddress("peer-id"),
-		providerConfigProvider: mockConfigProvider{},
+		providerConfigProvider: mockConfigProvider{onStart: func(id ID) { startedID = id }},
 		promiseLoader:          mpl,
 	}
 
@@ -52,6 +53,7 @@ func TestConsumer_Success(t *testing.T) {
 	sessionResponse, err := consumer.Consume(request)
 
 	assert.NoError(t, err)
+	assert.Equal(t, ID("new-id"), startedID)
 	assert.Exactly(t, mockManager.lastConsumerID, identity.FromAddress("peer-id"))
 	assert.Exactly(t, mockManager.lastProposalID, 101)
 	assert.Exactly(
@@ -128,10 +130,11 @@ func TestConsumer_UsesIssuerID(t *testing.T) {
 }
 
 type mockConfigProvider struct {
+	onStart StartCallback
 }
 
-func (mockConfigProvider) ProvideConfig(sessionConfig json.RawMessage) (*ConfigParams, error) {
-	return &ConfigParams{SessionServiceConfig: config, TraversalParams: &traversal.Params{}}, nil
+func (m mockConfigProvider) ProvideConfig(sessionConfig json.RawMessage) (*ConfigParams, error) {
+	return &ConfigParams{SessionServiceConfig: config, SessionStartCallback: m.onStart, TraversalParams: &traversal.Params{}}, nil
 }
 
 // managerFake represents fake Manager usually useful in tests
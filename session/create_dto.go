@@ -22,6 +22,7 @@ import (
 
 	"github.com/mysteriumnetwork/node/communication"
 	"github.com/mysteriumnetwork/node/identity"
+	"github.com/mysteriumnetwork/node/market"
 	"github.com/mysteriumnetwork/node/session/promise"
 )
 
@@ -65,4 +66,7 @@ type ConsumerInfo struct {
 	IssuerID       identity.Identity `json:"issuerID"`
 	AccountantID   identity.Identity `json:"accountantID"`
 	PaymentVersion PaymentVersion    `json:"paymentVersion"`
+	Country        string            `json:"country,omitempty"`
+	// Price is the price of the proposal consumer agreed to, provider rejects the session if it charges a different price.
+	Price *market.Price `json:"price,omitempty"`
 }
@@ -39,14 +39,15 @@ type DataTransfered struct {
 
 // Session structure holds all required information about current session between service consumer and provider
 type Session struct {
-	ID             ID
-	ConsumerID     identity.Identity
-	Config         ServiceConfiguration
-	ServiceID      string
-	CreatedAt      time.Time
-	DataTransfered DataTransfered
-	Last           bool
-	done           chan struct{}
+	ID              ID
+	ConsumerID      identity.Identity
+	ConsumerCountry string
+	Config          ServiceConfiguration
+	ServiceID       string
+	CreatedAt       time.Time
+	DataTransfered  DataTransfered
+	Last            bool
+	done            chan struct{}
 }
 
 // ServiceConfiguration defines service configuration from underlying transport mechanism to be passed to remote party
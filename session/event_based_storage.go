@@ -30,6 +30,7 @@ type storage interface {
 	UpdateDataTransfer(id ID, up, down uint64)
 	Find(id ID) (Session, bool)
 	Remove(id ID)
+	Terminate(id ID) bool
 	RemoveForService(serviceID string)
 }
 
@@ -91,6 +92,18 @@ func (ebs *EventBasedStorage) Remove(id ID) {
 	})
 }
 
+// Terminate terminates the session and publishes a removal event
+func (ebs *EventBasedStorage) Terminate(id ID) bool {
+	if !ebs.storage.Terminate(id) {
+		return false
+	}
+	go ebs.bus.Publish(event.AppTopicSession, event.Payload{
+		ID:     string(id),
+		Action: event.Removed,
+	})
+	return true
+}
+
 // RemoveForService removes all the sessions for a service and publishes a delete event
 func (ebs *EventBasedStorage) RemoveForService(serviceID string) {
 	ebs.storage.RemoveForService(serviceID)
@@ -38,6 +38,8 @@ var (
 	ErrorSessionNotExists = errors.New("session does not exists")
 	// ErrorWrongSessionOwner returned when consumer tries to destroy session that does not belongs to him
 	ErrorWrongSessionOwner = errors.New("wrong session owner")
+	// ErrorAccountantNotAccepted returned when consumer requests a session paid through an accountant the provider does not accept
+	ErrorAccountantNotAccepted = errors.New("accountant not accepted")
 )
 
 // IDGenerator defines method for session id generation
@@ -46,6 +48,7 @@ type IDGenerator func() (ID, error)
 // ConfigParams session configuration parameters
 type ConfigParams struct {
 	SessionServiceConfig   ServiceConfiguration
+	SessionStartCallback   StartCallback
 	SessionDestroyCallback DestroyCallback
 	TraversalParams        *traversal.Params
 }
@@ -59,6 +62,9 @@ type ConfigProvider interface {
 	ProvideConfig(sessionConfig json.RawMessage) (*ConfigParams, error)
 }
 
+// StartCallback is notified about the ID of created session
+type StartCallback func(sessionID ID)
+
 // DestroyCallback cleanups session
 type DestroyCallback func()
 
@@ -74,14 +80,14 @@ type PromiseProcessor interface {
 type Storage interface {
 	Add(sessionInstance Session)
 	Find(id ID) (Session, bool)
-	Remove(id ID)
+	Terminate(id ID) bool
 }
 
 // BalanceTrackerFactory returns a new instance of balance tracker
 type BalanceTrackerFactory func(consumer, provider, issuer identity.Identity) (PaymentEngine, error)
 
-// PaymentEngineFactory creates a new instance of payment engine
-type PaymentEngineFactory func(providerID, accountantID identity.Identity) (PaymentEngine, error)
+// PaymentEngineFactory creates a new instance of payment engine charging the price consumer agreed to, nil if unknown
+type PaymentEngineFactory func(providerID, accountantID identity.Identity, sessionID ID, agreedPrice *market.Price) (PaymentEngine, error)
 
 // NATEventGetter lets us access the last known traversal event
 type NATEventGetter interface {
@@ -147,6 +153,7 @@ func (manager *Manager) Create(consumerID identity.Identity, consumerInfo Consum
 	}
 	sessionInstance.ServiceID = manager.serviceId
 	sessionInstance.ConsumerID = consumerID
+	sessionInstance.ConsumerCountry = consumerInfo.Country
 	sessionInstance.done = make(chan struct{})
 	sessionInstance.Config = config
 	sessionInstance.CreatedAt = time.Now().UTC()
@@ -154,8 +161,12 @@ func (manager *Manager) Create(consumerID identity.Identity, consumerInfo Consum
 	// TODO: this whole block needs to go when we deprecate the old payment pingpong
 	var paymentEngine PaymentEngine
 	if consumerInfo.PaymentVersion == PaymentVersionV3 && !manager.paymentsDisabled {
+		if !manager.currentProposal.AcceptsAccountant(consumerInfo.AccountantID) {
+			return Session{}, ErrorAccountantNotAccepted
+		}
+
 		log.Info().Msg("Using new payments")
-		engine, err := manager.paymentEngineFactory(identity.FromAddress(manager.currentProposal.ProviderID), consumerInfo.AccountantID)
+		engine, err := manager.paymentEngineFactory(identity.FromAddress(manager.currentProposal.ProviderID), consumerInfo.AccountantID, sessionInstance.ID, consumerInfo.Price)
 		if err != nil {
 			return sessionInstance, err
 		}
@@ -229,8 +240,9 @@ func (manager *Manager) Destroy(consumerID identity.Identity, sessionID string)
 		return ErrorWrongSessionOwner
 	}
 
-	manager.sessionStorage.Remove(ID(sessionID))
-	close(sessionInstance.done)
+	if !manager.sessionStorage.Terminate(ID(sessionID)) {
+		return ErrorSessionNotExists
+	}
 
 	return nil
 }
@@ -85,7 +85,7 @@ func mockBalanceTrackerFactory(consumer, provider, issuer identity.Identity) (Pa
 	return &mockBalanceTracker{}, nil
 }
 
-func mockPaymentEngineFactory(providerID, accountant identity.Identity) (PaymentEngine, error) {
+func mockPaymentEngineFactory(providerID, accountant identity.Identity, sessionID ID, agreedPrice *market.Price) (PaymentEngine, error) {
 	return &mockBalanceTracker{}, nil
 }
 
@@ -125,6 +125,25 @@ func TestManager_Create_RejectsUnknownProposal(t *testing.T) {
 	assert.Exactly(t, Session{}, sessionInstance)
 }
 
+func TestManager_Create_RejectsNotAcceptedAccountant(t *testing.T) {
+	sessionStore := NewStorageMemory()
+	natPinger := func(*traversal.Params) {}
+
+	proposal := currentProposal
+	proposal.SetAccountants([]string{"0x1"})
+	manager := NewManager(proposal, generateSessionID, sessionStore, mockBalanceTrackerFactory, mockPaymentEngineFactory, natPinger,
+		&MockNatEventTracker{}, "test service id", &mockPublisher{}, false)
+
+	consumerInfo := ConsumerInfo{IssuerID: consumerID, AccountantID: identity.FromAddress("0x2"), PaymentVersion: PaymentVersionV3}
+	sessionInstance, err := manager.Create(consumerID, consumerInfo, currentProposalID, nil, &traversal.Params{})
+	assert.Exactly(t, ErrorAccountantNotAccepted, err)
+	assert.Exactly(t, Session{}, sessionInstance)
+
+	consumerInfo.AccountantID = identity.FromAddress("0x1")
+	_, err = manager.Create(consumerID, consumerInfo, currentProposalID, nil, &traversal.Params{})
+	assert.NoError(t, err)
+}
+
 type MockNatEventTracker struct {
 }
 
@@ -25,6 +25,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/mysteriumnetwork/node/core/ledger"
 	nodevent "github.com/mysteriumnetwork/node/core/node/event"
 	"github.com/mysteriumnetwork/node/core/service"
 	"github.com/mysteriumnetwork/node/eventbus"
@@ -60,6 +61,14 @@ type AccountantPromiseSettler struct {
 	ks                         ks
 	transactor                 transactor
 	promiseStorage             promiseStorage
+	ledger                     transactionLedger
+	publisher                  eventbus.Publisher
+	strategy                   SettlementStrategy
+	clock                      func() time.Time
+
+	// fee is fetched from the transactor outside of the state lock, as it takes a network round trip.
+	feeLock sync.Mutex
+	fee     settleFee
 
 	currentState map[identity.Identity]state
 	settleQueue  chan receivedPromise
@@ -72,10 +81,18 @@ type AccountantPromiseSettlerConfig struct {
 	AccountantAddress    common.Address
 	Threshold            float64
 	MaxWaitForSettlement time.Duration
+	// Strategy decides when to settle, defaults to ThresholdStrategy with the configured Threshold.
+	Strategy SettlementStrategy
+	// CheckInterval is how often the strategy is consulted without new promises arriving, zero disables the periodic check.
+	CheckInterval time.Duration
 }
 
 // NewAccountantPromiseSettler creates a new instance of accountant promise settler.
-func NewAccountantPromiseSettler(transactor transactor, promiseStorage promiseStorage, providerChannelStatusProvider providerChannelStatusProvider, registrationStatusProvider registrationStatusProvider, ks ks, accountantPromiseGetter accountantPromiseGetter, config AccountantPromiseSettlerConfig) *AccountantPromiseSettler {
+func NewAccountantPromiseSettler(transactor transactor, promiseStorage promiseStorage, providerChannelStatusProvider providerChannelStatusProvider, registrationStatusProvider registrationStatusProvider, ks ks, accountantPromiseGetter accountantPromiseGetter, ledger transactionLedger, publisher eventbus.Publisher, config AccountantPromiseSettlerConfig) *AccountantPromiseSettler {
+	strategy := config.Strategy
+	if strategy == nil {
+		strategy = ThresholdStrategy{Threshold: config.Threshold}
+	}
 	return &AccountantPromiseSettler{
 		bc:                         providerChannelStatusProvider,
 		accountantPromiseGetter:    accountantPromiseGetter,
@@ -84,6 +101,10 @@ func NewAccountantPromiseSettler(transactor transactor, promiseStorage promiseSt
 		config:                     config,
 		currentState:               make(map[identity.Identity]state),
 		promiseStorage:             promiseStorage,
+		ledger:                     ledger,
+		publisher:                  publisher,
+		strategy:                   strategy,
+		clock:                      time.Now,
 
 		// defaulting to a queue of 5, in case we have a few active identities.
 		settleQueue: make(chan receivedPromise, 5),
@@ -131,8 +152,10 @@ func (aps *AccountantPromiseSettler) resyncState(addr identity.Identity) error {
 	s := state{
 		balance:          currentBalance,
 		availableBalance: availableBalance,
+		settled:          res.Settled.Uint64(),
 		lastPromise:      accountantPromise.Promise,
 		registered:       true,
+		lastSettlement:   aps.clock(),
 	}
 
 	aps.currentState[addr] = s
@@ -216,6 +239,11 @@ func (aps *AccountantPromiseSettler) handleRegistrationEvent(payload registry.Re
 }
 
 func (aps *AccountantPromiseSettler) handleAccountantPromiseReceived(apep AccountantPromiseEventPayload) {
+	if apep.AccountantID.ToCommonAddress() != aps.config.AccountantAddress {
+		log.Debug().Msgf("Ignoring promise of accountant %q", apep.AccountantID)
+		return
+	}
+
 	aps.lock.Lock()
 	defer aps.lock.Unlock()
 
@@ -236,7 +264,7 @@ func (aps *AccountantPromiseSettler) handleAccountantPromiseReceived(apep Accoun
 	aps.currentState[apep.ProviderID] = newState
 	log.Info().Msgf("Accountant promise state updated for provider %q", apep.ProviderID)
 
-	if newState.needsSettling(aps.config.Threshold) {
+	if newState.needsSettling(aps.strategy, apep.ProviderID, aps.settleFee()) {
 		aps.settleQueue <- receivedPromise{
 			provider: apep.ProviderID,
 			promise:  apep.Promise,
@@ -250,16 +278,92 @@ func (aps *AccountantPromiseSettler) listenForSettlementRequests() {
 		log.Info().Msg("Stopped listening for settlement events")
 	}()
 
+	var check <-chan time.Time
+	if aps.config.CheckInterval > 0 {
+		ticker := time.NewTicker(aps.config.CheckInterval)
+		defer ticker.Stop()
+		check = ticker.C
+	}
+
 	for {
 		select {
 		case <-aps.stop:
 			return
 		case p := <-aps.settleQueue:
 			go aps.settle(p)
+		case <-check:
+			go func() {
+				aps.refreshSettleFee()
+				aps.settleDue()
+			}()
 		}
 	}
 }
 
+// settleDue settles the promises of providers the settlement strategy considers due, even if no new promises arrive.
+func (aps *AccountantPromiseSettler) settleDue() {
+	aps.lock.Lock()
+	states := make(map[identity.Identity]state, len(aps.currentState))
+	for provider, s := range aps.currentState {
+		states[provider] = s
+	}
+	aps.lock.Unlock()
+
+	fee := aps.settleFee()
+	accountantID := identity.FromAddress(aps.config.AccountantAddress.Hex())
+	for provider, s := range states {
+		if !s.needsSettling(aps.strategy, provider, fee) {
+			continue
+		}
+		go func(provider identity.Identity) {
+			err := aps.ForceSettle(provider, accountantID)
+			if err != nil && err != ErrNothingToSettle {
+				log.Error().Err(err).Msgf("Scheduled settlement failed for provider %v", provider)
+			}
+		}(provider)
+	}
+}
+
+// refreshSettleFee fetches the current settlement fee from the transactor and caches it for the settlement strategy.
+func (aps *AccountantPromiseSettler) refreshSettleFee() {
+	fees, err := aps.transactor.FetchSettleFees()
+	if err != nil {
+		log.Warn().Err(err).Msg("Could not fetch settlement fees, keeping the last known ones")
+		return
+	}
+
+	aps.feeLock.Lock()
+	defer aps.feeLock.Unlock()
+	aps.fee = settleFee{amount: fees.Fee, known: true}
+}
+
+func (aps *AccountantPromiseSettler) settleFee() settleFee {
+	aps.feeLock.Lock()
+	defer aps.feeLock.Unlock()
+	return aps.fee
+}
+
+// SettlementBalance returns the amount already settled on the blockchain for the provider
+// and the amount promised by the accountant which is not settled yet.
+func (aps *AccountantPromiseSettler) SettlementBalance(providerID identity.Identity) (settled, unsettled uint64, err error) {
+	s, ok := aps.getState(providerID)
+	if !ok {
+		if err := aps.loadInitialState(providerID); err != nil {
+			return 0, 0, err
+		}
+		s, _ = aps.getState(providerID)
+	}
+	return s.settled, amountSince(s.settled, s.lastPromise.Amount), nil
+}
+
+func (aps *AccountantPromiseSettler) getState(id identity.Identity) (state, bool) {
+	aps.lock.Lock()
+	defer aps.lock.Unlock()
+
+	s, ok := aps.currentState[id]
+	return s, ok
+}
+
 // ErrNothingToSettle indicates that there is nothing to settle.
 var ErrNothingToSettle = errors.New("nothing to settle for the given provider")
 
@@ -288,10 +392,15 @@ func (aps *AccountantPromiseSettler) ForceSettle(providerID, accountantID identi
 // ErrSettleTimeout indicates that the settlement has timed out
 var ErrSettleTimeout = errors.New("settle timeout")
 
-func (aps *AccountantPromiseSettler) settle(p receivedPromise) error {
+func (aps *AccountantPromiseSettler) settle(p receivedPromise) (err error) {
 	if aps.isSettling(p.provider) {
 		return errors.New("provider already has settlement in progress")
 	}
+	defer func() {
+		if err != nil {
+			aps.publishSettlement(p, 0, false)
+		}
+	}()
 
 	aps.setSettling(p.provider, true)
 	log.Info().Msgf("Marked provider %v as requesting setlement", p.provider)
@@ -310,12 +419,13 @@ func (aps *AccountantPromiseSettler) settle(p receivedPromise) error {
 		select {
 		case <-aps.stop:
 			return
-		case _, more := <-sink:
+		case event, more := <-sink:
 			if !more {
 				break
 			}
 
 			log.Info().Msgf("Settling complete for provider %v", p.provider)
+			aps.recordSettlement(p, event)
 
 			err := aps.resyncState(p.provider)
 			if err != nil {
@@ -345,6 +455,40 @@ func (aps *AccountantPromiseSettler) settle(p receivedPromise) error {
 	return <-errCh
 }
 
+func (aps *AccountantPromiseSettler) recordSettlement(p receivedPromise, event *bindings.AccountantImplementationPromiseSettled) {
+	accountantID := identity.FromAddress(aps.config.AccountantAddress.Hex())
+	entry := ledger.Entry{
+		Kind:         ledger.KindSettlement,
+		Direction:    ledger.DirectionIncoming,
+		Identity:     p.provider,
+		Counterparty: accountantID,
+		AccountantID: accountantID,
+		Total:        p.promise.Amount,
+		Fee:          p.promise.Fee,
+		Hashlock:     hex.EncodeToString(p.promise.Hashlock),
+	}
+	if event != nil {
+		if event.Amount != nil {
+			entry.Amount = event.Amount.Uint64()
+		}
+		entry.TxHash = event.Raw.TxHash.Hex()
+	}
+	recordTransaction(aps.ledger, entry)
+	aps.publishSettlement(p, entry.Amount, true)
+}
+
+func (aps *AccountantPromiseSettler) publishSettlement(p receivedPromise, amount uint64, successful bool) {
+	if aps.publisher == nil {
+		return
+	}
+	aps.publisher.Publish(AppTopicSettlement, SettlementEventPayload{
+		ProviderID:   p.provider,
+		AccountantID: identity.FromAddress(aps.config.AccountantAddress.Hex()),
+		Amount:       amount,
+		Successful:   successful,
+	})
+}
+
 func (aps *AccountantPromiseSettler) isSettling(id identity.Identity) bool {
 	aps.lock.Lock()
 	defer aps.lock.Unlock()
@@ -366,6 +510,7 @@ func (aps *AccountantPromiseSettler) setSettling(id identity.Identity, settling
 
 func (aps *AccountantPromiseSettler) handleNodeStart() {
 	go aps.listenForSettlementRequests()
+	go aps.refreshSettleFee()
 
 	for _, v := range aps.ks.Accounts() {
 		addr := identity.FromAddress(v.Address.Hex())
@@ -402,11 +547,18 @@ type state struct {
 	settleInProgress bool
 	balance          uint64
 	availableBalance uint64
+	settled          uint64
 	registered       bool
 	lastPromise      crypto.Promise
+	lastSettlement   time.Time
 }
 
-func (s state) needsSettling(threshold float64) bool {
+type settleFee struct {
+	amount uint64
+	known  bool
+}
+
+func (s state) needsSettling(strategy SettlementStrategy, provider identity.Identity, fee settleFee) bool {
 	if !s.registered {
 		return false
 	}
@@ -415,15 +567,15 @@ func (s state) needsSettling(threshold float64) bool {
 		return false
 	}
 
-	if float64(s.balance) <= 0 {
-		return true
-	}
-
-	if float64(s.balance) <= threshold*float64(s.availableBalance) {
-		return true
-	}
-
-	return false
+	return strategy.ShouldSettle(SettlementState{
+		Provider:         provider,
+		Balance:          s.balance,
+		AvailableBalance: s.availableBalance,
+		Unsettled:        amountSince(s.settled, s.lastPromise.Amount),
+		LastSettlement:   s.lastSettlement,
+		Fee:              fee.amount,
+		FeeKnown:         fee.known,
+	})
 }
 
 func (s state) updateWithNewPromise(promise crypto.Promise) state {
@@ -432,8 +584,10 @@ func (s state) updateWithNewPromise(promise crypto.Promise) state {
 		settleInProgress: s.settleInProgress,
 		balance:          s.balance - diff,
 		availableBalance: s.availableBalance,
+		settled:          s.settled,
 		registered:       s.registered,
 		lastPromise:      promise,
+		lastSettlement:   s.lastSettlement,
 	}
 }
 
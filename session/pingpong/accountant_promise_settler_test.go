@@ -47,7 +47,7 @@ func TestPromiseSettler_resyncState_returns_errors(t *testing.T) {
 
 	ks := keystore.NewKeyStore(dir, keystore.LightScryptN, keystore.LightScryptP)
 
-	settler := NewAccountantPromiseSettler(&mockTransactor{}, nil, channelStatusProvider, mrsp, ks, mapg, cfg)
+	settler := NewAccountantPromiseSettler(&mockTransactor{}, nil, channelStatusProvider, mrsp, ks, mapg, nil, nil, cfg)
 	err = settler.resyncState(mockID)
 	assert.Equal(t, fmt.Sprintf("could not get provider channel for %v: %v", mockID, errMock.Error()), err.Error())
 
@@ -72,7 +72,7 @@ func TestPromiseSettler_resyncState_handles_no_promise(t *testing.T) {
 	ks := keystore.NewKeyStore(dir, keystore.LightScryptN, keystore.LightScryptP)
 
 	id := identity.FromAddress("test")
-	settler := NewAccountantPromiseSettler(&mockTransactor{}, nil, channelStatusProvider, mrsp, ks, mapg, cfg)
+	settler := NewAccountantPromiseSettler(&mockTransactor{}, nil, channelStatusProvider, mrsp, ks, mapg, nil, nil, cfg)
 	err = settler.resyncState(id)
 	assert.NoError(t, err)
 
@@ -101,7 +101,7 @@ func TestPromiseSettler_resyncState_takes_promise_into_account(t *testing.T) {
 
 	ks := keystore.NewKeyStore(dir, keystore.LightScryptN, keystore.LightScryptP)
 
-	settler := NewAccountantPromiseSettler(&mockTransactor{}, nil, channelStatusProvider, mrsp, ks, mapg, cfg)
+	settler := NewAccountantPromiseSettler(&mockTransactor{}, nil, channelStatusProvider, mrsp, ks, mapg, nil, nil, cfg)
 	err = settler.resyncState(mockID)
 	assert.NoError(t, err)
 
@@ -112,6 +112,47 @@ func TestPromiseSettler_resyncState_takes_promise_into_account(t *testing.T) {
 	assert.True(t, v.registered)
 }
 
+func TestPromiseSettler_SettlementBalance(t *testing.T) {
+	channelStatusProvider := &mockProviderChannelStatusProvider{
+		channelToReturn: mockProviderChannel,
+	}
+	mrsp := &mockRegistrationStatusProvider{
+		identities: map[identity.Identity]mockRegistrationStatus{
+			mockID: {
+				status: registry.RegisteredProvider,
+			},
+		},
+	}
+	mapg := &mockAccountantPromiseGetter{
+		promise: AccountantPromise{
+			Promise: crypto.Promise{
+				Amount: 12000000,
+			},
+		},
+	}
+	dir, err := ioutil.TempDir("", "TestPromiseSettler_SettlementBalance")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	ks := keystore.NewKeyStore(dir, keystore.LightScryptN, keystore.LightScryptP)
+	settler := NewAccountantPromiseSettler(&mockTransactor{}, nil, channelStatusProvider, mrsp, ks, mapg, nil, nil, cfg)
+
+	settled, unsettled, err := settler.SettlementBalance(mockID)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(9000000), settled)
+	assert.Equal(t, uint64(3000000), unsettled)
+
+	settler.handleAccountantPromiseReceived(AccountantPromiseEventPayload{
+		AccountantID: identity.FromAddress(cfg.AccountantAddress.Hex()),
+		ProviderID:   mockID,
+		Promise:      crypto.Promise{Amount: 13000000},
+	})
+	settled, unsettled, err = settler.SettlementBalance(mockID)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(9000000), settled)
+	assert.Equal(t, uint64(4000000), unsettled)
+}
+
 func TestPromiseSettler_loadInitialState(t *testing.T) {
 	channelStatusProvider := &mockProviderChannelStatusProvider{
 		channelToReturn: mockProviderChannel,
@@ -130,7 +171,7 @@ func TestPromiseSettler_loadInitialState(t *testing.T) {
 
 	ks := keystore.NewKeyStore(dir, keystore.LightScryptN, keystore.LightScryptP)
 
-	settler := NewAccountantPromiseSettler(&mockTransactor{}, nil, channelStatusProvider, mrsp, ks, mapg, cfg)
+	settler := NewAccountantPromiseSettler(&mockTransactor{}, nil, channelStatusProvider, mrsp, ks, mapg, nil, nil, cfg)
 
 	settler.currentState[mockID] = state{}
 
@@ -200,7 +241,7 @@ func TestPromiseSettler_handleServiceEvent(t *testing.T) {
 
 	ks := keystore.NewKeyStore(dir, keystore.LightScryptN, keystore.LightScryptP)
 
-	settler := NewAccountantPromiseSettler(&mockTransactor{}, nil, channelStatusProvider, mrsp, ks, mapg, cfg)
+	settler := NewAccountantPromiseSettler(&mockTransactor{}, nil, channelStatusProvider, mrsp, ks, mapg, nil, nil, cfg)
 
 	statusesWithNoChangeExpected := []string{string(service.Starting), string(service.NotRunning)}
 
@@ -242,7 +283,7 @@ func TestPromiseSettler_handleRegistrationEvent(t *testing.T) {
 
 	ks := keystore.NewKeyStore(dir, keystore.LightScryptN, keystore.LightScryptP)
 
-	settler := NewAccountantPromiseSettler(&mockTransactor{}, nil, channelStatusProvider, mrsp, ks, mapg, cfg)
+	settler := NewAccountantPromiseSettler(&mockTransactor{}, nil, channelStatusProvider, mrsp, ks, mapg, nil, nil, cfg)
 
 	statusesWithNoChangeExpected := []registry.RegistrationStatus{registry.RegisteredConsumer, registry.Unregistered, registry.InProgress, registry.Promoting, registry.RegistrationError}
 	for _, v := range statusesWithNoChangeExpected {
@@ -284,7 +325,7 @@ func TestPromiseSettler_handleAccountantPromiseReceived(t *testing.T) {
 	ks := keystore.NewKeyStore(dir, keystore.LightScryptN, keystore.LightScryptP)
 
 	// no receive on unknown provider
-	settler := NewAccountantPromiseSettler(&mockTransactor{}, nil, channelStatusProvider, mrsp, ks, mapg, cfg)
+	settler := NewAccountantPromiseSettler(&mockTransactor{}, nil, channelStatusProvider, mrsp, ks, mapg, nil, nil, cfg)
 	settler.handleAccountantPromiseReceived(AccountantPromiseEventPayload{
 		AccountantID: identity.FromAddress(cfg.AccountantAddress.Hex()),
 		ProviderID:   mockID,
@@ -323,6 +364,17 @@ func TestPromiseSettler_handleAccountantPromiseReceived(t *testing.T) {
 	v := settler.currentState[mockID]
 	assert.Equal(t, uint64(13000000-10000), v.balance)
 
+	// should ignore promises of other accountants
+	settler.handleAccountantPromiseReceived(AccountantPromiseEventPayload{
+		AccountantID: identity.FromAddress("0x1"),
+		ProviderID:   mockID,
+		Promise: crypto.Promise{
+			Amount: 120000,
+		},
+	})
+	assertNoReceive(t, settler.settleQueue)
+	assert.Equal(t, v, settler.currentState[mockID])
+
 	// should not receive here due to balance being large and stake being small
 	settler.currentState[mockID] = state{
 		registered:       true,
@@ -343,6 +395,31 @@ func TestPromiseSettler_handleAccountantPromiseReceived(t *testing.T) {
 	assertNoReceive(t, settler.settleQueue)
 }
 
+func TestPromiseSettler_settle_publishesResult(t *testing.T) {
+	sink := make(chan *bindings.AccountantImplementationPromiseSettled, 1)
+	channelStatusProvider := &mockProviderChannelStatusProvider{
+		channelToReturn: mockProviderChannel,
+		sinkToReturn:    sink,
+		subCancel:       func() {},
+	}
+	publisher := &mockPublisher{publicationChan: make(chan event, 1)}
+	settler := NewAccountantPromiseSettler(&mockTransactor{}, nil, channelStatusProvider, &mockRegistrationStatusProvider{}, nil, &mockAccountantPromiseGetter{}, nil, publisher, cfg)
+	accountantID := identity.FromAddress(cfg.AccountantAddress.Hex())
+
+	// settlement times out without the settled event
+	err := settler.settle(receivedPromise{provider: mockID, promise: crypto.Promise{Amount: 100}})
+	assert.Equal(t, ErrSettleTimeout, err)
+	published := <-publisher.publicationChan
+	assert.Equal(t, AppTopicSettlement, published.name)
+	assert.Equal(t, SettlementEventPayload{ProviderID: mockID, AccountantID: accountantID}, published.value)
+
+	sink <- &bindings.AccountantImplementationPromiseSettled{Amount: big.NewInt(100)}
+	err = settler.settle(receivedPromise{provider: mockID, promise: crypto.Promise{Amount: 100}})
+	assert.NoError(t, err)
+	published = <-publisher.publicationChan
+	assert.Equal(t, SettlementEventPayload{ProviderID: mockID, AccountantID: accountantID, Amount: 100, Successful: true}, published.value)
+}
+
 func assertNoReceive(t *testing.T, ch chan receivedPromise) {
 	// at this point, we should not receive an event on settled queue as we have no info on provider, let's check for that
 	select {
@@ -381,7 +458,7 @@ func TestPromiseSettler_handleNodeStart(t *testing.T) {
 		},
 	}
 
-	settler := NewAccountantPromiseSettler(&mockTransactor{}, nil, channelStatusProvider, mrsp, ks, mapg, cfg)
+	settler := NewAccountantPromiseSettler(&mockTransactor{}, nil, channelStatusProvider, mrsp, ks, mapg, nil, nil, cfg)
 
 	settler.handleNodeStart()
 
@@ -404,7 +481,7 @@ func TestPromiseSettlerState_needsSettling(t *testing.T) {
 	}
 
 	// should be true with zero balance left
-	assert.True(t, s.needsSettling(0.1))
+	assert.True(t, s.needsSettling(ThresholdStrategy{Threshold: 0.1}, mockID, settleFee{}))
 
 	s = state{
 		balance:          1000,
@@ -413,7 +490,7 @@ func TestPromiseSettlerState_needsSettling(t *testing.T) {
 	}
 
 	// should be true with 10% missing
-	assert.True(t, s.needsSettling(0.1))
+	assert.True(t, s.needsSettling(ThresholdStrategy{Threshold: 0.1}, mockID, settleFee{}))
 
 	s = state{
 		balance:          1001,
@@ -422,7 +499,7 @@ func TestPromiseSettlerState_needsSettling(t *testing.T) {
 	}
 
 	// should be false with 10.01% missing
-	assert.False(t, s.needsSettling(0.1))
+	assert.False(t, s.needsSettling(ThresholdStrategy{Threshold: 0.1}, mockID, settleFee{}))
 
 	s = state{
 		balance:          1001,
@@ -432,14 +509,14 @@ func TestPromiseSettlerState_needsSettling(t *testing.T) {
 	}
 
 	// should be false with settle in progress
-	assert.False(t, s.needsSettling(0.1))
+	assert.False(t, s.needsSettling(ThresholdStrategy{Threshold: 0.1}, mockID, settleFee{}))
 	s = state{
 		balance:          1001,
 		availableBalance: 10,
 	}
 
 	// should be false with no registration
-	assert.False(t, s.needsSettling(0.1))
+	assert.False(t, s.needsSettling(ThresholdStrategy{Threshold: 0.1}, mockID, settleFee{}))
 }
 
 func TestPromiseSettlerState_updateWithNewPromise(t *testing.T) {
@@ -0,0 +1,242 @@
+/*
+ * Copyright (C) 2020 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package pingpong
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/mysteriumnetwork/node/core/node"
+	"github.com/mysteriumnetwork/node/eventbus"
+	"github.com/mysteriumnetwork/node/identity"
+	"github.com/mysteriumnetwork/node/market"
+	"github.com/mysteriumnetwork/node/requests"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+// ErrAccountantNotAccepted indicates that the accountant is not accepted by the peer.
+var ErrAccountantNotAccepted = errors.New("accountant not accepted")
+
+// ErrNoCommonAccountant indicates that consumer and provider do not accept any common accountant.
+var ErrNoCommonAccountant = errors.New("no common accountant")
+
+const (
+	// AccountantSelectionPreferred picks the accountant provider prefers the most.
+	AccountantSelectionPreferred = "preferred"
+	// AccountantSelectionLowestFee picks the accountant with the lowest fee.
+	AccountantSelectionLowestFee = "lowest-fee"
+	// AccountantSelectionBalance picks the accountant consumer has the largest channel balance with.
+	AccountantSelectionBalance = "balance"
+)
+
+// AccountantCallers holds the callers of the accepted accountants.
+type AccountantCallers map[common.Address]*AccountantCaller
+
+// NewAccountantCallers creates the callers for the given accountants.
+func NewAccountantCallers(transport *requests.HTTPClient, accountants []node.AccountantDefinition) AccountantCallers {
+	callers := make(AccountantCallers, len(accountants))
+	for _, a := range accountants {
+		callers[common.HexToAddress(a.ID)] = NewAccountantCaller(transport, a.EndpointAddress)
+	}
+	return callers
+}
+
+// Caller returns the caller of the given accountant.
+func (ac AccountantCallers) Caller(accountantID identity.Identity) (accountantCaller, error) {
+	caller, ok := ac[accountantID.ToCommonAddress()]
+	if !ok {
+		return nil, errors.Wrapf(ErrAccountantNotAccepted, "no caller for accountant %v", accountantID.Address)
+	}
+	return caller, nil
+}
+
+// GetConsumerData returns the consumer data known by the given accountant.
+func (ac AccountantCallers) GetConsumerData(accountantID identity.Identity, id string) (ConsumerData, error) {
+	caller, ok := ac[accountantID.ToCommonAddress()]
+	if !ok {
+		return ConsumerData{}, errors.Wrapf(ErrAccountantNotAccepted, "no caller for accountant %v", accountantID.Address)
+	}
+	return caller.GetConsumerData(id)
+}
+
+// AccountantPromiseSettlers settles the promises of every accepted accountant with a settler of its own.
+type AccountantPromiseSettlers struct {
+	settlers []*AccountantPromiseSettler
+}
+
+// NewAccountantPromiseSettlers groups the given settlers, the settler of the default accountant goes first.
+func NewAccountantPromiseSettlers(settlers ...*AccountantPromiseSettler) *AccountantPromiseSettlers {
+	return &AccountantPromiseSettlers{settlers: settlers}
+}
+
+// Subscribe subscribes every settler to the appropriate events.
+func (aps *AccountantPromiseSettlers) Subscribe(sub eventbus.Subscriber) error {
+	for _, settler := range aps.settlers {
+		if err := settler.Subscribe(sub); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ForceSettle forces the settlement for a provider with the given accountant.
+func (aps *AccountantPromiseSettlers) ForceSettle(providerID, accountantID identity.Identity) error {
+	for _, settler := range aps.settlers {
+		if settler.config.AccountantAddress == accountantID.ToCommonAddress() {
+			return settler.ForceSettle(providerID, accountantID)
+		}
+	}
+	return errors.Wrapf(ErrAccountantNotAccepted, "no settler for accountant %v", accountantID.Address)
+}
+
+// SettlementBalance returns the settled and unsettled amounts of the provider summed over all the accountants.
+func (aps *AccountantPromiseSettlers) SettlementBalance(providerID identity.Identity) (settled, unsettled uint64, err error) {
+	for _, settler := range aps.settlers {
+		s, u, err := settler.SettlementBalance(providerID)
+		if err != nil {
+			return 0, 0, err
+		}
+		settled += s
+		unsettled += u
+	}
+	return settled, unsettled, nil
+}
+
+type accountantSelectorBlockchain interface {
+	GetAccountantFee(accountantAddress common.Address) (uint16, error)
+	GetConsumerBalance(channel, mystSCAddress common.Address) (*big.Int, error)
+}
+
+// AccountantSelector picks the accountant both consumer and provider accept.
+type AccountantSelector struct {
+	policy                string
+	accepted              []identity.Identity
+	bc                    accountantSelectorBlockchain
+	channelImplementation string
+	registryAddress       string
+	mystSCAddress         common.Address
+}
+
+// NewAccountantSelector returns a new instance of accountant selector.
+// The first of the accepted accountants is the default one, used with providers not advertising their accountants.
+func NewAccountantSelector(policy string, accepted []string, bc accountantSelectorBlockchain, channelImplementation, registryAddress string, mystSCAddress common.Address) *AccountantSelector {
+	ids := make([]identity.Identity, len(accepted))
+	for i, id := range accepted {
+		ids[i] = identity.FromAddress(id)
+	}
+	return &AccountantSelector{
+		policy:                policy,
+		accepted:              ids,
+		bc:                    bc,
+		channelImplementation: channelImplementation,
+		registryAddress:       registryAddress,
+		mystSCAddress:         mystSCAddress,
+	}
+}
+
+// Select picks the accountant for the session with the provider of the given proposal.
+// The requested accountant is used if provider accepts it, otherwise the policy picks one accepted by both sides.
+func (as *AccountantSelector) Select(consumerID, requested identity.Identity, proposal market.ServiceProposal) (identity.Identity, error) {
+	if requested.Address != "" {
+		if !proposal.AcceptsAccountant(requested) {
+			return identity.Identity{}, errors.Wrapf(ErrAccountantNotAccepted, "provider does not accept accountant %v", requested.Address)
+		}
+		return requested, nil
+	}
+
+	candidates := as.candidates(proposal)
+	if len(candidates) == 0 {
+		return identity.Identity{}, ErrNoCommonAccountant
+	}
+
+	switch as.policy {
+	case AccountantSelectionLowestFee:
+		return as.lowestFee(candidates), nil
+	case AccountantSelectionBalance:
+		return as.largestBalance(consumerID, candidates), nil
+	default:
+		return candidates[0], nil
+	}
+}
+
+// candidates returns the accountants accepted by both sides in the order provider prefers them.
+func (as *AccountantSelector) candidates(proposal market.ServiceProposal) []identity.Identity {
+	if len(proposal.AccountantIDs) == 0 {
+		if len(as.accepted) == 0 {
+			return nil
+		}
+		return as.accepted[:1]
+	}
+
+	var result []identity.Identity
+	for _, id := range proposal.AccountantIDs {
+		candidate := identity.FromAddress(id)
+		if as.accepts(candidate) {
+			result = append(result, candidate)
+		}
+	}
+	return result
+}
+
+func (as *AccountantSelector) accepts(accountantID identity.Identity) bool {
+	for _, id := range as.accepted {
+		if id == accountantID {
+			return true
+		}
+	}
+	return false
+}
+
+func (as *AccountantSelector) lowestFee(candidates []identity.Identity) identity.Identity {
+	best := candidates[0]
+	var bestFee uint16
+	found := false
+	for _, candidate := range candidates {
+		fee, err := as.bc.GetAccountantFee(candidate.ToCommonAddress())
+		if err != nil {
+			log.Warn().Err(err).Msgf("Could not get fee of accountant %v, skipping", candidate.Address)
+			continue
+		}
+		if !found || fee < bestFee {
+			best, bestFee, found = candidate, fee, true
+		}
+	}
+	return best
+}
+
+func (as *AccountantSelector) largestBalance(consumerID identity.Identity, candidates []identity.Identity) identity.Identity {
+	best := candidates[0]
+	bestBalance := big.NewInt(0)
+	for _, candidate := range candidates {
+		channel, err := NewChannelAddressCalculator(candidate.Address, as.channelImplementation, as.registryAddress).GetChannelAddress(consumerID)
+		if err != nil {
+			log.Warn().Err(err).Msgf("Could not calculate channel address with accountant %v, skipping", candidate.Address)
+			continue
+		}
+		balance, err := as.bc.GetConsumerBalance(channel, as.mystSCAddress)
+		if err != nil {
+			log.Warn().Err(err).Msgf("Could not get channel balance with accountant %v, skipping", candidate.Address)
+			continue
+		}
+		if balance.Cmp(bestBalance) > 0 {
+			best, bestBalance = candidate, balance
+		}
+	}
+	return best
+}
@@ -0,0 +1,176 @@
+/*
+ * Copyright (C) 2020 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package pingpong
+
+import (
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/mysteriumnetwork/node/core/node"
+	"github.com/mysteriumnetwork/node/identity"
+	"github.com/mysteriumnetwork/node/market"
+	"github.com/mysteriumnetwork/node/requests"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	mockAccountant1 = "0x0000000000000000000000000000000000000001"
+	mockAccountant2 = "0x0000000000000000000000000000000000000002"
+	mockAccountant3 = "0x0000000000000000000000000000000000000003"
+)
+
+type mockSelectorBlockchain struct {
+	fees     map[common.Address]uint16
+	balances map[common.Address]int64
+}
+
+func (msb *mockSelectorBlockchain) GetAccountantFee(accountantAddress common.Address) (uint16, error) {
+	fee, ok := msb.fees[accountantAddress]
+	if !ok {
+		return 0, errors.New("unknown accountant")
+	}
+	return fee, nil
+}
+
+func (msb *mockSelectorBlockchain) GetConsumerBalance(channel, mystSCAddress common.Address) (*big.Int, error) {
+	return big.NewInt(msb.balances[channel]), nil
+}
+
+func TestAccountantSelector_Select(t *testing.T) {
+	consumer := identity.FromAddress("0x44440954558C5bFA0D4153B0002B1d1E3E3f5Ff5")
+	channel3, err := NewChannelAddressCalculator(mockAccountant3, mockChannelImplementation, mockRegistryAddress).GetChannelAddress(consumer)
+	assert.NoError(t, err)
+	bc := &mockSelectorBlockchain{
+		fees: map[common.Address]uint16{
+			common.HexToAddress(mockAccountant2): 500,
+			common.HexToAddress(mockAccountant3): 200,
+		},
+		balances: map[common.Address]int64{channel3: 100},
+	}
+	accepted := []string{mockAccountant1, mockAccountant2, mockAccountant3}
+	advertising := market.ServiceProposal{AccountantIDs: []string{mockAccountant2, mockAccountant3}}
+
+	tests := []struct {
+		name      string
+		policy    string
+		requested identity.Identity
+		proposal  market.ServiceProposal
+		want      identity.Identity
+		wantErr   error
+	}{
+		{
+			name:     "picks provider's preferred accountant",
+			policy:   AccountantSelectionPreferred,
+			proposal: advertising,
+			want:     identity.FromAddress(mockAccountant2),
+		},
+		{
+			name:     "picks accountant with the lowest fee",
+			policy:   AccountantSelectionLowestFee,
+			proposal: advertising,
+			want:     identity.FromAddress(mockAccountant3),
+		},
+		{
+			name:     "picks accountant with the largest balance",
+			policy:   AccountantSelectionBalance,
+			proposal: advertising,
+			want:     identity.FromAddress(mockAccountant3),
+		},
+		{
+			name:     "picks default accountant for providers not advertising accountants",
+			policy:   AccountantSelectionLowestFee,
+			proposal: market.ServiceProposal{},
+			want:     identity.FromAddress(mockAccountant1),
+		},
+		{
+			name:      "honours the requested accountant",
+			policy:    AccountantSelectionLowestFee,
+			requested: identity.FromAddress(mockAccountant2),
+			proposal:  advertising,
+			want:      identity.FromAddress(mockAccountant2),
+		},
+		{
+			name:      "refuses requested accountant provider does not accept",
+			policy:    AccountantSelectionPreferred,
+			requested: identity.FromAddress(mockAccountant1),
+			proposal:  advertising,
+			wantErr:   ErrAccountantNotAccepted,
+		},
+		{
+			name:     "fails without common accountant",
+			policy:   AccountantSelectionPreferred,
+			proposal: market.ServiceProposal{AccountantIDs: []string{"0x0000000000000000000000000000000000000004"}},
+			wantErr:  ErrNoCommonAccountant,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selector := NewAccountantSelector(tt.policy, accepted, bc, mockChannelImplementation, mockRegistryAddress, common.Address{})
+			got, err := selector.Select(consumer, tt.requested, tt.proposal)
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr, errors.Cause(err))
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestAccountantCallers_Caller(t *testing.T) {
+	callers := NewAccountantCallers(requests.NewHTTPClient("0.0.0.0", requests.DefaultTimeout), []node.AccountantDefinition{
+		{ID: mockAccountant1, EndpointAddress: "http://accountant1"},
+		{ID: mockAccountant2, EndpointAddress: "http://accountant2"},
+	})
+
+	caller, err := callers.Caller(identity.FromAddress(mockAccountant2))
+	assert.NoError(t, err)
+	assert.Equal(t, callers[common.HexToAddress(mockAccountant2)], caller)
+
+	_, err = callers.Caller(identity.FromAddress(mockAccountant3))
+	assert.Equal(t, ErrAccountantNotAccepted, errors.Cause(err))
+}
+
+func TestAccountantCallers_GetConsumerData(t *testing.T) {
+	newServer := func(balance uint64) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			bytes, err := json.Marshal(ConsumerData{Balance: balance})
+			assert.NoError(t, err)
+			w.Write(bytes)
+		}))
+	}
+	server1, server2 := newServer(100), newServer(200)
+	defer server1.Close()
+	defer server2.Close()
+	callers := NewAccountantCallers(requests.NewHTTPClient("0.0.0.0", requests.DefaultTimeout), []node.AccountantDefinition{
+		{ID: mockAccountant1, EndpointAddress: server1.URL},
+		{ID: mockAccountant2, EndpointAddress: server2.URL},
+	})
+
+	data, err := callers.GetConsumerData(identity.FromAddress(mockAccountant2), "0x1")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(200), data.Balance)
+
+	_, err = callers.GetConsumerData(identity.FromAddress(mockAccountant3), "0x1")
+	assert.Equal(t, ErrAccountantNotAccepted, errors.Cause(err))
+}
@@ -0,0 +1,136 @@
+/*
+ * Copyright (C) 2020 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package pingpong
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/mysteriumnetwork/node/core/channel"
+	"github.com/mysteriumnetwork/node/identity"
+	"github.com/pkg/errors"
+)
+
+type channelBlockchain interface {
+	GetConsumerBalance(channel, mystSCAddress common.Address) (*big.Int, error)
+	GetProviderChannel(accountantAddress common.Address, addressToCheck common.Address) (ProviderChannel, error)
+	IsRegistered(registryAddress, addressToCheck common.Address) (bool, error)
+}
+
+// ChannelInspector collects the payment channel state from the blockchain and the local storages.
+type ChannelInspector struct {
+	accountantID             identity.Identity
+	registryAddress          common.Address
+	mystSCAddress            common.Address
+	channelAddressCalculator channelAddressCalculator
+	bc                       channelBlockchain
+	consumerTotalsStorage    consumerTotalsStorage
+	accountantPromiseStorage accountantPromiseStorage
+}
+
+// NewChannelInspector returns a new instance of channel inspector.
+func NewChannelInspector(
+	accountantID identity.Identity,
+	registryAddress, mystSCAddress common.Address,
+	channelAddressCalculator channelAddressCalculator,
+	bc channelBlockchain,
+	consumerTotalsStorage consumerTotalsStorage,
+	accountantPromiseStorage accountantPromiseStorage,
+) *ChannelInspector {
+	return &ChannelInspector{
+		accountantID:             accountantID,
+		registryAddress:          registryAddress,
+		mystSCAddress:            mystSCAddress,
+		channelAddressCalculator: channelAddressCalculator,
+		bc:                       bc,
+		consumerTotalsStorage:    consumerTotalsStorage,
+		accountantPromiseStorage: accountantPromiseStorage,
+	}
+}
+
+// Inspect returns the state of the given identity's payment channel.
+func (ci *ChannelInspector) Inspect(id identity.Identity) (channel.Status, error) {
+	status := channel.Status{
+		Identity:     id,
+		AccountantID: ci.accountantID,
+	}
+
+	address, err := ci.channelAddressCalculator.GetChannelAddress(id)
+	if err != nil {
+		return status, errors.Wrap(err, "could not calculate channel address")
+	}
+	status.Address = address
+
+	balance, err := ci.bc.GetConsumerBalance(address, ci.mystSCAddress)
+	if err != nil {
+		return status, errors.Wrap(err, "could not get channel balance")
+	}
+	status.Balance, err = toUint64(balance)
+	if err != nil {
+		return status, errors.Wrap(err, "could not convert channel balance")
+	}
+
+	providerChannel, err := ci.bc.GetProviderChannel(ci.accountantID.ToCommonAddress(), id.ToCommonAddress())
+	if err != nil {
+		return status, errors.Wrap(err, "could not get provider channel")
+	}
+	status.Settled, err = toUint64(providerChannel.Settled)
+	if err != nil {
+		return status, errors.Wrap(err, "could not convert settled amount")
+	}
+
+	status.Registered, err = ci.bc.IsRegistered(ci.registryAddress, id.ToCommonAddress())
+	if err != nil {
+		return status, errors.Wrap(err, "could not check registration status")
+	}
+
+	status.GrandTotalPromised, err = ci.consumerTotalsStorage.Get(id.Address, ci.accountantID.Address)
+	if err != nil && err != ErrNotFound {
+		return status, errors.Wrap(err, "could not get grand total promised")
+	}
+
+	promise, err := ci.accountantPromiseStorage.Get(id, ci.accountantID)
+	switch err {
+	case nil:
+		status.LastPromise = &channel.Promise{
+			ChannelID:   promise.Promise.ChannelID,
+			Amount:      promise.Promise.Amount,
+			Fee:         promise.Promise.Fee,
+			Hashlock:    promise.Promise.Hashlock,
+			AgreementID: promise.AgreementID,
+			Revealed:    promise.Revealed,
+		}
+	case ErrNotFound:
+	default:
+		return status, errors.Wrap(err, "could not get last accountant promise")
+	}
+
+	return status, nil
+}
+
+// toUint64 converts the given big.Int, failing instead of silently truncating values out of the uint64 range.
+func toUint64(i *big.Int) (uint64, error) {
+	if i == nil {
+		return 0, nil
+	}
+	if !i.IsUint64() {
+		return 0, fmt.Errorf("value %v does not fit into uint64", i)
+	}
+	return i.Uint64(), nil
+}
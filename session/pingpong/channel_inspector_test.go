@@ -0,0 +1,119 @@
+/*
+ * Copyright (C) 2020 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package pingpong
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/mysteriumnetwork/node/core/channel"
+	"github.com/mysteriumnetwork/node/identity"
+	"github.com/mysteriumnetwork/payments/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockChannelBlockchain struct {
+	balance    *big.Int
+	channel    ProviderChannel
+	registered bool
+	err        error
+}
+
+func (mcb *mockChannelBlockchain) GetConsumerBalance(channel, mystSCAddress common.Address) (*big.Int, error) {
+	return mcb.balance, mcb.err
+}
+
+func (mcb *mockChannelBlockchain) GetProviderChannel(accountantAddress common.Address, addressToCheck common.Address) (ProviderChannel, error) {
+	return mcb.channel, nil
+}
+
+func (mcb *mockChannelBlockchain) IsRegistered(registryAddress, addressToCheck common.Address) (bool, error) {
+	return mcb.registered, nil
+}
+
+type mockChannelPromiseStorage struct {
+	promise AccountantPromise
+	err     error
+}
+
+func (mcps *mockChannelPromiseStorage) Store(providerID, accountantID identity.Identity, promise AccountantPromise) error {
+	return nil
+}
+
+func (mcps *mockChannelPromiseStorage) Get(providerID, accountantID identity.Identity) (AccountantPromise, error) {
+	return mcps.promise, mcps.err
+}
+
+func TestChannelInspector_Inspect(t *testing.T) {
+	id := identity.FromAddress("0x44440954558C5bFA0D4153B0002B1d1E3E3f5Ff5")
+	accountant := identity.FromAddress(mockAccountantAddress)
+	calculator := NewChannelAddressCalculator(mockAccountantAddress, mockChannelImplementation, mockRegistryAddress)
+	channelAddress, err := calculator.GetChannelAddress(id)
+	assert.NoError(t, err)
+
+	bc := &mockChannelBlockchain{
+		balance:    big.NewInt(1000),
+		channel:    ProviderChannel{Settled: big.NewInt(200)},
+		registered: true,
+	}
+	totals := &mockConsumerTotalsStorage{res: 300}
+	promises := &mockChannelPromiseStorage{promise: AccountantPromise{
+		Promise:     crypto.Promise{Amount: 250, Fee: 10, Hashlock: []byte{1}},
+		AgreementID: 7,
+		Revealed:    true,
+	}}
+	inspector := NewChannelInspector(accountant, common.HexToAddress(mockRegistryAddress), common.Address{}, calculator, bc, totals, promises)
+
+	status, err := inspector.Inspect(id)
+	assert.NoError(t, err)
+	assert.Equal(t, channel.Status{
+		Identity:           id,
+		AccountantID:       accountant,
+		Address:            channelAddress,
+		Balance:            1000,
+		Settled:            200,
+		GrandTotalPromised: 300,
+		LastPromise:        &channel.Promise{Amount: 250, Fee: 10, Hashlock: []byte{1}, AgreementID: 7, Revealed: true},
+		Registered:         true,
+	}, status)
+
+	promises.err = ErrNotFound
+	totals.err = ErrNotFound
+	totals.res = 0
+	status, err = inspector.Inspect(id)
+	assert.NoError(t, err)
+	assert.Nil(t, status.LastPromise)
+	assert.Zero(t, status.GrandTotalPromised)
+
+	bc.err = errors.New("bc unavailable")
+	_, err = inspector.Inspect(id)
+	assert.EqualError(t, err, "could not get channel balance: bc unavailable")
+}
+
+func TestChannelInspector_Inspect_RejectsBalanceOverflow(t *testing.T) {
+	id := identity.FromAddress("0x44440954558C5bFA0D4153B0002B1d1E3E3f5Ff5")
+	calculator := NewChannelAddressCalculator(mockAccountantAddress, mockChannelImplementation, mockRegistryAddress)
+	overflow := new(big.Int).Lsh(big.NewInt(1), 64)
+	bc := &mockChannelBlockchain{balance: overflow, channel: ProviderChannel{Settled: big.NewInt(0)}}
+	inspector := NewChannelInspector(identity.FromAddress(mockAccountantAddress), common.Address{}, common.Address{}, calculator, bc, &mockConsumerTotalsStorage{}, &mockChannelPromiseStorage{})
+
+	_, err := inspector.Inspect(id)
+	assert.EqualError(t, err, "could not convert channel balance: value 18446744073709551616 does not fit into uint64")
+}
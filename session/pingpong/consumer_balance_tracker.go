@@ -32,17 +32,25 @@ import (
 	"github.com/rs/zerolog/log"
 )
 
-type accountantBalanceFetcher func(id string) (ConsumerData, error)
+type accountantBalanceFetcher func(accountantID identity.Identity, id string) (ConsumerData, error)
 
-// ConsumerBalanceTracker keeps track of consumer balances.
+// balanceKey identifies the consumer channel with an accountant.
+type balanceKey struct {
+	consumer   identity.Identity
+	accountant common.Address
+}
+
+// ConsumerBalanceTracker keeps track of consumer balances in the channels with every accepted accountant.
 // TODO: this needs to take into account the saved state.
 type ConsumerBalanceTracker struct {
 	balancesLock sync.Mutex
-	balances     map[identity.Identity]Balance
+	balances     map[balanceKey]Balance
 
 	mystSCAddress            common.Address
+	accountants              []identity.Identity
 	consumerBalanceChecker   consumerBalanceChecker
-	channelAddressCalculator channelAddressCalculator
+	channelImplementation    string
+	registryAddress          string
 	accountantBalanceFetcher accountantBalanceFetcher
 	publisher                eventbus.Publisher
 
@@ -51,13 +59,19 @@ type ConsumerBalanceTracker struct {
 }
 
 // NewConsumerBalanceTracker creates a new instance
-func NewConsumerBalanceTracker(publisher eventbus.Publisher, mystSCAddress common.Address, consumerBalanceChecker consumerBalanceChecker, channelAddressCalculator channelAddressCalculator, accountantBalanceFetcher accountantBalanceFetcher) *ConsumerBalanceTracker {
+func NewConsumerBalanceTracker(publisher eventbus.Publisher, mystSCAddress common.Address, accountants []string, consumerBalanceChecker consumerBalanceChecker, channelImplementation, registryAddress string, accountantBalanceFetcher accountantBalanceFetcher) *ConsumerBalanceTracker {
+	ids := make([]identity.Identity, len(accountants))
+	for i, id := range accountants {
+		ids[i] = identity.FromAddress(id)
+	}
 	return &ConsumerBalanceTracker{
-		balances:                 make(map[identity.Identity]Balance),
+		balances:                 make(map[balanceKey]Balance),
+		accountants:              ids,
 		consumerBalanceChecker:   consumerBalanceChecker,
 		mystSCAddress:            mystSCAddress,
 		publisher:                publisher,
-		channelAddressCalculator: channelAddressCalculator,
+		channelImplementation:    channelImplementation,
+		registryAddress:          registryAddress,
 		accountantBalanceFetcher: accountantBalanceFetcher,
 		stop:                     make(chan struct{}),
 	}
@@ -94,18 +108,15 @@ func (cbt *ConsumerBalanceTracker) Subscribe(bus eventbus.Subscriber) error {
 	return bus.SubscribeAsync(identity.AppTopicIdentityUnlock, cbt.handleUnlockEvent)
 }
 
-// GetBalance gets the current balance for given identity
+// GetBalance gets the current balance for given identity summed over the channels with all the accountants.
 func (cbt *ConsumerBalanceTracker) GetBalance(ID identity.Identity) uint64 {
 	cbt.balancesLock.Lock()
 	defer cbt.balancesLock.Unlock()
-	if v, ok := cbt.balances[ID]; ok {
-		return v.CurrentEstimate
-	}
-	return 0
+	return cbt.total(ID)
 }
 
 func (cbt *ConsumerBalanceTracker) handleExchangeMessageEvent(event ExchangeMessageEventPayload) {
-	cbt.decreaseBalance(event.Identity, event.AmountPromised)
+	cbt.decreaseBalance(event.Identity, event.AccountantID, event.AmountPromised)
 }
 
 func (cbt *ConsumerBalanceTracker) publishChangeEvent(id identity.Identity, before, after uint64) {
@@ -117,14 +128,25 @@ func (cbt *ConsumerBalanceTracker) publishChangeEvent(id identity.Identity, befo
 }
 
 func (cbt *ConsumerBalanceTracker) handleUnlockEvent(id string) {
-	identity := identity.FromAddress(id)
-	cbt.updateBalanceFromAccountant(identity)
+	cbt.updateBalancesFromAccountants(identity.FromAddress(id))
 }
 
 func (cbt *ConsumerBalanceTracker) handleTopUpEvent(id string) {
-	addr, err := cbt.channelAddressCalculator.GetChannelAddress(identity.FromAddress(id))
+	var wg sync.WaitGroup
+	for _, accountantID := range cbt.accountants {
+		wg.Add(1)
+		go func(accountantID identity.Identity) {
+			defer wg.Done()
+			cbt.waitForTopUp(identity.FromAddress(id), accountantID)
+		}(accountantID)
+	}
+	wg.Wait()
+}
+
+func (cbt *ConsumerBalanceTracker) waitForTopUp(id, accountantID identity.Identity) {
+	addr, err := NewChannelAddressCalculator(accountantID.Address, cbt.channelImplementation, cbt.registryAddress).GetChannelAddress(id)
 	if err != nil {
-		log.Error().Err(err).Msg("Could not generate channel address")
+		log.Error().Err(err).Msgf("Could not generate channel address with accountant %v", accountantID.Address)
 		return
 	}
 	sub, cancel, err := cbt.consumerBalanceChecker.SubscribeToConsumerBalanceEvent(addr, cbt.mystSCAddress)
@@ -138,7 +160,7 @@ func (cbt *ConsumerBalanceTracker) handleTopUpEvent(id string) {
 		if !more {
 			return
 		}
-		cbt.increaseBalance(identity.FromAddress(id), ev.Value.Uint64())
+		cbt.increaseBalance(id, accountantID, ev.Value.Uint64())
 	case <-cbt.stop:
 		return
 	}
@@ -167,7 +189,7 @@ func (cbt *ConsumerBalanceTracker) handleRegistrationEvent(event registry.Regist
 
 		boff = backoff.WithContext(boff, ctx)
 		toRetry := func() error {
-			return cbt.updateBalanceFromAccountant(event.ID)
+			return cbt.updateBalancesFromAccountants(event.ID)
 		}
 
 		err := backoff.Retry(toRetry, boff)
@@ -183,52 +205,61 @@ func (cbt *ConsumerBalanceTracker) handleStopEvent() {
 	})
 }
 
-func (cbt *ConsumerBalanceTracker) increaseBalance(id identity.Identity, b uint64) {
+func (cbt *ConsumerBalanceTracker) increaseBalance(id, accountantID identity.Identity, b uint64) {
 	cbt.balancesLock.Lock()
 	defer cbt.balancesLock.Unlock()
-	if v, ok := cbt.balances[id]; ok {
-		v.CurrentEstimate += b
-		v.BCBalance += b
-		cbt.balances[id] = v
-		go cbt.publishChangeEvent(id, v.CurrentEstimate, v.BCBalance)
-	} else {
-		cbt.balances[id] = Balance{
-			BCBalance:       b,
-			CurrentEstimate: b,
-		}
-		go cbt.publishChangeEvent(id, 0, b)
-	}
+	before := cbt.total(id)
+	key := newBalanceKey(id, accountantID)
+	v := cbt.balances[key]
+	v.CurrentEstimate += b
+	v.BCBalance += b
+	cbt.balances[key] = v
+	go cbt.publishChangeEvent(id, before, cbt.total(id))
 }
 
-func (cbt *ConsumerBalanceTracker) decreaseBalance(id identity.Identity, b uint64) {
+func (cbt *ConsumerBalanceTracker) decreaseBalance(id, accountantID identity.Identity, b uint64) {
 	cbt.balancesLock.Lock()
 	defer cbt.balancesLock.Unlock()
-	if v, ok := cbt.balances[id]; ok {
+	before := cbt.total(id)
+	key := newBalanceKey(id, accountantID)
+	if v, ok := cbt.balances[key]; ok {
 		if v.BCBalance != 0 {
-			after := safeSub(v.BCBalance, b)
-			go cbt.publishChangeEvent(id, v.CurrentEstimate, after)
-			v.CurrentEstimate = after
-			cbt.balances[id] = v
+			v.CurrentEstimate = safeSub(v.BCBalance, b)
+			cbt.balances[key] = v
+			go cbt.publishChangeEvent(id, before, cbt.total(id))
 		}
 	} else {
-		cbt.balances[id] = Balance{
+		cbt.balances[key] = Balance{
 			BCBalance:       0,
 			CurrentEstimate: 0,
 		}
-		go cbt.publishChangeEvent(id, 0, 0)
+		go cbt.publishChangeEvent(id, before, before)
 	}
 }
 
-func (cbt *ConsumerBalanceTracker) updateBalanceFromAccountant(id identity.Identity) error {
-	cb, err := cbt.accountantBalanceFetcher(id.Address)
+// updateBalancesFromAccountants updates the balances of the channels with all the accountants, returns the first error.
+func (cbt *ConsumerBalanceTracker) updateBalancesFromAccountants(id identity.Identity) error {
+	var firstErr error
+	for _, accountantID := range cbt.accountants {
+		if err := cbt.updateBalanceFromAccountant(id, accountantID); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+func (cbt *ConsumerBalanceTracker) updateBalanceFromAccountant(id, accountantID identity.Identity) error {
+	cb, err := cbt.accountantBalanceFetcher(accountantID, id.Address)
 	if err != nil {
-		log.Error().Err(err).Msg("could not get accountant balance")
+		log.Error().Err(err).Msgf("could not get balance from accountant %v", accountantID.Address)
 		return err
 	}
 
 	cbt.balancesLock.Lock()
 	defer cbt.balancesLock.Unlock()
-	if v, ok := cbt.balances[id]; ok {
+	before := cbt.total(id)
+	key := newBalanceKey(id, accountantID)
+	if v, ok := cbt.balances[key]; ok {
 		isIncreased := true
 		var diff uint64
 
@@ -239,30 +270,43 @@ func (cbt *ConsumerBalanceTracker) updateBalanceFromAccountant(id identity.Ident
 			diff = safeSub(cb.Balance, v.BCBalance)
 		}
 
-		before := cbt.balances[id].CurrentEstimate
 		if isIncreased {
-			cbt.balances[id] = Balance{
+			cbt.balances[key] = Balance{
 				BCBalance:       v.BCBalance + diff,
 				CurrentEstimate: v.CurrentEstimate + diff,
 			}
 		} else {
-			cbt.balances[id] = Balance{
+			cbt.balances[key] = Balance{
 				BCBalance:       safeSub(v.BCBalance, diff),
 				CurrentEstimate: safeSub(v.CurrentEstimate, diff),
 			}
 		}
-		go cbt.publishChangeEvent(id, before, cbt.balances[id].CurrentEstimate)
 	} else {
-		cbt.balances[id] = Balance{
+		cbt.balances[key] = Balance{
 			BCBalance:       cb.Balance,
 			CurrentEstimate: cb.Balance,
 		}
-		go cbt.publishChangeEvent(id, 0, cb.Balance)
 	}
+	go cbt.publishChangeEvent(id, before, cbt.total(id))
 
 	return nil
 }
 
+// total returns the balance of the identity summed over all the channels, must be called with the lock held.
+func (cbt *ConsumerBalanceTracker) total(id identity.Identity) uint64 {
+	var result uint64
+	for key, v := range cbt.balances {
+		if key.consumer == id {
+			result += v.CurrentEstimate
+		}
+	}
+	return result
+}
+
+func newBalanceKey(id, accountantID identity.Identity) balanceKey {
+	return balanceKey{consumer: id, accountant: accountantID.ToCommonAddress()}
+}
+
 func safeSub(a, b uint64) uint64 {
 	if a >= b {
 		return a - b
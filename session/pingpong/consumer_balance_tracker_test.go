@@ -31,7 +31,11 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
-var mockMystSCaddress = common.HexToAddress("0x0")
+var (
+	mockMystSCaddress = common.HexToAddress("0x0")
+	acc1              = identity.FromAddress("0x00000000000000000000000000000000000000a1")
+	acc2              = identity.FromAddress("0x00000000000000000000000000000000000000a2")
+)
 
 const initialBalance = 100000000
 
@@ -44,14 +48,12 @@ func TestConsumerBalanceTracker(t *testing.T) {
 	bc := mockConsumerBalanceChecker{
 		amountToReturn: big.NewInt(initialBalance),
 	}
-	calc := mockChannelAddressCalculator{}
-
 	balanceFetcher := &mockAccountantBalanceFetcher{consumerData: ConsumerData{
 		Balance:  initialBalance,
 		Promised: 0,
 	}}
 
-	cbt := NewConsumerBalanceTracker(bus, mockMystSCaddress, &bc, &calc, balanceFetcher.GetConsumerData)
+	cbt := NewConsumerBalanceTracker(bus, mockMystSCaddress, []string{acc1.Address}, &bc, "0x1", "0x2", balanceFetcher.GetConsumerData)
 
 	err := cbt.Subscribe(bus)
 	assert.NoError(t, err)
@@ -79,6 +81,7 @@ func TestConsumerBalanceTracker(t *testing.T) {
 	var promised uint64 = 100
 	bus.Publish(AppTopicExchangeMessage, ExchangeMessageEventPayload{
 		Identity:       id1,
+		AccountantID:   acc1,
 		AmountPromised: promised,
 	})
 
@@ -86,6 +89,33 @@ func TestConsumerBalanceTracker(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestConsumerBalanceTracker_TracksEveryAccountant(t *testing.T) {
+	id := identity.FromAddress("0x000000001")
+	bus := eventbus.New()
+	balanceFetcher := &mockAccountantBalanceFetcher{balances: map[identity.Identity]uint64{
+		acc1: 100,
+		acc2: 200,
+	}}
+
+	cbt := NewConsumerBalanceTracker(bus, mockMystSCaddress, []string{acc1.Address, acc2.Address}, &mockConsumerBalanceChecker{}, "0x1", "0x2", balanceFetcher.GetConsumerData)
+	assert.NoError(t, cbt.Subscribe(bus))
+
+	bus.Publish(identity.AppTopicIdentityUnlock, id.Address)
+	assert.NoError(t, waitForBalance(cbt, id, 300))
+
+	bus.Publish(AppTopicExchangeMessage, ExchangeMessageEventPayload{
+		Identity:       id,
+		AccountantID:   acc2,
+		AmountPromised: 50,
+	})
+	assert.NoError(t, waitForBalance(cbt, id, 250))
+
+	cbt.balancesLock.Lock()
+	defer cbt.balancesLock.Unlock()
+	assert.Equal(t, uint64(100), cbt.balances[newBalanceKey(id, acc1)].CurrentEstimate)
+	assert.Equal(t, uint64(150), cbt.balances[newBalanceKey(id, acc2)].CurrentEstimate)
+}
+
 func waitForBalance(balanceTracker *ConsumerBalanceTracker, id identity.Identity, balance uint64) error {
 	timer := time.NewTimer(time.Millisecond)
 	for i := 0; i < 20; i++ {
@@ -103,8 +133,8 @@ func waitForBalance(balanceTracker *ConsumerBalanceTracker, id identity.Identity
 
 func TestConsumerBalanceTracker_UpdateAccountantBalance(t *testing.T) {
 	type fields struct {
-		balances                 map[identity.Identity]Balance
-		accountantBalanceFetcher func(id string) (ConsumerData, error)
+		balances                 map[balanceKey]Balance
+		accountantBalanceFetcher accountantBalanceFetcher
 	}
 	type args struct {
 		id identity.Identity
@@ -119,8 +149,8 @@ func TestConsumerBalanceTracker_UpdateAccountantBalance(t *testing.T) {
 		{
 			name: "set balance to an unknown identity",
 			fields: fields{
-				balances: make(map[identity.Identity]Balance),
-				accountantBalanceFetcher: func(id string) (ConsumerData, error) {
+				balances: make(map[balanceKey]Balance),
+				accountantBalanceFetcher: func(accountantID identity.Identity, id string) (ConsumerData, error) {
 					return ConsumerData{
 						Balance: 100,
 					}, nil
@@ -135,13 +165,13 @@ func TestConsumerBalanceTracker_UpdateAccountantBalance(t *testing.T) {
 		{
 			name: "increases balance to an known identity",
 			fields: fields{
-				balances: map[identity.Identity]Balance{
-					mockID: Balance{
+				balances: map[balanceKey]Balance{
+					newBalanceKey(mockID, acc1): Balance{
 						BCBalance:       1020,
 						CurrentEstimate: 1010,
 					},
 				},
-				accountantBalanceFetcher: func(id string) (ConsumerData, error) {
+				accountantBalanceFetcher: func(accountantID identity.Identity, id string) (ConsumerData, error) {
 					return ConsumerData{
 						Balance: 1100,
 					}, nil
@@ -156,13 +186,13 @@ func TestConsumerBalanceTracker_UpdateAccountantBalance(t *testing.T) {
 		{
 			name: "decreases balance to an known identity",
 			fields: fields{
-				balances: map[identity.Identity]Balance{
-					mockID: Balance{
+				balances: map[balanceKey]Balance{
+					newBalanceKey(mockID, acc1): Balance{
 						BCBalance:       1020,
 						CurrentEstimate: 1010,
 					},
 				},
-				accountantBalanceFetcher: func(id string) (ConsumerData, error) {
+				accountantBalanceFetcher: func(accountantID identity.Identity, id string) (ConsumerData, error) {
 					return ConsumerData{
 						Balance: 1000,
 					}, nil
@@ -177,8 +207,8 @@ func TestConsumerBalanceTracker_UpdateAccountantBalance(t *testing.T) {
 		{
 			name: "ignores errors, sets nothing",
 			fields: fields{
-				balances: make(map[identity.Identity]Balance),
-				accountantBalanceFetcher: func(id string) (ConsumerData, error) {
+				balances: make(map[balanceKey]Balance),
+				accountantBalanceFetcher: func(accountantID identity.Identity, id string) (ConsumerData, error) {
 					return ConsumerData{}, errors.New("explosions")
 				},
 			},
@@ -197,8 +227,8 @@ func TestConsumerBalanceTracker_UpdateAccountantBalance(t *testing.T) {
 				accountantBalanceFetcher: tt.fields.accountantBalanceFetcher,
 			}
 
-			cbt.updateBalanceFromAccountant(tt.args.id)
-			res := cbt.balances[tt.args.id]
+			cbt.updateBalanceFromAccountant(tt.args.id, acc1)
+			res := cbt.balances[newBalanceKey(tt.args.id, acc1)]
 			assert.Equal(t, tt.expectedBalance, res.BCBalance)
 			assert.Equal(t, tt.expectedEstimate, res.CurrentEstimate)
 		})
@@ -207,7 +237,7 @@ func TestConsumerBalanceTracker_UpdateAccountantBalance(t *testing.T) {
 
 func TestConsumerBalanceTracker_increaseBalance(t *testing.T) {
 	type fields struct {
-		balances map[identity.Identity]Balance
+		balances map[balanceKey]Balance
 	}
 	type args struct {
 		id identity.Identity
@@ -222,7 +252,7 @@ func TestConsumerBalanceTracker_increaseBalance(t *testing.T) {
 		{
 			name: "defaults to new balance",
 			fields: fields{
-				balances: make(map[identity.Identity]Balance),
+				balances: make(map[balanceKey]Balance),
 			},
 			args: args{
 				id: mockID,
@@ -233,7 +263,7 @@ func TestConsumerBalanceTracker_increaseBalance(t *testing.T) {
 		{
 			name: "adds to existing balance",
 			fields: fields{
-				balances: map[identity.Identity]Balance{mockID: Balance{
+				balances: map[balanceKey]Balance{newBalanceKey(mockID, acc1): Balance{
 					BCBalance:       100000,
 					CurrentEstimate: 100000,
 				}},
@@ -251,7 +281,7 @@ func TestConsumerBalanceTracker_increaseBalance(t *testing.T) {
 				balances:  tt.fields.balances,
 				publisher: eventbus.New(),
 			}
-			cbt.increaseBalance(tt.args.id, tt.args.b)
+			cbt.increaseBalance(tt.args.id, acc1, tt.args.b)
 			res := cbt.GetBalance(tt.args.id)
 			assert.Equal(t, tt.expectedBalance, res)
 		})
@@ -260,10 +290,14 @@ func TestConsumerBalanceTracker_increaseBalance(t *testing.T) {
 
 type mockAccountantBalanceFetcher struct {
 	consumerData ConsumerData
+	balances     map[identity.Identity]uint64
 	err          error
 }
 
-func (mabf *mockAccountantBalanceFetcher) GetConsumerData(channel string) (ConsumerData, error) {
+func (mabf *mockAccountantBalanceFetcher) GetConsumerData(accountantID identity.Identity, channel string) (ConsumerData, error) {
+	if balance, ok := mabf.balances[accountantID]; ok {
+		return ConsumerData{Balance: balance}, mabf.err
+	}
 	return mabf.consumerData, mabf.err
 }
 
@@ -38,6 +38,7 @@ const AppTopicExchangeMessage = "exchange_message_topic"
 // ExchangeMessageEventPayload represents the messages that are sent on the AppTopicExchangeMessage.
 type ExchangeMessageEventPayload struct {
 	Identity       identity.Identity
+	AccountantID   identity.Identity
 	AmountPromised uint64
 }
 
@@ -50,3 +51,15 @@ type BalanceChangedEvent struct {
 	Previous uint64
 	Current  uint64
 }
+
+// AppTopicSettlement represents the topic to which settlement results are published.
+const AppTopicSettlement = "settlement"
+
+// SettlementEventPayload represents the result of the settlement attempt.
+type SettlementEventPayload struct {
+	ProviderID   identity.Identity
+	AccountantID identity.Identity
+	// Amount settled on the blockchain, zero if the settlement failed.
+	Amount     uint64
+	Successful bool
+}
@@ -23,12 +23,15 @@ import (
 	"sync"
 	"time"
 
+	"github.com/mysteriumnetwork/node/core/ledger"
 	"github.com/mysteriumnetwork/node/eventbus"
 	"github.com/mysteriumnetwork/node/services/openvpn/discovery/dto"
+	"github.com/mysteriumnetwork/node/session"
 
 	"github.com/ethereum/go-ethereum/accounts/keystore"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/mysteriumnetwork/node/identity"
+	"github.com/mysteriumnetwork/node/market"
 	"github.com/mysteriumnetwork/payments/crypto"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog/log"
@@ -62,6 +65,10 @@ type timeTracker interface {
 	Elapsed() time.Duration
 }
 
+type spendingLimiter interface {
+	Spend(id identity.Identity, sessionSpent, amount uint64) error
+}
+
 type channelAddressCalculator interface {
 	GetChannelAddress(id identity.Identity) (common.Address, error)
 }
@@ -72,9 +79,11 @@ type ExchangeMessageTracker struct {
 	once           sync.Once
 	channelAddress identity.Identity
 	receivedFirst  bool
+	spent          uint64
 
 	lastInvoice crypto.Invoice
 	deps        ExchangeMessageTrackerDeps
+	trial       *trialTracker
 }
 
 // ExchangeMessageTrackerDeps contains all the dependencies for the exchange message tracker.
@@ -86,10 +95,19 @@ type ExchangeMessageTrackerDeps struct {
 	Ks                        *keystore.KeyStore
 	Identity, Peer            identity.Identity
 	PaymentInfo               dto.PaymentRate
+	Package                   *market.PaymentPackage
+	Trial                     *market.Trial
+	TrialStorage              trialStorage
+	DataTracker               dataTracker
 	ChannelAddressCalculator  channelAddressCalculator
 	Publisher                 eventbus.Publisher
 	AccountantAddress         identity.Identity
 	ConsumerInfoGetter        getConsumerInfo
+	SpendingLimiter           spendingLimiter
+	SessionID                 session.ID
+	ServiceType               string
+	PeerCountry               string
+	Ledger                    transactionLedger
 }
 
 // NewExchangeMessageTracker returns a new instance of exchange message tracker.
@@ -98,6 +116,7 @@ func NewExchangeMessageTracker(emtd ExchangeMessageTrackerDeps) *ExchangeMessage
 		stop:        make(chan struct{}),
 		deps:        emtd,
 		lastInvoice: crypto.Invoice{},
+		trial:       newTrialTracker(emtd.Trial, emtd.TrialStorage, emtd.Identity, emtd.Peer),
 	}
 }
 
@@ -118,6 +137,7 @@ func (emt *ExchangeMessageTracker) Start() error {
 	for {
 		select {
 		case <-emt.stop:
+			emt.updateTrial()
 			return nil
 		case invoice := <-emt.deps.InvoiceChan:
 			log.Debug().Msgf("Invoice received: %v", invoice)
@@ -177,26 +197,26 @@ func (emt *ExchangeMessageTracker) incrementGrandTotalPromised(amount uint64) er
 	return emt.deps.ConsumerTotalsStorage.Store(emt.deps.Identity.Address, emt.deps.AccountantAddress.Address, res+amount)
 }
 
-func (emt *ExchangeMessageTracker) isServiceFree() bool {
-	return emt.deps.PaymentInfo.Duration == 0 || emt.deps.PaymentInfo.Price.Amount == 0
-}
-
 func (emt *ExchangeMessageTracker) isInvoiceOK(invoice crypto.Invoice) error {
 	if strings.ToLower(invoice.Provider) != strings.ToLower(emt.deps.Peer.Address) {
 		return ErrWrongProvider
 	}
 
-	var ticksPassed float64
-	// avoid division by zero on free service
-	if !emt.isServiceFree() {
-		ticksPassed = float64(emt.deps.TimeTracker.Elapsed()) / float64(emt.deps.PaymentInfo.Duration)
-	}
-
-	shouldBe := uint64(math.Round(ticksPassed * float64(emt.deps.PaymentInfo.GetPrice().Amount)))
-
-	upperBound := uint64(math.Trunc(float64(shouldBe) * 1.05))
-	if !emt.receivedFirst {
-		upperBound = uint64(math.Trunc(float64(shouldBe) * 1.35))
+	var upperBound uint64
+	if emt.updateTrial() {
+		// nothing is charged during the free trial
+		upperBound = 0
+	} else if emt.deps.Package != nil {
+		// provider measures the usage on its own, so it may see the package used up a little earlier
+		elapsed := emt.deps.TimeTracker.Elapsed()
+		upperBound = calculatePackageAmount(*emt.deps.Package, elapsed+elapsed/20, leewayDataTracker{emt.deps.DataTracker})
+	} else {
+		shouldBe := calculatePaymentAmount(emt.deps.PaymentInfo, emt.deps.TimeTracker.Elapsed(), emt.deps.DataTracker)
+
+		upperBound = uint64(math.Trunc(float64(shouldBe) * 1.05))
+		if !emt.receivedFirst {
+			upperBound = uint64(math.Trunc(float64(shouldBe) * 1.35))
+		}
 	}
 
 	log.Debug().Msgf("Upper bound %v", upperBound)
@@ -210,6 +230,26 @@ func (emt *ExchangeMessageTracker) isInvoiceOK(invoice crypto.Invoice) error {
 	return nil
 }
 
+// updateTrial records the trial usage, returns true while the trial lasts.
+// Usage is overstated the same as when checking the invoices, so that the trial never lasts longer than provider sees it.
+func (emt *ExchangeMessageTracker) updateTrial() bool {
+	if emt.trial == nil {
+		return false
+	}
+	elapsed := emt.deps.TimeTracker.Elapsed()
+	return emt.trial.update(elapsed+elapsed/20, leewayDataTracker{emt.deps.DataTracker}.DataTransferred())
+}
+
+// leewayDataTracker reports 5% more data than transferred to compensate for the usage measured differently by the provider.
+type leewayDataTracker struct {
+	tracker dataTracker
+}
+
+func (ldt leewayDataTracker) DataTransferred() uint64 {
+	transferred := dataTransferred(ldt.tracker)
+	return transferred + transferred/20
+}
+
 func (emt *ExchangeMessageTracker) calculateAmountToPromise(invoice crypto.Invoice) (toPromise uint64, diff uint64, err error) {
 	diff = invoice.AgreementTotal - emt.lastInvoice.AgreementTotal
 	totalPromised, err := emt.getGrandTotalPromised()
@@ -233,19 +273,30 @@ func (emt *ExchangeMessageTracker) issueExchangeMessage(invoice crypto.Invoice)
 	if err != nil {
 		return errors.Wrap(err, "could not calculate amount to promise")
 	}
+	recordTransaction(emt.deps.Ledger, emt.ledgerEntry(ledger.KindInvoice, ledger.DirectionIncoming, invoice.Hashlock, diff, invoice.AgreementTotal))
 
 	msg, err := crypto.CreateExchangeMessage(invoice, amountToPromise, emt.channelAddress.Address, emt.deps.Ks, common.HexToAddress(emt.deps.Identity.Address))
 	if err != nil {
 		return errors.Wrap(err, "could not create exchange message")
 	}
 
+	if emt.deps.SpendingLimiter != nil {
+		if err := emt.deps.SpendingLimiter.Spend(emt.deps.Identity, emt.spent, diff); err != nil {
+			return errors.Wrap(err, "refused to pay for the service")
+		}
+	}
+	emt.spent += diff
+
 	err = emt.deps.PeerExchangeMessageSender.Send(*msg)
 	if err != nil {
 		log.Warn().Err(err).Msg("Failed to send exchange message")
+	} else {
+		recordTransaction(emt.deps.Ledger, emt.ledgerEntry(ledger.KindExchangeMessage, ledger.DirectionOutgoing, invoice.Hashlock, diff, msg.AgreementTotal))
 	}
 
 	defer emt.deps.Publisher.Publish(AppTopicExchangeMessage, ExchangeMessageEventPayload{
 		Identity:       emt.deps.Identity,
+		AccountantID:   emt.deps.AccountantAddress,
 		AmountPromised: diff,
 	})
 
@@ -254,6 +305,22 @@ func (emt *ExchangeMessageTracker) issueExchangeMessage(invoice crypto.Invoice)
 	return errors.Wrap(err, "could not increment grand total")
 }
 
+func (emt *ExchangeMessageTracker) ledgerEntry(kind ledger.Kind, direction ledger.Direction, hashlock string, amount, total uint64) ledger.Entry {
+	return ledger.Entry{
+		Kind:         kind,
+		Direction:    direction,
+		SessionID:    string(emt.deps.SessionID),
+		Identity:     emt.deps.Identity,
+		Counterparty: emt.deps.Peer,
+		AccountantID: emt.deps.AccountantAddress,
+		ServiceType:  emt.deps.ServiceType,
+		Country:      emt.deps.PeerCountry,
+		Amount:       amount,
+		Total:        total,
+		Hashlock:     hashlock,
+	}
+}
+
 // Stop stops the message tracker.
 func (emt *ExchangeMessageTracker) Stop() {
 	emt.once.Do(func() {
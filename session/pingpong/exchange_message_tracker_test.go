@@ -24,8 +24,11 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/mysteriumnetwork/node/consumer/budget"
+	"github.com/mysteriumnetwork/node/core/ledger"
 	"github.com/mysteriumnetwork/node/core/storage/boltdb"
 	"github.com/mysteriumnetwork/node/identity"
+	"github.com/mysteriumnetwork/node/market"
 	"github.com/mysteriumnetwork/node/money"
 	"github.com/mysteriumnetwork/node/services/openvpn/discovery/dto"
 	"github.com/mysteriumnetwork/node/session"
@@ -274,7 +277,10 @@ func TestExchangeMessageTracker_isInvoiceOK(t *testing.T) {
 		peer        identity.Identity
 		timeTracker timeTracker
 		paymentInfo dto.PaymentRate
+		pkg         *market.PaymentPackage
+		trial       *market.Trial
 	}
+	pkg := &market.PaymentPackage{Price: money.NewMoney(100000, money.CurrencyMyst), Duration: time.Hour}
 	tests := []struct {
 		name    string
 		fields  fields
@@ -331,16 +337,105 @@ func TestExchangeMessageTracker_isInvoiceOK(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "accepts package paid up front",
+			fields: fields{
+				peer: identity.FromAddress("0x441Da57A51e42DAB7Daf55909Af93A9b00eEF23C"),
+				timeTracker: &mockTimeTracker{
+					timeToReturn: time.Second,
+				},
+				pkg: pkg,
+			},
+			invoice: crypto.Invoice{
+				AgreementID:    1,
+				AgreementTotal: 100000,
+				Provider:       "0x441Da57A51e42DAB7Daf55909Af93A9b00eEF23C",
+			},
+			wantErr: false,
+		},
+		{
+			name: "accepts next package when current one is almost used up",
+			fields: fields{
+				peer: identity.FromAddress("0x441Da57A51e42DAB7Daf55909Af93A9b00eEF23C"),
+				timeTracker: &mockTimeTracker{
+					timeToReturn: 58 * time.Minute,
+				},
+				pkg: pkg,
+			},
+			invoice: crypto.Invoice{
+				AgreementID:    1,
+				AgreementTotal: 200000,
+				Provider:       "0x441Da57A51e42DAB7Daf55909Af93A9b00eEF23C",
+			},
+			wantErr: false,
+		},
+		{
+			name: "errors on next package requested too early",
+			fields: fields{
+				peer: identity.FromAddress("0x441Da57A51e42DAB7Daf55909Af93A9b00eEF23C"),
+				timeTracker: &mockTimeTracker{
+					timeToReturn: 30 * time.Minute,
+				},
+				pkg: pkg,
+			},
+			invoice: crypto.Invoice{
+				AgreementID:    1,
+				AgreementTotal: 200000,
+				Provider:       "0x441Da57A51e42DAB7Daf55909Af93A9b00eEF23C",
+			},
+			wantErr: true,
+		},
+		{
+			name: "errors on invoice during trial",
+			fields: fields{
+				peer: identity.FromAddress("0x441Da57A51e42DAB7Daf55909Af93A9b00eEF23C"),
+				timeTracker: &mockTimeTracker{
+					timeToReturn: time.Minute,
+				},
+				paymentInfo: dto.PaymentRate{
+					Duration: time.Minute,
+					Price:    money.NewMoney(100000, money.CurrencyMyst),
+				},
+				trial: &market.Trial{Duration: 10 * time.Minute},
+			},
+			invoice: crypto.Invoice{
+				AgreementID:    1,
+				AgreementTotal: 100000,
+				Provider:       "0x441Da57A51e42DAB7Daf55909Af93A9b00eEF23C",
+			},
+			wantErr: true,
+		},
+		{
+			name: "accepts invoice after trial",
+			fields: fields{
+				peer: identity.FromAddress("0x441Da57A51e42DAB7Daf55909Af93A9b00eEF23C"),
+				timeTracker: &mockTimeTracker{
+					timeToReturn: 11 * time.Minute,
+				},
+				paymentInfo: dto.PaymentRate{
+					Duration: time.Minute,
+					Price:    money.NewMoney(100000, money.CurrencyMyst),
+				},
+				trial: &market.Trial{Duration: 10 * time.Minute},
+			},
+			invoice: crypto.Invoice{
+				AgreementID:    1,
+				AgreementTotal: 100000,
+				Provider:       "0x441Da57A51e42DAB7Daf55909Af93A9b00eEF23C",
+			},
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			emt := &ExchangeMessageTracker{
-				deps: ExchangeMessageTrackerDeps{
-					TimeTracker: tt.fields.timeTracker,
-					PaymentInfo: tt.fields.paymentInfo,
-					Peer:        tt.fields.peer,
-				},
-			}
+			emt := NewExchangeMessageTracker(ExchangeMessageTrackerDeps{
+				TimeTracker:  tt.fields.timeTracker,
+				PaymentInfo:  tt.fields.paymentInfo,
+				Package:      tt.fields.pkg,
+				Trial:        tt.fields.trial,
+				TrialStorage: &mockTrialStorage{},
+				Peer:         tt.fields.peer,
+			})
 			if err := emt.isInvoiceOK(tt.invoice); (err != nil) != tt.wantErr {
 				t.Errorf("ExchangeMessageTracker.isInvoiceOK() error = %v, wantErr %v", err, tt.wantErr)
 			}
@@ -641,6 +736,127 @@ func TestExchangeMessageTracker_issueExchangeMessage_publishesEvents(t *testing.
 	}, ev.value)
 }
 
+type mockSpendingLimiter struct {
+	limit uint64
+	spent uint64
+}
+
+func (msl *mockSpendingLimiter) Spend(id identity.Identity, sessionSpent, amount uint64) error {
+	if sessionSpent+amount > msl.limit {
+		return budget.ErrLimitReached
+	}
+	msl.spent += amount
+	return nil
+}
+
+func TestExchangeMessageTracker_issueExchangeMessage_respectsSpendingLimit(t *testing.T) {
+	dir, err := ioutil.TempDir("", "TestExchangeMessageTracker_issueExchangeMessage_test")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	ks := keystore.NewKeyStore(dir, keystore.LightScryptN, keystore.LightScryptP)
+	acc, err := ks.NewAccount("")
+	assert.Nil(t, err)
+
+	err = ks.Unlock(acc, "")
+	assert.Nil(t, err)
+
+	sender := &MockPeerExchangeMessageSender{
+		chanToWriteTo: make(chan crypto.ExchangeMessage, 10),
+	}
+	limiter := &mockSpendingLimiter{limit: 20}
+	emt := &ExchangeMessageTracker{
+		deps: ExchangeMessageTrackerDeps{
+			PeerExchangeMessageSender: sender,
+			ConsumerTotalsStorage:     &mockConsumerTotalsStorage{},
+			Peer:                      identity.FromAddress("0x01"),
+			Ks:                        ks,
+			Identity:                  identity.FromAddress(acc.Address.Hex()),
+			Publisher:                 &mockPublisher{publicationChan: make(chan event, 10)},
+			SpendingLimiter:           limiter,
+		},
+	}
+
+	invoice := crypto.Invoice{
+		AgreementTotal: 15,
+		Hashlock:       "0x441Da57A51e42DAB7Daf55909Af93A9b00eEF23C",
+	}
+	assert.NoError(t, emt.issueExchangeMessage(invoice))
+	emt.lastInvoice = invoice
+	assert.Len(t, sender.chanToWriteTo, 1)
+
+	err = emt.issueExchangeMessage(crypto.Invoice{
+		AgreementTotal: 25,
+		Hashlock:       "0x441Da57A51e42DAB7Daf55909Af93A9b00eEF23C",
+	})
+	assert.Equal(t, budget.ErrLimitReached, errors.Cause(err))
+	assert.Len(t, sender.chanToWriteTo, 1)
+	assert.EqualValues(t, 15, limiter.spent)
+}
+
+type mockTransactionLedger struct {
+	entries []ledger.Entry
+}
+
+func (mtl *mockTransactionLedger) Record(entry ledger.Entry) error {
+	mtl.entries = append(mtl.entries, entry)
+	return nil
+}
+
+func TestExchangeMessageTracker_issueExchangeMessage_recordsTransactions(t *testing.T) {
+	dir, err := ioutil.TempDir("", "TestExchangeMessageTracker_issueExchangeMessage_test")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	ks := keystore.NewKeyStore(dir, keystore.LightScryptN, keystore.LightScryptP)
+	acc, err := ks.NewAccount("")
+	assert.Nil(t, err)
+
+	err = ks.Unlock(acc, "")
+	assert.Nil(t, err)
+
+	consumerID := identity.FromAddress(acc.Address.Hex())
+	peerID := identity.FromAddress("0x01")
+	accountantID := identity.FromAddress("0x02")
+	transactions := &mockTransactionLedger{}
+	sender := &MockPeerExchangeMessageSender{chanToWriteTo: make(chan crypto.ExchangeMessage, 10)}
+	emt := &ExchangeMessageTracker{
+		deps: ExchangeMessageTrackerDeps{
+			PeerExchangeMessageSender: sender,
+			ConsumerTotalsStorage:     &mockConsumerTotalsStorage{},
+			Peer:                      peerID,
+			Ks:                        ks,
+			Identity:                  consumerID,
+			AccountantAddress:         accountantID,
+			Publisher:                 &mockPublisher{publicationChan: make(chan event, 10)},
+			SessionID:                 "session1",
+			Ledger:                    transactions,
+		},
+	}
+
+	hashlock := "0x441Da57A51e42DAB7Daf55909Af93A9b00eEF23C"
+	assert.NoError(t, emt.issueExchangeMessage(crypto.Invoice{AgreementTotal: 15, Hashlock: hashlock}))
+
+	expected := ledger.Entry{
+		SessionID:    "session1",
+		Identity:     consumerID,
+		Counterparty: peerID,
+		AccountantID: accountantID,
+		Amount:       15,
+		Total:        15,
+		Hashlock:     hashlock,
+	}
+	invoice, message := expected, expected
+	invoice.Kind, invoice.Direction = ledger.KindInvoice, ledger.DirectionIncoming
+	message.Kind, message.Direction = ledger.KindExchangeMessage, ledger.DirectionOutgoing
+	assert.Equal(t, []ledger.Entry{invoice, message}, transactions.entries)
+
+	sender.mockError = errors.New("peer is gone")
+	assert.NoError(t, emt.issueExchangeMessage(crypto.Invoice{AgreementTotal: 20, Hashlock: hashlock}))
+	assert.Len(t, transactions.entries, 3)
+	assert.Equal(t, ledger.KindInvoice, transactions.entries[2].Kind)
+}
+
 func TestExchangeMessageTracker_issueExchangeMessage(t *testing.T) {
 	dir, err := ioutil.TempDir("", "TestExchangeMessageTracker_issueExchangeMessage_test")
 	assert.Nil(t, err)
@@ -52,7 +52,7 @@ func InvoiceFactoryCreator(
 	dialog communication.Dialog,
 	balanceSendPeriod, promiseTimeout time.Duration,
 	invoiceStorage providerInvoiceStorage,
-	accountantCaller accountantCaller,
+	accountantCallers accountantCallers,
 	accountantPromiseStorage accountantPromiseStorage,
 	registryAddress string,
 	channelImplementationAddress string,
@@ -63,20 +63,41 @@ func InvoiceFactoryCreator(
 	publisher eventbus.Publisher,
 	feeProvider feeProvider,
 	proposal market.ServiceProposal,
+	quoter priceQuoter,
 	settler settler,
-) func(identity.Identity, identity.Identity) (session.PaymentEngine, error) {
-	return func(providerID identity.Identity, accountantID identity.Identity) (session.PaymentEngine, error) {
+	sessionStorage sessionFinder,
+	ledger transactionLedger,
+	stateStorage invoiceTrackerStateStorage,
+	trialStorage trialStorage,
+) func(identity.Identity, identity.Identity, session.ID, *market.Price) (session.PaymentEngine, error) {
+	return func(providerID identity.Identity, accountantID identity.Identity, sessionID session.ID, agreedPrice *market.Price) (session.PaymentEngine, error) {
+		accountantCaller, err := accountantCallers.Caller(accountantID)
+		if err != nil {
+			return nil, err
+		}
+		pkg, packaged := ProposalToPaymentPackage(proposal)
+		var rate dto.PaymentRate
+		if !packaged {
+			rate, err = ProposalToPaymentRate(proposal)
+			if err != nil {
+				return nil, errors.Wrap(err, "could not parse payment rate")
+			}
+			rate, err = agreedRate(rate, proposal, quoter, agreedPrice)
+			if err != nil {
+				return nil, err
+			}
+		}
 		exchangeChan := make(chan crypto.ExchangeMessage, 1)
 		listener := NewExchangeListener(exchangeChan)
 		invoiceSender := NewInvoiceSender(dialog)
-		err := dialog.Receive(listener.GetConsumer())
+		err = dialog.Receive(listener.GetConsumer())
 		if err != nil {
 			return nil, err
 		}
 		timeTracker := session.NewTracker(time.Now)
-		rate, err := ProposalToPaymentRate(proposal)
-		if err != nil {
-			return nil, errors.Wrap(err, "could not parse payment rate")
+		consumerCountry := func() string {
+			s, _ := sessionStorage.Find(sessionID)
+			return s.ConsumerCountry
 		}
 		deps := InvoiceTrackerDeps{
 			Peer:                       dialog.PeerID(),
@@ -87,6 +108,10 @@ func InvoiceFactoryCreator(
 			ExchangeMessageChan:        exchangeChan,
 			ExchangeMessageWaitTimeout: promiseTimeout,
 			PaymentInfo:                rate,
+			Package:                    pkg,
+			Trial:                      proposal.Trial,
+			TrialStorage:               trialStorage,
+			DataTracker:                sessionDataTracker{sessions: sessionStorage, id: sessionID},
 			ProviderID:                 providerID,
 			AccountantCaller:           accountantCaller,
 			AccountantPromiseStorage:   accountantPromiseStorage,
@@ -99,6 +124,11 @@ func InvoiceFactoryCreator(
 			FeeProvider:                feeProvider,
 			MaxRRecoveryLength:         maxRRecovery,
 			Settler:                    settler,
+			SessionID:                  sessionID,
+			ServiceType:                proposal.ServiceType,
+			ConsumerCountry:            consumerCountry,
+			Ledger:                     ledger,
+			StateStorage:               stateStorage,
 			ChannelAddressCalculator:   NewChannelAddressCalculator(accountantID.Address, channelImplementationAddress, registryAddress),
 		}
 		paymentEngine := NewInvoiceTracker(deps)
@@ -106,7 +136,11 @@ func InvoiceFactoryCreator(
 	}
 }
 
+// ErrPaymentsNeedFileKeystore represents an error when consumer tries to pay while keys are held by an external signer
+var ErrPaymentsNeedFileKeystore = errors.New("payment promises can only be signed with keys held in the file keystore")
+
 // BackwardsCompatibleExchangeFactoryFunc returns a backwards compatible version of the exchange factory.
+// Keystore may be nil if keys are held by an external signer, sessions using new payments are refused then.
 func BackwardsCompatibleExchangeFactoryFunc(
 	keystore *keystore.KeyStore,
 	options node.Options,
@@ -115,12 +149,16 @@ func BackwardsCompatibleExchangeFactoryFunc(
 	channelImplementation string,
 	registryAddress string,
 	publisher eventbus.Publisher,
-	getConsumerInfo getConsumerInfo) func(paymentInfo *promise.PaymentInfo,
+	getConsumerData accountantBalanceFetcher,
+	statistics statisticsRetriever,
+	limiter spendingLimiter,
+	ledger transactionLedger,
+	trialStorage trialStorage) func(paymentInfo *promise.PaymentInfo,
 	dialog communication.Dialog,
-	consumer, provider, accountant identity.Identity, proposal market.ServiceProposal) (connection.PaymentIssuer, error) {
+	consumer, provider, accountant identity.Identity, proposal market.ServiceProposal, sessionID session.ID) (connection.PaymentIssuer, error) {
 	return func(paymentInfo *promise.PaymentInfo,
 		dialog communication.Dialog,
-		consumer, provider, accountant identity.Identity, proposal market.ServiceProposal) (connection.PaymentIssuer, error) {
+		consumer, provider, accountant identity.Identity, proposal market.ServiceProposal, sessionID session.ID) (connection.PaymentIssuer, error) {
 		var promiseState promise.PaymentInfo
 		payment := dto.PaymentRate{
 			Price: money.Money{
@@ -142,15 +180,22 @@ func BackwardsCompatibleExchangeFactoryFunc(
 		var payments connection.PaymentIssuer
 		if useNewPayments {
 			log.Info().Msg("Using new payments")
+			if keystore == nil {
+				return nil, ErrPaymentsNeedFileKeystore
+			}
 			invoices := make(chan crypto.Invoice)
 			listener := NewInvoiceListener(invoices)
 			err := dialog.Receive(listener.GetConsumer())
 			if err != nil {
 				return nil, err
 			}
-			rate, err := ProposalToPaymentRate(proposal)
-			if err != nil {
-				return nil, errors.Wrap(err, "could not parse payment rate")
+			pkg, packaged := ProposalToPaymentPackage(proposal)
+			var rate dto.PaymentRate
+			if !packaged {
+				rate, err = ProposalToPaymentRate(proposal)
+				if err != nil {
+					return nil, errors.Wrap(err, "could not parse payment rate")
+				}
 			}
 			timeTracker := session.NewTracker(time.Now)
 			deps := ExchangeMessageTrackerDeps{
@@ -162,10 +207,21 @@ func BackwardsCompatibleExchangeFactoryFunc(
 				Identity:                  consumer,
 				Peer:                      dialog.PeerID(),
 				PaymentInfo:               rate,
+				Package:                   pkg,
+				Trial:                     proposal.Trial,
+				TrialStorage:              trialStorage,
+				DataTracker:               newConsumerDataTracker(statistics),
 				ChannelAddressCalculator:  NewChannelAddressCalculator(accountant.Address, channelImplementation, registryAddress),
 				Publisher:                 publisher,
 				AccountantAddress:         accountant,
-				ConsumerInfoGetter:        getConsumerInfo,
+				ConsumerInfoGetter: func(id string) (ConsumerData, error) {
+					return getConsumerData(accountant, id)
+				},
+				SpendingLimiter: limiter,
+				SessionID:       sessionID,
+				ServiceType:     proposal.ServiceType,
+				PeerCountry:     proposal.ServiceDefinition.GetLocation().Country,
+				Ledger:          ledger,
 			}
 			payments = NewExchangeMessageTracker(deps)
 		} else {
@@ -28,10 +28,13 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/mysteriumnetwork/node/core/ledger"
 	"github.com/mysteriumnetwork/node/eventbus"
 	"github.com/mysteriumnetwork/node/identity"
 	"github.com/mysteriumnetwork/node/identity/registry"
+	"github.com/mysteriumnetwork/node/market"
 	"github.com/mysteriumnetwork/node/services/openvpn/discovery/dto"
+	"github.com/mysteriumnetwork/node/session"
 	"github.com/mysteriumnetwork/payments/crypto"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog/log"
@@ -86,6 +89,15 @@ type accountantCaller interface {
 	RevealR(r string, provider string, agreementID uint64) error
 }
 
+type accountantCallers interface {
+	Caller(accountantID identity.Identity) (accountantCaller, error)
+}
+
+type invoiceTrackerStateStorage interface {
+	Store(state InvoiceTrackerState) error
+	Delete(sessionID string) error
+}
+
 type settler func(providerID, accountantID identity.Identity) error
 
 const chargePeriodLeeway = time.Hour * 2
@@ -108,9 +120,15 @@ type InvoiceTracker struct {
 	once                           sync.Once
 	agreementID                    uint64
 	lastExchangeMessage            crypto.ExchangeMessage
+	lastExchangeMessageR           []byte
+	lastInvoice                    crypto.Invoice
+	forwarded                      bool
+	stateLock                      sync.Mutex
 	transactorFee                  registry.FeesResponse
 	invoicesSent                   map[string]sentInvoice
 	invoiceLock                    sync.Mutex
+	rate                           dto.PaymentRate
+	trial                          *trialTracker
 	deps                           InvoiceTrackerDeps
 }
 
@@ -124,6 +142,10 @@ type InvoiceTrackerDeps struct {
 	ExchangeMessageChan        chan crypto.ExchangeMessage
 	ExchangeMessageWaitTimeout time.Duration
 	PaymentInfo                dto.PaymentRate
+	Package                    *market.PaymentPackage
+	Trial                      *market.Trial
+	TrialStorage               trialStorage
+	DataTracker                dataTracker
 	ProviderID                 identity.Identity
 	AccountantID               identity.Identity
 	AccountantCaller           accountantCaller
@@ -137,6 +159,11 @@ type InvoiceTrackerDeps struct {
 	FeeProvider                feeProvider
 	ChannelAddressCalculator   channelAddressCalculator
 	Settler                    settler
+	SessionID                  session.ID
+	ServiceType                string
+	ConsumerCountry            func() string
+	Ledger                     transactionLedger
+	StateStorage               invoiceTrackerStateStorage
 }
 
 // NewInvoiceTracker creates a new instance of invoice tracker.
@@ -147,6 +174,8 @@ func NewInvoiceTracker(
 		deps:                           itd,
 		maxNotReceivedExchangeMessages: calculateMaxNotReceivedExchangeMessageCount(chargePeriodLeeway, itd.ChargePeriod),
 		invoicesSent:                   make(map[string]sentInvoice),
+		rate:                           itd.PaymentInfo,
+		trial:                          newTrialTracker(itd.Trial, itd.TrialStorage, itd.ProviderID, itd.Peer),
 	}
 }
 
@@ -206,9 +235,13 @@ func (it *InvoiceTracker) handleExchangeMessage(pm crypto.ExchangeMessage) error
 		return err
 	}
 
+	recordTransaction(it.deps.Ledger, it.ledgerEntry(ledger.KindExchangeMessage, ledger.DirectionIncoming, it.deps.Peer, invoice.invoice.Hashlock, amountSince(it.lastExchangeMessage.AgreementTotal, pm.AgreementTotal), pm.AgreementTotal))
 	it.lastExchangeMessage = pm
+	it.lastExchangeMessageR = invoice.r
+	it.forwarded = it.isServiceFree()
 	it.markInvoicePaid(pm.Promise.Hashlock)
 	it.resetNotReceivedExchangeMessageCount()
+	it.saveState()
 
 	// incase of zero payment, we'll just skip going to the accountant
 	if it.isServiceFree() {
@@ -269,6 +302,20 @@ func (it *InvoiceTracker) requestPromise(r []byte, pm crypto.ExchangeMessage) er
 
 	it.resetAccountantFailureCount()
 
+	// marked before storing the promise, so that a crash in between does not get the exchange message forwarded twice.
+	if bytes.Equal(pm.Promise.Hashlock, it.lastExchangeMessage.Promise.Hashlock) {
+		it.forwarded = true
+		it.saveState()
+	}
+
+	var previous AccountantPromise
+	if it.deps.Ledger != nil {
+		previous, err = it.deps.AccountantPromiseStorage.Get(it.deps.ProviderID, it.deps.AccountantID)
+		if err != nil && err != ErrNotFound {
+			log.Warn().Err(err).Msg("Could not get previous accountant promise")
+		}
+	}
+
 	ap := AccountantPromise{
 		Promise:     promise,
 		R:           hex.EncodeToString(r),
@@ -280,6 +327,10 @@ func (it *InvoiceTracker) requestPromise(r []byte, pm crypto.ExchangeMessage) er
 		return errors.Wrap(err, "could not store accountant promise")
 	}
 
+	entry := it.ledgerEntry(ledger.KindAccountantPromise, ledger.DirectionIncoming, it.deps.AccountantID, hex.EncodeToString(promise.Hashlock), amountSince(previous.Promise.Amount, promise.Amount), promise.Amount)
+	entry.Fee = promise.Fee
+	recordTransaction(it.deps.Ledger, entry)
+
 	promise.R = r
 	it.deps.Publisher.Publish(AppTopicAccountantPromise, AccountantPromiseEventPayload{
 		Promise:      promise,
@@ -357,6 +408,9 @@ func (it *InvoiceTracker) Start() error {
 		emErrors <- it.listenForExchangeMessages()
 	}()
 
+	// the state is no longer needed once the session closes, unless there's still something to forward
+	defer it.closeState()
+
 	// on session close, try and reveal the promise before exiting
 	defer it.revealPromise()
 
@@ -370,6 +424,7 @@ func (it *InvoiceTracker) Start() error {
 				return errors.Wrap(err, "sending first invoice failed")
 			}
 		case <-it.stop:
+			it.trial.update(it.deps.TimeTracker.Elapsed(), dataTransferred(it.deps.DataTracker))
 			return nil
 		case <-time.After(it.deps.ChargePeriod):
 			err := it.sendInvoice()
@@ -409,7 +464,17 @@ func (it *InvoiceTracker) generateR() []byte {
 }
 
 func (it *InvoiceTracker) isServiceFree() bool {
-	return it.deps.PaymentInfo.Duration == 0 || it.deps.PaymentInfo.Price.Amount == 0
+	if it.deps.Package != nil {
+		return it.deps.Package.Price.Amount == 0
+	}
+	return isFreeRate(it.rate)
+}
+
+// hasPendingInvoices checks if any of the sent invoices is still waiting for the payment.
+func (it *InvoiceTracker) hasPendingInvoices() bool {
+	it.invoiceLock.Lock()
+	defer it.invoiceLock.Unlock()
+	return len(it.invoicesSent) > 0
 }
 
 func (it *InvoiceTracker) sendInvoice() error {
@@ -417,13 +482,18 @@ func (it *InvoiceTracker) sendInvoice() error {
 		return ErrExchangeWaitTimeout
 	}
 
-	var ticksPassed float64
-	// avoid division by zero on free service
-	if !it.isServiceFree() {
-		ticksPassed = float64(it.deps.TimeTracker.Elapsed()) / float64(it.deps.PaymentInfo.Duration)
+	elapsed, tracker := it.deps.TimeTracker.Elapsed(), it.deps.DataTracker
+	if it.trial.update(elapsed, dataTransferred(tracker)) {
+		// nothing is invoiced during the free trial
+		return nil
 	}
+	elapsed, tracker = it.trial.chargeable(elapsed, tracker)
 
-	shouldBe := uint64(math.Round(ticksPassed * float64(it.deps.PaymentInfo.GetPrice().Amount)))
+	if it.deps.Package != nil {
+		return it.sendPackageInvoice(elapsed, tracker)
+	}
+
+	shouldBe := calculatePaymentAmount(it.rate, elapsed, tracker)
 
 	// In case we're sending a first invoice, there might be a big missmatch percentage wise on the consumer side.
 	// This is due to the fact that both payment providers start at different times.
@@ -434,6 +504,25 @@ func (it *InvoiceTracker) sendInvoice() error {
 		log.Debug().Msgf("Being lenient for the first payment, asking for %v", shouldBe)
 	}
 
+	return it.issueInvoice(shouldBe)
+}
+
+// sendPackageInvoice invoices the next package once the paid ones are used up.
+// Consumer pays for the package up front, so nothing is invoiced while the paid package lasts.
+func (it *InvoiceTracker) sendPackageInvoice(elapsed time.Duration, tracker dataTracker) error {
+	shouldBe := calculatePackageAmount(*it.deps.Package, elapsed, tracker)
+	if shouldBe <= it.lastExchangeMessage.AgreementTotal {
+		return nil
+	}
+	if shouldBe == it.lastInvoice.AgreementTotal && it.hasPendingInvoices() {
+		return nil
+	}
+
+	log.Debug().Msgf("Package used up, asking for %v", shouldBe)
+	return it.issueInvoice(shouldBe)
+}
+
+func (it *InvoiceTracker) issueInvoice(shouldBe uint64) error {
 	r := it.generateR()
 	invoice := crypto.CreateInvoice(it.agreementID, shouldBe, 0, r)
 	invoice.Provider = it.deps.ProviderID.Address
@@ -442,10 +531,14 @@ func (it *InvoiceTracker) sendInvoice() error {
 		return err
 	}
 
+	recordTransaction(it.deps.Ledger, it.ledgerEntry(ledger.KindInvoice, ledger.DirectionOutgoing, it.deps.Peer, invoice.Hashlock, amountSince(it.lastExchangeMessage.AgreementTotal, invoice.AgreementTotal), invoice.AgreementTotal))
+
+	it.lastInvoice = invoice
 	it.markInvoiceSent(sentInvoice{
 		invoice: invoice,
 		r:       r,
 	})
+	it.saveState()
 
 	hlock, err := hex.DecodeString(invoice.Hashlock)
 	if err != nil {
@@ -458,6 +551,75 @@ func (it *InvoiceTracker) sendInvoice() error {
 	return errors.Wrap(err, "could not store invoice")
 }
 
+// saveState persists the tracker state, so that it can be recovered if the node stops mid-session.
+func (it *InvoiceTracker) saveState() {
+	if it.deps.StateStorage == nil {
+		return
+	}
+
+	it.stateLock.Lock()
+	defer it.stateLock.Unlock()
+
+	state := InvoiceTrackerState{
+		SessionID:            string(it.deps.SessionID),
+		ProviderID:           it.deps.ProviderID,
+		ConsumerID:           it.deps.Peer,
+		AccountantID:         it.deps.AccountantID,
+		ServiceType:          it.deps.ServiceType,
+		ConsumerCountry:      it.consumerCountry(),
+		AgreementID:          it.agreementID,
+		LastInvoice:          it.lastInvoice,
+		LastExchangeMessage:  it.lastExchangeMessage,
+		LastExchangeMessageR: hex.EncodeToString(it.lastExchangeMessageR),
+		Forwarded:            it.forwarded,
+	}
+	if err := it.deps.StateStorage.Store(state); err != nil {
+		log.Warn().Err(err).Msgf("Could not store invoice tracker state for session %v", it.deps.SessionID)
+	}
+}
+
+// closeState removes the persisted state of a finished session.
+// The state of a session whose last exchange message did not reach the accountant is kept for the recovery.
+func (it *InvoiceTracker) closeState() {
+	if it.deps.StateStorage == nil {
+		return
+	}
+
+	it.stateLock.Lock()
+	defer it.stateLock.Unlock()
+
+	if it.lastExchangeMessage.AgreementTotal > 0 && !it.forwarded {
+		log.Warn().Msgf("Last exchange message of session %v was not forwarded to accountant, keeping it for recovery", it.deps.SessionID)
+		return
+	}
+	if err := it.deps.StateStorage.Delete(string(it.deps.SessionID)); err != nil {
+		log.Warn().Err(err).Msgf("Could not delete invoice tracker state for session %v", it.deps.SessionID)
+	}
+}
+
+func (it *InvoiceTracker) ledgerEntry(kind ledger.Kind, direction ledger.Direction, counterparty identity.Identity, hashlock string, amount, total uint64) ledger.Entry {
+	return ledger.Entry{
+		Kind:         kind,
+		Direction:    direction,
+		SessionID:    string(it.deps.SessionID),
+		Identity:     it.deps.ProviderID,
+		Counterparty: counterparty,
+		AccountantID: it.deps.AccountantID,
+		ServiceType:  it.deps.ServiceType,
+		Country:      it.consumerCountry(),
+		Amount:       amount,
+		Total:        total,
+		Hashlock:     hashlock,
+	}
+}
+
+func (it *InvoiceTracker) consumerCountry() string {
+	if it.deps.ConsumerCountry == nil {
+		return ""
+	}
+	return it.deps.ConsumerCountry()
+}
+
 func (it *InvoiceTracker) waitForInvoicePayment(hlock []byte) {
 	select {
 	case <-time.After(it.deps.ExchangeMessageWaitTimeout):
@@ -0,0 +1,146 @@
+/*
+ * Copyright (C) 2020 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package pingpong
+
+import (
+	"encoding/hex"
+
+	"github.com/mysteriumnetwork/node/eventbus"
+	"github.com/mysteriumnetwork/node/session"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+// InvoiceTrackerRecovery closes the invoice trackers that were in flight when the node stopped.
+// Sessions do not survive a restart, so instead of resuming the tracking, the recovery forwards
+// the last exchange message to the accountant if it did not get there yet, reveals the R and forgets the state.
+type InvoiceTrackerRecovery struct {
+	states                   invoiceTrackerStateStorage
+	invoiceStorage           providerInvoiceStorage
+	accountantCallers        accountantCallers
+	accountantPromiseStorage accountantPromiseStorage
+	feeProvider              feeProvider
+	publisher                eventbus.Publisher
+	settler                  settler
+	ledger                   transactionLedger
+}
+
+// NewInvoiceTrackerRecovery returns a new instance of the invoice tracker recovery.
+func NewInvoiceTrackerRecovery(
+	states invoiceTrackerStateStorage,
+	invoiceStorage providerInvoiceStorage,
+	accountantCallers accountantCallers,
+	accountantPromiseStorage accountantPromiseStorage,
+	feeProvider feeProvider,
+	publisher eventbus.Publisher,
+	settler settler,
+	ledger transactionLedger,
+) *InvoiceTrackerRecovery {
+	return &InvoiceTrackerRecovery{
+		states:                   states,
+		invoiceStorage:           invoiceStorage,
+		accountantCallers:        accountantCallers,
+		accountantPromiseStorage: accountantPromiseStorage,
+		feeProvider:              feeProvider,
+		publisher:                publisher,
+		settler:                  settler,
+		ledger:                   ledger,
+	}
+}
+
+// Recover closes the given invoice tracker states.
+// States that fail to recover are kept, so that the recovery is retried on the next start.
+func (itr *InvoiceTrackerRecovery) Recover(states []InvoiceTrackerState) {
+	for _, state := range states {
+		if err := itr.recover(state); err != nil {
+			log.Error().Err(err).Msgf("Could not recover invoice tracker of session %v", state.SessionID)
+			continue
+		}
+
+		if err := itr.states.Delete(state.SessionID); err != nil {
+			log.Error().Err(err).Msgf("Could not delete invoice tracker state of session %v", state.SessionID)
+			continue
+		}
+		log.Info().Msgf("Recovered invoice tracker of session %v", state.SessionID)
+	}
+}
+
+func (itr *InvoiceTrackerRecovery) recover(state InvoiceTrackerState) error {
+	caller, err := itr.accountantCallers.Caller(state.AccountantID)
+	if err != nil {
+		return err
+	}
+	r, err := hex.DecodeString(state.LastExchangeMessageR)
+	if err != nil {
+		return errors.Wrap(err, "could not decode r")
+	}
+	tracker := itr.trackerFor(state, r, caller)
+
+	// the forwarded flag is kept per session, as the stored accountant promise
+	// gets overwritten by the other sessions of the same provider.
+	if state.needsForwarding() {
+		if err := itr.forward(tracker, state, r); err != nil {
+			return err
+		}
+	}
+
+	return errors.Wrap(tracker.revealPromise(), "could not reveal promise")
+}
+
+func (itr *InvoiceTrackerRecovery) forward(tracker *InvoiceTracker, state InvoiceTrackerState, r []byte) error {
+	if err := tracker.revealPromise(); err != nil {
+		return errors.Wrap(err, "could not reveal previous promise")
+	}
+
+	err := itr.invoiceStorage.StoreR(state.ProviderID, state.AgreementID, state.LastExchangeMessageR)
+	if err != nil {
+		return errors.Wrap(err, "could not store r")
+	}
+
+	return errors.Wrap(tracker.requestPromise(r, state.LastExchangeMessage), "could not forward exchange message")
+}
+
+// trackerFor rebuilds an invoice tracker from the state, capable of talking to the accountant only.
+// The accountant failures are not retried, as the recovery gets retried on the next start anyway.
+func (itr *InvoiceTrackerRecovery) trackerFor(state InvoiceTrackerState, r []byte, caller accountantCaller) *InvoiceTracker {
+	return &InvoiceTracker{
+		stop:                 make(chan struct{}),
+		agreementID:          state.AgreementID,
+		lastExchangeMessage:  state.LastExchangeMessage,
+		lastExchangeMessageR: r,
+		lastInvoice:          state.LastInvoice,
+		forwarded:            state.Forwarded,
+		invoicesSent:         make(map[string]sentInvoice),
+		deps: InvoiceTrackerDeps{
+			Peer:                     state.ConsumerID,
+			InvoiceStorage:           itr.invoiceStorage,
+			ProviderID:               state.ProviderID,
+			AccountantID:             state.AccountantID,
+			AccountantCaller:         caller,
+			AccountantPromiseStorage: itr.accountantPromiseStorage,
+			Publisher:                itr.publisher,
+			FeeProvider:              itr.feeProvider,
+			Settler:                  itr.settler,
+			SessionID:                session.ID(state.SessionID),
+			ServiceType:              state.ServiceType,
+			ConsumerCountry:          func() string { return state.ConsumerCountry },
+			Ledger:                   itr.ledger,
+			StateStorage:             itr.states,
+		},
+	}
+}
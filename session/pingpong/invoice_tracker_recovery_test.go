@@ -0,0 +1,180 @@
+/*
+ * Copyright (C) 2020 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package pingpong
+
+import (
+	"encoding/hex"
+	"errors"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/mysteriumnetwork/node/core/storage/boltdb"
+	"github.com/mysteriumnetwork/node/identity"
+	"github.com/mysteriumnetwork/payments/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+type recordingAccountantCaller struct {
+	requested []RequestPromise
+	revealed  []string
+	err       error
+	revealErr error
+}
+
+func (rac *recordingAccountantCaller) RequestPromise(rp RequestPromise) (crypto.Promise, error) {
+	if rac.err != nil {
+		return crypto.Promise{}, rac.err
+	}
+	rac.requested = append(rac.requested, rp)
+	return crypto.Promise{Hashlock: rp.ExchangeMessage.Promise.Hashlock, Amount: rp.ExchangeMessage.AgreementTotal}, nil
+}
+
+func (rac *recordingAccountantCaller) RevealR(r string, provider string, agreementID uint64) error {
+	if rac.err != nil {
+		return rac.err
+	}
+	if rac.revealErr != nil {
+		return rac.revealErr
+	}
+	rac.revealed = append(rac.revealed, r)
+	return nil
+}
+
+type mockAccountantCallers struct {
+	caller accountantCaller
+}
+
+func (mac *mockAccountantCallers) Caller(accountantID identity.Identity) (accountantCaller, error) {
+	return mac.caller, nil
+}
+
+func TestInvoiceTrackerStateStorage(t *testing.T) {
+	dir, err := ioutil.TempDir("", "invoiceTrackerStateStorageTest")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	bolt, err := boltdb.NewStorage(dir)
+	assert.NoError(t, err)
+	defer bolt.Close()
+
+	storage := NewInvoiceTrackerStateStorage(bolt)
+
+	states, err := storage.List()
+	assert.NoError(t, err)
+	assert.Len(t, states, 0)
+
+	state := InvoiceTrackerState{
+		SessionID:   "session",
+		ProviderID:  identity.FromAddress("0x1"),
+		AgreementID: 1,
+	}
+	assert.NoError(t, storage.Store(state))
+	state.AgreementID = 2
+	assert.NoError(t, storage.Store(state))
+
+	states, err = storage.List()
+	assert.NoError(t, err)
+	assert.Equal(t, []InvoiceTrackerState{state}, states)
+
+	assert.NoError(t, storage.Delete("session"))
+	assert.NoError(t, storage.Delete("session"))
+	states, err = storage.List()
+	assert.NoError(t, err)
+	assert.Len(t, states, 0)
+}
+
+func TestInvoiceTrackerRecovery_Recover(t *testing.T) {
+	dir, err := ioutil.TempDir("", "invoiceTrackerRecoveryTest")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	bolt, err := boltdb.NewStorage(dir)
+	assert.NoError(t, err)
+	defer bolt.Close()
+
+	provider := identity.FromAddress("0x44440954558C5bFA0D4153B0002B1d1E3E3f5Ff5")
+	accountant := identity.FromAddress(mockAccountantAddress)
+	r := []byte("some r")
+	unforwarded := InvoiceTrackerState{
+		SessionID:            "unforwarded",
+		ProviderID:           provider,
+		ConsumerID:           identity.FromAddress("0x2"),
+		AccountantID:         accountant,
+		AgreementID:          1,
+		LastExchangeMessage:  crypto.ExchangeMessage{AgreementTotal: 100, Promise: crypto.Promise{Hashlock: []byte("hashlock")}},
+		LastExchangeMessageR: hex.EncodeToString(r),
+	}
+
+	states := NewInvoiceTrackerStateStorage(bolt)
+	invoices := NewProviderInvoiceStorage(NewInvoiceStorage(bolt))
+	promises := NewAccountantPromiseStorage(bolt)
+	publisher := &mockPublisher{publicationChan: make(chan event, 1)}
+	caller := &recordingAccountantCaller{err: errors.New("accountant unavailable")}
+	recovery := NewInvoiceTrackerRecovery(states, invoices, &mockAccountantCallers{caller: caller}, promises, &mockTransactor{}, publisher, nil, nil)
+	assert.NoError(t, states.Store(unforwarded))
+
+	// failed recovery keeps the state for the next start
+	recovery.Recover([]InvoiceTrackerState{unforwarded})
+	kept, err := states.List()
+	assert.NoError(t, err)
+	assert.Len(t, kept, 1)
+
+	// forwards the exchange message, but fails to reveal its R
+	caller.err = nil
+	caller.revealErr = errors.New("reveal failed")
+	recovery.Recover(kept)
+	assert.Len(t, caller.requested, 1)
+	assert.Equal(t, unforwarded.LastExchangeMessage, caller.requested[0].ExchangeMessage)
+	assert.Equal(t, AppTopicAccountantPromise, (<-publisher.publicationChan).name)
+
+	promise, err := promises.Get(provider, accountant)
+	assert.NoError(t, err)
+	assert.False(t, promise.Revealed)
+	assert.Equal(t, hex.EncodeToString(r), promise.R)
+
+	storedR, err := invoices.GetR(provider, unforwarded.AgreementID)
+	assert.NoError(t, err)
+	assert.Equal(t, hex.EncodeToString(r), storedR)
+
+	kept, err = states.List()
+	assert.NoError(t, err)
+	assert.Len(t, kept, 1)
+	assert.True(t, kept[0].Forwarded)
+	assert.Equal(t, unforwarded.LastExchangeMessageR, kept[0].LastExchangeMessageR)
+
+	// reveals the R without forwarding the exchange message again
+	caller.revealErr = nil
+	recovery.Recover(kept)
+	assert.Len(t, caller.requested, 1)
+	assert.Equal(t, []string{hex.EncodeToString(r)}, caller.revealed)
+
+	promise, err = promises.Get(provider, accountant)
+	assert.NoError(t, err)
+	assert.True(t, promise.Revealed)
+
+	left, err := states.List()
+	assert.NoError(t, err)
+	assert.Len(t, left, 0)
+
+	// does not forward the exchange message again, even if another session overwrote the stored promise
+	assert.NoError(t, promises.Store(provider, accountant, AccountantPromise{AgreementID: 2, R: "abcd", Revealed: true}))
+	recovery.Recover(kept)
+	assert.Len(t, caller.requested, 1)
+	assert.Len(t, caller.revealed, 1)
+}
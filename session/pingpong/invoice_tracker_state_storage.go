@@ -0,0 +1,102 @@
+/*
+ * Copyright (C) 2020 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package pingpong
+
+import (
+	"sync"
+
+	"github.com/mysteriumnetwork/node/identity"
+	"github.com/mysteriumnetwork/payments/crypto"
+	"github.com/pkg/errors"
+)
+
+const invoiceTrackerStateBucketName = "invoice_tracker_states"
+
+// InvoiceTrackerState represents the persisted state of an in-flight invoice tracker.
+type InvoiceTrackerState struct {
+	SessionID           string `storm:"id"`
+	ProviderID          identity.Identity
+	ConsumerID          identity.Identity
+	AccountantID        identity.Identity
+	ServiceType         string
+	ConsumerCountry     string
+	AgreementID         uint64
+	LastInvoice         crypto.Invoice
+	LastExchangeMessage crypto.ExchangeMessage
+	// LastExchangeMessageR is the R of the invoice the last exchange message pays for.
+	LastExchangeMessageR string
+	// Forwarded indicates that the accountant already issued a promise for the last exchange message.
+	Forwarded bool
+}
+
+// needsForwarding returns true if there's an exchange message that did not reach the accountant yet.
+func (s InvoiceTrackerState) needsForwarding() bool {
+	return s.LastExchangeMessage.AgreementTotal > 0 && !s.Forwarded
+}
+
+type invoiceTrackerStateBolt interface {
+	Store(bucket string, data interface{}) error
+	GetAllFrom(bucket string, data interface{}) error
+	Delete(bucket string, data interface{}) error
+}
+
+// InvoiceTrackerStateStorage allows for storing of the invoice tracker states.
+type InvoiceTrackerStateStorage struct {
+	lock sync.Mutex
+	bolt invoiceTrackerStateBolt
+}
+
+// NewInvoiceTrackerStateStorage returns a new instance of the invoice tracker state storage.
+func NewInvoiceTrackerStateStorage(bolt invoiceTrackerStateBolt) *InvoiceTrackerStateStorage {
+	return &InvoiceTrackerStateStorage{
+		bolt: bolt,
+	}
+}
+
+// Store stores the given state, replacing the previous state of the same session.
+func (itss *InvoiceTrackerStateStorage) Store(state InvoiceTrackerState) error {
+	itss.lock.Lock()
+	defer itss.lock.Unlock()
+
+	return errors.Wrap(itss.bolt.Store(invoiceTrackerStateBucketName, &state), "could not store invoice tracker state")
+}
+
+// List returns all the stored states.
+func (itss *InvoiceTrackerStateStorage) List() ([]InvoiceTrackerState, error) {
+	itss.lock.Lock()
+	defer itss.lock.Unlock()
+
+	var states []InvoiceTrackerState
+	err := itss.bolt.GetAllFrom(invoiceTrackerStateBucketName, &states)
+	if err != nil && err.Error() != errBoltNotFound {
+		return nil, errors.Wrap(err, "could not get invoice tracker states")
+	}
+	return states, nil
+}
+
+// Delete removes the state of the given session.
+func (itss *InvoiceTrackerStateStorage) Delete(sessionID string) error {
+	itss.lock.Lock()
+	defer itss.lock.Unlock()
+
+	err := itss.bolt.Delete(invoiceTrackerStateBucketName, &InvoiceTrackerState{SessionID: sessionID})
+	if err != nil && err.Error() != errBoltNotFound {
+		return errors.Wrap(err, "could not delete invoice tracker state")
+	}
+	return nil
+}
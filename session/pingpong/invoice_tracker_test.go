@@ -30,6 +30,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/mysteriumnetwork/node/core/storage/boltdb"
 	"github.com/mysteriumnetwork/node/identity"
+	"github.com/mysteriumnetwork/node/market"
 	"github.com/mysteriumnetwork/node/money"
 	"github.com/mysteriumnetwork/node/services/openvpn/discovery/dto"
 	"github.com/mysteriumnetwork/node/session"
@@ -480,6 +481,33 @@ func Test_InvoiceTracker_FreeServiceSendsInvoices(t *testing.T) {
 	assert.NoError(t, <-errChan)
 }
 
+func Test_InvoiceTracker_DoesNotInvoicePaidPackage(t *testing.T) {
+	pkg := &market.PaymentPackage{Price: money.NewMoney(100, money.CurrencyMyst), Duration: time.Hour}
+	invoiceTracker := NewInvoiceTracker(InvoiceTrackerDeps{
+		Package:     pkg,
+		TimeTracker: &mockTimeTracker{timeToReturn: 30 * time.Minute},
+	})
+	invoiceTracker.lastExchangeMessage = crypto.ExchangeMessage{AgreementTotal: 100}
+
+	assert.False(t, invoiceTracker.isServiceFree())
+	assert.NoError(t, invoiceTracker.sendInvoice())
+	assert.Equal(t, uint64(0), invoiceTracker.lastInvoice.AgreementTotal)
+}
+
+func Test_InvoiceTracker_DoesNotInvoiceDuringTrial(t *testing.T) {
+	storage := &mockTrialStorage{}
+	invoiceTracker := NewInvoiceTracker(InvoiceTrackerDeps{
+		PaymentInfo:  dto.PaymentRate{Price: money.NewMoney(10, money.CurrencyMyst), Duration: time.Minute},
+		Trial:        &market.Trial{Duration: 10 * time.Minute},
+		TrialStorage: storage,
+		TimeTracker:  &mockTimeTracker{timeToReturn: 5 * time.Minute},
+	})
+
+	assert.NoError(t, invoiceTracker.sendInvoice())
+	assert.Equal(t, uint64(0), invoiceTracker.lastInvoice.AgreementTotal)
+	assert.Equal(t, TrialUsage{Duration: 5 * time.Minute}, storage.usage)
+}
+
 func Test_calculateMaxNotReceivedExchangeMessageCount(t *testing.T) {
 	res := calculateMaxNotReceivedExchangeMessageCount(time.Minute*5, time.Second*240)
 	assert.Equal(t, uint64(1), res)
@@ -628,6 +656,56 @@ func TestInvoiceTracker_receiveExchangeMessageOrTimeout(t *testing.T) {
 	}
 }
 
+func TestInvoiceTracker_handleExchangeMessage_persistsState(t *testing.T) {
+	dir, err := ioutil.TempDir("", "invoice_tracker_test")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	bolt, err := boltdb.NewStorage(dir)
+	assert.Nil(t, err)
+	defer bolt.Close()
+
+	msg, addr := generateExchangeMessage(t, 10, crypto.Invoice{AgreementTotal: 10, Hashlock: "0x441Da57A51e42DAB7Daf55909Af93A9b00eEF23C"}, "")
+	hashlock := hex.EncodeToString(msg.Promise.Hashlock)
+	states := NewInvoiceTrackerStateStorage(bolt)
+	it := &InvoiceTracker{
+		agreementID: 1,
+		rate:        DefaultPaymentInfo,
+		deps: InvoiceTrackerDeps{
+			Peer:                     identity.FromAddress(addr),
+			AccountantPromiseStorage: &mockAccountantPromiseStorage{},
+			AccountantID:             identity.FromAddress(mockAccountantAddress),
+			AccountantCaller:         &mockAccountantCaller{},
+			Publisher:                &mockPublisher{},
+			FeeProvider:              &mockTransactor{},
+			InvoiceStorage:           NewProviderInvoiceStorage(NewInvoiceStorage(bolt)),
+			ChannelAddressCalculator: NewChannelAddressCalculator(mockAccountantAddress, mockChannelImplementation, mockRegistryAddress),
+			SessionID:                "session",
+			StateStorage:             states,
+		},
+		invoicesSent: map[string]sentInvoice{
+			hashlock: {invoice: crypto.Invoice{Hashlock: hashlock}, r: []byte("r")},
+		},
+	}
+
+	err = it.handleExchangeMessage(msg)
+	assert.NoError(t, err)
+
+	stored, err := states.List()
+	assert.NoError(t, err)
+	assert.Len(t, stored, 1)
+	assert.Equal(t, "session", stored[0].SessionID)
+	assert.Equal(t, uint64(1), stored[0].AgreementID)
+	assert.Equal(t, msg, stored[0].LastExchangeMessage)
+	assert.Equal(t, hex.EncodeToString([]byte("r")), stored[0].LastExchangeMessageR)
+	assert.True(t, stored[0].Forwarded)
+
+	it.closeState()
+	stored, err = states.List()
+	assert.NoError(t, err)
+	assert.Len(t, stored, 0)
+}
+
 type mockAccountantPromiseStorage struct {
 }
 
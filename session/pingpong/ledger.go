@@ -0,0 +1,45 @@
+/*
+ * Copyright (C) 2020 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package pingpong
+
+import (
+	"github.com/mysteriumnetwork/node/core/ledger"
+	"github.com/rs/zerolog/log"
+)
+
+type transactionLedger interface {
+	Record(entry ledger.Entry) error
+}
+
+// recordTransaction appends the entry to the ledger, failing to record never interrupts the payment flow.
+func recordTransaction(l transactionLedger, entry ledger.Entry) {
+	if l == nil {
+		return
+	}
+	if err := l.Record(entry); err != nil {
+		log.Warn().Err(err).Msgf("Could not record %s in the ledger", entry.Kind)
+	}
+}
+
+// amountSince returns the difference between the cumulative totals, or zero if the total did not grow.
+func amountSince(previous, current uint64) uint64 {
+	if current < previous {
+		return 0
+	}
+	return current - previous
+}
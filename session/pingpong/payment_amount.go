@@ -0,0 +1,114 @@
+/*
+ * Copyright (C) 2020 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package pingpong
+
+import (
+	"math"
+	"time"
+
+	"github.com/mysteriumnetwork/node/consumer"
+	"github.com/mysteriumnetwork/node/core/service/pricing"
+	"github.com/mysteriumnetwork/node/datasize"
+	"github.com/mysteriumnetwork/node/market"
+	"github.com/mysteriumnetwork/node/services/openvpn/discovery/dto"
+	"github.com/mysteriumnetwork/node/session"
+)
+
+type priceQuoter func() pricing.Quote
+
+type dataTracker interface {
+	// DataTransferred returns the amount of bytes transferred during the session
+	DataTransferred() uint64
+}
+
+// isFreeRate checks if nothing is charged at the given payment rate.
+func isFreeRate(rate dto.PaymentRate) bool {
+	return (rate.Duration == 0 || rate.Price.Amount == 0) && rate.GetPricePerGB().Amount == 0
+}
+
+// calculatePaymentAmount returns the amount charged at the given payment rate for the given service usage.
+func calculatePaymentAmount(rate dto.PaymentRate, elapsed time.Duration, tracker dataTracker) uint64 {
+	var amount float64
+	// avoid division by zero on service charged by data only
+	if rate.Duration != 0 {
+		amount += float64(elapsed) / float64(rate.Duration) * float64(rate.GetPrice().Amount)
+	}
+
+	if perGB := rate.GetPricePerGB().Amount; perGB > 0 && tracker != nil {
+		transferred := datasize.BitSize(tracker.DataTransferred()) * datasize.Byte
+		amount += transferred.Gigabytes() * float64(perGB)
+	}
+
+	return uint64(math.Round(amount))
+}
+
+// calculatePackageAmount returns the amount charged for the packages used up by the given service usage,
+// including the package currently in use, which is paid up front.
+func calculatePackageAmount(pkg market.PaymentPackage, elapsed time.Duration, tracker dataTracker) uint64 {
+	return (pkg.PackagesUsed(elapsed, dataTransferred(tracker)) + 1) * pkg.Price.Amount
+}
+
+type sessionFinder interface {
+	Find(id session.ID) (session.Session, bool)
+}
+
+// sessionDataTracker reports data transferred during the provider session.
+type sessionDataTracker struct {
+	sessions sessionFinder
+	id       session.ID
+}
+
+// DataTransferred returns the amount of bytes transferred during the provider session.
+func (sdt sessionDataTracker) DataTransferred() uint64 {
+	s, ok := sdt.sessions.Find(sdt.id)
+	if !ok {
+		return 0
+	}
+	return s.DataTransfered.Up + s.DataTransfered.Down
+}
+
+type statisticsRetriever interface {
+	Retrieve() consumer.SessionStatistics
+}
+
+// consumerDataTracker reports data transferred by the consumer since the tracker was created.
+type consumerDataTracker struct {
+	statistics statisticsRetriever
+	initial    uint64
+}
+
+func newConsumerDataTracker(statistics statisticsRetriever) *consumerDataTracker {
+	cdt := &consumerDataTracker{statistics: statistics}
+	cdt.initial = cdt.total()
+	return cdt
+}
+
+// DataTransferred returns the amount of bytes transferred by the consumer since the tracker was created.
+func (cdt *consumerDataTracker) DataTransferred() uint64 {
+	total := cdt.total()
+	if total < cdt.initial {
+		// statistics were reset
+		return total
+	}
+	return total - cdt.initial
+}
+
+func (cdt *consumerDataTracker) total() uint64 {
+	stats := cdt.statistics.Retrieve()
+	return stats.BytesSent + stats.BytesReceived
+}
@@ -0,0 +1,94 @@
+/*
+ * Copyright (C) 2020 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package pingpong
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mysteriumnetwork/node/market"
+	"github.com/mysteriumnetwork/node/money"
+	"github.com/mysteriumnetwork/node/services/openvpn/discovery/dto"
+	"github.com/stretchr/testify/assert"
+)
+
+type fixedDataTracker uint64
+
+func (fdt fixedDataTracker) DataTransferred() uint64 {
+	return uint64(fdt)
+}
+
+func Test_calculatePaymentAmount(t *testing.T) {
+	perGB := money.NewMoney(1000, money.CurrencyMyst)
+	tests := []struct {
+		name    string
+		rate    dto.PaymentRate
+		elapsed time.Duration
+		tracker dataTracker
+		want    uint64
+	}{
+		{
+			name:    "charges for time",
+			rate:    dto.PaymentRate{Price: money.NewMoney(10, money.CurrencyMyst), Duration: time.Minute},
+			elapsed: 90 * time.Second,
+			want:    15,
+		},
+		{
+			name:    "charges for data",
+			rate:    dto.PaymentRate{PricePerGB: &perGB},
+			elapsed: time.Hour,
+			tracker: fixedDataTracker(512 * 1024 * 1024),
+			want:    500,
+		},
+		{
+			name:    "charges for time and data",
+			rate:    dto.PaymentRate{Price: money.NewMoney(10, money.CurrencyMyst), Duration: time.Minute, PricePerGB: &perGB},
+			elapsed: time.Minute,
+			tracker: fixedDataTracker(1024 * 1024 * 1024),
+			want:    1010,
+		},
+		{
+			name:    "ignores data without tracker",
+			rate:    dto.PaymentRate{PricePerGB: &perGB},
+			elapsed: time.Minute,
+			want:    0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, calculatePaymentAmount(tt.rate, tt.elapsed, tt.tracker))
+		})
+	}
+}
+
+func Test_calculatePackageAmount(t *testing.T) {
+	pkg := market.PaymentPackage{Price: money.NewMoney(100, money.CurrencyMyst), Duration: time.Hour, Bytes: 1024}
+
+	assert.Equal(t, uint64(100), calculatePackageAmount(pkg, 0, nil))
+	assert.Equal(t, uint64(100), calculatePackageAmount(pkg, 59*time.Minute, fixedDataTracker(1023)))
+	assert.Equal(t, uint64(200), calculatePackageAmount(pkg, time.Hour, fixedDataTracker(10)))
+	assert.Equal(t, uint64(300), calculatePackageAmount(pkg, time.Minute, fixedDataTracker(2048)))
+}
+
+func Test_isFreeRate(t *testing.T) {
+	perGB := money.NewMoney(1000, money.CurrencyMyst)
+	assert.True(t, isFreeRate(dto.PaymentRate{}))
+	assert.True(t, isFreeRate(dto.PaymentRate{Duration: time.Minute}))
+	assert.False(t, isFreeRate(dto.PaymentRate{Price: money.NewMoney(10, money.CurrencyMyst), Duration: time.Minute}))
+	assert.False(t, isFreeRate(dto.PaymentRate{PricePerGB: &perGB}))
+}
@@ -20,10 +20,15 @@ package pingpong
 import (
 	"fmt"
 
+	"github.com/mysteriumnetwork/node/core/service/pricing"
 	"github.com/mysteriumnetwork/node/market"
 	"github.com/mysteriumnetwork/node/services/openvpn/discovery/dto"
+	"github.com/pkg/errors"
 )
 
+// ErrPriceChanged indicates that provider no longer charges the price consumer agreed to.
+var ErrPriceChanged = errors.New("price changed, proposal needs to be refreshed")
+
 // ProposalToPaymentRate parses the proposal and converts it to payment method
 func ProposalToPaymentRate(proposal market.ServiceProposal) (dto.PaymentRate, error) {
 	switch proposal.PaymentMethod.GetType() {
@@ -36,11 +41,53 @@ func ProposalToPaymentRate(proposal market.ServiceProposal) (dto.PaymentRate, er
 		if time == 0 {
 			return dto.PaymentRate{}, fmt.Errorf("unsupported payment per time %q", time)
 		}
-		return dto.PaymentRate{
+		rate := dto.PaymentRate{
 			Price:    proposal.PaymentMethod.GetPrice(),
 			Duration: proposal.PaymentMethod.GetRate().PerTime,
-		}, nil
+		}
+		if priced, ok := proposal.PaymentMethod.(market.PricedPaymentMethod); ok && priced.GetPricePerGB().Amount > 0 {
+			perGB := priced.GetPricePerGB()
+			rate.PricePerGB = &perGB
+		}
+		return rate, nil
 	default:
 		return dto.PaymentRate{}, fmt.Errorf("unsupported payment method %q", proposal.PaymentMethod.GetType())
 	}
 }
+
+// ProposalToPaymentPackage returns the prepaid package the proposal charges in, if it charges in packages.
+func ProposalToPaymentPackage(proposal market.ServiceProposal) (*market.PaymentPackage, bool) {
+	pkg, ok := proposal.PaymentMethod.(market.PaymentPackage)
+	if !ok {
+		return nil, false
+	}
+	return &pkg, true
+}
+
+// agreedRate returns the rate session is charged at during its whole lifetime.
+// Consumer agrees to the price of the proposal it connects to, session is rejected if provider charges a different price by then.
+// Dynamically priced session keeps the price quoted at its start, even if the price changes later on.
+func agreedRate(rate dto.PaymentRate, proposal market.ServiceProposal, quoter priceQuoter, agreed *market.Price) (dto.PaymentRate, error) {
+	current, priced := currentPrice(proposal, quoter)
+	if !priced {
+		return rate, nil
+	}
+	if agreed != nil && !pricing.SamePrice(*agreed, current) {
+		return rate, ErrPriceChanged
+	}
+	return rate.WithPrice(current).(dto.PaymentRate), nil
+}
+
+// currentPrice returns the price provider currently charges, false if the proposal is not priced.
+func currentPrice(proposal market.ServiceProposal, quoter priceQuoter) (market.Price, bool) {
+	if quoter != nil {
+		if quote := quoter(); quote.Version != 0 {
+			return quote.Price, true
+		}
+	}
+	method, ok := proposal.PaymentMethod.(market.PricedPaymentMethod)
+	if !ok {
+		return market.Price{}, false
+	}
+	return market.Price{PerMinute: method.GetPrice(), PerGB: method.GetPricePerGB()}, true
+}
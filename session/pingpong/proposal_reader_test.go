@@ -22,12 +22,15 @@ import (
 	"testing"
 	"time"
 
+	"github.com/mysteriumnetwork/node/core/service/pricing"
 	"github.com/mysteriumnetwork/node/market"
 	"github.com/mysteriumnetwork/node/money"
 	"github.com/mysteriumnetwork/node/services/openvpn/discovery/dto"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestProposalToPaymentRate(t *testing.T) {
+	perGB := money.NewMoney(100, money.CurrencyMyst)
 	tests := []struct {
 		name     string
 		proposal market.ServiceProposal
@@ -88,6 +91,22 @@ func TestProposalToPaymentRate(t *testing.T) {
 				Duration: time.Minute,
 			},
 		},
+		{
+			name: "accepts price per GB",
+			proposal: market.ServiceProposal{
+				PaymentMethod: dto.PaymentRate{
+					Price:      money.NewMoney(1, money.CurrencyMyst),
+					Duration:   time.Minute,
+					PricePerGB: &perGB,
+				},
+			},
+			wantErr: false,
+			want: dto.PaymentRate{
+				Price:      money.NewMoney(1, money.CurrencyMyst),
+				Duration:   time.Minute,
+				PricePerGB: &perGB,
+			},
+		},
 		{
 			name: "rejects unknown proposal",
 			proposal: market.ServiceProposal{
@@ -125,6 +144,18 @@ func TestProposalToPaymentRate(t *testing.T) {
 	}
 }
 
+func TestProposalToPaymentPackage(t *testing.T) {
+	pkg := market.PaymentPackage{Price: money.NewMoney(100, money.CurrencyMyst), Duration: time.Hour}
+
+	got, ok := ProposalToPaymentPackage(market.ServiceProposal{PaymentMethod: pkg})
+	assert.True(t, ok)
+	assert.Equal(t, &pkg, got)
+
+	got, ok = ProposalToPaymentPackage(market.ServiceProposal{PaymentMethod: mockMethod{method: "PER_TIME"}})
+	assert.False(t, ok)
+	assert.Nil(t, got)
+}
+
 type mockMethod struct {
 	method      string
 	price       money.Money
@@ -134,3 +165,35 @@ type mockMethod struct {
 func (method mockMethod) GetPrice() money.Money       { return method.price }
 func (method mockMethod) GetType() string             { return method.method }
 func (method mockMethod) GetRate() market.PaymentRate { return method.paymentRate }
+
+func Test_agreedRate(t *testing.T) {
+	rate := dto.PaymentRate{Price: money.NewMoney(10, money.CurrencyMyst), Duration: time.Minute}
+	proposal := market.ServiceProposal{PaymentMethod: rate}
+	price := func(amount uint64) *market.Price {
+		return &market.Price{PerMinute: money.NewMoney(amount, money.CurrencyMyst), PerGB: money.NewMoney(0, money.CurrencyMyst)}
+	}
+
+	agreed, err := agreedRate(rate, proposal, nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, rate, agreed)
+
+	agreed, err = agreedRate(rate, proposal, nil, price(10))
+	assert.NoError(t, err)
+	assert.Equal(t, rate, agreed)
+
+	_, err = agreedRate(rate, proposal, nil, price(20))
+	assert.Equal(t, ErrPriceChanged, err)
+
+	quote := pricing.Quote{Version: 2, Price: *price(20)}
+	quoter := func() pricing.Quote { return quote }
+	agreed, err = agreedRate(rate, proposal, quoter, price(20))
+	assert.NoError(t, err)
+	assert.Equal(t, dto.PaymentRate{Price: money.NewMoney(20, money.CurrencyMyst), Duration: time.Minute}, agreed)
+
+	_, err = agreedRate(rate, proposal, quoter, price(10))
+	assert.Equal(t, ErrPriceChanged, err)
+
+	agreed, err = agreedRate(rate, market.ServiceProposal{PaymentMethod: market.UnsupportedPaymentMethod{}}, nil, price(20))
+	assert.NoError(t, err)
+	assert.Equal(t, rate, agreed)
+}
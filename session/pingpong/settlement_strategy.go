@@ -0,0 +1,142 @@
+/*
+ * Copyright (C) 2020 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package pingpong
+
+import (
+	"time"
+
+	"github.com/mysteriumnetwork/node/identity"
+	"github.com/rs/zerolog/log"
+)
+
+// SettlementState describes the provider channel the settlement strategy decides on.
+type SettlementState struct {
+	Provider identity.Identity
+	// Balance is the amount the accountant can still promise before the channel runs out.
+	Balance uint64
+	// AvailableBalance is the capacity of the channel.
+	AvailableBalance uint64
+	// Unsettled is the amount promised by the accountant which is not settled yet.
+	Unsettled uint64
+	// LastSettlement is the time of the last settlement, or the time the state was loaded if it was not settled since.
+	LastSettlement time.Time
+	// Fee is the last fetched transactor settlement fee, only valid if FeeKnown is set.
+	Fee      uint64
+	FeeKnown bool
+}
+
+// SettlementStrategy decides whether the provider should settle its accountant promise.
+type SettlementStrategy interface {
+	ShouldSettle(state SettlementState) bool
+}
+
+// ThresholdStrategy settles once the channel balance drops to the given share of the channel capacity.
+type ThresholdStrategy struct {
+	Threshold float64
+}
+
+// ShouldSettle implements SettlementStrategy.
+func (ts ThresholdStrategy) ShouldSettle(state SettlementState) bool {
+	if state.Balance == 0 {
+		return true
+	}
+	return float64(state.Balance) <= ts.Threshold*float64(state.AvailableBalance)
+}
+
+// FeeRatioStrategy settles only when the transactor fee is at most the given share of the unsettled amount,
+// so that the fees do not eat small payouts. It is meant to be combined with other strategies using AllOf.
+type FeeRatioStrategy struct {
+	MaxFeeRatio float64
+}
+
+// ShouldSettle implements SettlementStrategy.
+func (fs FeeRatioStrategy) ShouldSettle(state SettlementState) bool {
+	if state.Unsettled == 0 {
+		return false
+	}
+	if !state.FeeKnown {
+		log.Warn().Msg("Settlement fees are not known yet, postponing settlement")
+		return false
+	}
+	return float64(state.Fee) <= fs.MaxFeeRatio*float64(state.Unsettled)
+}
+
+// ScheduleStrategy settles the unsettled amount once the given interval passes since the last settlement.
+type ScheduleStrategy struct {
+	Interval time.Duration
+	Clock    func() time.Time
+}
+
+// NewScheduleStrategy returns a new schedule strategy settling every given interval.
+func NewScheduleStrategy(interval time.Duration) ScheduleStrategy {
+	return ScheduleStrategy{Interval: interval, Clock: time.Now}
+}
+
+// ShouldSettle implements SettlementStrategy.
+func (ss ScheduleStrategy) ShouldSettle(state SettlementState) bool {
+	if state.Unsettled == 0 {
+		return false
+	}
+	return !ss.Clock().Before(state.LastSettlement.Add(ss.Interval))
+}
+
+// AmountStrategy settles once the unsettled amount reaches the given amount.
+type AmountStrategy struct {
+	Amount uint64
+}
+
+// ShouldSettle implements SettlementStrategy.
+func (as AmountStrategy) ShouldSettle(state SettlementState) bool {
+	return state.Unsettled > 0 && state.Unsettled >= as.Amount
+}
+
+// CapacityStrategy settles before the channel runs out of capacity, once its balance drops to the given reserve.
+type CapacityStrategy struct {
+	Reserve uint64
+}
+
+// ShouldSettle implements SettlementStrategy.
+func (cs CapacityStrategy) ShouldSettle(state SettlementState) bool {
+	return state.Balance <= cs.Reserve
+}
+
+// AllOf settles only when all of the strategies agree to settle.
+type AllOf []SettlementStrategy
+
+// ShouldSettle implements SettlementStrategy.
+func (all AllOf) ShouldSettle(state SettlementState) bool {
+	for _, strategy := range all {
+		if !strategy.ShouldSettle(state) {
+			return false
+		}
+	}
+	return len(all) > 0
+}
+
+// AnyOf settles when any of the strategies wants to settle.
+type AnyOf []SettlementStrategy
+
+// ShouldSettle implements SettlementStrategy.
+func (a AnyOf) ShouldSettle(state SettlementState) bool {
+	for _, strategy := range a {
+		if strategy.ShouldSettle(state) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,263 @@
+/*
+ * Copyright (C) 2020 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package pingpong
+
+import (
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/mysteriumnetwork/node/identity/registry"
+	"github.com/mysteriumnetwork/node/utils"
+	"github.com/mysteriumnetwork/payments/bindings"
+	"github.com/mysteriumnetwork/payments/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSettlementStrategies(t *testing.T) {
+	clock := &utils.SettableClock{}
+	clock.SetTime(time.Date(2020, 3, 1, 12, 0, 0, 0, time.UTC))
+	lastSettlement := clock.GetTime().Add(-time.Hour)
+
+	tests := []struct {
+		name     string
+		strategy SettlementStrategy
+		state    SettlementState
+		settle   bool
+	}{
+		{
+			name:     "threshold settles with balance below threshold",
+			strategy: ThresholdStrategy{Threshold: 0.1},
+			state:    SettlementState{Balance: 100, AvailableBalance: 1000},
+			settle:   true,
+		},
+		{
+			name:     "threshold waits with balance above threshold",
+			strategy: ThresholdStrategy{Threshold: 0.1},
+			state:    SettlementState{Balance: 101, AvailableBalance: 1000},
+			settle:   false,
+		},
+		{
+			name:     "fee ratio settles with cheap fees",
+			strategy: FeeRatioStrategy{MaxFeeRatio: 0.05},
+			state:    SettlementState{Unsettled: 1000, Fee: 10, FeeKnown: true},
+			settle:   true,
+		},
+		{
+			name:     "fee ratio waits with expensive fees",
+			strategy: FeeRatioStrategy{MaxFeeRatio: 0.05},
+			state:    SettlementState{Unsettled: 1000, Fee: 500, FeeKnown: true},
+			settle:   false,
+		},
+		{
+			name:     "fee ratio waits without fees",
+			strategy: FeeRatioStrategy{MaxFeeRatio: 0.05},
+			state:    SettlementState{Unsettled: 1000},
+			settle:   false,
+		},
+		{
+			name:     "fee ratio waits with nothing to settle",
+			strategy: FeeRatioStrategy{MaxFeeRatio: 0.05},
+			state:    SettlementState{Fee: 10, FeeKnown: true},
+			settle:   false,
+		},
+		{
+			name:     "schedule settles once interval passes",
+			strategy: ScheduleStrategy{Interval: time.Hour, Clock: clock.GetTime},
+			state:    SettlementState{Unsettled: 1, LastSettlement: lastSettlement},
+			settle:   true,
+		},
+		{
+			name:     "schedule waits for interval",
+			strategy: ScheduleStrategy{Interval: 2 * time.Hour, Clock: clock.GetTime},
+			state:    SettlementState{Unsettled: 1, LastSettlement: lastSettlement},
+			settle:   false,
+		},
+		{
+			name:     "schedule waits with nothing to settle",
+			strategy: ScheduleStrategy{Interval: time.Hour, Clock: clock.GetTime},
+			state:    SettlementState{LastSettlement: lastSettlement},
+			settle:   false,
+		},
+		{
+			name:     "amount settles once reached",
+			strategy: AmountStrategy{Amount: 1000},
+			state:    SettlementState{Unsettled: 1000},
+			settle:   true,
+		},
+		{
+			name:     "amount waits below amount",
+			strategy: AmountStrategy{Amount: 1000},
+			state:    SettlementState{Unsettled: 999},
+			settle:   false,
+		},
+		{
+			name:     "capacity settles near the cliff",
+			strategy: CapacityStrategy{Reserve: 500},
+			state:    SettlementState{Balance: 500, AvailableBalance: 100000},
+			settle:   true,
+		},
+		{
+			name:     "capacity waits with enough balance",
+			strategy: CapacityStrategy{Reserve: 500},
+			state:    SettlementState{Balance: 501, AvailableBalance: 100000},
+			settle:   false,
+		},
+		{
+			name:     "all of requires every strategy",
+			strategy: AllOf{AmountStrategy{Amount: 1000}, FeeRatioStrategy{MaxFeeRatio: 0.05}},
+			state:    SettlementState{Unsettled: 2000, Fee: 500, FeeKnown: true},
+			settle:   false,
+		},
+		{
+			name:     "all of settles when every strategy agrees",
+			strategy: AllOf{AmountStrategy{Amount: 1000}, FeeRatioStrategy{MaxFeeRatio: 0.05}},
+			state:    SettlementState{Unsettled: 2000, Fee: 10, FeeKnown: true},
+			settle:   true,
+		},
+		{
+			name:     "empty all of never settles",
+			strategy: AllOf{},
+			state:    SettlementState{Unsettled: 2000},
+			settle:   false,
+		},
+		{
+			name:     "any of settles when one strategy wants to",
+			strategy: AnyOf{AmountStrategy{Amount: 1000}, CapacityStrategy{Reserve: 500}},
+			state:    SettlementState{Unsettled: 10, Balance: 100},
+			settle:   true,
+		},
+		{
+			name:     "any of waits when none wants to",
+			strategy: AnyOf{AmountStrategy{Amount: 1000}, CapacityStrategy{Reserve: 500}},
+			state:    SettlementState{Unsettled: 10, Balance: 1000},
+			settle:   false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.settle, test.strategy.ShouldSettle(test.state))
+		})
+	}
+}
+
+type settleRecordingTransactor struct {
+	mockTransactor
+	settled chan crypto.Promise
+}
+
+func (srt *settleRecordingTransactor) SettleAndRebalance(id string, promise crypto.Promise) error {
+	srt.settled <- promise
+	return nil
+}
+
+func TestPromiseSettler_settleDue(t *testing.T) {
+	dir, err := ioutil.TempDir("", "TestPromiseSettler_settleDue")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	ks := keystore.NewKeyStore(dir, keystore.LightScryptN, keystore.LightScryptP)
+
+	clock := &utils.SettableClock{}
+	clock.SetTime(time.Date(2020, 3, 1, 12, 0, 0, 0, time.UTC))
+	transactor := &settleRecordingTransactor{settled: make(chan crypto.Promise, 1)}
+	storage := &mockAccountantPromiseGetter{promise: AccountantPromise{
+		Promise: crypto.Promise{Amount: 100},
+		R:       hex.EncodeToString([]byte("r")),
+	}}
+	channelStatusProvider := &mockProviderChannelStatusProvider{
+		sinkToReturn: make(chan *bindings.AccountantImplementationPromiseSettled),
+		subCancel:    func() {},
+	}
+
+	config := cfg
+	config.Strategy = ScheduleStrategy{Interval: time.Hour, Clock: clock.GetTime}
+	settler := NewAccountantPromiseSettler(transactor, storage, channelStatusProvider, &mockRegistrationStatusProvider{}, ks, storage, nil, nil, config)
+	settler.currentState[mockID] = state{
+		registered:       true,
+		balance:          900,
+		availableBalance: 1000,
+		lastPromise:      crypto.Promise{Amount: 100},
+		lastSettlement:   clock.GetTime(),
+	}
+
+	settler.settleDue()
+	select {
+	case <-transactor.settled:
+		t.Fatal("settled before the schedule")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	clock.AddTime(time.Hour)
+	settler.settleDue()
+	select {
+	case promise := <-transactor.settled:
+		assert.Equal(t, uint64(100), promise.Amount)
+		assert.Equal(t, []byte("r"), promise.R)
+	case <-time.After(time.Second):
+		t.Fatal("promise was not settled")
+	}
+}
+
+func TestPromiseSettler_settleDueUsesRefreshedFee(t *testing.T) {
+	dir, err := ioutil.TempDir("", "TestPromiseSettler_settleDueUsesRefreshedFee")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	ks := keystore.NewKeyStore(dir, keystore.LightScryptN, keystore.LightScryptP)
+
+	transactor := &settleRecordingTransactor{
+		mockTransactor: mockTransactor{feesToReturn: registry.FeesResponse{Fee: 1}},
+		settled:        make(chan crypto.Promise, 1),
+	}
+	storage := &mockAccountantPromiseGetter{promise: AccountantPromise{
+		Promise: crypto.Promise{Amount: 100},
+		R:       hex.EncodeToString([]byte("r")),
+	}}
+	channelStatusProvider := &mockProviderChannelStatusProvider{
+		sinkToReturn: make(chan *bindings.AccountantImplementationPromiseSettled),
+		subCancel:    func() {},
+	}
+
+	config := cfg
+	config.Strategy = FeeRatioStrategy{MaxFeeRatio: 0.05}
+	settler := NewAccountantPromiseSettler(transactor, storage, channelStatusProvider, &mockRegistrationStatusProvider{}, ks, storage, nil, nil, config)
+	settler.currentState[mockID] = state{
+		registered:       true,
+		balance:          900,
+		availableBalance: 1000,
+		lastPromise:      crypto.Promise{Amount: 100},
+	}
+
+	settler.settleDue()
+	select {
+	case <-transactor.settled:
+		t.Fatal("settled without known fees")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	settler.refreshSettleFee()
+	settler.settleDue()
+	select {
+	case promise := <-transactor.settled:
+		assert.Equal(t, uint64(100), promise.Amount)
+	case <-time.After(time.Second):
+		t.Fatal("promise was not settled")
+	}
+}
@@ -0,0 +1,197 @@
+/*
+ * Copyright (C) 2020 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package pingpong
+
+import (
+	"sync"
+	"time"
+
+	"github.com/mysteriumnetwork/node/identity"
+	"github.com/mysteriumnetwork/node/market"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+const trialUsageBucketName = "trial_usage"
+
+// TrialUsage represents how much of the free trial was used by the identity.
+type TrialUsage struct {
+	Duration time.Duration
+	Bytes    uint64
+}
+
+type trialStorage interface {
+	Get(own, peer identity.Identity) (TrialUsage, error)
+	Store(own, peer identity.Identity, usage TrialUsage) error
+}
+
+// TrialStorage allows to store the free trial usage of each peer.
+// Providers keep track of the trials used by consumers, consumers keep track of the trials used with providers.
+type TrialStorage struct {
+	bolt persistentStorage
+	lock sync.Mutex
+}
+
+// NewTrialStorage creates a new instance of trial storage.
+func NewTrialStorage(bolt persistentStorage) *TrialStorage {
+	return &TrialStorage{
+		bolt: bolt,
+	}
+}
+
+// Store stores the trial usage of the given peer.
+func (ts *TrialStorage) Store(own, peer identity.Identity, usage TrialUsage) error {
+	ts.lock.Lock()
+	defer ts.lock.Unlock()
+	return errors.Wrap(ts.bolt.SetValue(trialUsageBucketName, own.Address+peer.Address, usage), "could not store trial usage")
+}
+
+// Get fetches the trial usage of the given peer, peers which never used the trial have zero usage.
+func (ts *TrialStorage) Get(own, peer identity.Identity) (TrialUsage, error) {
+	ts.lock.Lock()
+	defer ts.lock.Unlock()
+	var usage TrialUsage
+	err := ts.bolt.GetValue(trialUsageBucketName, own.Address+peer.Address, &usage)
+	if err != nil {
+		if err.Error() == errBoltNotFound {
+			return TrialUsage{}, nil
+		}
+		return usage, errors.Wrap(err, "could not get trial usage")
+	}
+	return usage, nil
+}
+
+// trialTracker keeps track of the free trial usage during the session.
+type trialTracker struct {
+	terms       market.Trial
+	own, peer   identity.Identity
+	storage     trialStorage
+	used        TrialUsage
+	ended       bool
+	freeElapsed time.Duration
+	freeBytes   uint64
+	lock        sync.Mutex
+}
+
+// newTrialTracker returns the tracker of the trial peer is entitled to, nil if there's no trial.
+func newTrialTracker(terms *market.Trial, storage trialStorage, own, peer identity.Identity) *trialTracker {
+	if terms.IsEmpty() || storage == nil {
+		return nil
+	}
+	used, err := storage.Get(own, peer)
+	if err != nil {
+		log.Warn().Err(err).Msg("Could not get trial usage, assuming trial is used up")
+		used = TrialUsage{Duration: terms.Duration, Bytes: terms.Bytes}
+	}
+	tracker := &trialTracker{
+		terms:   *terms,
+		own:     own,
+		peer:    peer,
+		storage: storage,
+		used:    used,
+	}
+	// nothing is free of charge for peers who used up the trial before
+	leftDuration, leftBytes := tracker.left()
+	tracker.ended = (terms.Duration > 0 && leftDuration == 0) || (terms.Bytes > 0 && leftBytes == 0)
+	return tracker
+}
+
+// update records the usage of the session, returns true while the trial lasts.
+func (tt *trialTracker) update(elapsed time.Duration, transferred uint64) bool {
+	if tt == nil {
+		return false
+	}
+	tt.lock.Lock()
+	defer tt.lock.Unlock()
+
+	if tt.ended {
+		return false
+	}
+
+	leftDuration, leftBytes := tt.left()
+	timeUp := tt.terms.Duration > 0 && elapsed >= leftDuration
+	dataUp := tt.terms.Bytes > 0 && transferred >= leftBytes
+	if !timeUp && !dataUp {
+		tt.save(TrialUsage{Duration: tt.used.Duration + elapsed, Bytes: tt.used.Bytes + transferred})
+		return true
+	}
+
+	tt.ended = true
+	tt.freeElapsed, tt.freeBytes = elapsed, transferred
+	if timeUp {
+		tt.freeElapsed = leftDuration
+	}
+	if dataUp {
+		tt.freeBytes = leftBytes
+	}
+	tt.save(TrialUsage{Duration: tt.terms.Duration, Bytes: tt.terms.Bytes})
+	return false
+}
+
+// chargeable returns the usage of the session excluding the usage free of charge.
+// Whole usage is free of charge while the trial lasts.
+func (tt *trialTracker) chargeable(elapsed time.Duration, tracker dataTracker) (time.Duration, dataTracker) {
+	if tt == nil {
+		return elapsed, tracker
+	}
+	tt.lock.Lock()
+	defer tt.lock.Unlock()
+
+	if !tt.ended {
+		return 0, nil
+	}
+	return subtractDuration(elapsed, tt.freeElapsed), trialDataTracker{tracker: tracker, free: tt.freeBytes}
+}
+
+// left returns the trial usage left before the session.
+func (tt *trialTracker) left() (time.Duration, uint64) {
+	return subtractDuration(tt.terms.Duration, tt.used.Duration), amountSince(tt.used.Bytes, tt.terms.Bytes)
+}
+
+func (tt *trialTracker) save(usage TrialUsage) {
+	if err := tt.storage.Store(tt.own, tt.peer, usage); err != nil {
+		log.Warn().Err(err).Msg("Could not store trial usage")
+	}
+}
+
+// trialDataTracker reports the data transferred after the trial.
+type trialDataTracker struct {
+	tracker dataTracker
+	free    uint64
+}
+
+func (tdt trialDataTracker) DataTransferred() uint64 {
+	if tdt.tracker == nil {
+		return 0
+	}
+	return amountSince(tdt.free, tdt.tracker.DataTransferred())
+}
+
+func dataTransferred(tracker dataTracker) uint64 {
+	if tracker == nil {
+		return 0
+	}
+	return tracker.DataTransferred()
+}
+
+func subtractDuration(from, d time.Duration) time.Duration {
+	if d >= from {
+		return 0
+	}
+	return from - d
+}
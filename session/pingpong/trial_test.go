@@ -0,0 +1,150 @@
+/*
+ * Copyright (C) 2020 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package pingpong
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/mysteriumnetwork/node/core/storage/boltdb"
+	"github.com/mysteriumnetwork/node/identity"
+	"github.com/mysteriumnetwork/node/market"
+	"github.com/mysteriumnetwork/node/session"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	trialProvider = identity.FromAddress("0x1")
+	trialConsumer = identity.FromAddress("0x2")
+)
+
+func TestTrialStorage(t *testing.T) {
+	dir, err := ioutil.TempDir("", "trialStorageTest")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	bolt, err := boltdb.NewStorage(dir)
+	assert.NoError(t, err)
+	defer bolt.Close()
+
+	storage := NewTrialStorage(bolt)
+
+	usage, err := storage.Get(trialProvider, trialConsumer)
+	assert.NoError(t, err)
+	assert.Equal(t, TrialUsage{}, usage)
+
+	err = storage.Store(trialProvider, trialConsumer, TrialUsage{Duration: time.Minute, Bytes: 10})
+	assert.NoError(t, err)
+
+	usage, err = storage.Get(trialProvider, trialConsumer)
+	assert.NoError(t, err)
+	assert.Equal(t, TrialUsage{Duration: time.Minute, Bytes: 10}, usage)
+
+	usage, err = storage.Get(trialConsumer, trialProvider)
+	assert.NoError(t, err)
+	assert.Equal(t, TrialUsage{}, usage)
+}
+
+func Test_newTrialTracker_WithoutTrial(t *testing.T) {
+	storage := &mockTrialStorage{}
+	assert.Nil(t, newTrialTracker(nil, storage, trialProvider, trialConsumer))
+	assert.Nil(t, newTrialTracker(&market.Trial{}, storage, trialProvider, trialConsumer))
+
+	var tracker *trialTracker
+	assert.False(t, tracker.update(time.Hour, 100))
+	elapsed, data := tracker.chargeable(time.Hour, fixedDataTracker(100))
+	assert.Equal(t, time.Hour, elapsed)
+	assert.Equal(t, uint64(100), data.DataTransferred())
+}
+
+func Test_trialTracker_EndsOnTime(t *testing.T) {
+	storage := &mockTrialStorage{usage: TrialUsage{Duration: 4 * time.Minute}}
+	tracker := newTrialTracker(&market.Trial{Duration: 10 * time.Minute, Bytes: 1000}, storage, trialProvider, trialConsumer)
+
+	assert.True(t, tracker.update(5*time.Minute, 100))
+	assert.Equal(t, TrialUsage{Duration: 9 * time.Minute, Bytes: 100}, storage.usage)
+	elapsed, data := tracker.chargeable(5*time.Minute, fixedDataTracker(100))
+	assert.Equal(t, time.Duration(0), elapsed)
+	assert.Nil(t, data)
+
+	assert.False(t, tracker.update(7*time.Minute, 200))
+	assert.Equal(t, TrialUsage{Duration: 10 * time.Minute, Bytes: 1000}, storage.usage)
+	elapsed, data = tracker.chargeable(8*time.Minute, fixedDataTracker(300))
+	assert.Equal(t, 2*time.Minute, elapsed)
+	assert.Equal(t, uint64(100), data.DataTransferred())
+}
+
+func Test_trialTracker_EndsOnData(t *testing.T) {
+	storage := &mockTrialStorage{}
+	tracker := newTrialTracker(&market.Trial{Duration: 10 * time.Minute, Bytes: 1000}, storage, trialProvider, trialConsumer)
+
+	assert.False(t, tracker.update(time.Minute, 1500))
+	elapsed, data := tracker.chargeable(2*time.Minute, fixedDataTracker(2000))
+	assert.Equal(t, time.Minute, elapsed)
+	assert.Equal(t, uint64(1000), data.DataTransferred())
+}
+
+func Test_trialTracker_BytesOnlyEndsOnSessionData(t *testing.T) {
+	storage := &mockTrialStorage{}
+	tracker := newTrialTracker(&market.Trial{Bytes: 1000}, storage, trialProvider, trialConsumer)
+	sessions := &mockSessionFinder{session: session.Session{ID: "session"}}
+	data := sessionDataTracker{sessions: sessions, id: "session"}
+
+	assert.True(t, tracker.update(time.Hour, dataTransferred(data)))
+
+	sessions.session.DataTransfered = session.DataTransfered{Up: 400, Down: 800}
+	assert.False(t, tracker.update(2*time.Hour, dataTransferred(data)))
+	assert.Equal(t, TrialUsage{Bytes: 1000}, storage.usage)
+	elapsed, chargeable := tracker.chargeable(3*time.Hour, data)
+	assert.Equal(t, time.Hour, elapsed)
+	assert.Equal(t, uint64(200), chargeable.DataTransferred())
+}
+
+func Test_trialTracker_UsedUp(t *testing.T) {
+	storage := &mockTrialStorage{usage: TrialUsage{Bytes: 1000}}
+	tracker := newTrialTracker(&market.Trial{Bytes: 1000}, storage, trialProvider, trialConsumer)
+
+	assert.False(t, tracker.update(time.Minute, 0))
+	elapsed, data := tracker.chargeable(time.Minute, fixedDataTracker(10))
+	assert.Equal(t, time.Minute, elapsed)
+	assert.Equal(t, uint64(10), data.DataTransferred())
+}
+
+type mockTrialStorage struct {
+	usage TrialUsage
+	err   error
+}
+
+func (mts *mockTrialStorage) Get(own, peer identity.Identity) (TrialUsage, error) {
+	return mts.usage, mts.err
+}
+
+func (mts *mockTrialStorage) Store(own, peer identity.Identity, usage TrialUsage) error {
+	mts.usage = usage
+	return mts.err
+}
+
+type mockSessionFinder struct {
+	session session.Session
+}
+
+func (msf *mockSessionFinder) Find(id session.ID) (session.Session, bool) {
+	return msf.session, msf.session.ID == id
+}
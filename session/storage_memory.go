@@ -94,6 +94,24 @@ func (storage *StorageMemory) Remove(id ID) {
 	delete(storage.sessions, id)
 }
 
+// Terminate removes given session from underlying storage and signals the session to shut down.
+// Returns false if the session was not found, i.e. it was already terminated.
+func (storage *StorageMemory) Terminate(id ID) bool {
+	storage.lock.Lock()
+	defer storage.lock.Unlock()
+
+	instance, found := storage.sessions[id]
+	if !found {
+		return false
+	}
+
+	delete(storage.sessions, id)
+	if instance.done != nil {
+		close(instance.done)
+	}
+	return true
+}
+
 // RemoveForService removes all sessions which belong to given service
 func (storage *StorageMemory) RemoveForService(serviceID string) {
 	sessions := storage.GetAll()
@@ -91,6 +91,18 @@ func TestStorage_Remove(t *testing.T) {
 	assert.Len(t, storage.sessions, 0)
 }
 
+func TestStorage_Terminate(t *testing.T) {
+	done := make(chan struct{})
+	storage := mockStorage(Session{ID: sessionExisting.ID, done: done})
+
+	assert.True(t, storage.Terminate(sessionExisting.ID))
+	assert.Len(t, storage.sessions, 0)
+	_, open := <-done
+	assert.False(t, open)
+
+	assert.False(t, storage.Terminate(sessionExisting.ID))
+}
+
 func TestStorage_RemoveNonExisting(t *testing.T) {
 	storage := &StorageMemory{
 		sessions: map[ID]Session{},
@@ -22,7 +22,9 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"time"
 
+	"github.com/mysteriumnetwork/node/core/auth"
 	"github.com/mysteriumnetwork/node/identity"
 	"github.com/mysteriumnetwork/node/identity/registry"
 	"github.com/pkg/errors"
@@ -179,6 +181,69 @@ func (client *Client) IdentityRegistrationStatus(address string) (RegistrationDa
 	return status, err
 }
 
+// IdentityBudget returns spending limits of the consumer identity and its current spending
+func (client *Client) IdentityBudget(address string) (budget BudgetDTO, err error) {
+	response, err := client.http.Get("identities/"+address+"/budget", url.Values{})
+	if err != nil {
+		return budget, err
+	}
+	defer response.Body.Close()
+
+	err = parseResponseJSON(response, &budget)
+	return budget, err
+}
+
+// IdentityBudgetSet replaces spending limits of the consumer identity
+func (client *Client) IdentityBudgetSet(address string, limits BudgetLimitsDTO) (budget BudgetDTO, err error) {
+	response, err := client.http.Put("identities/"+address+"/budget", limits)
+	if err != nil {
+		return budget, err
+	}
+	defer response.Body.Close()
+
+	err = parseResponseJSON(response, &budget)
+	return budget, err
+}
+
+// Transactions returns payment transactions recorded in the ledger, query narrows them down by identity, sessionId, kind, direction, from and to
+func (client *Client) Transactions(query url.Values) ([]TransactionDTO, error) {
+	response, err := client.http.Get("transactions", query)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	var list TransactionListDTO
+	err = parseResponseJSON(response, &list)
+	return list.Transactions, err
+}
+
+// IdentityEarnings returns provider earnings grouped by the given period: day, week or month
+func (client *Client) IdentityEarnings(address, groupBy string) (earnings EarningsDTO, err error) {
+	query := url.Values{}
+	query.Set("groupBy", groupBy)
+	response, err := client.http.Get("identities/"+address+"/earnings", query)
+	if err != nil {
+		return earnings, err
+	}
+	defer response.Body.Close()
+
+	err = parseResponseJSON(response, &earnings)
+	return earnings, err
+}
+
+// IdentityChannel returns the state of the identity's payment channel
+func (client *Client) IdentityChannel(address string) (channel ChannelDTO, err error) {
+	response, err := client.http.Get("identities/"+address+"/channel", nil)
+	if err != nil {
+		return channel, err
+	}
+	defer response.Body.Close()
+
+	err = parseResponseJSON(response, &channel)
+	return channel, err
+}
+
 // ConnectionCreate initiates a new connection to a host identified by providerID
 func (client *Client) ConnectionCreate(consumerID, providerID, accountantID, serviceType string, options ConnectOptions) (status StatusDTO, err error) {
 	payload := struct {
@@ -342,6 +407,70 @@ func (client *Client) Unlock(identity, passphrase string) error {
 	return nil
 }
 
+// ChangePassphrase re-encrypts identity stored in keystore with the new passphrase
+func (client *Client) ChangePassphrase(identity, passphrase, newPassphrase string) error {
+	path := fmt.Sprintf("identities/%s/passphrase", identity)
+	payload := struct {
+		Passphrase    string `json:"passphrase"`
+		NewPassphrase string `json:"newPassphrase"`
+	}{
+		passphrase,
+		newPassphrase,
+	}
+
+	response, err := client.http.Put(path, payload)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	return nil
+}
+
+// ExportIdentity returns identity as JSON keystore blob encrypted with the export passphrase
+func (client *Client) ExportIdentity(identity, passphrase, exportPassphrase string) ([]byte, error) {
+	path := fmt.Sprintf("identities/%s/export", identity)
+	payload := struct {
+		Passphrase       string `json:"passphrase"`
+		ExportPassphrase string `json:"exportPassphrase"`
+	}{
+		passphrase,
+		exportPassphrase,
+	}
+
+	response, err := client.http.Post(path, payload)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	var blob json.RawMessage
+	err = parseResponseJSON(response, &blob)
+	return blob, err
+}
+
+// ImportIdentity stores identity exported as JSON keystore blob, encrypting it with the new passphrase
+func (client *Client) ImportIdentity(blob []byte, passphrase, newPassphrase string) (id IdentityDTO, err error) {
+	payload := struct {
+		Blob          json.RawMessage `json:"blob"`
+		Passphrase    string          `json:"passphrase"`
+		NewPassphrase string          `json:"newPassphrase"`
+	}{
+		blob,
+		passphrase,
+		newPassphrase,
+	}
+
+	response, err := client.http.Post("identities/import", payload)
+	if err != nil {
+		return
+	}
+	defer response.Body.Close()
+
+	err = parseResponseJSON(response, &id)
+	return id, err
+}
+
 // Payout registers payout address for identity
 func (client *Client) Payout(identity, ethAddress string) error {
 	path := fmt.Sprintf("identities/%s/payout", identity)
@@ -452,6 +581,44 @@ func (client *Client) ServiceStart(providerID, serviceType string, options inter
 	return service, err
 }
 
+// ServiceUpdate replaces access policies of the running service instance.
+func (client *Client) ServiceUpdate(id string, ap AccessPoliciesRequest) (service ServiceInfoDTO, err error) {
+	payload := struct {
+		AccessPolicies AccessPoliciesRequest `json:"accessPolicies"`
+	}{
+		ap,
+	}
+
+	path := fmt.Sprintf("services/%s", id)
+	response, err := client.http.Put(path, payload)
+	if err != nil {
+		return service, err
+	}
+	defer response.Body.Close()
+
+	err = parseResponseJSON(response, &service)
+	return service, err
+}
+
+// ServiceUpdatePrice changes the price of the running service instance.
+func (client *Client) ServiceUpdatePrice(id string, price PriceDTO) (service ServiceInfoDTO, err error) {
+	payload := struct {
+		Price PriceDTO `json:"price"`
+	}{
+		price,
+	}
+
+	path := fmt.Sprintf("services/%s", id)
+	response, err := client.http.Put(path, payload)
+	if err != nil {
+		return service, err
+	}
+	defer response.Body.Close()
+
+	err = parseResponseJSON(response, &service)
+	return service, err
+}
+
 // ServiceStop stops the running service instance by the requested id.
 func (client *Client) ServiceStop(id string) error {
 	path := fmt.Sprintf("services/%s", id)
@@ -541,3 +708,142 @@ func (client *Client) Settle(providerID, accountantID identity.Identity, waitFor
 	}
 	return nil
 }
+
+// SetAuthToken authenticates the following requests with the given session or API token
+func (client *Client) SetAuthToken(token string) {
+	client.http.SetToken(token)
+}
+
+// AuthLogin logs in with user credentials, following requests are authenticated with the issued token
+func (client *Client) AuthLogin(username, password string) (user UserDTO, err error) {
+	payload := struct {
+		Username string `json:"username"`
+		Password string `json:"password"`
+	}{
+		username,
+		password,
+	}
+	response, err := client.http.Post("auth/login", payload)
+	if err != nil {
+		return user, err
+	}
+	defer response.Body.Close()
+
+	for _, cookie := range response.Cookies() {
+		if cookie.Name == auth.JWTCookieName {
+			client.http.SetToken(cookie.Value)
+		}
+	}
+
+	err = parseResponseJSON(response, &user)
+	return user, err
+}
+
+// AuthUsers returns users allowed to access Tequilapi
+func (client *Client) AuthUsers() ([]UserDTO, error) {
+	response, err := client.http.Get("auth/users", url.Values{})
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	var list UserListDTO
+	err = parseResponseJSON(response, &list)
+	return list.Users, err
+}
+
+// AuthCreateUser creates user with the given role
+func (client *Client) AuthCreateUser(username, password, role string) (user UserDTO, err error) {
+	payload := struct {
+		Username string `json:"username"`
+		Password string `json:"password"`
+		Role     string `json:"role"`
+	}{
+		username,
+		password,
+		role,
+	}
+	response, err := client.http.Post("auth/users", payload)
+	if err != nil {
+		return user, err
+	}
+	defer response.Body.Close()
+
+	err = parseResponseJSON(response, &user)
+	return user, err
+}
+
+// AuthUpdateUser changes the role of user and the password if it is not empty
+func (client *Client) AuthUpdateUser(username, password, role string) (user UserDTO, err error) {
+	payload := struct {
+		Password string `json:"password,omitempty"`
+		Role     string `json:"role"`
+	}{
+		password,
+		role,
+	}
+	response, err := client.http.Put("auth/users/"+username, payload)
+	if err != nil {
+		return user, err
+	}
+	defer response.Body.Close()
+
+	err = parseResponseJSON(response, &user)
+	return user, err
+}
+
+// AuthDeleteUser deletes user
+func (client *Client) AuthDeleteUser(username string) error {
+	response, err := client.http.Delete("auth/users/"+username, nil)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	return nil
+}
+
+// AuthCreateToken creates API token with the given scope, token never expires if expiresAt is nil
+func (client *Client) AuthCreateToken(name, scope string, expiresAt *time.Time) (token APITokenDTO, err error) {
+	payload := struct {
+		Name      string     `json:"name"`
+		Scope     string     `json:"scope,omitempty"`
+		ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	}{
+		name,
+		scope,
+		expiresAt,
+	}
+	response, err := client.http.Post("auth/tokens", payload)
+	if err != nil {
+		return token, err
+	}
+	defer response.Body.Close()
+
+	err = parseResponseJSON(response, &token)
+	return token, err
+}
+
+// AuthTokens returns API tokens of the user
+func (client *Client) AuthTokens() ([]APITokenDTO, error) {
+	response, err := client.http.Get("auth/tokens", url.Values{})
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	var list APITokenListDTO
+	err = parseResponseJSON(response, &list)
+	return list.Tokens, err
+}
+
+// AuthRevokeToken revokes API token
+func (client *Client) AuthRevokeToken(id string) error {
+	response, err := client.http.Delete("auth/tokens/"+id, nil)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	return nil
+}
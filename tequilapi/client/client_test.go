@@ -122,3 +122,26 @@ func (tc *trackingCloser) Close() error {
 }
 
 var _ io.ReadCloser = (*trackingCloser)(nil)
+
+func Test_AuthLogin_AuthenticatesFollowingRequests(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/auth/login":
+			http.SetCookie(w, &http.Cookie{Name: "token", Value: "jwt"})
+			w.Write([]byte(`{"username": "noc", "role": "monitor"}`))
+		case "/auth/tokens":
+			assert.Equal(t, "Bearer jwt", r.Header.Get("Authorization"))
+			w.Write([]byte(`{"tokens": []}`))
+		}
+	}))
+	defer server.Close()
+	client := Client{http: newHTTPClient(server.URL, "")}
+
+	user, err := client.AuthLogin("noc", "secret")
+	assert.NoError(t, err)
+	assert.Equal(t, UserDTO{Username: "noc", Role: "monitor"}, user)
+
+	tokens, err := client.AuthTokens()
+	assert.NoError(t, err)
+	assert.Empty(t, tokens)
+}
@@ -20,14 +20,59 @@ package client
 import (
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/mysteriumnetwork/node/core/connection"
 )
 
 // Fees represents the transactor fee
 type Fees struct {
-	Registration uint64 `json:"registration"`
-	Settlement   uint64 `json:"settlement"`
+	Registration        uint64   `json:"registration"`
+	Settlement          uint64   `json:"settlement"`
+	RegistrationDisplay MoneyDTO `json:"registrationDisplay"`
+	SettlementDisplay   MoneyDTO `json:"settlementDisplay"`
+}
+
+// MoneyDTO holds the amount along with its human readable representations
+type MoneyDTO struct {
+	Amount   uint64 `json:"amount"`
+	Currency string `json:"currency"`
+	Human    string `json:"human"`
+	Fiat     string `json:"fiat,omitempty"`
+}
+
+func (m MoneyDTO) String() string {
+	if m.Fiat == "" {
+		return m.Human
+	}
+	return fmt.Sprintf("%s (%s)", m.Human, m.Fiat)
+}
+
+// PaymentMethodDTO describes the price of proposal
+type PaymentMethodDTO struct {
+	Type       string    `json:"type"`
+	Price      MoneyDTO  `json:"price"`
+	PricePerGB *MoneyDTO `json:"pricePerGB,omitempty"`
+	PerSeconds uint64    `json:"perSeconds,omitempty"`
+	PerBytes   uint64    `json:"perBytes,omitempty"`
+}
+
+func (pm PaymentMethodDTO) String() string {
+	var price string
+	switch {
+	case pm.PerSeconds == 60:
+		price = fmt.Sprintf("%v per minute", pm.Price)
+	case pm.PerSeconds > 0:
+		price = fmt.Sprintf("%v per %d seconds", pm.Price, pm.PerSeconds)
+	case pm.PerBytes > 0:
+		price = fmt.Sprintf("%v per %d bytes", pm.Price, pm.PerBytes)
+	default:
+		price = pm.Price.String()
+	}
+	if pm.PricePerGB != nil {
+		price = fmt.Sprintf("%s + %v per GB", price, *pm.PricePerGB)
+	}
+	return price
 }
 
 // StatusDTO holds connection status and session id
@@ -56,6 +101,14 @@ type ProposalDTO struct {
 	ServiceType       string               `json:"serviceType"`
 	ServiceDefinition ServiceDefinitionDTO `json:"serviceDefinition"`
 	AccessPolicies    []AccessPolicy       `json:"accessPolicies"`
+	PaymentMethod     *PaymentMethodDTO    `json:"paymentMethod,omitempty"`
+	Trial             *TrialDTO            `json:"trial,omitempty"`
+}
+
+// TrialDTO describes the free trial offered to new consumers
+type TrialDTO struct {
+	DurationSeconds uint64 `json:"durationSeconds,omitempty"`
+	Bytes           uint64 `json:"bytes,omitempty"`
 }
 
 // AccessPolicy represents the access controls for proposal
@@ -95,6 +148,128 @@ type IdentityList struct {
 	Identities []IdentityDTO `json:"identities"`
 }
 
+// UserDTO holds Tequilapi user and its role
+type UserDTO struct {
+	Username string `json:"username"`
+	Role     string `json:"role"`
+}
+
+// UserListDTO holds returned list of users
+type UserListDTO struct {
+	Users []UserDTO `json:"users"`
+}
+
+// APITokenDTO holds API token details, the token itself is returned only when it is created
+type APITokenDTO struct {
+	ID        string     `json:"id"`
+	Name      string     `json:"name"`
+	Owner     string     `json:"owner,omitempty"`
+	Scope     string     `json:"scope"`
+	CreatedAt time.Time  `json:"created_at"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	Token     string     `json:"token,omitempty"`
+}
+
+// APITokenListDTO holds returned list of API tokens
+type APITokenListDTO struct {
+	Tokens []APITokenDTO `json:"tokens"`
+}
+
+// BudgetLimitsDTO holds consumer spending limits, zero limit means unlimited spending
+type BudgetLimitsDTO struct {
+	PerSession      uint64 `json:"perSession"`
+	PerDay          uint64 `json:"perDay"`
+	PerMonth        uint64 `json:"perMonth"`
+	AlertThresholds []int  `json:"alertThresholds"`
+}
+
+// BudgetDTO holds consumer spending limits and the amount spent in the current day and month
+type BudgetDTO struct {
+	BudgetLimitsDTO
+	Spent struct {
+		Day   uint64 `json:"day"`
+		Month uint64 `json:"month"`
+	} `json:"spent"`
+}
+
+// TransactionListDTO copied from tequilapi endpoint
+type TransactionListDTO struct {
+	Transactions []TransactionDTO `json:"transactions"`
+}
+
+// TransactionDTO copied from tequilapi endpoint
+type TransactionDTO struct {
+	ID           int    `json:"id"`
+	Kind         string `json:"kind"`
+	Direction    string `json:"direction"`
+	Time         string `json:"time"`
+	SessionID    string `json:"sessionId"`
+	Identity     string `json:"identity"`
+	Counterparty string `json:"counterparty"`
+	AccountantID string `json:"accountantId"`
+	ServiceType  string `json:"serviceType,omitempty"`
+	Country      string `json:"country,omitempty"`
+	Amount       uint64 `json:"amount"`
+	Total        uint64 `json:"total"`
+	Fee          uint64 `json:"fee"`
+	Hashlock     string `json:"hashlock,omitempty"`
+	TxHash       string `json:"txHash,omitempty"`
+}
+
+// EarningsDTO holds provider earnings
+type EarningsDTO struct {
+	Earned             uint64               `json:"earned"`
+	Settled            uint64               `json:"settled"`
+	Unsettled          uint64               `json:"unsettled"`
+	SettlementFee      uint64               `json:"settlementFee"`
+	UnsettledNetOfFees uint64               `json:"unsettledNetOfFees"`
+	GroupBy            string               `json:"groupBy"`
+	Periods            []EarningsGroupDTO   `json:"periods"`
+	ServiceTypes       []EarningsGroupDTO   `json:"serviceTypes"`
+	Countries          []EarningsGroupDTO   `json:"countries"`
+	Sessions           []SessionEarningsDTO `json:"sessions"`
+}
+
+// EarningsGroupDTO holds the amount earned by a group of sessions
+type EarningsGroupDTO struct {
+	Key      string `json:"key"`
+	Earned   uint64 `json:"earned"`
+	Sessions int    `json:"sessions"`
+}
+
+// SessionEarningsDTO holds the amounts invoiced and paid during a single session
+type SessionEarningsDTO struct {
+	SessionID       string `json:"sessionId"`
+	ServiceType     string `json:"serviceType"`
+	ConsumerCountry string `json:"consumerCountry"`
+	ConsumerID      string `json:"consumerId"`
+	Started         string `json:"started"`
+	Invoiced        uint64 `json:"invoiced"`
+	Paid            uint64 `json:"paid"`
+}
+
+// ChannelDTO holds the state of the identity's payment channel
+type ChannelDTO struct {
+	Identity           string                `json:"identity"`
+	AccountantID       string                `json:"accountantId"`
+	ChannelAddress     string                `json:"channelAddress"`
+	Balance            uint64                `json:"balance"`
+	Settled            uint64                `json:"settled"`
+	GrandTotalPromised uint64                `json:"grandTotalPromised"`
+	LastPromise        *AccountantPromiseDTO `json:"lastPromise"`
+	Registered         bool                  `json:"registered"`
+}
+
+// AccountantPromiseDTO holds a promise issued by the accountant
+type AccountantPromiseDTO struct {
+	ChannelID   string `json:"channelId"`
+	Amount      uint64 `json:"amount"`
+	Fee         uint64 `json:"fee"`
+	Hashlock    string `json:"hashlock"`
+	AgreementID uint64 `json:"agreementId"`
+	Revealed    bool   `json:"revealed"`
+}
+
 // HealthcheckDTO holds returned healthcheck response
 type HealthcheckDTO struct {
 	Uptime    string       `json:"uptime"`
@@ -172,12 +347,28 @@ type ServiceListDTO []ServiceInfoDTO
 
 // ServiceInfoDTO represents running service information
 type ServiceInfoDTO struct {
-	ID          string          `json:"id"`
-	ProviderID  string          `json:"providerId"`
-	ServiceType string          `json:"type"`
-	Options     json.RawMessage `json:"options"`
-	Status      string          `json:"status"`
-	Proposal    ProposalDTO     `json:"proposal"`
+	ID            string           `json:"id"`
+	ProviderID    string           `json:"providerId"`
+	ServiceType   string           `json:"type"`
+	Options       json.RawMessage  `json:"options"`
+	Status        string           `json:"status"`
+	Proposal      ProposalDTO      `json:"proposal"`
+	RestartPolicy RestartPolicyDTO `json:"restartPolicy"`
+	RestartCount  int              `json:"restartCount"`
+	LastFailure   string           `json:"lastFailure,omitempty"`
+	Price         *PriceDTO        `json:"price,omitempty"`
+}
+
+// PriceDTO represents the price provider asks for the service
+type PriceDTO struct {
+	PerMinute uint64 `json:"perMinute"`
+	PerGB     uint64 `json:"perGB"`
+}
+
+// RestartPolicyDTO represents the service restart policy
+type RestartPolicyDTO struct {
+	Type        string `json:"type"`
+	MaxRestarts int    `json:"maxRestarts"`
 }
 
 // ServiceSessionListDTO copied from tequilapi endpoint
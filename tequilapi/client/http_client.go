@@ -24,7 +24,6 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/url"
-	"time"
 
 	"github.com/mysteriumnetwork/node/requests"
 	"github.com/pkg/errors"
@@ -36,6 +35,7 @@ type httpClientInterface interface {
 	Post(path string, payload interface{}) (*http.Response, error)
 	Put(path string, payload interface{}) (*http.Response, error)
 	Delete(path string, payload interface{}) (*http.Response, error)
+	SetToken(token string)
 }
 
 type httpRequestInterface interface {
@@ -44,7 +44,15 @@ type httpRequestInterface interface {
 
 func newHTTPClient(baseURL string, ua string) *httpClient {
 	return &httpClient{
-		http:    requests.NewHTTPClient("0.0.0.0", 100*time.Second),
+		http:    requests.NewHTTPClient("0.0.0.0", requestTimeout),
+		baseURL: baseURL,
+		ua:      ua,
+	}
+}
+
+func newHTTPClientWithTransport(baseURL string, ua string, transport http.RoundTripper) *httpClient {
+	return &httpClient{
+		http:    &http.Client{Transport: transport, Timeout: requestTimeout},
 		baseURL: baseURL,
 		ua:      ua,
 	}
@@ -54,6 +62,12 @@ type httpClient struct {
 	http    httpRequestInterface
 	baseURL string
 	ua      string
+	token   string
+}
+
+// SetToken sets the token requests are authenticated with
+func (client *httpClient) SetToken(token string) {
+	client.token = token
 }
 
 func (client *httpClient) Get(path string, values url.Values) (*http.Response, error) {
@@ -100,6 +114,9 @@ func (client *httpClient) executeRequest(method, fullPath string, payloadJSON []
 	request.Header.Set("User-Agent", client.ua)
 	request.Header.Set("Content-Type", "application/json")
 	request.Header.Set("Accept", "application/json")
+	if client.token != "" {
+		request.Header.Set("Authorization", "Bearer "+client.token)
+	}
 
 	response, err := client.http.Do(request)
 
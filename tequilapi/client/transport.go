@@ -0,0 +1,99 @@
+/*
+ * Copyright (C) 2020 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const requestTimeout = 100 * time.Second
+
+// Options describes how the client reaches Tequilapi server
+type Options struct {
+	Address string
+	Port    int
+	// Socket is a path of Tequilapi unix domain socket, Address and Port are used when empty
+	Socket string
+	// TLS enables HTTPS when connecting to Address and Port
+	TLS bool
+	// CertFile is a PEM encoded certificate trusted in addition to system roots, e.g. self-signed certificate of Tequilapi
+	CertFile string
+}
+
+// NewClientWithOptions returns a new instance of Client connecting to Tequilapi via the transport given in options
+func NewClientWithOptions(options Options) (*Client, error) {
+	if options.Socket != "" {
+		return &Client{
+			http: newHTTPClientWithTransport("http://unix", "goclient-v0.1", unixSocketTransport(options.Socket)),
+		}, nil
+	}
+	if !options.TLS {
+		return NewClient(options.Address, options.Port), nil
+	}
+
+	tlsConfig, err := newTLSConfig(options.CertFile)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{
+		http: newHTTPClientWithTransport(
+			fmt.Sprintf("https://%s:%d", options.Address, options.Port),
+			"goclient-v0.1",
+			&http.Transport{TLSClientConfig: tlsConfig},
+		),
+	}, nil
+}
+
+func unixSocketTransport(socket string) *http.Transport {
+	dialer := net.Dialer{Timeout: 5 * time.Second}
+	return &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return dialer.DialContext(ctx, "unix", socket)
+		},
+	}
+}
+
+func newTLSConfig(certFile string) (*tls.Config, error) {
+	config := &tls.Config{MinVersion: tls.VersionTLS12}
+	if certFile == "" {
+		return config, nil
+	}
+
+	pem, err := ioutil.ReadFile(certFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read Tequilapi certificate")
+	}
+	roots, err := x509.SystemCertPool()
+	if err != nil {
+		roots = x509.NewCertPool()
+	}
+	if !roots.AppendCertsFromPEM(pem) {
+		return nil, errors.Errorf("no certificates found in %s", certFile)
+	}
+	config.RootCAs = roots
+	return config, nil
+}
@@ -0,0 +1,84 @@
+/*
+ * Copyright (C) 2020 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package client
+
+import (
+	"encoding/pem"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func healthcheckHandler() http.Handler {
+	return http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		resp.Write([]byte(`{"version": "1.0.0"}`))
+	})
+}
+
+func Test_NewClientWithOptions_ConnectsViaSocket(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tequilapi-client")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	socket := filepath.Join(dir, "tequilapi.sock")
+
+	listener, err := net.Listen("unix", socket)
+	assert.NoError(t, err)
+	server := httptest.NewUnstartedServer(healthcheckHandler())
+	server.Listener = listener
+	server.Start()
+	defer server.Close()
+
+	client, err := NewClientWithOptions(Options{Address: "127.0.0.1", Port: 1, Socket: socket})
+	assert.NoError(t, err)
+	healthcheck, err := client.Healthcheck()
+	assert.NoError(t, err)
+	assert.Equal(t, "1.0.0", healthcheck.Version)
+}
+
+func Test_NewClientWithOptions_TrustsGivenCertificate(t *testing.T) {
+	server := httptest.NewTLSServer(healthcheckHandler())
+	defer server.Close()
+	host, portValue, err := net.SplitHostPort(server.Listener.Addr().String())
+	assert.NoError(t, err)
+	port, err := strconv.Atoi(portValue)
+	assert.NoError(t, err)
+
+	untrusted, err := NewClientWithOptions(Options{Address: host, Port: port, TLS: true})
+	assert.NoError(t, err)
+	_, err = untrusted.Healthcheck()
+	assert.Error(t, err)
+
+	certFile, err := ioutil.TempFile("", "tequilapi-cert")
+	assert.NoError(t, err)
+	defer os.Remove(certFile.Name())
+	assert.NoError(t, pem.Encode(certFile, &pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+	certFile.Close()
+
+	client, err := NewClientWithOptions(Options{Address: host, Port: port, TLS: true, CertFile: certFile.Name()})
+	assert.NoError(t, err)
+	healthcheck, err := client.Healthcheck()
+	assert.NoError(t, err)
+	assert.Equal(t, "1.0.0", healthcheck.Version)
+}
@@ -0,0 +1,194 @@
+/*
+ * Copyright (C) 2020 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package endpoints
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/mysteriumnetwork/node/core/audit"
+	"github.com/mysteriumnetwork/node/tequilapi/utils"
+	"github.com/mysteriumnetwork/node/tequilapi/validation"
+)
+
+const defaultAuditLimit = 100
+
+// auditRecordList defines audit record list representable as json
+// swagger:model AuditRecordListDTO
+type auditRecordList struct {
+	Records []auditRecord `json:"records"`
+}
+
+// auditRecord represents a single API request recorded in the audit log
+// swagger:model AuditRecordDTO
+type auditRecord struct {
+	// example: 1
+	ID int `json:"id"`
+
+	// example: 2020-03-01T12:00:00Z
+	Time string `json:"time"`
+
+	// authenticated user, empty if the request was not authenticated
+	// example: myst
+	Username string `json:"username"`
+
+	// example: admin
+	Role string `json:"role"`
+
+	// address the request came from, empty for requests via unix domain socket
+	// example: 127.0.0.1:52044
+	RemoteAddr string `json:"remoteAddr"`
+
+	// example: POST
+	Method string `json:"method"`
+
+	// example: /services
+	Path string `json:"path"`
+
+	// query and body parameters with the secrets redacted
+	// example: {"body": {"providerId": "0x0000000000000000000000000000000000000001", "type": "openvpn"}}
+	Params map[string]interface{} `json:"params"`
+
+	// response status code
+	// example: 201
+	Status int `json:"status"`
+
+	// request handling duration in milliseconds
+	// example: 12
+	DurationMs int64 `json:"durationMs"`
+}
+
+type auditRecords interface {
+	Records(filter audit.Filter) ([]audit.Record, error)
+}
+
+type auditEndpoint struct {
+	log auditRecords
+}
+
+// NewAuditEndpoint creates and returns audit log endpoint
+func NewAuditEndpoint(log auditRecords) *auditEndpoint {
+	return &auditEndpoint{log: log}
+}
+
+// swagger:operation GET /audit Audit audit
+// ---
+// summary: Returns audit log
+// description: Returns API requests which may have changed the node state, latest first
+// parameters:
+// - in: query
+//   name: username
+//   description: User who made the requests
+//   type: string
+// - in: query
+//   name: method
+//   description: HTTP method of the requests
+//   type: string
+// - in: query
+//   name: path
+//   description: Include requests to paths starting with the given prefix
+//   type: string
+// - in: query
+//   name: from
+//   description: Include requests made at or after the given RFC3339 time
+//   type: string
+// - in: query
+//   name: to
+//   description: Include requests made before the given RFC3339 time
+//   type: string
+// - in: query
+//   name: limit
+//   description: Maximum number of records to return, 100 by default
+//   type: integer
+// responses:
+//   200:
+//     description: List of audit records
+//     schema:
+//       "$ref": "#/definitions/AuditRecordListDTO"
+//   422:
+//     description: Parameters validation error
+//     schema:
+//       "$ref": "#/definitions/ValidationErrorDTO"
+//   500:
+//     description: Internal server error
+//     schema:
+//       "$ref": "#/definitions/ErrorMessageDTO"
+func (ae *auditEndpoint) List(resp http.ResponseWriter, request *http.Request, _ httprouter.Params) {
+	filter, errorMap := parseAuditQuery(request)
+	if errorMap.HasErrors() {
+		utils.SendValidationErrorMessage(resp, errorMap)
+		return
+	}
+
+	records, err := ae.log.Records(filter)
+	if err != nil {
+		utils.SendError(resp, err, http.StatusInternalServerError)
+		return
+	}
+
+	result := auditRecordList{Records: make([]auditRecord, len(records))}
+	for i, r := range records {
+		result.Records[i] = auditRecordToDto(r)
+	}
+	utils.WriteAsJSON(result, resp)
+}
+
+func parseAuditQuery(request *http.Request) (audit.Filter, *validation.FieldErrorMap) {
+	query := request.URL.Query()
+	errorMap := validation.NewErrorMap()
+	filter := audit.Filter{
+		Username:   query.Get("username"),
+		Method:     query.Get("method"),
+		PathPrefix: query.Get("path"),
+		Limit:      defaultAuditLimit,
+	}
+	filter.From = parseQueryTime(query, "from", errorMap)
+	filter.To = parseQueryTime(query, "to", errorMap)
+
+	if value := query.Get("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit <= 0 {
+			errorMap.ForField("limit").AddError("invalid", "Limit must be a positive number")
+		}
+		filter.Limit = limit
+	}
+	return filter, errorMap
+}
+
+func auditRecordToDto(r audit.Record) auditRecord {
+	return auditRecord{
+		ID:         r.ID,
+		Time:       r.Time.Format(time.RFC3339),
+		Username:   r.Username,
+		Role:       r.Role,
+		RemoteAddr: r.RemoteAddr,
+		Method:     r.Method,
+		Path:       r.Path,
+		Params:     r.Params,
+		Status:     r.Status,
+		DurationMs: int64(r.Duration / time.Millisecond),
+	}
+}
+
+// AddRoutesForAudit attaches audit log endpoints to router
+func AddRoutesForAudit(router *httprouter.Router, log auditRecords) {
+	ae := NewAuditEndpoint(log)
+	router.GET("/audit", ae.List)
+}
@@ -0,0 +1,97 @@
+/*
+ * Copyright (C) 2020 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package endpoints
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/mysteriumnetwork/node/core/audit"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockAuditRecords struct {
+	records []audit.Record
+	filter  audit.Filter
+}
+
+func (mar *mockAuditRecords) Records(filter audit.Filter) ([]audit.Record, error) {
+	mar.filter = filter
+	return mar.records, nil
+}
+
+func Test_Audit(t *testing.T) {
+	mockLog := &mockAuditRecords{records: []audit.Record{
+		{
+			ID:         1,
+			Time:       time.Date(2020, 3, 1, 12, 0, 0, 0, time.UTC),
+			Username:   "myst",
+			Role:       "admin",
+			RemoteAddr: "127.0.0.1:52044",
+			Method:     http.MethodPost,
+			Path:       "/services",
+			Params:     map[string]interface{}{"body": map[string]interface{}{"type": "openvpn"}},
+			Status:     http.StatusCreated,
+			Duration:   12 * time.Millisecond,
+		},
+	}}
+	router := httprouter.New()
+	AddRoutesForAudit(router, mockLog)
+
+	req, err := http.NewRequest(http.MethodGet, "/audit?username=myst&path=/services&from=2020-03-01T00:00:00Z&limit=10", nil)
+	assert.NoError(t, err)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, audit.Filter{
+		Username:   "myst",
+		PathPrefix: "/services",
+		From:       time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC),
+		Limit:      10,
+	}, mockLog.filter)
+	assert.JSONEq(t, `{
+		"records": [{
+			"id": 1,
+			"time": "2020-03-01T12:00:00Z",
+			"username": "myst",
+			"role": "admin",
+			"remoteAddr": "127.0.0.1:52044",
+			"method": "POST",
+			"path": "/services",
+			"params": {"body": {"type": "openvpn"}},
+			"status": 201,
+			"durationMs": 12
+		}]
+	}`, resp.Body.String())
+}
+
+func Test_Audit_InvalidLimit(t *testing.T) {
+	router := httprouter.New()
+	AddRoutesForAudit(router, &mockAuditRecords{})
+
+	req, err := http.NewRequest(http.MethodGet, "/audit?limit=0", nil)
+	assert.NoError(t, err)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.Code)
+}
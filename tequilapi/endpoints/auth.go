@@ -25,6 +25,7 @@ import (
 
 	"github.com/mysteriumnetwork/node/core/auth"
 	"github.com/mysteriumnetwork/node/tequilapi/utils"
+	"github.com/mysteriumnetwork/node/tequilapi/validation"
 )
 
 type authenticationAPI struct {
@@ -33,12 +34,17 @@ type authenticationAPI struct {
 }
 
 type jwtAuthenticator interface {
-	CreateToken(username string) (auth.JWT, error)
+	CreateToken(user auth.User) (auth.JWT, error)
 }
 
 type authenticator interface {
 	CheckCredentials(username, password string) error
 	ChangePassword(username, oldPassword, newPassword string) error
+	User(username string) (auth.User, error)
+	Users() ([]auth.User, error)
+	CreateUser(username, password string, role auth.Role) (auth.User, error)
+	UpdateUser(username, password string, role auth.Role) (auth.User, error)
+	DeleteUser(username string) error
 }
 
 // swagger:model LoginRequest
@@ -54,6 +60,38 @@ type changePasswordRequest struct {
 	NewPassword string `json:"new_password"`
 }
 
+// swagger:model UserDTO
+type userDTO struct {
+	Username string `json:"username"`
+	// enum: monitor,consumer,provider,admin
+	Role string `json:"role"`
+}
+
+// swagger:model UserListDTO
+type userListDTO struct {
+	Users []userDTO `json:"users"`
+}
+
+// swagger:model CreateUserRequest
+type createUserRequest struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+	// enum: monitor,consumer,provider,admin
+	Role string `json:"role"`
+}
+
+// swagger:model UpdateUserRequest
+type updateUserRequest struct {
+	// password is left unchanged if omitted
+	Password string `json:"password,omitempty"`
+	// enum: monitor,consumer,provider,admin
+	Role string `json:"role"`
+}
+
+func newUserDTO(user auth.User) userDTO {
+	return userDTO{Username: user.Username, Role: string(user.Role)}
+}
+
 // swagger:operation POST /auth/login Authentication Login
 // ---
 // summary: Login
@@ -66,6 +104,8 @@ type changePasswordRequest struct {
 // responses:
 //   200:
 //     description: Logged in successfully
+//     schema:
+//       "$ref": "#/definitions/UserDTO"
 //   400:
 //     description: Body parsing error
 //     schema:
@@ -89,7 +129,13 @@ func (api *authenticationAPI) Login(httpRes http.ResponseWriter, httpReq *http.R
 		return
 	}
 
-	jwtToken, err := api.jwtAuthenticator.CreateToken(req.Username)
+	user, err := api.authenticator.User(req.Username)
+	if err != nil {
+		utils.SendError(httpRes, err, http.StatusUnauthorized)
+		return
+	}
+
+	jwtToken, err := api.jwtAuthenticator.CreateToken(user)
 	if err != nil {
 		utils.SendError(httpRes, err, http.StatusBadRequest)
 		return
@@ -103,12 +149,13 @@ func (api *authenticationAPI) Login(httpRes http.ResponseWriter, httpReq *http.R
 		Secure:   false,
 		Path:     "/",
 	})
+	utils.WriteAsJSON(newUserDTO(user), httpRes)
 }
 
 // swagger:operation PUT /auth/password Authentication changePassword
 // ---
 // summary: Change password
-// description: Changes user password
+// description: Changes user password, session tokens issued with the previous password are revoked
 // parameters:
 //   - in: body
 //     name: body
@@ -140,6 +187,204 @@ func (api *authenticationAPI) ChangePassword(httpRes http.ResponseWriter, httpRe
 	}
 }
 
+// swagger:operation GET /auth/users Authentication listUsers
+// ---
+// summary: Returns users
+// description: Returns users allowed to access Tequilapi and their roles
+// responses:
+//   200:
+//     description: List of users
+//     schema:
+//       "$ref": "#/definitions/UserListDTO"
+//   500:
+//     description: Internal server error
+//     schema:
+//       "$ref": "#/definitions/ErrorMessageDTO"
+func (api *authenticationAPI) ListUsers(httpRes http.ResponseWriter, _ *http.Request, _ httprouter.Params) {
+	users, err := api.authenticator.Users()
+	if err != nil {
+		utils.SendError(httpRes, err, http.StatusInternalServerError)
+		return
+	}
+
+	list := userListDTO{Users: []userDTO{}}
+	for _, user := range users {
+		list.Users = append(list.Users, newUserDTO(user))
+	}
+	utils.WriteAsJSON(list, httpRes)
+}
+
+// swagger:operation POST /auth/users Authentication createUser
+// ---
+// summary: Creates user
+// description: Creates user allowed to access Tequilapi with the given role
+// parameters:
+//   - in: body
+//     name: body
+//     schema:
+//       $ref: "#/definitions/CreateUserRequest"
+// responses:
+//   200:
+//     description: User created
+//     schema:
+//       "$ref": "#/definitions/UserDTO"
+//   400:
+//     description: Body parsing error
+//     schema:
+//       "$ref": "#/definitions/ErrorMessageDTO"
+//   409:
+//     description: User already exists
+//     schema:
+//       "$ref": "#/definitions/ErrorMessageDTO"
+//   422:
+//     description: Parameters validation error
+//     schema:
+//       "$ref": "#/definitions/ValidationErrorDTO"
+//   500:
+//     description: Internal server error
+//     schema:
+//       "$ref": "#/definitions/ErrorMessageDTO"
+func (api *authenticationAPI) CreateUser(httpRes http.ResponseWriter, httpReq *http.Request, _ httprouter.Params) {
+	req := createUserRequest{}
+	if err := json.NewDecoder(httpReq.Body).Decode(&req); err != nil {
+		utils.SendError(httpRes, err, http.StatusBadRequest)
+		return
+	}
+
+	errorMap := validateUserRequest(req.Role)
+	if req.Username == "" {
+		errorMap.ForField("username").AddError("required", "Field is required")
+	}
+	if req.Password == "" {
+		errorMap.ForField("password").AddError("required", "Field is required")
+	}
+	if errorMap.HasErrors() {
+		utils.SendValidationErrorMessage(httpRes, errorMap)
+		return
+	}
+
+	user, err := api.authenticator.CreateUser(req.Username, req.Password, auth.Role(req.Role))
+	if err != nil {
+		utils.SendError(httpRes, err, userErrorStatus(err))
+		return
+	}
+	utils.WriteAsJSON(newUserDTO(user), httpRes)
+}
+
+// swagger:operation PUT /auth/users/{username} Authentication updateUser
+// ---
+// summary: Updates user
+// description: Changes the role of user and the password if it is given
+// parameters:
+//   - in: path
+//     name: username
+//     type: string
+//     required: true
+//   - in: body
+//     name: body
+//     schema:
+//       $ref: "#/definitions/UpdateUserRequest"
+// responses:
+//   200:
+//     description: User updated
+//     schema:
+//       "$ref": "#/definitions/UserDTO"
+//   400:
+//     description: Body parsing error
+//     schema:
+//       "$ref": "#/definitions/ErrorMessageDTO"
+//   404:
+//     description: User not found
+//     schema:
+//       "$ref": "#/definitions/ErrorMessageDTO"
+//   409:
+//     description: The only admin can't be demoted
+//     schema:
+//       "$ref": "#/definitions/ErrorMessageDTO"
+//   422:
+//     description: Parameters validation error
+//     schema:
+//       "$ref": "#/definitions/ValidationErrorDTO"
+//   500:
+//     description: Internal server error
+//     schema:
+//       "$ref": "#/definitions/ErrorMessageDTO"
+func (api *authenticationAPI) UpdateUser(httpRes http.ResponseWriter, httpReq *http.Request, params httprouter.Params) {
+	req := updateUserRequest{}
+	if err := json.NewDecoder(httpReq.Body).Decode(&req); err != nil {
+		utils.SendError(httpRes, err, http.StatusBadRequest)
+		return
+	}
+
+	errorMap := validateUserRequest(req.Role)
+	if errorMap.HasErrors() {
+		utils.SendValidationErrorMessage(httpRes, errorMap)
+		return
+	}
+
+	user, err := api.authenticator.UpdateUser(params.ByName("username"), req.Password, auth.Role(req.Role))
+	if err != nil {
+		utils.SendError(httpRes, err, userErrorStatus(err))
+		return
+	}
+	utils.WriteAsJSON(newUserDTO(user), httpRes)
+}
+
+// swagger:operation DELETE /auth/users/{username} Authentication deleteUser
+// ---
+// summary: Deletes user
+// description: Deletes user, so that it can't access Tequilapi anymore
+// parameters:
+//   - in: path
+//     name: username
+//     type: string
+//     required: true
+// responses:
+//   202:
+//     description: User deleted
+//   404:
+//     description: User not found
+//     schema:
+//       "$ref": "#/definitions/ErrorMessageDTO"
+//   409:
+//     description: The only admin can't be deleted
+//     schema:
+//       "$ref": "#/definitions/ErrorMessageDTO"
+//   500:
+//     description: Internal server error
+//     schema:
+//       "$ref": "#/definitions/ErrorMessageDTO"
+func (api *authenticationAPI) DeleteUser(httpRes http.ResponseWriter, _ *http.Request, params httprouter.Params) {
+	if err := api.authenticator.DeleteUser(params.ByName("username")); err != nil {
+		utils.SendError(httpRes, err, userErrorStatus(err))
+		return
+	}
+	httpRes.WriteHeader(http.StatusAccepted)
+}
+
+func validateUserRequest(role string) *validation.FieldErrorMap {
+	errorMap := validation.NewErrorMap()
+	if role == "" {
+		errorMap.ForField("role").AddError("required", "Field is required")
+	} else if !auth.Role(role).Valid() {
+		errorMap.ForField("role").AddError("invalid", "Role is not one of: monitor, consumer, provider, admin")
+	}
+	return errorMap
+}
+
+func userErrorStatus(err error) int {
+	switch err {
+	case auth.ErrUserNotFound:
+		return http.StatusNotFound
+	case auth.ErrUserExists, auth.ErrLastAdmin:
+		return http.StatusConflict
+	case auth.ErrInvalidRole:
+		return http.StatusUnprocessableEntity
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
 func toLoginRequest(req *http.Request) (*loginRequest, error) {
 	var loginReq = loginRequest{}
 	if err := json.NewDecoder(req.Body).Decode(&loginReq); err != nil {
@@ -171,4 +416,8 @@ func AddRoutesForAuthentication(
 	}
 	router.PUT("/auth/password", api.ChangePassword)
 	router.POST(TequilapiLoginEndpointPath, api.Login)
+	router.GET("/auth/users", api.ListUsers)
+	router.POST("/auth/users", api.CreateUser)
+	router.PUT("/auth/users/:username", api.UpdateUser)
+	router.DELETE("/auth/users/:username", api.DeleteUser)
 }
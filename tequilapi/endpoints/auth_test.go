@@ -0,0 +1,168 @@
+/*
+ * Copyright (C) 2020 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package endpoints
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/mysteriumnetwork/node/core/auth"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockAuthenticator struct {
+	users map[string]auth.User
+}
+
+func (m *mockAuthenticator) CheckCredentials(username, password string) error {
+	if _, ok := m.users[username]; !ok || password != "secret" {
+		return auth.ErrUnauthorized
+	}
+	return nil
+}
+
+func (m *mockAuthenticator) ChangePassword(username, oldPassword, newPassword string) error {
+	return m.CheckCredentials(username, oldPassword)
+}
+
+func (m *mockAuthenticator) User(username string) (auth.User, error) {
+	user, ok := m.users[username]
+	if !ok {
+		return auth.User{}, auth.ErrUserNotFound
+	}
+	return user, nil
+}
+
+func (m *mockAuthenticator) Users() ([]auth.User, error) {
+	var users []auth.User
+	for _, user := range m.users {
+		users = append(users, user)
+	}
+	return users, nil
+}
+
+func (m *mockAuthenticator) CreateUser(username, password string, role auth.Role) (auth.User, error) {
+	if _, ok := m.users[username]; ok {
+		return auth.User{}, auth.ErrUserExists
+	}
+	m.users[username] = auth.User{Username: username, Role: role}
+	return m.users[username], nil
+}
+
+func (m *mockAuthenticator) UpdateUser(username, password string, role auth.Role) (auth.User, error) {
+	if _, ok := m.users[username]; !ok {
+		return auth.User{}, auth.ErrUserNotFound
+	}
+	m.users[username] = auth.User{Username: username, Role: role}
+	return m.users[username], nil
+}
+
+func (m *mockAuthenticator) DeleteUser(username string) error {
+	if m.users[username].Role == auth.RoleAdmin {
+		return auth.ErrLastAdmin
+	}
+	delete(m.users, username)
+	return nil
+}
+
+type mockJWTAuthenticator struct{}
+
+func (m *mockJWTAuthenticator) CreateToken(user auth.User) (auth.JWT, error) {
+	return auth.JWT{Token: user.Username + ":" + string(user.Role), ExpirationTime: time.Now().Add(time.Hour)}, nil
+}
+
+func newAuthTestRouter() (*httprouter.Router, *mockAuthenticator) {
+	authenticator := &mockAuthenticator{users: map[string]auth.User{"myst": {Username: "myst", Role: auth.RoleAdmin}}}
+	router := httprouter.New()
+	AddRoutesForAuthentication(router, authenticator, &mockJWTAuthenticator{})
+	return router, authenticator
+}
+
+func TestLoginIssuesTokenWithRole(t *testing.T) {
+	router, _ := newAuthTestRouter()
+
+	req := httptest.NewRequest(http.MethodPost, "/auth/login", bytes.NewBufferString(`{"username": "myst", "password": "secret"}`))
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.JSONEq(t, `{"username": "myst", "role": "admin"}`, resp.Body.String())
+	assert.Contains(t, resp.Header().Get("Set-Cookie"), "token=myst:admin")
+}
+
+func TestUserManagement(t *testing.T) {
+	router, authenticator := newAuthTestRouter()
+
+	req := httptest.NewRequest(http.MethodPost, "/auth/users", bytes.NewBufferString(`{"username": "noc", "password": "secret", "role": "monitor"}`))
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.JSONEq(t, `{"username": "noc", "role": "monitor"}`, resp.Body.String())
+
+	req = httptest.NewRequest(http.MethodPost, "/auth/users", bytes.NewBufferString(`{"username": "noc", "password": "secret", "role": "monitor"}`))
+	resp = httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	assert.Equal(t, http.StatusConflict, resp.Code)
+
+	req = httptest.NewRequest(http.MethodPut, "/auth/users/noc", bytes.NewBufferString(`{"role": "consumer"}`))
+	resp = httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, auth.RoleConsumer, authenticator.users["noc"].Role)
+
+	req = httptest.NewRequest(http.MethodDelete, "/auth/users/noc", nil)
+	resp = httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	assert.Equal(t, http.StatusAccepted, resp.Code)
+
+	req = httptest.NewRequest(http.MethodDelete, "/auth/users/myst", nil)
+	resp = httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	assert.Equal(t, http.StatusConflict, resp.Code)
+
+	req = httptest.NewRequest(http.MethodGet, "/auth/users", nil)
+	resp = httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.JSONEq(t, `{"users": [{"username": "myst", "role": "admin"}]}`, resp.Body.String())
+}
+
+func TestCreateUserValidatesRole(t *testing.T) {
+	router, _ := newAuthTestRouter()
+
+	req := httptest.NewRequest(http.MethodPost, "/auth/users", bytes.NewBufferString(`{"username": "noc", "role": "root"}`))
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.Code)
+	assert.JSONEq(
+		t,
+		`{
+			"message": "validation_error",
+			"errors": {
+				"password": [ {"code": "required" , "message": "Field is required"} ],
+				"role": [ {"code": "invalid" , "message": "Role is not one of: monitor, consumer, provider, admin"} ]
+			}
+		}`,
+		resp.Body.String(),
+	)
+}
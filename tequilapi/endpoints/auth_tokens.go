@@ -0,0 +1,277 @@
+/*
+ * Copyright (C) 2020 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package endpoints
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+
+	"github.com/mysteriumnetwork/node/core/auth"
+	"github.com/mysteriumnetwork/node/tequilapi/utils"
+	"github.com/mysteriumnetwork/node/tequilapi/validation"
+)
+
+type apiTokens interface {
+	Create(name string, owner auth.User, scope auth.Role, expiresAt time.Time) (string, auth.APIToken, error)
+	List() ([]auth.APIToken, error)
+	Get(id string) (auth.APIToken, error)
+	Revoke(id string) error
+}
+
+type apiTokensAPI struct {
+	tokens apiTokens
+}
+
+// swagger:model APITokenDTO
+type apiTokenDTO struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Owner string `json:"owner,omitempty"`
+	// enum: monitor,consumer,provider,admin
+	Scope     string     `json:"scope"`
+	CreatedAt time.Time  `json:"created_at"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// swagger:model APITokenListDTO
+type apiTokenListDTO struct {
+	Tokens []apiTokenDTO `json:"tokens"`
+}
+
+// swagger:model CreatedAPITokenDTO
+type createdAPITokenDTO struct {
+	apiTokenDTO
+	// bearer token, it is shown only once
+	Token string `json:"token"`
+}
+
+// swagger:model CreateAPITokenRequest
+type createAPITokenRequest struct {
+	Name string `json:"name"`
+	// role the token acts with, role of the creating user if omitted
+	// enum: monitor,consumer,provider,admin
+	Scope string `json:"scope,omitempty"`
+	// token never expires if omitted
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+func newAPITokenDTO(token auth.APIToken) apiTokenDTO {
+	dto := apiTokenDTO{
+		ID:        token.ID,
+		Name:      token.Name,
+		Owner:     token.Owner,
+		Scope:     string(token.Scope),
+		CreatedAt: token.CreatedAt,
+	}
+	if !token.ExpiresAt.IsZero() {
+		dto.ExpiresAt = &token.ExpiresAt
+	}
+	return dto
+}
+
+// requestUser returns the authenticated user of request
+func requestUser(resp http.ResponseWriter, request *http.Request) (auth.User, bool) {
+	user, ok := auth.UserFromContext(request.Context())
+	if !ok {
+		utils.SendErrorMessage(resp, "authentication required", http.StatusUnauthorized)
+	}
+	return user, ok
+}
+
+// canManageToken checks if the user is the owner of token or an admin
+func canManageToken(user auth.User, token auth.APIToken) bool {
+	return user.Role == auth.RoleAdmin || user.Username == token.Owner
+}
+
+// swagger:operation GET /auth/tokens Authentication listAPITokens
+// ---
+// summary: Returns API tokens
+// description: Returns API tokens of the user, admins get the tokens of all users
+// responses:
+//   200:
+//     description: List of API tokens
+//     schema:
+//       "$ref": "#/definitions/APITokenListDTO"
+//   500:
+//     description: Internal server error
+//     schema:
+//       "$ref": "#/definitions/ErrorMessageDTO"
+func (api *apiTokensAPI) List(resp http.ResponseWriter, request *http.Request, _ httprouter.Params) {
+	tokens, err := api.tokens.List()
+	if err != nil {
+		utils.SendError(resp, err, http.StatusInternalServerError)
+		return
+	}
+
+	user, ok := requestUser(resp, request)
+	if !ok {
+		return
+	}
+	list := apiTokenListDTO{Tokens: []apiTokenDTO{}}
+	for _, token := range tokens {
+		if canManageToken(user, token) {
+			list.Tokens = append(list.Tokens, newAPITokenDTO(token))
+		}
+	}
+	utils.WriteAsJSON(list, resp)
+}
+
+// swagger:operation POST /auth/tokens Authentication createAPIToken
+// ---
+// summary: Creates API token
+// description: Creates long-lived API token, which is accepted as a bearer authorization header
+// parameters:
+//   - in: body
+//     name: body
+//     schema:
+//       $ref: "#/definitions/CreateAPITokenRequest"
+// responses:
+//   200:
+//     description: API token created
+//     schema:
+//       "$ref": "#/definitions/CreatedAPITokenDTO"
+//   400:
+//     description: Body parsing error
+//     schema:
+//       "$ref": "#/definitions/ErrorMessageDTO"
+//   401:
+//     description: Authentication required
+//     schema:
+//       "$ref": "#/definitions/ErrorMessageDTO"
+//   403:
+//     description: Scope exceeds the role of user or the user has no username, e.g. when calling via unix socket
+//     schema:
+//       "$ref": "#/definitions/ErrorMessageDTO"
+//   422:
+//     description: Parameters validation error
+//     schema:
+//       "$ref": "#/definitions/ValidationErrorDTO"
+//   500:
+//     description: Internal server error
+//     schema:
+//       "$ref": "#/definitions/ErrorMessageDTO"
+func (api *apiTokensAPI) Create(resp http.ResponseWriter, request *http.Request, _ httprouter.Params) {
+	user, ok := requestUser(resp, request)
+	if !ok {
+		return
+	}
+	if user.Username == "" {
+		utils.SendErrorMessage(resp, auth.ErrAPITokenOwner.Error(), http.StatusForbidden)
+		return
+	}
+
+	req := createAPITokenRequest{}
+	if err := json.NewDecoder(request.Body).Decode(&req); err != nil {
+		utils.SendError(resp, err, http.StatusBadRequest)
+		return
+	}
+
+	scope := user.Role
+	if req.Scope != "" {
+		scope = auth.Role(req.Scope)
+	}
+	var expiresAt time.Time
+	if req.ExpiresAt != nil {
+		expiresAt = *req.ExpiresAt
+	}
+
+	errorMap := validateCreateAPITokenRequest(req.Name, scope, expiresAt)
+	if errorMap.HasErrors() {
+		utils.SendValidationErrorMessage(resp, errorMap)
+		return
+	}
+
+	secret, token, err := api.tokens.Create(req.Name, user, scope, expiresAt)
+	if err == auth.ErrAPITokenScope {
+		utils.SendError(resp, err, http.StatusForbidden)
+		return
+	}
+	if err != nil {
+		utils.SendError(resp, err, http.StatusInternalServerError)
+		return
+	}
+	utils.WriteAsJSON(createdAPITokenDTO{apiTokenDTO: newAPITokenDTO(token), Token: secret}, resp)
+}
+
+// swagger:operation DELETE /auth/tokens/{id} Authentication revokeAPIToken
+// ---
+// summary: Revokes API token
+// description: Revokes API token, so that it is not accepted anymore
+// parameters:
+//   - in: path
+//     name: id
+//     type: string
+//     required: true
+// responses:
+//   202:
+//     description: API token revoked
+//   404:
+//     description: API token not found
+//     schema:
+//       "$ref": "#/definitions/ErrorMessageDTO"
+//   500:
+//     description: Internal server error
+//     schema:
+//       "$ref": "#/definitions/ErrorMessageDTO"
+func (api *apiTokensAPI) Revoke(resp http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	user, ok := requestUser(resp, request)
+	if !ok {
+		return
+	}
+
+	token, err := api.tokens.Get(params.ByName("id"))
+	if err == auth.ErrAPITokenNotFound || (err == nil && !canManageToken(user, token)) {
+		utils.SendErrorMessage(resp, auth.ErrAPITokenNotFound.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		utils.SendError(resp, err, http.StatusInternalServerError)
+		return
+	}
+
+	if err := api.tokens.Revoke(token.ID); err != nil {
+		utils.SendError(resp, err, http.StatusInternalServerError)
+		return
+	}
+	resp.WriteHeader(http.StatusAccepted)
+}
+
+func validateCreateAPITokenRequest(name string, scope auth.Role, expiresAt time.Time) *validation.FieldErrorMap {
+	errorMap := validation.NewErrorMap()
+	if name == "" {
+		errorMap.ForField("name").AddError("required", "Field is required")
+	}
+	if !scope.Valid() {
+		errorMap.ForField("scope").AddError("invalid", "Scope is not one of: monitor, consumer, provider, admin")
+	}
+	if !expiresAt.IsZero() && !expiresAt.After(time.Now()) {
+		errorMap.ForField("expires_at").AddError("invalid", "Expiry has to be in the future")
+	}
+	return errorMap
+}
+
+// AddRoutesForAPITokens registers /auth/tokens endpoints in Tequilapi
+func AddRoutesForAPITokens(router *httprouter.Router, tokens apiTokens) {
+	api := &apiTokensAPI{tokens: tokens}
+	router.GET("/auth/tokens", api.List)
+	router.POST("/auth/tokens", api.Create)
+	router.DELETE("/auth/tokens/:id", api.Revoke)
+}
@@ -0,0 +1,149 @@
+/*
+ * Copyright (C) 2020 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package endpoints
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/mysteriumnetwork/node/core/auth"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockAPITokens struct {
+	tokens []auth.APIToken
+}
+
+func (m *mockAPITokens) Create(name string, owner auth.User, scope auth.Role, expiresAt time.Time) (string, auth.APIToken, error) {
+	if !owner.Role.Covers(scope) {
+		return "", auth.APIToken{}, auth.ErrAPITokenScope
+	}
+	token := auth.APIToken{ID: name + "-id", Name: name, Owner: owner.Username, Scope: scope, ExpiresAt: expiresAt}
+	m.tokens = append(m.tokens, token)
+	return "myst_" + token.ID + "_secret", token, nil
+}
+
+func (m *mockAPITokens) List() ([]auth.APIToken, error) {
+	return m.tokens, nil
+}
+
+func (m *mockAPITokens) Get(id string) (auth.APIToken, error) {
+	for _, token := range m.tokens {
+		if token.ID == id {
+			return token, nil
+		}
+	}
+	return auth.APIToken{}, auth.ErrAPITokenNotFound
+}
+
+func (m *mockAPITokens) Revoke(id string) error {
+	for i, token := range m.tokens {
+		if token.ID == id {
+			m.tokens = append(m.tokens[:i], m.tokens[i+1:]...)
+			return nil
+		}
+	}
+	return auth.ErrAPITokenNotFound
+}
+
+func serveAsUser(router http.Handler, method, path, body string, user auth.User) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+	req = req.WithContext(auth.WithUser(req.Context(), user))
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	return resp
+}
+
+func TestAPITokensCreate(t *testing.T) {
+	tokens := &mockAPITokens{}
+	router := httprouter.New()
+	AddRoutesForAPITokens(router, tokens)
+	noc := auth.User{Username: "noc", Role: auth.RoleMonitor}
+
+	resp := serveAsUser(router, http.MethodPost, "/auth/tokens", `{"name": "dashboard"}`, noc)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.JSONEq(
+		t,
+		`{
+			"id": "dashboard-id",
+			"name": "dashboard",
+			"owner": "noc",
+			"scope": "monitor",
+			"created_at": "0001-01-01T00:00:00Z",
+			"token": "myst_dashboard-id_secret"
+		}`,
+		resp.Body.String(),
+	)
+
+	resp = serveAsUser(router, http.MethodPost, "/auth/tokens", `{"name": "escalate", "scope": "admin"}`, noc)
+	assert.Equal(t, http.StatusForbidden, resp.Code)
+
+	resp = serveAsUser(router, http.MethodPost, "/auth/tokens", `{"name": "ownerless"}`, auth.User{Role: auth.RoleAdmin})
+	assert.Equal(t, http.StatusForbidden, resp.Code)
+
+	req := httptest.NewRequest(http.MethodPost, "/auth/tokens", bytes.NewBufferString(`{"name": "anonymous"}`))
+	resp = httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	assert.Equal(t, http.StatusUnauthorized, resp.Code)
+	assert.Len(t, tokens.tokens, 1)
+
+	resp = serveAsUser(router, http.MethodPost, "/auth/tokens", `{"scope": "root", "expires_at": "2000-01-01T00:00:00Z"}`, noc)
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.Code)
+	assert.JSONEq(
+		t,
+		`{
+			"message": "validation_error",
+			"errors": {
+				"name": [ {"code": "required" , "message": "Field is required"} ],
+				"scope": [ {"code": "invalid" , "message": "Scope is not one of: monitor, consumer, provider, admin"} ],
+				"expires_at": [ {"code": "invalid" , "message": "Expiry has to be in the future"} ]
+			}
+		}`,
+		resp.Body.String(),
+	)
+}
+
+func TestAPITokensAreManagedByOwnerOrAdmin(t *testing.T) {
+	tokens := &mockAPITokens{tokens: []auth.APIToken{
+		{ID: "1", Name: "dashboard", Owner: "noc", Scope: auth.RoleMonitor},
+		{ID: "2", Name: "backup", Owner: "myst", Scope: auth.RoleAdmin},
+	}}
+	router := httprouter.New()
+	AddRoutesForAPITokens(router, tokens)
+	noc := auth.User{Username: "noc", Role: auth.RoleMonitor}
+	admin := auth.User{Username: "myst", Role: auth.RoleAdmin}
+
+	resp := serveAsUser(router, http.MethodGet, "/auth/tokens", "", noc)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.JSONEq(
+		t,
+		`{"tokens": [{"id": "1", "name": "dashboard", "owner": "noc", "scope": "monitor", "created_at": "0001-01-01T00:00:00Z"}]}`,
+		resp.Body.String(),
+	)
+
+	resp = serveAsUser(router, http.MethodDelete, "/auth/tokens/2", "", noc)
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+
+	resp = serveAsUser(router, http.MethodDelete, "/auth/tokens/1", "", admin)
+	assert.Equal(t, http.StatusAccepted, resp.Code)
+	assert.Len(t, tokens.tokens, 1)
+}
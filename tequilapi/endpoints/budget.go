@@ -0,0 +1,173 @@
+/*
+ * Copyright (C) 2020 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package endpoints
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/mysteriumnetwork/node/consumer/budget"
+	"github.com/mysteriumnetwork/node/identity"
+	"github.com/mysteriumnetwork/node/tequilapi/utils"
+	"github.com/mysteriumnetwork/node/tequilapi/validation"
+)
+
+// budgetRequest represents the consumer spending limits, zero limit means unlimited spending
+// swagger:model BudgetRequestDTO
+type budgetRequest struct {
+	// spending limit of a single session
+	PerSession uint64 `json:"perSession"`
+	// spending limit of a calendar day
+	PerDay uint64 `json:"perDay"`
+	// spending limit of a calendar month
+	PerMonth uint64 `json:"perMonth"`
+	// budget usage percents to publish alerts at
+	// example: [50, 80]
+	AlertThresholds []int `json:"alertThresholds"`
+}
+
+// budgetResponse represents the consumer spending limits and current spending
+// swagger:model BudgetDTO
+type budgetResponse struct {
+	budgetRequest
+	Spent budget.Spending `json:"spent"`
+}
+
+type spendingLimiter interface {
+	Budget(id identity.Identity) (budget.Budget, error)
+	SetBudget(id identity.Identity, budget budget.Budget) error
+	Spending(id identity.Identity) (budget.Spending, error)
+}
+
+type budgetEndpoint struct {
+	limiter spendingLimiter
+}
+
+// swagger:operation GET /identities/{id}/budget Budget
+// ---
+// summary: Returns consumer budget
+// description: Returns spending limits of the consumer identity and the amount spent in the current day and month
+// parameters:
+// - in: path
+//   name: id
+//   description: Identity stored in keystore
+//   type: string
+//   required: true
+// responses:
+//   200:
+//     description: Consumer budget
+//     schema:
+//       "$ref": "#/definitions/BudgetDTO"
+//   500:
+//     description: Internal server error
+//     schema:
+//       "$ref": "#/definitions/ErrorMessageDTO"
+func (be *budgetEndpoint) Budget(resp http.ResponseWriter, _ *http.Request, params httprouter.Params) {
+	be.writeBudget(resp, identity.FromAddress(params.ByName("id")))
+}
+
+// swagger:operation PUT /identities/{id}/budget BudgetSet
+// ---
+// summary: Sets consumer budget
+// description: Replaces spending limits of the consumer identity. Once a limit is reached, the ongoing session is disconnected and new sessions are refused.
+// parameters:
+// - in: path
+//   name: id
+//   description: Identity stored in keystore
+//   type: string
+//   required: true
+// - in: body
+//   name: body
+//   description: Spending limits
+//   schema:
+//     $ref: "#/definitions/BudgetRequestDTO"
+// responses:
+//   200:
+//     description: Consumer budget
+//     schema:
+//       "$ref": "#/definitions/BudgetDTO"
+//   400:
+//     description: Bad request
+//     schema:
+//       "$ref": "#/definitions/ErrorMessageDTO"
+//   422:
+//     description: Parameters validation error
+//     schema:
+//       "$ref": "#/definitions/ValidationErrorDTO"
+//   500:
+//     description: Internal server error
+//     schema:
+//       "$ref": "#/definitions/ErrorMessageDTO"
+func (be *budgetEndpoint) BudgetSet(resp http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	req := budgetRequest{}
+	if err := json.NewDecoder(request.Body).Decode(&req); err != nil {
+		utils.SendError(resp, err, http.StatusBadRequest)
+		return
+	}
+
+	newBudget := budget.Budget{
+		PerSession:      req.PerSession,
+		PerDay:          req.PerDay,
+		PerMonth:        req.PerMonth,
+		AlertThresholds: req.AlertThresholds,
+	}
+	if err := newBudget.Validate(); err != nil {
+		errorMap := validation.NewErrorMap()
+		errorMap.ForField("alertThresholds").AddError("invalid", err.Error())
+		utils.SendValidationErrorMessage(resp, errorMap)
+		return
+	}
+
+	id := identity.FromAddress(params.ByName("id"))
+	if err := be.limiter.SetBudget(id, newBudget); err != nil {
+		utils.SendError(resp, err, http.StatusInternalServerError)
+		return
+	}
+	be.writeBudget(resp, id)
+}
+
+func (be *budgetEndpoint) writeBudget(resp http.ResponseWriter, id identity.Identity) {
+	b, err := be.limiter.Budget(id)
+	if err != nil {
+		utils.SendError(resp, err, http.StatusInternalServerError)
+		return
+	}
+	spent, err := be.limiter.Spending(id)
+	if err != nil {
+		utils.SendError(resp, err, http.StatusInternalServerError)
+		return
+	}
+
+	utils.WriteAsJSON(budgetResponse{
+		budgetRequest: budgetRequest{
+			PerSession:      b.PerSession,
+			PerDay:          b.PerDay,
+			PerMonth:        b.PerMonth,
+			AlertThresholds: b.AlertThresholds,
+		},
+		Spent: spent,
+	}, resp)
+}
+
+// AddRoutesForBudget attaches consumer budget endpoints to router
+func AddRoutesForBudget(router *httprouter.Router, limiter spendingLimiter) {
+	be := &budgetEndpoint{limiter: limiter}
+	router.GET("/identities/:id/budget", be.Budget)
+	router.PUT("/identities/:id/budget", be.BudgetSet)
+}
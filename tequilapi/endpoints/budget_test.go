@@ -0,0 +1,123 @@
+/*
+ * Copyright (C) 2020 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package endpoints
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/mysteriumnetwork/node/consumer/budget"
+	"github.com/mysteriumnetwork/node/identity"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockSpendingLimiter struct {
+	budgets map[identity.Identity]budget.Budget
+}
+
+func (msl *mockSpendingLimiter) Budget(id identity.Identity) (budget.Budget, error) {
+	return msl.budgets[id], nil
+}
+
+func (msl *mockSpendingLimiter) SetBudget(id identity.Identity, b budget.Budget) error {
+	msl.budgets[id] = b
+	return nil
+}
+
+func (msl *mockSpendingLimiter) Spending(id identity.Identity) (budget.Spending, error) {
+	return budget.Spending{Day: 5, Month: 50}, nil
+}
+
+func Test_Budget(t *testing.T) {
+	limiter := &mockSpendingLimiter{budgets: map[identity.Identity]budget.Budget{
+		identity.FromAddress("0x1"): {PerDay: 100, AlertThresholds: []int{80}},
+	}}
+	router := httprouter.New()
+	AddRoutesForBudget(router, limiter)
+
+	req, err := http.NewRequest(http.MethodGet, "/identities/0x1/budget", nil)
+	assert.NoError(t, err)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.JSONEq(t, `{
+		"perSession": 0,
+		"perDay": 100,
+		"perMonth": 0,
+		"alertThresholds": [80],
+		"spent": {"day": 5, "month": 50}
+	}`, resp.Body.String())
+}
+
+func Test_BudgetSet(t *testing.T) {
+	tests := []struct {
+		name         string
+		body         string
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name:         "sets budget",
+			body:         `{"perSession": 10, "perMonth": 1000, "alertThresholds": [50, 90]}`,
+			expectedCode: http.StatusOK,
+			expectedBody: `{
+				"perSession": 10,
+				"perDay": 0,
+				"perMonth": 1000,
+				"alertThresholds": [50, 90],
+				"spent": {"day": 5, "month": 50}
+			}`,
+		},
+		{
+			name:         "rejects invalid thresholds",
+			body:         `{"perSession": 10, "alertThresholds": [100]}`,
+			expectedCode: http.StatusUnprocessableEntity,
+			expectedBody: `{
+				"message": "validation_error",
+				"errors": {
+					"alertThresholds": [{"code": "invalid", "message": "alert threshold 100% is out of range, expected 1-99"}]
+				}
+			}`,
+		},
+		{
+			name:         "rejects malformed body",
+			body:         `{`,
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"message": "unexpected EOF"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limiter := &mockSpendingLimiter{budgets: make(map[identity.Identity]budget.Budget)}
+			router := httprouter.New()
+			AddRoutesForBudget(router, limiter)
+
+			req, err := http.NewRequest(http.MethodPut, "/identities/0x1/budget", bytes.NewBufferString(tt.body))
+			assert.NoError(t, err)
+			resp := httptest.NewRecorder()
+			router.ServeHTTP(resp, req)
+
+			assert.Equal(t, tt.expectedCode, resp.Code)
+			assert.JSONEq(t, tt.expectedBody, resp.Body.String())
+		})
+	}
+}
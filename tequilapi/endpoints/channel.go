@@ -0,0 +1,148 @@
+/*
+ * Copyright (C) 2020 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package endpoints
+
+import (
+	"encoding/hex"
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/mysteriumnetwork/node/core/channel"
+	"github.com/mysteriumnetwork/node/identity"
+	"github.com/mysteriumnetwork/node/tequilapi/utils"
+)
+
+// channelResponse represents the state of the identity's payment channel
+// swagger:model ChannelDTO
+type channelResponse struct {
+	// example: 0x0000000000000000000000000000000000000001
+	Identity string `json:"identity"`
+
+	// example: 0x0000000000000000000000000000000000000002
+	AccountantID string `json:"accountantId"`
+
+	// on-chain address of the consumer channel
+	// example: 0x0000000000000000000000000000000000000003
+	ChannelAddress string `json:"channelAddress"`
+
+	// amount of tokens the consumer channel holds
+	// example: 1000000
+	Balance uint64 `json:"balance"`
+
+	// amount the provider has settled with the accountant
+	// example: 500000
+	Settled uint64 `json:"settled"`
+
+	// total amount the consumer has promised through the accountant
+	// example: 750000
+	GrandTotalPromised uint64 `json:"grandTotalPromised"`
+
+	// last promise the provider got from the accountant
+	LastPromise *accountantPromise `json:"lastPromise"`
+
+	// example: true
+	Registered bool `json:"registered"`
+}
+
+// accountantPromise represents a promise issued by the accountant
+// swagger:model AccountantPromiseDTO
+type accountantPromise struct {
+	// example: 0000000000000000000000000000000000000000000000000000000000000001
+	ChannelID string `json:"channelId"`
+
+	// example: 500000
+	Amount uint64 `json:"amount"`
+
+	// example: 1000
+	Fee uint64 `json:"fee"`
+
+	// example: 0000000000000000000000000000000000000000000000000000000000000002
+	Hashlock string `json:"hashlock"`
+
+	// example: 1234
+	AgreementID uint64 `json:"agreementId"`
+
+	// example: false
+	Revealed bool `json:"revealed"`
+}
+
+type channelInspector interface {
+	Inspect(id identity.Identity) (channel.Status, error)
+}
+
+type channelEndpoint struct {
+	inspector channelInspector
+}
+
+// swagger:operation GET /identities/{id}/channel Channel
+// ---
+// summary: Returns payment channel state
+// description: Returns the on-chain channel address and balance, settled and promised amounts, the last accountant promise and the registration status of the identity
+// parameters:
+// - in: path
+//   name: id
+//   description: Identity
+//   type: string
+//   required: true
+// responses:
+//   200:
+//     description: Payment channel state
+//     schema:
+//       "$ref": "#/definitions/ChannelDTO"
+//   500:
+//     description: Internal server error
+//     schema:
+//       "$ref": "#/definitions/ErrorMessageDTO"
+func (ce *channelEndpoint) Channel(resp http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	status, err := ce.inspector.Inspect(identity.FromAddress(params.ByName("id")))
+	if err != nil {
+		utils.SendError(resp, err, http.StatusInternalServerError)
+		return
+	}
+
+	utils.WriteAsJSON(mapChannelStatus(status), resp)
+}
+
+func mapChannelStatus(status channel.Status) channelResponse {
+	response := channelResponse{
+		Identity:           status.Identity.Address,
+		AccountantID:       status.AccountantID.Address,
+		ChannelAddress:     status.Address.Hex(),
+		Balance:            status.Balance,
+		Settled:            status.Settled,
+		GrandTotalPromised: status.GrandTotalPromised,
+		Registered:         status.Registered,
+	}
+	if p := status.LastPromise; p != nil {
+		response.LastPromise = &accountantPromise{
+			ChannelID:   hex.EncodeToString(p.ChannelID),
+			Amount:      p.Amount,
+			Fee:         p.Fee,
+			Hashlock:    hex.EncodeToString(p.Hashlock),
+			AgreementID: p.AgreementID,
+			Revealed:    p.Revealed,
+		}
+	}
+	return response
+}
+
+// AddRoutesForChannel attaches payment channel inspection endpoints to router
+func AddRoutesForChannel(router *httprouter.Router, inspector channelInspector) {
+	ce := &channelEndpoint{inspector: inspector}
+	router.GET("/identities/:id/channel", ce.Channel)
+}
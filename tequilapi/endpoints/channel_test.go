@@ -0,0 +1,109 @@
+/*
+ * Copyright (C) 2020 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package endpoints
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/julienschmidt/httprouter"
+	"github.com/mysteriumnetwork/node/core/channel"
+	"github.com/mysteriumnetwork/node/identity"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockChannelInspector struct {
+	status channel.Status
+	err    error
+}
+
+func (mci *mockChannelInspector) Inspect(id identity.Identity) (channel.Status, error) {
+	mci.status.Identity = id
+	return mci.status, mci.err
+}
+
+func Test_Channel(t *testing.T) {
+	tests := []struct {
+		name         string
+		inspector    *mockChannelInspector
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name: "returns channel state",
+			inspector: &mockChannelInspector{status: channel.Status{
+				AccountantID:       identity.FromAddress("0x2"),
+				Address:            common.HexToAddress("0x3"),
+				Balance:            1000,
+				Settled:            200,
+				GrandTotalPromised: 300,
+				LastPromise:        &channel.Promise{ChannelID: []byte{1}, Amount: 250, Fee: 10, Hashlock: []byte{2}, AgreementID: 7},
+				Registered:         true,
+			}},
+			expectedCode: http.StatusOK,
+			expectedBody: `{
+				"identity": "0x1",
+				"accountantId": "0x2",
+				"channelAddress": "0x0000000000000000000000000000000000000003",
+				"balance": 1000,
+				"settled": 200,
+				"grandTotalPromised": 300,
+				"lastPromise": {"channelId": "01", "amount": 250, "fee": 10, "hashlock": "02", "agreementId": 7, "revealed": false},
+				"registered": true
+			}`,
+		},
+		{
+			name:         "returns null without accountant promise",
+			inspector:    &mockChannelInspector{status: channel.Status{AccountantID: identity.FromAddress("0x2")}},
+			expectedCode: http.StatusOK,
+			expectedBody: `{
+				"identity": "0x1",
+				"accountantId": "0x2",
+				"channelAddress": "0x0000000000000000000000000000000000000000",
+				"balance": 0,
+				"settled": 0,
+				"grandTotalPromised": 0,
+				"lastPromise": null,
+				"registered": false
+			}`,
+		},
+		{
+			name:         "returns inspection errors",
+			inspector:    &mockChannelInspector{err: errors.New("bc unavailable")},
+			expectedCode: http.StatusInternalServerError,
+			expectedBody: `{"message": "bc unavailable"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := httprouter.New()
+			AddRoutesForChannel(router, tt.inspector)
+
+			req, err := http.NewRequest(http.MethodGet, "/identities/0x1/channel", nil)
+			assert.NoError(t, err)
+			resp := httptest.NewRecorder()
+			router.ServeHTTP(resp, req)
+
+			assert.Equal(t, tt.expectedCode, resp.Code)
+			assert.JSONEq(t, tt.expectedBody, resp.Body.String())
+		})
+	}
+}
@@ -50,7 +50,7 @@ func newConfigAPI(config configProvider) *configAPI {
 }
 
 // GetUserConfig returns current user configuration
-// swagger:operation GET /user/config Configuration getUserConfig
+// swagger:operation GET /config/user Configuration getUserConfig
 // ---
 // summary: Returns current user configuration
 // description: Returns current user configuration
@@ -69,7 +69,7 @@ func (api *configAPI) GetUserConfig(writer http.ResponseWriter, httpReq *http.Re
 }
 
 // SetUserConfig sets and returns current configuration
-// swagger:operation POST /user/config Configuration serUserConfig
+// swagger:operation POST /config/user Configuration serUserConfig
 // ---
 // summary: Sets and returns user configuration
 // description: For keys present in the payload, it will set or remove the user config values (if the key is null). Changes are persisted to the config file.
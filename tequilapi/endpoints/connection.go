@@ -25,6 +25,7 @@ import (
 
 	"github.com/julienschmidt/httprouter"
 	"github.com/mysteriumnetwork/node/consumer"
+	"github.com/mysteriumnetwork/node/consumer/budget"
 	"github.com/mysteriumnetwork/node/core/connection"
 	"github.com/mysteriumnetwork/node/core/discovery/proposal"
 	"github.com/mysteriumnetwork/node/identity"
@@ -66,8 +67,8 @@ type connectionRequest struct {
 	// example: 0x0000000000000000000000000000000000000002
 	ProviderID string `json:"providerId"`
 
-	// accountant identity
-	// required: true
+	// accountant identity, picked by the node's accountant selection policy if omitted
+	// required: false
 	// example: 0x0000000000000000000000000000000000000003
 	AccountantID string `json:"accountantId"`
 
@@ -187,6 +188,10 @@ func (ce *ConnectionEndpoint) Status(resp http.ResponseWriter, _ *http.Request,
 //     description: Bad request
 //     schema:
 //       "$ref": "#/definitions/ErrorMessageDTO"
+//   402:
+//     description: Consumer spending limit reached
+//     schema:
+//       "$ref": "#/definitions/ErrorMessageDTO"
 //   409:
 //     description: Conflict. Connection already exists
 //     schema:
@@ -255,6 +260,8 @@ func (ce *ConnectionEndpoint) Create(resp http.ResponseWriter, req *http.Request
 			utils.SendError(resp, err, http.StatusConflict)
 		case connection.ErrConnectionCancelled:
 			utils.SendError(resp, err, statusConnectCancelled)
+		case budget.ErrLimitReached:
+			utils.SendError(resp, err, http.StatusPaymentRequired)
 		default:
 			log.Error().Err(err).Msg("")
 			utils.SendError(resp, err, http.StatusInternalServerError)
@@ -365,9 +372,6 @@ func validateConnectionRequest(cr *connectionRequest) *validation.FieldErrorMap
 	if len(cr.ProviderID) == 0 {
 		errs.ForField("providerId").AddError("required", "Field is required")
 	}
-	if len(cr.AccountantID) == 0 {
-		errs.ForField("accountantId").AddError("required", "Field is required")
-	}
 	return errs
 }
 
@@ -378,7 +382,7 @@ func toConnectionResponse(status connection.Status) connectionResponse {
 	}
 
 	if status.Proposal.ProviderID != "" {
-		proposalRes := proposalToRes(status.Proposal)
+		proposalRes := proposalToRes(status.Proposal, nil)
 		response.Proposal = proposalRes
 	}
 	return response
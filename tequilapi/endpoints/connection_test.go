@@ -271,13 +271,33 @@ func TestPutReturns422ErrorIfRequestBodyIsMissingFieldValues(t *testing.T) {
 		`{
 			"message" : "validation_error",
 			"errors" : {
-				"accountantId" : [ {"code" : "required" , "message" : "Field is required" } ],
 				"consumerId" : [ { "code" : "required" , "message" : "Field is required" } ],
 				"providerId" : [ {"code" : "required" , "message" : "Field is required" } ]
 			}
 		}`, resp.Body.String())
 }
 
+func TestPutWithoutAccountantCreatesConnection(t *testing.T) {
+	fakeManager := mockConnectionManager{}
+
+	proposalProvider := mockRepositoryWithProposal("required-node", "openvpn")
+	connEndpoint := NewConnectionEndpoint(&fakeManager, nil, proposalProvider, mockIdentityRegistryInstance)
+	req := httptest.NewRequest(
+		http.MethodPut,
+		"/irrelevant",
+		strings.NewReader(
+			`{
+				"consumerId" : "my-identity",
+				"providerId" : "required-node"
+			}`))
+	resp := httptest.NewRecorder()
+
+	connEndpoint.Create(resp, req, httprouter.Params{})
+
+	assert.Equal(t, http.StatusCreated, resp.Code)
+	assert.Equal(t, identity.Identity{}, fakeManager.requestedAccountantID)
+}
+
 func TestPutWithValidBodyCreatesConnection(t *testing.T) {
 	fakeManager := mockConnectionManager{}
 
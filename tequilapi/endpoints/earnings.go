@@ -0,0 +1,246 @@
+/*
+ * Copyright (C) 2020 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package endpoints
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/mysteriumnetwork/node/core/ledger"
+	"github.com/mysteriumnetwork/node/identity"
+	"github.com/mysteriumnetwork/node/identity/registry"
+	"github.com/mysteriumnetwork/node/tequilapi/utils"
+	"github.com/mysteriumnetwork/node/tequilapi/validation"
+)
+
+// earningsResponse represents the provider earnings
+// swagger:model EarningsDTO
+type earningsResponse struct {
+	// sum of payments received within the requested time range
+	// example: 1500000
+	Earned uint64 `json:"earned"`
+
+	// amount settled on the blockchain
+	// example: 9000000
+	Settled uint64 `json:"settled"`
+
+	// amount promised by the accountant but not settled yet
+	// example: 3000000
+	Unsettled uint64 `json:"unsettled"`
+
+	// projected transactor fee of settling the unsettled amount
+	// example: 100000
+	SettlementFee uint64 `json:"settlementFee"`
+
+	// unsettled amount left after the settlement fee is paid
+	// example: 2900000
+	UnsettledNetOfFees uint64 `json:"unsettledNetOfFees"`
+
+	// example: day
+	GroupBy string `json:"groupBy"`
+
+	// earnings grouped by the beginning of the period payments were received in
+	Periods []earningsGroup `json:"periods"`
+
+	// earnings grouped by the service type
+	ServiceTypes []earningsGroup `json:"serviceTypes"`
+
+	// earnings grouped by the consumer country, unknown if consumer did not tell it
+	Countries []earningsGroup `json:"countries"`
+
+	Sessions []sessionEarnings `json:"sessions"`
+}
+
+// earningsGroup represents the amount earned by a group of sessions
+// swagger:model EarningsGroupDTO
+type earningsGroup struct {
+	// example: 2020-03-01
+	Key string `json:"key"`
+
+	// example: 1500000
+	Earned uint64 `json:"earned"`
+
+	// example: 3
+	Sessions int `json:"sessions"`
+}
+
+// sessionEarnings represents the amounts invoiced and paid during a single session
+// swagger:model SessionEarningsDTO
+type sessionEarnings struct {
+	// example: 4cfb0324-daf6-4ad8-448b-e61fe0a1f918
+	SessionID string `json:"sessionId"`
+
+	// example: openvpn
+	ServiceType string `json:"serviceType"`
+
+	// example: NL
+	ConsumerCountry string `json:"consumerCountry"`
+
+	// example: 0x0000000000000000000000000000000000000001
+	ConsumerID string `json:"consumerId"`
+
+	// example: 2020-03-01T12:00:00Z
+	Started string `json:"started"`
+
+	// example: 550000
+	Invoiced uint64 `json:"invoiced"`
+
+	// example: 500000
+	Paid uint64 `json:"paid"`
+}
+
+type settlementBalanceProvider interface {
+	SettlementBalance(providerID identity.Identity) (settled, unsettled uint64, err error)
+}
+
+type settleFeeProvider interface {
+	FetchSettleFees() (registry.FeesResponse, error)
+}
+
+type earningsEndpoint struct {
+	ledger  transactionLedger
+	settler settlementBalanceProvider
+	fees    settleFeeProvider
+}
+
+// swagger:operation GET /identities/{id}/earnings Earnings
+// ---
+// summary: Returns provider earnings
+// description: Returns payments received by the provider grouped by period, service type and consumer country, together with settled and unsettled amounts and projected settlement fees
+// parameters:
+// - in: path
+//   name: id
+//   description: Provider identity
+//   type: string
+//   required: true
+// - in: query
+//   name: groupBy
+//   description: Period to group earnings by, one of day (default), week, month
+//   type: string
+// - in: query
+//   name: from
+//   description: Include payments received at or after the given RFC3339 time
+//   type: string
+// - in: query
+//   name: to
+//   description: Include payments received before the given RFC3339 time
+//   type: string
+// responses:
+//   200:
+//     description: Provider earnings
+//     schema:
+//       "$ref": "#/definitions/EarningsDTO"
+//   422:
+//     description: Parameters validation error
+//     schema:
+//       "$ref": "#/definitions/ValidationErrorDTO"
+//   500:
+//     description: Internal server error
+//     schema:
+//       "$ref": "#/definitions/ErrorMessageDTO"
+func (ee *earningsEndpoint) Earnings(resp http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	query := request.URL.Query()
+	errorMap := validation.NewErrorMap()
+	period := ledger.PeriodDay
+	if groupBy := query.Get("groupBy"); groupBy != "" {
+		period = ledger.Period(groupBy)
+		if !period.IsValid() {
+			errorMap.ForField("groupBy").AddError("invalid", "Group by must be day, week or month")
+		}
+	}
+	id := identity.FromAddress(params.ByName("id"))
+	filter := ledger.Filter{
+		Identity: id,
+		From:     parseQueryTime(query, "from", errorMap),
+		To:       parseQueryTime(query, "to", errorMap),
+	}
+	if errorMap.HasErrors() {
+		utils.SendValidationErrorMessage(resp, errorMap)
+		return
+	}
+
+	entries, err := ee.ledger.Entries(filter)
+	if err != nil {
+		utils.SendError(resp, err, http.StatusInternalServerError)
+		return
+	}
+	settled, unsettled, err := ee.settler.SettlementBalance(id)
+	if err != nil {
+		utils.SendError(resp, err, http.StatusInternalServerError)
+		return
+	}
+
+	var fee uint64
+	if unsettled > 0 {
+		fees, err := ee.fees.FetchSettleFees()
+		if err != nil {
+			utils.SendError(resp, err, http.StatusInternalServerError)
+			return
+		}
+		fee = fees.Fee
+	}
+	var net uint64
+	if unsettled > fee {
+		net = unsettled - fee
+	}
+
+	earnings := ledger.AggregateEarnings(entries, period)
+	utils.WriteAsJSON(earningsResponse{
+		Earned:             earnings.Earned,
+		Settled:            settled,
+		Unsettled:          unsettled,
+		SettlementFee:      fee,
+		UnsettledNetOfFees: net,
+		GroupBy:            string(period),
+		Periods:            mapEarningsGroups(earnings.Periods),
+		ServiceTypes:       mapEarningsGroups(earnings.ServiceTypes),
+		Countries:          mapEarningsGroups(earnings.Countries),
+		Sessions:           mapSessionEarnings(earnings.Sessions),
+	}, resp)
+}
+
+func mapEarningsGroups(groups []ledger.EarningsGroup) []earningsGroup {
+	result := make([]earningsGroup, len(groups))
+	for i, g := range groups {
+		result[i] = earningsGroup{Key: g.Key, Earned: g.Earned, Sessions: g.Sessions}
+	}
+	return result
+}
+
+func mapSessionEarnings(sessions []ledger.SessionEarnings) []sessionEarnings {
+	result := make([]sessionEarnings, len(sessions))
+	for i, s := range sessions {
+		result[i] = sessionEarnings{
+			SessionID:       s.SessionID,
+			ServiceType:     s.ServiceType,
+			ConsumerCountry: s.Country,
+			ConsumerID:      s.Consumer,
+			Started:         s.Started.Format(time.RFC3339),
+			Invoiced:        s.Invoiced,
+			Paid:            s.Paid,
+		}
+	}
+	return result
+}
+
+// AddRoutesForEarnings attaches provider earnings endpoints to router
+func AddRoutesForEarnings(router *httprouter.Router, ledger transactionLedger, settler settlementBalanceProvider, fees settleFeeProvider) {
+	ee := &earningsEndpoint{ledger: ledger, settler: settler, fees: fees}
+	router.GET("/identities/:id/earnings", ee.Earnings)
+}
@@ -0,0 +1,128 @@
+/*
+ * Copyright (C) 2020 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package endpoints
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/mysteriumnetwork/node/core/ledger"
+	"github.com/mysteriumnetwork/node/identity"
+	"github.com/mysteriumnetwork/node/identity/registry"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockSettlementBalanceProvider struct {
+	settled, unsettled uint64
+}
+
+func (msbp *mockSettlementBalanceProvider) SettlementBalance(providerID identity.Identity) (settled, unsettled uint64, err error) {
+	return msbp.settled, msbp.unsettled, nil
+}
+
+type mockSettleFeeProvider struct {
+	fee uint64
+	err error
+}
+
+func (msfp *mockSettleFeeProvider) FetchSettleFees() (registry.FeesResponse, error) {
+	return registry.FeesResponse{Fee: msfp.fee}, msfp.err
+}
+
+func Test_Earnings(t *testing.T) {
+	started := time.Date(2020, 3, 1, 12, 0, 0, 0, time.UTC)
+	mockLedger := &mockTransactionLedger{entries: []ledger.Entry{
+		{Kind: ledger.KindInvoice, Direction: ledger.DirectionOutgoing, Time: started, SessionID: "s1", Counterparty: identity.FromAddress("0x2"), ServiceType: "openvpn", Country: "LT", Total: 600},
+		{Kind: ledger.KindExchangeMessage, Direction: ledger.DirectionIncoming, Time: started, SessionID: "s1", Counterparty: identity.FromAddress("0x2"), ServiceType: "openvpn", Country: "LT", Amount: 500, Total: 500},
+	}}
+	router := httprouter.New()
+	AddRoutesForEarnings(router, mockLedger, &mockSettlementBalanceProvider{settled: 1000, unsettled: 300}, &mockSettleFeeProvider{fee: 100})
+
+	req, err := http.NewRequest(http.MethodGet, "/identities/0x1/earnings?groupBy=month", nil)
+	assert.NoError(t, err)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, ledger.Filter{Identity: identity.FromAddress("0x1")}, mockLedger.filter)
+	assert.JSONEq(t, `{
+		"earned": 500,
+		"settled": 1000,
+		"unsettled": 300,
+		"settlementFee": 100,
+		"unsettledNetOfFees": 200,
+		"groupBy": "month",
+		"periods": [{"key": "2020-03-01", "earned": 500, "sessions": 1}],
+		"serviceTypes": [{"key": "openvpn", "earned": 500, "sessions": 1}],
+		"countries": [{"key": "LT", "earned": 500, "sessions": 1}],
+		"sessions": [{
+			"sessionId": "s1",
+			"serviceType": "openvpn",
+			"consumerCountry": "LT",
+			"consumerId": "0x2",
+			"started": "2020-03-01T12:00:00Z",
+			"invoiced": 600,
+			"paid": 500
+		}]
+	}`, resp.Body.String())
+}
+
+func Test_EarningsFailures(t *testing.T) {
+	tests := []struct {
+		name         string
+		url          string
+		fees         *mockSettleFeeProvider
+		expectedCode int
+	}{
+		{
+			name:         "invalid period",
+			url:          "/identities/0x1/earnings?groupBy=year",
+			fees:         &mockSettleFeeProvider{},
+			expectedCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:         "invalid time range",
+			url:          "/identities/0x1/earnings?from=today",
+			fees:         &mockSettleFeeProvider{},
+			expectedCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:         "fees unavailable",
+			url:          "/identities/0x1/earnings",
+			fees:         &mockSettleFeeProvider{err: errors.New("transactor is down")},
+			expectedCode: http.StatusInternalServerError,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			router := httprouter.New()
+			AddRoutesForEarnings(router, &mockTransactionLedger{}, &mockSettlementBalanceProvider{unsettled: 300}, test.fees)
+
+			req, err := http.NewRequest(http.MethodGet, test.url, nil)
+			assert.NoError(t, err)
+			resp := httptest.NewRecorder()
+			router.ServeHTTP(resp, req)
+
+			assert.Equal(t, test.expectedCode, resp.Code)
+		})
+	}
+}
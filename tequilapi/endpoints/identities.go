@@ -21,9 +21,11 @@ import (
 	"encoding/json"
 	"net/http"
 
+	"github.com/ethereum/go-ethereum/accounts/keystore"
 	"github.com/julienschmidt/httprouter"
 	pc "github.com/mysteriumnetwork/payments/crypto"
 	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
 
 	"github.com/mysteriumnetwork/node/identity"
 	"github.com/mysteriumnetwork/node/identity/registry"
@@ -61,6 +63,36 @@ type identityUnlockingDto struct {
 	Passphrase *string `json:"passphrase"`
 }
 
+// swagger:model IdentityExportDTO
+type identityExportDto struct {
+	// passphrase identity is stored with
+	Passphrase *string `json:"passphrase"`
+
+	// passphrase exported identity is encrypted with, passphrase identity is stored with if omitted
+	ExportPassphrase *string `json:"exportPassphrase,omitempty"`
+}
+
+// swagger:model IdentityImportDTO
+type identityImportDto struct {
+	// exported identity, JSON keystore blob
+	Blob json.RawMessage `json:"blob"`
+
+	// passphrase exported identity is encrypted with
+	Passphrase *string `json:"passphrase"`
+
+	// passphrase identity is stored with after the import, passphrase of exported identity if omitted
+	NewPassphrase *string `json:"newPassphrase,omitempty"`
+}
+
+// swagger:model IdentityPassphraseChangeDTO
+type identityPassphraseChangeDto struct {
+	// passphrase identity is stored with
+	Passphrase *string `json:"passphrase"`
+
+	// passphrase identity is stored with after the change
+	NewPassphrase *string `json:"newPassphrase"`
+}
+
 // swagger:model StatusDTO
 type statusDTO struct {
 	ChannelAddress string `json:"channel_address"`
@@ -115,11 +147,16 @@ func (endpoint *identitiesAPI) List(resp http.ResponseWriter, request *http.Requ
 	utils.WriteAsJSON(idsSerializable, resp)
 }
 
-// swagger:operation PUT /identities/current Identity currentIdentity
+// swagger:operation PUT /identities/{id} Identity currentIdentity
 // ---
 // summary: Returns my current identity
 // description: Tries to retrieve the last used identity, the first identity, or creates and returns a new identity
 // parameters:
+//   - in: path
+//     name: id
+//     description: Identity to use, or "current" to use the last used identity
+//     type: string
+//     required: true
 //   - in: body
 //     name: body
 //     description: Parameter in body (passphrase) required for creating new identity
@@ -274,6 +311,252 @@ func (endpoint *identitiesAPI) Unlock(resp http.ResponseWriter, request *http.Re
 	resp.WriteHeader(http.StatusAccepted)
 }
 
+// swagger:operation POST /identities/{id}/export Identity exportIdentity
+// ---
+// summary: Exports identity
+// description: Returns identity stored in keystore as JSON keystore blob encrypted with export passphrase
+// parameters:
+// - in: path
+//   name: id
+//   description: Identity stored in keystore
+//   type: string
+//   required: true
+// - in: body
+//   name: body
+//   description: Parameters in body (passphrase, exportPassphrase) required for exporting identity
+//   schema:
+//     $ref: "#/definitions/IdentityExportDTO"
+// responses:
+//   200:
+//     description: Exported identity as JSON keystore blob
+//   400:
+//     description: Body parsing error
+//     schema:
+//       "$ref": "#/definitions/ErrorMessageDTO"
+//   403:
+//     description: Forbidden
+//     schema:
+//       "$ref": "#/definitions/ErrorMessageDTO"
+//   404:
+//     description: Identity not found
+//     schema:
+//       "$ref": "#/definitions/ErrorMessageDTO"
+//   422:
+//     description: Parameters validation error
+//     schema:
+//       "$ref": "#/definitions/ValidationErrorDTO"
+//   500:
+//     description: Internal server error
+//     schema:
+//       "$ref": "#/definitions/ErrorMessageDTO"
+func (endpoint *identitiesAPI) Export(resp http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	id := params.ByName("id")
+	exportReq := identityExportDto{}
+	if err := json.NewDecoder(request.Body).Decode(&exportReq); err != nil {
+		utils.SendError(resp, err, http.StatusBadRequest)
+		return
+	}
+
+	errorMap := validateExportRequest(exportReq)
+	if errorMap.HasErrors() {
+		utils.SendValidationErrorMessage(resp, errorMap)
+		return
+	}
+
+	if !endpoint.idm.HasIdentity(id) {
+		utils.SendErrorMessage(resp, "identity not found", http.StatusNotFound)
+		return
+	}
+
+	exportPassphrase := *exportReq.Passphrase
+	if exportReq.ExportPassphrase != nil {
+		exportPassphrase = *exportReq.ExportPassphrase
+	}
+	keyJSON, err := endpoint.idm.ExportIdentity(id, *exportReq.Passphrase, exportPassphrase)
+	if err != nil {
+		utils.SendError(resp, err, keystoreErrorStatus(err))
+		return
+	}
+	utils.WriteAsJSON(json.RawMessage(keyJSON), resp)
+}
+
+// swagger:operation POST /identities/import Identity importIdentity
+// ---
+// summary: Imports identity
+// description: Stores identity exported as JSON keystore blob in keystore and restores its registration status
+// parameters:
+// - in: body
+//   name: body
+//   description: Parameters in body (blob, passphrase, newPassphrase) required for importing identity
+//   schema:
+//     $ref: "#/definitions/IdentityImportDTO"
+// responses:
+//   200:
+//     description: Identity imported
+//     schema:
+//       "$ref": "#/definitions/IdentityDTO"
+//   400:
+//     description: Body parsing error
+//     schema:
+//       "$ref": "#/definitions/ErrorMessageDTO"
+//   403:
+//     description: Forbidden
+//     schema:
+//       "$ref": "#/definitions/ErrorMessageDTO"
+//   409:
+//     description: Identity already exists
+//     schema:
+//       "$ref": "#/definitions/ErrorMessageDTO"
+//   422:
+//     description: Parameters validation error
+//     schema:
+//       "$ref": "#/definitions/ValidationErrorDTO"
+//   500:
+//     description: Internal server error
+//     schema:
+//       "$ref": "#/definitions/ErrorMessageDTO"
+func (endpoint *identitiesAPI) Import(resp http.ResponseWriter, request *http.Request, _ httprouter.Params) {
+	importReq := identityImportDto{}
+	if err := json.NewDecoder(request.Body).Decode(&importReq); err != nil {
+		utils.SendError(resp, err, http.StatusBadRequest)
+		return
+	}
+
+	errorMap := validateImportRequest(importReq)
+	if errorMap.HasErrors() {
+		utils.SendValidationErrorMessage(resp, errorMap)
+		return
+	}
+
+	newPassphrase := *importReq.Passphrase
+	if importReq.NewPassphrase != nil {
+		newPassphrase = *importReq.NewPassphrase
+	}
+	id, err := endpoint.idm.ImportIdentity(importReq.Blob, *importReq.Passphrase, newPassphrase)
+	if err != nil {
+		utils.SendError(resp, err, keystoreErrorStatus(err))
+		return
+	}
+
+	if _, err := endpoint.registry.RestoreRegistrationStatus(id); err != nil {
+		log.Warn().Err(err).Msgf("Could not restore registration status of imported identity %s", id.Address)
+	}
+
+	utils.WriteAsJSON(idToDto(id), resp)
+}
+
+// identityImportID is the :id routing POST /identities/:id to the identity import.
+// httprouter can not register the static /identities/import next to the :id wildcard of POST /identities/:id/... routes.
+const identityImportID = "import"
+
+func (endpoint *identitiesAPI) importByID(resp http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	if params.ByName("id") != identityImportID {
+		http.NotFound(resp, request)
+		return
+	}
+	endpoint.Import(resp, request, params)
+}
+
+// swagger:operation PUT /identities/{id}/passphrase Identity changeIdentityPassphrase
+// ---
+// summary: Changes identity passphrase
+// description: Re-encrypts identity stored in keystore with new passphrase
+// parameters:
+// - in: path
+//   name: id
+//   description: Identity stored in keystore
+//   type: string
+//   required: true
+// - in: body
+//   name: body
+//   description: Parameters in body (passphrase, newPassphrase) required for changing identity passphrase
+//   schema:
+//     $ref: "#/definitions/IdentityPassphraseChangeDTO"
+// responses:
+//   202:
+//     description: Identity passphrase changed
+//   400:
+//     description: Body parsing error
+//     schema:
+//       "$ref": "#/definitions/ErrorMessageDTO"
+//   403:
+//     description: Forbidden
+//     schema:
+//       "$ref": "#/definitions/ErrorMessageDTO"
+//   404:
+//     description: Identity not found
+//     schema:
+//       "$ref": "#/definitions/ErrorMessageDTO"
+//   422:
+//     description: Parameters validation error
+//     schema:
+//       "$ref": "#/definitions/ValidationErrorDTO"
+//   500:
+//     description: Internal server error
+//     schema:
+//       "$ref": "#/definitions/ErrorMessageDTO"
+//   501:
+//     description: Not supported by keystore
+//     schema:
+//       "$ref": "#/definitions/ErrorMessageDTO"
+func (endpoint *identitiesAPI) ChangePassphrase(resp http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	id := params.ByName("id")
+	changeReq := identityPassphraseChangeDto{}
+	if err := json.NewDecoder(request.Body).Decode(&changeReq); err != nil {
+		utils.SendError(resp, err, http.StatusBadRequest)
+		return
+	}
+
+	errorMap := validatePassphraseChangeRequest(changeReq)
+	if errorMap.HasErrors() {
+		utils.SendValidationErrorMessage(resp, errorMap)
+		return
+	}
+
+	if !endpoint.idm.HasIdentity(id) {
+		utils.SendErrorMessage(resp, "identity not found", http.StatusNotFound)
+		return
+	}
+
+	if err := endpoint.idm.ChangePassphrase(id, *changeReq.Passphrase, *changeReq.NewPassphrase); err != nil {
+		utils.SendError(resp, err, keystoreErrorStatus(err))
+		return
+	}
+	resp.WriteHeader(http.StatusAccepted)
+}
+
+func keystoreErrorStatus(err error) int {
+	switch errors.Cause(err) {
+	case keystore.ErrDecrypt:
+		return http.StatusForbidden
+	case identity.ErrIdentityExists:
+		return http.StatusConflict
+	case identity.ErrUnsupportedByExternalSigner:
+		return http.StatusNotImplemented
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// swagger:operation GET /identities/{id}/status Identity identityStatus
+// ---
+// summary: Returns identity status
+// description: Returns channel address, registration status and balance of identity
+// parameters:
+// - in: path
+//   name: id
+//   description: Identity stored in keystore, or "current" for the last used identity
+//   type: string
+//   required: true
+// responses:
+//   200:
+//     description: Identity status
+//     schema:
+//       "$ref": "#/definitions/StatusDTO"
+//   500:
+//     description: Internal server error
+//     schema:
+//       "$ref": "#/definitions/ErrorMessageDTO"
 func (endpoint *identitiesAPI) Status(resp http.ResponseWriter, request *http.Request, params httprouter.Params) {
 	// TODO: remove this hack when we replace our router
 	identityAddress := params.ByName("id")
@@ -344,6 +627,36 @@ func validateUnlockRequest(unlockReq identityUnlockingDto) (errors *validation.F
 	return
 }
 
+func validateExportRequest(exportReq identityExportDto) (errors *validation.FieldErrorMap) {
+	errors = validation.NewErrorMap()
+	if exportReq.Passphrase == nil {
+		errors.ForField("passphrase").AddError("required", "Field is required")
+	}
+	return
+}
+
+func validateImportRequest(importReq identityImportDto) (errors *validation.FieldErrorMap) {
+	errors = validation.NewErrorMap()
+	if len(importReq.Blob) == 0 {
+		errors.ForField("blob").AddError("required", "Field is required")
+	}
+	if importReq.Passphrase == nil {
+		errors.ForField("passphrase").AddError("required", "Field is required")
+	}
+	return
+}
+
+func validatePassphraseChangeRequest(changeReq identityPassphraseChangeDto) (errors *validation.FieldErrorMap) {
+	errors = validation.NewErrorMap()
+	if changeReq.Passphrase == nil {
+		errors.ForField("passphrase").AddError("required", "Field is required")
+	}
+	if changeReq.NewPassphrase == nil {
+		errors.ForField("newPassphrase").AddError("required", "Field is required")
+	}
+	return
+}
+
 func validateCreationRequest(createReq *identityCreationDto) (errors *validation.FieldErrorMap) {
 	errors = validation.NewErrorMap()
 	if createReq.Passphrase == nil {
@@ -366,5 +679,8 @@ func AddRoutesForIdentities(
 	router.POST("/identities", idmEnd.Create)
 	router.PUT("/identities/:id", idmEnd.Current)
 	router.PUT("/identities/:id/unlock", idmEnd.Unlock)
+	router.PUT("/identities/:id/passphrase", idmEnd.ChangePassphrase)
+	router.POST("/identities/:id/export", idmEnd.Export)
+	router.POST("/identities/:id", idmEnd.importByID)
 	router.GET("/identities/:id/status", idmEnd.Status)
 }
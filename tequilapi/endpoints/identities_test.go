@@ -25,6 +25,7 @@ import (
 
 	"github.com/julienschmidt/httprouter"
 	"github.com/mysteriumnetwork/node/identity"
+	"github.com/mysteriumnetwork/node/identity/registry"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -244,3 +245,156 @@ func TestListIdentities(t *testing.T) {
 		resp.Body.String(),
 	)
 }
+
+func TestExportIdentity(t *testing.T) {
+	mockIdm := identity.NewIdentityManagerFake(existingIdentities, newIdentity)
+	req, err := http.NewRequest(
+		http.MethodPost,
+		identityUrl,
+		bytes.NewBufferString(`{"passphrase": "mypassphrase", "exportPassphrase": "backup"}`),
+	)
+	assert.Nil(t, err)
+	resp := httptest.NewRecorder()
+
+	endpoint := &identitiesAPI{idm: mockIdm}
+	endpoint.Export(resp, req, httprouter.Params{{Key: "id", Value: "0x000000000000000000000000000000000000000a"}})
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.JSONEq(t, `{"address": "0x000000000000000000000000000000000000000a"}`, resp.Body.String())
+}
+
+func TestExportIdentityNoPassphrase(t *testing.T) {
+	mockIdm := identity.NewIdentityManagerFake(existingIdentities, newIdentity)
+	req, err := http.NewRequest(http.MethodPost, identityUrl, bytes.NewBufferString(`{}`))
+	assert.Nil(t, err)
+	resp := httptest.NewRecorder()
+
+	endpoint := &identitiesAPI{idm: mockIdm}
+	endpoint.Export(resp, req, httprouter.Params{{Key: "id", Value: "0x000000000000000000000000000000000000000a"}})
+
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.Code)
+	assert.JSONEq(
+		t,
+		`{
+			"message": "validation_error",
+			"errors": {
+				"passphrase": [ {"code": "required" , "message": "Field is required"} ]
+			}
+		}`,
+		resp.Body.String(),
+	)
+}
+
+func TestImportIdentity(t *testing.T) {
+	mockIdm := identity.NewIdentityManagerFake(existingIdentities, newIdentity)
+	req, err := http.NewRequest(
+		http.MethodPost,
+		identityUrl,
+		bytes.NewBufferString(`{"blob": {"address": "000000000000000000000000000000000000aaac"}, "passphrase": "backup"}`),
+	)
+	assert.Nil(t, err)
+	resp := httptest.NewRecorder()
+
+	endpoint := &identitiesAPI{idm: mockIdm, registry: &registry.FakeRegistry{RegistrationStatus: registry.RegisteredConsumer}}
+	endpoint.Import(resp, req, nil)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.JSONEq(t, `{"id": "0x000000000000000000000000000000000000aaac"}`, resp.Body.String())
+	assert.Contains(t, mockIdm.GetIdentities(), newIdentity)
+}
+
+func TestImportIdentityRoute(t *testing.T) {
+	mockIdm := identity.NewIdentityManagerFake(existingIdentities, newIdentity)
+	router := httprouter.New()
+	AddRoutesForIdentities(router, mockIdm, nil, &registry.FakeRegistry{}, "", "", nil)
+
+	body := `{"blob": {"address": "000000000000000000000000000000000000aaac"}, "passphrase": "backup"}`
+	req := httptest.NewRequest(http.MethodPost, "/identities/import", bytes.NewBufferString(body))
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	assert.Equal(t, http.StatusOK, resp.Code)
+
+	req = httptest.NewRequest(http.MethodPost, "/identities/0x1", bytes.NewBufferString(body))
+	resp = httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+}
+
+func TestImportIdentityNoBlob(t *testing.T) {
+	mockIdm := identity.NewIdentityManagerFake(existingIdentities, newIdentity)
+	req, err := http.NewRequest(http.MethodPost, identityUrl, bytes.NewBufferString(`{"passphrase": "backup"}`))
+	assert.Nil(t, err)
+	resp := httptest.NewRecorder()
+
+	endpoint := &identitiesAPI{idm: mockIdm}
+	endpoint.Import(resp, req, nil)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.Code)
+	assert.JSONEq(
+		t,
+		`{
+			"message": "validation_error",
+			"errors": {
+				"blob": [ {"code": "required" , "message": "Field is required"} ]
+			}
+		}`,
+		resp.Body.String(),
+	)
+}
+
+func TestChangeIdentityPassphrase(t *testing.T) {
+	mockIdm := identity.NewIdentityManagerFake(existingIdentities, newIdentity)
+	req, err := http.NewRequest(
+		http.MethodPut,
+		identityUrl,
+		bytes.NewBufferString(`{"passphrase": "mypassphrase", "newPassphrase": "changed"}`),
+	)
+	assert.Nil(t, err)
+	resp := httptest.NewRecorder()
+
+	endpoint := &identitiesAPI{idm: mockIdm}
+	endpoint.ChangePassphrase(resp, req, httprouter.Params{{Key: "id", Value: "0x000000000000000000000000000000000000000a"}})
+
+	assert.Equal(t, http.StatusAccepted, resp.Code)
+	assert.Equal(t, "0x000000000000000000000000000000000000000a", mockIdm.LastUnlockAddress)
+	assert.Equal(t, "mypassphrase", mockIdm.LastUnlockPassphrase)
+}
+
+func TestChangeIdentityPassphraseWrongPassphrase(t *testing.T) {
+	mockIdm := identity.NewIdentityManagerFake(existingIdentities, newIdentity)
+	mockIdm.MarkUnlockToFail()
+	req, err := http.NewRequest(
+		http.MethodPut,
+		identityUrl,
+		bytes.NewBufferString(`{"passphrase": "wrong", "newPassphrase": "changed"}`),
+	)
+	assert.Nil(t, err)
+	resp := httptest.NewRecorder()
+
+	endpoint := &identitiesAPI{idm: mockIdm}
+	endpoint.ChangePassphrase(resp, req, httprouter.Params{{Key: "id", Value: "0x000000000000000000000000000000000000000a"}})
+
+	assert.Equal(t, http.StatusForbidden, resp.Code)
+}
+
+func TestChangeIdentityPassphraseNoNewPassphrase(t *testing.T) {
+	mockIdm := identity.NewIdentityManagerFake(existingIdentities, newIdentity)
+	req, err := http.NewRequest(http.MethodPut, identityUrl, bytes.NewBufferString(`{"passphrase": "mypassphrase"}`))
+	assert.Nil(t, err)
+	resp := httptest.NewRecorder()
+
+	endpoint := &identitiesAPI{idm: mockIdm}
+	endpoint.ChangePassphrase(resp, req, httprouter.Params{{Key: "id", Value: "0x000000000000000000000000000000000000000a"}})
+
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.Code)
+	assert.JSONEq(
+		t,
+		`{
+			"message": "validation_error",
+			"errors": {
+				"newPassphrase": [ {"code": "required" , "message": "Field is required"} ]
+			}
+		}`,
+		resp.Body.String(),
+	)
+}
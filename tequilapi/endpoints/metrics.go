@@ -0,0 +1,67 @@
+/*
+ * Copyright (C) 2020 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package endpoints
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/mysteriumnetwork/node/tequilapi/utils"
+)
+
+type metricsWriter interface {
+	Write(w io.Writer) error
+}
+
+type metricsEndpoint struct {
+	metrics metricsWriter
+}
+
+// swagger:operation GET /metrics Client metrics
+// ---
+// summary: Returns node metrics
+// description: Returns node metrics in Prometheus text exposition format
+// produces:
+// - text/plain
+// responses:
+//   200:
+//     description: Node metrics
+//     schema:
+//       type: string
+//   500:
+//     description: Internal server error
+//     schema:
+//       "$ref": "#/definitions/ErrorMessageDTO"
+func (me *metricsEndpoint) Metrics(resp http.ResponseWriter, _ *http.Request, _ httprouter.Params) {
+	var buffer bytes.Buffer
+	if err := me.metrics.Write(&buffer); err != nil {
+		utils.SendError(resp, err, http.StatusInternalServerError)
+		return
+	}
+
+	resp.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	resp.Write(buffer.Bytes())
+}
+
+// AddRouteForMetrics attaches metrics endpoint to router
+func AddRouteForMetrics(router *httprouter.Router, metrics metricsWriter) {
+	me := &metricsEndpoint{metrics: metrics}
+	router.GET("/metrics", me.Metrics)
+}
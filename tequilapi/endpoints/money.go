@@ -0,0 +1,103 @@
+/*
+ * Copyright (C) 2020 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package endpoints
+
+import (
+	"github.com/mysteriumnetwork/node/market"
+	"github.com/mysteriumnetwork/node/money"
+)
+
+// moneyFormatter converts amounts to the display currency
+type moneyFormatter interface {
+	FormatFiat(value money.Money) string
+}
+
+// swagger:model MoneyDTO
+type moneyDTO struct {
+	// amount in token base units
+	// example: 50000000
+	Amount uint64 `json:"amount"`
+
+	// example: MYST
+	Currency string `json:"currency"`
+
+	// human readable amount
+	// example: 0.5 MYST
+	Human string `json:"human"`
+
+	// approximate value in the display currency, omitted if the exchange rate is unknown
+	// example: ~0.13 USD
+	Fiat string `json:"fiat,omitempty"`
+}
+
+func newMoneyDTO(value money.Money, formatter moneyFormatter) moneyDTO {
+	if value.Currency == "" {
+		value.Currency = money.CurrencyMyst
+	}
+	dto := moneyDTO{
+		Amount:   value.Amount,
+		Currency: string(value.Currency),
+		Human:    value.Format(),
+	}
+	if formatter != nil {
+		dto.Fiat = formatter.FormatFiat(value)
+	}
+	return dto
+}
+
+// swagger:model PaymentMethodDTO
+type paymentMethodDTO struct {
+	// example: WG
+	Type string `json:"type"`
+
+	// price charged for every rate period
+	Price moneyDTO `json:"price"`
+
+	// price of a gigabyte of transferred data, charged in addition to the price per rate period
+	PricePerGB *moneyDTO `json:"pricePerGB,omitempty"`
+
+	// rate period in seconds
+	// example: 60
+	PerSeconds uint64 `json:"perSeconds,omitempty"`
+
+	// rate period in bytes
+	// example: 0
+	PerBytes uint64 `json:"perBytes,omitempty"`
+}
+
+func newPaymentMethodDTO(method market.PaymentMethod, formatter moneyFormatter) *paymentMethodDTO {
+	if method == nil {
+		return nil
+	}
+	if _, ok := method.(market.UnsupportedPaymentMethod); ok {
+		return nil
+	}
+
+	rate := method.GetRate()
+	dto := &paymentMethodDTO{
+		Type:       method.GetType(),
+		Price:      newMoneyDTO(method.GetPrice(), formatter),
+		PerSeconds: uint64(rate.PerTime.Seconds()),
+		PerBytes:   rate.PerByte,
+	}
+	if priced, ok := method.(market.PricedPaymentMethod); ok && priced.GetPricePerGB().Amount > 0 {
+		perGB := newMoneyDTO(priced.GetPricePerGB(), formatter)
+		dto.PricePerGB = &perGB
+	}
+	return dto
+}
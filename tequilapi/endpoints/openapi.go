@@ -0,0 +1,59 @@
+/*
+ * Copyright (C) 2020 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package endpoints
+
+import (
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/mysteriumnetwork/node/metadata"
+	"github.com/mysteriumnetwork/node/tequilapi/openapi"
+	"github.com/mysteriumnetwork/node/tequilapi/utils"
+)
+
+// OpenAPIEndpointPath is the path of OpenAPI document of Tequilapi
+const OpenAPIEndpointPath = "/openapi.json"
+
+// swagger:operation GET /openapi.json Client openAPISpec
+// ---
+// summary: Returns OpenAPI document
+// description: Returns OpenAPI 3 document describing all Tequilapi endpoints and models
+// responses:
+//   200:
+//     description: OpenAPI document
+//     schema:
+//       type: object
+//   500:
+//     description: Internal server error
+//     schema:
+//       "$ref": "#/definitions/ErrorMessageDTO"
+func openAPISpec(resp http.ResponseWriter, _ *http.Request, _ httprouter.Params) {
+	spec, err := openapi.Spec(metadata.VersionAsString())
+	if err != nil {
+		utils.SendError(resp, err, http.StatusInternalServerError)
+		return
+	}
+
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Write(spec)
+}
+
+// AddRouteForOpenAPI attaches OpenAPI document endpoint to router
+func AddRouteForOpenAPI(router *httprouter.Router) {
+	router.GET(OpenAPIEndpointPath, openAPISpec)
+}
@@ -50,6 +50,7 @@ type emailInfo struct {
 	Email string `json:"email"`
 }
 
+// swagger:model PayoutInfoResponseDTO
 type payoutInfoResponse struct {
 	EthAddress   string `json:"ethAddress"`
 	ReferralCode string `json:"referralCode"`
@@ -75,6 +76,25 @@ func NewPayoutEndpoint(idm identity.Manager, signerFactory identity.SignerFactor
 	return &payoutEndpoint{idm, signerFactory, payoutInfoRegistry}
 }
 
+// swagger:operation GET /identities/{id}/payout Identity getPayoutInfo
+// ---
+// summary: Returns payout info
+// description: Returns payout address, referral code and email registered for identity
+// parameters:
+// - name: id
+//   in: path
+//   description: Identity stored in keystore
+//   type: string
+//   required: true
+// responses:
+//   200:
+//     description: Payout info
+//     schema:
+//       "$ref": "#/definitions/PayoutInfoResponseDTO"
+//   404:
+//     description: Payout info not found
+//     schema:
+//       "$ref": "#/definitions/ErrorMessageDTO"
 func (endpoint *payoutEndpoint) GetPayoutInfo(resp http.ResponseWriter, request *http.Request, params httprouter.Params) {
 	id := identity.FromAddress(params.ByName("id"))
 	payoutInfo, err := endpoint.payoutInfoRegistry.GetPayoutInfo(id, endpoint.signerFactory(id))
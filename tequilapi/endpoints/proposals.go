@@ -60,6 +60,27 @@ type metricsRes struct {
 	ConnectCount quality.ConnectCount `json:"connectCount"`
 }
 
+// swagger:model TrialDTO
+type trialDTO struct {
+	// service duration free of charge for new consumers, in seconds
+	// example: 600
+	DurationSeconds uint64 `json:"durationSeconds,omitempty"`
+
+	// data free of charge for new consumers, in bytes
+	// example: 104857600
+	Bytes uint64 `json:"bytes,omitempty"`
+}
+
+func newTrialDTO(trial *market.Trial) *trialDTO {
+	if trial.IsEmpty() {
+		return nil
+	}
+	return &trialDTO{
+		DurationSeconds: uint64(trial.Duration.Seconds()),
+		Bytes:           trial.Bytes,
+	}
+}
+
 // swagger:model ProposalDTO
 type proposalDTO struct {
 	// per provider unique serial number of service description provided
@@ -82,9 +103,15 @@ type proposalDTO struct {
 
 	// AccessPolicies
 	AccessPolicies *[]market.AccessPolicy `json:"accessPolicies,omitempty"`
+
+	// price of the service, omitted if the payment method is unknown
+	PaymentMethod *paymentMethodDTO `json:"paymentMethod,omitempty"`
+
+	// free trial offered to new consumers, omitted if there's none
+	Trial *trialDTO `json:"trial,omitempty"`
 }
 
-func proposalToRes(p market.ServiceProposal) *proposalDTO {
+func proposalToRes(p market.ServiceProposal, formatter moneyFormatter) *proposalDTO {
 	return &proposalDTO{
 		ID:          p.ID,
 		ProviderID:  p.ProviderID,
@@ -101,6 +128,8 @@ func proposalToRes(p market.ServiceProposal) *proposalDTO {
 			},
 		},
 		AccessPolicies: p.AccessPolicies,
+		PaymentMethod:  newPaymentMethodDTO(p.PaymentMethod, formatter),
+		Trial:          newTrialDTO(p.Trial),
 	}
 }
 
@@ -112,13 +141,15 @@ type QualityFinder interface {
 type proposalsEndpoint struct {
 	proposalRepository proposal.Repository
 	qualityProvider    QualityFinder
+	moneyFormatter     moneyFormatter
 }
 
 // NewProposalsEndpoint creates and returns proposal creation endpoint
-func NewProposalsEndpoint(proposalRepository proposal.Repository, qualityProvider QualityFinder) *proposalsEndpoint {
+func NewProposalsEndpoint(proposalRepository proposal.Repository, qualityProvider QualityFinder, moneyFormatter moneyFormatter) *proposalsEndpoint {
 	return &proposalsEndpoint{
 		proposalRepository: proposalRepository,
 		qualityProvider:    qualityProvider,
+		moneyFormatter:     moneyFormatter,
 	}
 }
 
@@ -186,7 +217,7 @@ func (pe *proposalsEndpoint) List(resp http.ResponseWriter, req *http.Request, p
 
 	proposalsRes := proposalsRes{Proposals: []*proposalDTO{}}
 	for _, p := range proposals {
-		proposalsRes.Proposals = append(proposalsRes.Proposals, proposalToRes(p))
+		proposalsRes.Proposals = append(proposalsRes.Proposals, proposalToRes(p, pe.moneyFormatter))
 	}
 
 	if fetchConnectCounts == "true" {
@@ -207,8 +238,8 @@ func parsePriceBound(req *http.Request, key string) (*uint64, error) {
 }
 
 // AddRoutesForProposals attaches proposals endpoints to router
-func AddRoutesForProposals(router *httprouter.Router, proposalRepository proposal.Repository, qualityProvider QualityFinder) {
-	pe := NewProposalsEndpoint(proposalRepository, qualityProvider)
+func AddRoutesForProposals(router *httprouter.Router, proposalRepository proposal.Repository, qualityProvider QualityFinder, moneyFormatter moneyFormatter) {
+	pe := NewProposalsEndpoint(proposalRepository, qualityProvider, moneyFormatter)
 	router.GET("/proposals", pe.List)
 }
 
@@ -23,10 +23,12 @@ import (
 	"net/http/httptest"
 	"net/url"
 	"testing"
+	"time"
 
 	"github.com/mysteriumnetwork/node/core/discovery/proposal"
 	"github.com/mysteriumnetwork/node/core/quality"
 	"github.com/mysteriumnetwork/node/market"
+	"github.com/mysteriumnetwork/node/money"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -73,7 +75,7 @@ func TestProposalsEndpointListByNodeId(t *testing.T) {
 	req.URL.RawQuery = query.Encode()
 
 	resp := httptest.NewRecorder()
-	handlerFunc := NewProposalsEndpoint(repository, &mockQualityProvider{}).List
+	handlerFunc := NewProposalsEndpoint(repository, &mockQualityProvider{}, nil).List
 	handlerFunc(resp, req, nil)
 
 	assert.JSONEq(
@@ -123,7 +125,7 @@ func TestProposalsEndpointAcceptsAccessPolicyParams(t *testing.T) {
 	req.URL.RawQuery = query.Encode()
 
 	resp := httptest.NewRecorder()
-	handlerFunc := NewProposalsEndpoint(repository, &mockQualityProvider{}).List
+	handlerFunc := NewProposalsEndpoint(repository, &mockQualityProvider{}, nil).List
 	handlerFunc(resp, req, nil)
 
 	assert.JSONEq(
@@ -168,7 +170,7 @@ func TestProposalsEndpointList(t *testing.T) {
 	assert.Nil(t, err)
 
 	resp := httptest.NewRecorder()
-	handlerFunc := NewProposalsEndpoint(repository, &mockQualityProvider{}).List
+	handlerFunc := NewProposalsEndpoint(repository, &mockQualityProvider{}, nil).List
 	handlerFunc(resp, req, nil)
 
 	assert.JSONEq(
@@ -218,7 +220,7 @@ func TestProposalsEndpointListFetchConnectCounts(t *testing.T) {
 
 	resp := httptest.NewRecorder()
 
-	handlerFunc := NewProposalsEndpoint(repository, &mockQualityProvider{}).List
+	handlerFunc := NewProposalsEndpoint(repository, &mockQualityProvider{}, nil).List
 	handlerFunc(resp, req, nil)
 
 	assert.JSONEq(
@@ -262,6 +264,91 @@ func TestProposalsEndpointListFetchConnectCounts(t *testing.T) {
 	)
 }
 
+type mockPricedPaymentMethod struct {
+	price market.Price
+}
+
+func (m mockPricedPaymentMethod) GetPrice() money.Money {
+	return m.price.PerMinute
+}
+
+func (m mockPricedPaymentMethod) GetType() string {
+	return "MOCK"
+}
+
+func (m mockPricedPaymentMethod) GetRate() market.PaymentRate {
+	return market.PaymentRate{PerTime: time.Minute}
+}
+
+func (m mockPricedPaymentMethod) GetPricePerGB() money.Money {
+	return m.price.PerGB
+}
+
+func (m mockPricedPaymentMethod) WithPrice(price market.Price) market.PaymentMethod {
+	return mockPricedPaymentMethod{price: price}
+}
+
+func TestProposalsEndpointListShowsPriceAndTrial(t *testing.T) {
+	priced := serviceProposals[0]
+	priced.PaymentMethod = mockPricedPaymentMethod{price: market.Price{
+		PerMinute: money.NewMoney(50000000, money.CurrencyMyst),
+		PerGB:     money.NewMoney(400000000, money.CurrencyMyst),
+	}}
+	priced.Trial = &market.Trial{Duration: 10 * time.Minute, Bytes: 100000000}
+	unsupported := serviceProposals[1]
+	unsupported.PaymentMethod = market.UnsupportedPaymentMethod{}
+	repository := &mockProposalRepository{
+		proposals: []market.ServiceProposal{priced, unsupported},
+	}
+
+	req, err := http.NewRequest(http.MethodGet, "/irrelevant", nil)
+	assert.Nil(t, err)
+	resp := httptest.NewRecorder()
+
+	handlerFunc := NewProposalsEndpoint(repository, &mockQualityProvider{}, money.NewConverter(money.StaticRates{"EUR": 0.2}, "EUR")).List
+	handlerFunc(resp, req, nil)
+
+	assert.JSONEq(
+		t,
+		`{
+			"proposals": [
+				{
+					"id": 1,
+					"providerId": "0xProviderId",
+					"serviceType": "testprotocol",
+					"serviceDefinition": {
+						"locationOriginate": {
+							"asn": 123,
+							"country": "Lithuania",
+							"city": "Vilnius"
+						}
+					},
+					"paymentMethod": {
+						"type": "MOCK",
+						"price": {"amount": 50000000, "currency": "MYST", "human": "0.5 MYST", "fiat": "~0.10 EUR"},
+						"pricePerGB": {"amount": 400000000, "currency": "MYST", "human": "4 MYST", "fiat": "~0.80 EUR"},
+						"perSeconds": 60
+					},
+					"trial": {"durationSeconds": 600, "bytes": 100000000}
+				},
+				{
+					"id": 1,
+					"providerId": "other_provider",
+					"serviceType": "testprotocol",
+					"serviceDefinition": {
+						"locationOriginate": {
+							"asn": 123,
+							"country": "Lithuania",
+							"city": "Vilnius"
+						}
+					}
+				}
+			]
+		}`,
+		resp.Body.String(),
+	)
+}
+
 type mockQualityProvider struct{}
 
 func (m *mockQualityProvider) ProposalsMetrics() []quality.ConnectMetric {
@@ -20,11 +20,13 @@ package endpoints
 import (
 	"encoding/json"
 	"net/http"
+	"time"
 
 	"github.com/julienschmidt/httprouter"
 	"github.com/mysteriumnetwork/node/core/service"
 	"github.com/mysteriumnetwork/node/identity"
 	"github.com/mysteriumnetwork/node/market"
+	"github.com/mysteriumnetwork/node/money"
 	"github.com/mysteriumnetwork/node/services"
 	"github.com/mysteriumnetwork/node/tequilapi/utils"
 	"github.com/mysteriumnetwork/node/tequilapi/validation"
@@ -51,6 +53,49 @@ type serviceRequest struct {
 	// access list which determines which identities will be able to receive the service
 	// required: false
 	AccessPolicies accessPoliciesRequest `json:"accessPolicies"`
+
+	// policy which determines whether service is restarted once it exits
+	// required: false
+	RestartPolicy *restartPolicyRequest `json:"restartPolicy,omitempty"`
+
+	// price of the service, default price is used if not given
+	// required: false
+	Price *priceRequest `json:"price,omitempty"`
+}
+
+// priceRequest represents the price provider asks for the service
+// swagger:model PriceDTO
+type priceRequest struct {
+	// price per minute of the service, in the smallest MYST units
+	// example: 50000
+	PerMinute uint64 `json:"perMinute"`
+
+	// price per gigabyte of transferred data, in the smallest MYST units
+	// example: 100000
+	PerGB uint64 `json:"perGB"`
+}
+
+// restartPolicyRequest represents the service restart policy
+// swagger:model RestartPolicyDTO
+type restartPolicyRequest struct {
+	// restart policy type. Possible values are "never", "on-failure" and "always"
+	// example: on-failure
+	Type string `json:"type"`
+
+	// maximum number of restarts, zero means unlimited
+	// example: 5
+	MaxRestarts int `json:"maxRestarts"`
+}
+
+// swagger:model ServiceUpdateRequestDTO
+type serviceUpdateRequest struct {
+	// access list which determines which identities will be able to receive the service, left unchanged if not given
+	// required: false
+	AccessPolicies *accessPoliciesRequest `json:"accessPolicies,omitempty"`
+
+	// price of the service, left unchanged if not given
+	// required: false
+	Price *priceRequest `json:"price,omitempty"`
 }
 
 // accessPolicy represents the access controls
@@ -85,6 +130,21 @@ type serviceInfo struct {
 	Proposal proposalDTO `json:"proposal"`
 
 	AccessPolicies *[]market.AccessPolicy `json:"accessPolicies,omitempty"`
+
+	RestartPolicy restartPolicyRequest `json:"restartPolicy"`
+
+	// number of times the service was restarted
+	// example: 1
+	RestartCount int `json:"restartCount"`
+
+	// reason of the last service failure
+	// example: liveness probe failed
+	LastFailure string `json:"lastFailure,omitempty"`
+
+	// example: 2019-06-06T11:04:43.910035Z
+	LastFailureAt *time.Time `json:"lastFailureAt,omitempty"`
+
+	Price *priceRequest `json:"price,omitempty"`
 }
 
 // ServiceEndpoint struct represents management of service resource and it's sub-resources
@@ -206,8 +266,8 @@ func (se *ServiceEndpoint) ServiceStart(resp http.ResponseWriter, req *http.Requ
 	}
 
 	log.Info().Msgf("Service start options: %+v", sr)
-	id, err := se.serviceManager.Start(identity.FromAddress(sr.ProviderID), sr.Type, sr.AccessPolicies.Ids, sr.Options)
-	if err == service.ErrorLocation {
+	id, err := se.serviceManager.Start(identity.FromAddress(sr.ProviderID), sr.Type, sr.AccessPolicies.Ids, sr.Options, toRestartPolicy(sr.RestartPolicy), toPrice(sr.Price))
+	if err == service.ErrorLocation || err == service.ErrUnsupportedPricing || err == service.ErrDataTransferNotReported {
 		utils.SendError(resp, err, http.StatusBadRequest)
 		return
 	} else if err != nil {
@@ -222,6 +282,78 @@ func (se *ServiceEndpoint) ServiceStart(resp http.ResponseWriter, req *http.Requ
 	utils.WriteAsJSON(statusResponse, resp)
 }
 
+// ServiceUpdate updates access policies and price of the running service on the node.
+// swagger:operation PUT /services/:id Service serviceUpdate
+// ---
+// summary: Updates service
+// description: Replaces access policies or price of the running service and re-announces its proposal. Existing sessions of consumers which are no longer allowed are terminated, other sessions keep their price.
+// parameters:
+//   - in: body
+//     name: body
+//     description: Access policies and price to apply to the service
+//     schema:
+//       $ref: "#/definitions/ServiceUpdateRequestDTO"
+// responses:
+//   200:
+//     description: Service updated
+//     schema:
+//       "$ref": "#/definitions/ServiceInfoDTO"
+//   400:
+//     description: Bad request
+//     schema:
+//       "$ref": "#/definitions/ErrorMessageDTO"
+//   404:
+//     description: Service not found
+//     schema:
+//       "$ref": "#/definitions/ErrorMessageDTO"
+//   500:
+//     description: Internal server error
+//     schema:
+//       "$ref": "#/definitions/ErrorMessageDTO"
+func (se *ServiceEndpoint) ServiceUpdate(resp http.ResponseWriter, req *http.Request, params httprouter.Params) {
+	id := service.ID(params.ByName("id"))
+
+	instance := se.serviceManager.Service(id)
+	if instance == nil {
+		utils.SendErrorMessage(resp, "Service not found", http.StatusNotFound)
+		return
+	}
+
+	var ur serviceUpdateRequest
+	decoder := json.NewDecoder(req.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&ur); err != nil {
+		utils.SendError(resp, err, http.StatusBadRequest)
+		return
+	}
+
+	if ur.AccessPolicies == nil && ur.Price == nil {
+		utils.SendErrorMessage(resp, "Nothing to update", http.StatusBadRequest)
+		return
+	}
+
+	var err error
+	if ur.Price != nil {
+		err = se.serviceManager.UpdatePrice(id, *toPrice(ur.Price))
+	}
+	if err == nil && ur.AccessPolicies != nil {
+		err = se.serviceManager.UpdateAccessPolicies(id, ur.AccessPolicies.Ids)
+	}
+	if err == service.ErrUnsupportedAccessPolicy || err == service.ErrUnsupportedPricing || err == service.ErrDataTransferNotReported {
+		utils.SendError(resp, err, http.StatusBadRequest)
+		return
+	} else if err == service.ErrNoSuchInstance {
+		utils.SendError(resp, err, http.StatusNotFound)
+		return
+	} else if err != nil {
+		utils.SendError(resp, err, http.StatusInternalServerError)
+		return
+	}
+
+	statusResponse := toServiceInfoResponse(id, instance)
+	utils.WriteAsJSON(statusResponse, resp)
+}
+
 // ServiceStop stops service on the node.
 // swagger:operation DELETE /services/:id Service serviceStop
 // ---
@@ -272,6 +404,7 @@ func AddRoutesForService(router *httprouter.Router, serviceManager ServiceManage
 	router.GET("/services", serviceEndpoint.ServiceList)
 	router.POST("/services", serviceEndpoint.ServiceStart)
 	router.GET("/services/:id", serviceEndpoint.ServiceGet)
+	router.PUT("/services/:id", serviceEndpoint.ServiceUpdate)
 	router.DELETE("/services/:id", serviceEndpoint.ServiceStop)
 }
 
@@ -281,6 +414,7 @@ func (se *ServiceEndpoint) toServiceRequest(req *http.Request) (serviceRequest,
 		Type           string                `json:"type"`
 		Options        *json.RawMessage      `json:"options"`
 		AccessPolicies accessPoliciesRequest `json:"accessPolicies"`
+		RestartPolicy  *restartPolicyRequest `json:"restartPolicy"`
 	}{
 		AccessPolicies: accessPoliciesRequest{
 			Ids: services.SharedConfiguredOptions().AccessPolicyList,
@@ -297,6 +431,7 @@ func (se *ServiceEndpoint) toServiceRequest(req *http.Request) (serviceRequest,
 		Type:           se.toServiceType(jsonData.Type),
 		Options:        se.toServiceOptions(jsonData.Type, jsonData.Options),
 		AccessPolicies: jsonData.AccessPolicies,
+		RestartPolicy:  jsonData.RestartPolicy,
 	}
 	return sr, nil
 }
@@ -328,16 +463,59 @@ func (se *ServiceEndpoint) toServiceOptions(serviceType string, value *json.RawM
 	return options
 }
 
+func toRestartPolicy(req *restartPolicyRequest) service.RestartPolicy {
+	policy := service.DefaultRestartPolicy()
+	if req != nil {
+		policy.Type = service.RestartPolicyType(req.Type)
+		policy.MaxRestarts = req.MaxRestarts
+	}
+	return policy
+}
+
+func toPrice(req *priceRequest) *market.Price {
+	if req == nil {
+		return nil
+	}
+	return &market.Price{
+		PerMinute: money.NewMoney(req.PerMinute, money.CurrencyMyst),
+		PerGB:     money.NewMoney(req.PerGB, money.CurrencyMyst),
+	}
+}
+
+func toPriceResponse(method market.PaymentMethod) *priceRequest {
+	priced, ok := method.(market.PricedPaymentMethod)
+	if !ok {
+		return nil
+	}
+	return &priceRequest{
+		PerMinute: priced.GetPrice().Amount,
+		PerGB:     priced.GetPricePerGB().Amount,
+	}
+}
+
 func toServiceInfoResponse(id service.ID, instance *service.Instance) serviceInfo {
 	proposal := instance.Proposal()
-	return serviceInfo{
+	restartPolicy := instance.RestartPolicy()
+	restartInfo := instance.RestartInfo()
+	info := serviceInfo{
 		ID:         string(id),
 		ProviderID: proposal.ProviderID,
 		Type:       proposal.ServiceType,
 		Options:    instance.Options(),
 		Status:     string(instance.State()),
-		Proposal:   *proposalToRes(instance.Proposal()),
+		Proposal:   *proposalToRes(proposal, nil),
+		RestartPolicy: restartPolicyRequest{
+			Type:        string(restartPolicy.Type),
+			MaxRestarts: restartPolicy.MaxRestarts,
+		},
+		RestartCount: restartInfo.Count,
+		LastFailure:  restartInfo.LastFailure,
+		Price:        toPriceResponse(proposal.PaymentMethod),
+	}
+	if !restartInfo.LastFailureAt.IsZero() {
+		info.LastFailureAt = &restartInfo.LastFailureAt
 	}
+	return info
 }
 
 func toServiceListResponse(instances map[service.ID]*service.Instance) serviceList {
@@ -362,13 +540,23 @@ func validateServiceRequest(sr serviceRequest) *validation.FieldErrorMap {
 	if sr.Options == serviceOptionsInvalid {
 		errors.ForField("options").AddError("invalid", "Invalid options")
 	}
+	if sr.RestartPolicy != nil {
+		if !toRestartPolicy(sr.RestartPolicy).IsValid() {
+			errors.ForField("restartPolicy").AddError("invalid", "Invalid restart policy type")
+		}
+		if sr.RestartPolicy.MaxRestarts < 0 {
+			errors.ForField("restartPolicy").AddError("invalid", "Max restarts can not be negative")
+		}
+	}
 	return errors
 }
 
 // ServiceManager represents service manager that is used for services management.
 type ServiceManager interface {
-	Start(providerID identity.Identity, serviceType string, policies []string, options service.Options) (service.ID, error)
+	Start(providerID identity.Identity, serviceType string, policies []string, options service.Options, restartPolicy service.RestartPolicy, price *market.Price) (service.ID, error)
 	Stop(id service.ID) error
+	UpdateAccessPolicies(id service.ID, policyIDs []string) error
+	UpdatePrice(id service.ID, price market.Price) error
 	Service(id service.ID) *service.Instance
 	Kill() error
 	List() map[service.ID]*service.Instance
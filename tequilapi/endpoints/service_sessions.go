@@ -47,7 +47,7 @@ func NewServiceSessionsEndpoint(stateStorage stateStorage) *serviceSessionsEndpo
 	}
 }
 
-// swagger:operation GET /service/:id/sessions Service serviceSessions
+// swagger:operation GET /service-sessions Service serviceSessions
 // ---
 // summary: Returns current sessions
 // description: Returns list of sessions in currently running service
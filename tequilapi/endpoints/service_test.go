@@ -83,13 +83,25 @@ type fancyServiceOptions struct {
 
 type mockServiceManager struct{}
 
-func (sm *mockServiceManager) Start(providerID identity.Identity, serviceType string, policyIDs []string, options service.Options) (service.ID, error) {
+func (sm *mockServiceManager) Start(providerID identity.Identity, serviceType string, policyIDs []string, options service.Options, restartPolicy service.RestartPolicy, price *market.Price) (service.ID, error) {
 	if serviceType == serviceTypeWithAccessPolicy {
 		return mockAccessPolicyServiceID, nil
 	}
 	return mockServiceID, nil
 }
 func (sm *mockServiceManager) Stop(id service.ID) error { return nil }
+func (sm *mockServiceManager) UpdateAccessPolicies(id service.ID, policyIDs []string) error {
+	if len(policyIDs) > 0 && policyIDs[0] == "unknown-policy" {
+		return service.ErrUnsupportedAccessPolicy
+	}
+	return nil
+}
+func (sm *mockServiceManager) UpdatePrice(id service.ID, price market.Price) error {
+	if id == mockAccessPolicyServiceID {
+		return service.ErrUnsupportedPricing
+	}
+	return nil
+}
 func (sm *mockServiceManager) Service(id service.ID) *service.Instance {
 	if id == "6ba7b810-9dad-11d1-80b4-00c04fd430c8" {
 		return mockServiceRunning
@@ -140,6 +152,8 @@ func Test_AddRoutesForServiceAddsRoutes(t *testing.T) {
 				"type": "testprotocol",
 				"options": {"foo": "bar"},
 				"status": "NotRunning",
+				"restartPolicy": {"type": "never", "maxRestarts": 0},
+				"restartCount": 0,
 				"proposal": {
 					"id": 1,
 					"providerId": "0xProviderId",
@@ -161,6 +175,8 @@ func Test_AddRoutesForServiceAddsRoutes(t *testing.T) {
 				"type": "testprotocol",
 				"options": {"foo": "bar"},
 				"status": "Running",
+				"restartPolicy": {"type": "never", "maxRestarts": 0},
+				"restartCount": 0,
 				"proposal": {
 					"id": 1,
 					"providerId": "0xProviderId",
@@ -182,6 +198,31 @@ func Test_AddRoutesForServiceAddsRoutes(t *testing.T) {
 				"type": "testprotocol",
 				"options": {"foo": "bar"},
 				"status": "Running",
+				"restartPolicy": {"type": "never", "maxRestarts": 0},
+				"restartCount": 0,
+				"proposal": {
+					"id": 1,
+					"providerId": "0xProviderId",
+					"serviceType": "testprotocol",
+					"serviceDefinition": {
+						"locationOriginate": {"asn": 123, "country": "Lithuania", "city": "Vilnius"}
+					}
+				}
+			}`,
+		},
+		{
+			http.MethodPut,
+			"/services/6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+			`{"accessPolicies": {"ids": ["verified-traffic"]}}`,
+			http.StatusOK,
+			`{
+				"id": "6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+				"providerId": "0xProviderId",
+				"type": "testprotocol",
+				"options": {"foo": "bar"},
+				"status": "Running",
+				"restartPolicy": {"type": "never", "maxRestarts": 0},
+				"restartCount": 0,
 				"proposal": {
 					"id": 1,
 					"providerId": "0xProviderId",
@@ -192,6 +233,45 @@ func Test_AddRoutesForServiceAddsRoutes(t *testing.T) {
 				}
 			}`,
 		},
+		{
+			http.MethodPut, "/services/00000000-9dad-11d1-80b4-00c04fd43000", `{"accessPolicies": {"ids": []}}`,
+			http.StatusNotFound, `{"message":"Service not found"}`,
+		},
+		{
+			http.MethodPut, "/services/6ba7b810-9dad-11d1-80b4-00c04fd430c8", `{"accessPolicies": {"ids": ["unknown-policy"]}}`,
+			http.StatusBadRequest, `{"message":"unsupported access policy"}`,
+		},
+		{
+			http.MethodPut,
+			"/services/6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+			`{"price": {"perMinute": 100, "perGB": 1000}}`,
+			http.StatusOK,
+			`{
+				"id": "6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+				"providerId": "0xProviderId",
+				"type": "testprotocol",
+				"options": {"foo": "bar"},
+				"status": "Running",
+				"restartPolicy": {"type": "never", "maxRestarts": 0},
+				"restartCount": 0,
+				"proposal": {
+					"id": 1,
+					"providerId": "0xProviderId",
+					"serviceType": "testprotocol",
+					"serviceDefinition": {
+						"locationOriginate": {"asn": 123, "country": "Lithuania", "city": "Vilnius"}
+					}
+				}
+			}`,
+		},
+		{
+			http.MethodPut, "/services/" + string(mockAccessPolicyServiceID), `{"price": {"perMinute": 100, "perGB": 1000}}`,
+			http.StatusBadRequest, `{"message":"service does not support custom pricing"}`,
+		},
+		{
+			http.MethodPut, "/services/6ba7b810-9dad-11d1-80b4-00c04fd430c8", `{}`,
+			http.StatusBadRequest, `{"message":"Nothing to update"}`,
+		},
 		{
 			http.MethodDelete, "/services/6ba7b810-9dad-11d1-80b4-00c04fd430c8", "",
 			http.StatusAccepted, "",
@@ -216,6 +296,35 @@ func Test_AddRoutesForServiceAddsRoutes(t *testing.T) {
 	}
 }
 
+func Test_ServiceStart_InvalidRestartPolicy(t *testing.T) {
+	serviceEndpoint := NewServiceEndpoint(&mockServiceManager{}, fakeOptionsParser)
+
+	req := httptest.NewRequest(
+		http.MethodPost,
+		"/irrelevant",
+		strings.NewReader(`{
+			"type": "testprotocol",
+			"providerId": "0x9edf75f870d87d2d1a69f0d950a99984ae955ee0",
+			"restartPolicy": {"type": "sometimes"}
+		}`),
+	)
+	resp := httptest.NewRecorder()
+
+	serviceEndpoint.ServiceStart(resp, req, httprouter.Params{})
+
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.Code)
+	assert.JSONEq(
+		t,
+		`{
+			"message": "validation_error",
+			"errors": {
+				"restartPolicy": [ {"code": "invalid", "message": "Invalid restart policy type"} ]
+			}
+		}`,
+		resp.Body.String(),
+	)
+}
+
 func Test_ServiceStartInvalidType(t *testing.T) {
 	serviceEndpoint := NewServiceEndpoint(&mockServiceManager{}, fakeOptionsParser)
 
@@ -355,6 +464,8 @@ func Test_ServiceGetReturnsServiceInfo(t *testing.T) {
 			"type": "testprotocol",
 			"options": {"foo": "bar"},
 			"status": "Running",
+			"restartPolicy": {"type": "never", "maxRestarts": 0},
+			"restartCount": 0,
 			"proposal": {
 				"id": 1,
 				"providerId": "0xProviderId",
@@ -437,6 +548,8 @@ func Test_ServiceStart_WithAccessPolicy(t *testing.T) {
 			"type": "mockAccessPolicyService",
 			"options": {"foo": "bar"},
 			"status": "Running",
+			"restartPolicy": {"type": "never", "maxRestarts": 0},
+			"restartCount": 0,
 			"proposal": {
 				"id": 1,
 				"providerId": "0xProviderId",
@@ -29,6 +29,17 @@ type SSEHandler interface {
 }
 
 // AddRoutesForSSE adds route for sse
+// swagger:operation GET /events/state Events stateEvents
+// ---
+// summary: Subscribes to node state events
+// description: Streams node state changes as server-sent events
+// produces:
+// - text/event-stream
+// responses:
+//   200:
+//     description: Stream of state events
+//     schema:
+//       type: string
 func AddRoutesForSSE(router *httprouter.Router, handler SSEHandler) {
 	router.GET("/events/state", handler.Sub)
 }
@@ -0,0 +1,307 @@
+/*
+ * Copyright (C) 2020 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package endpoints
+
+import (
+	"bytes"
+	"encoding/csv"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/mysteriumnetwork/node/core/ledger"
+	"github.com/mysteriumnetwork/node/identity"
+	"github.com/mysteriumnetwork/node/tequilapi/utils"
+	"github.com/mysteriumnetwork/node/tequilapi/validation"
+	"github.com/rs/zerolog/log"
+)
+
+// transactionList defines payment transaction list representable as json
+// swagger:model TransactionListDTO
+type transactionList struct {
+	Transactions []transaction `json:"transactions"`
+}
+
+// transaction represents a single payment transaction recorded in the ledger
+// swagger:model TransactionDTO
+type transaction struct {
+	// example: 1
+	ID int `json:"id"`
+
+	// example: exchange_message
+	Kind string `json:"kind"`
+
+	// example: outgoing
+	Direction string `json:"direction"`
+
+	// example: 2020-03-01T12:00:00Z
+	Time string `json:"time"`
+
+	// example: 4cfb0324-daf6-4ad8-448b-e61fe0a1f918
+	SessionID string `json:"sessionId"`
+
+	// example: 0x0000000000000000000000000000000000000001
+	Identity string `json:"identity"`
+
+	// example: 0x0000000000000000000000000000000000000002
+	Counterparty string `json:"counterparty"`
+
+	// example: 0x0000000000000000000000000000000000000003
+	AccountantID string `json:"accountantId"`
+
+	// example: openvpn
+	ServiceType string `json:"serviceType,omitempty"`
+
+	// country of the counterparty
+	// example: NL
+	Country string `json:"country,omitempty"`
+
+	// value of this transaction alone
+	// example: 500000
+	Amount uint64 `json:"amount"`
+
+	// cumulative amount stated by the transaction
+	// example: 1500000
+	Total uint64 `json:"total"`
+
+	// example: 0
+	Fee uint64 `json:"fee"`
+
+	Hashlock string `json:"hashlock,omitempty"`
+
+	TxHash string `json:"txHash,omitempty"`
+}
+
+var transactionCSVHeader = []string{"id", "kind", "direction", "time", "session_id", "identity", "counterparty", "accountant_id", "service_type", "country", "amount", "total", "fee", "hashlock", "tx_hash"}
+
+func (t transaction) csvRecord() []string {
+	return []string{
+		strconv.Itoa(t.ID),
+		t.Kind,
+		t.Direction,
+		t.Time,
+		t.SessionID,
+		t.Identity,
+		t.Counterparty,
+		t.AccountantID,
+		t.ServiceType,
+		t.Country,
+		strconv.FormatUint(t.Amount, 10),
+		strconv.FormatUint(t.Total, 10),
+		strconv.FormatUint(t.Fee, 10),
+		t.Hashlock,
+		t.TxHash,
+	}
+}
+
+type transactionLedger interface {
+	Entries(filter ledger.Filter) ([]ledger.Entry, error)
+}
+
+type transactionsEndpoint struct {
+	ledger transactionLedger
+}
+
+// NewTransactionsEndpoint creates and returns payment transactions endpoint
+func NewTransactionsEndpoint(ledger transactionLedger) *transactionsEndpoint {
+	return &transactionsEndpoint{ledger: ledger}
+}
+
+// swagger:operation GET /transactions Transactions transactions
+// ---
+// summary: Returns payment transactions history
+// description: Returns invoices, exchange messages, accountant promises and settlements recorded in the payment ledger, oldest first
+// parameters:
+// - in: query
+//   name: identity
+//   description: Identity which sent or received the transactions
+//   type: string
+// - in: query
+//   name: sessionId
+//   description: Session the transactions belong to
+//   type: string
+// - in: query
+//   name: kind
+//   description: Transaction kind, one of invoice, exchange_message, accountant_promise, settlement
+//   type: string
+// - in: query
+//   name: direction
+//   description: Transaction direction, one of incoming, outgoing
+//   type: string
+// - in: query
+//   name: from
+//   description: Include transactions made at or after the given RFC3339 time
+//   type: string
+// - in: query
+//   name: to
+//   description: Include transactions made before the given RFC3339 time
+//   type: string
+// - in: query
+//   name: offset
+//   description: Number of the oldest matching transactions to skip
+//   type: integer
+// - in: query
+//   name: limit
+//   description: Maximum number of transactions to return, all by default
+//   type: integer
+// - in: query
+//   name: format
+//   description: Export format, one of json (default), csv
+//   type: string
+// produces:
+// - application/json
+// - text/csv
+// responses:
+//   200:
+//     description: List of transactions
+//     schema:
+//       "$ref": "#/definitions/TransactionListDTO"
+//   422:
+//     description: Parameters validation error
+//     schema:
+//       "$ref": "#/definitions/ValidationErrorDTO"
+//   500:
+//     description: Internal server error
+//     schema:
+//       "$ref": "#/definitions/ErrorMessageDTO"
+func (te *transactionsEndpoint) List(resp http.ResponseWriter, request *http.Request, _ httprouter.Params) {
+	filter, format, errorMap := parseTransactionsQuery(request)
+	if errorMap.HasErrors() {
+		utils.SendValidationErrorMessage(resp, errorMap)
+		return
+	}
+
+	entries, err := te.ledger.Entries(filter)
+	if err != nil {
+		utils.SendError(resp, err, http.StatusInternalServerError)
+		return
+	}
+
+	transactions := make([]transaction, len(entries))
+	for i, e := range entries {
+		transactions[i] = transactionToDto(e)
+	}
+
+	if format == "csv" {
+		writeTransactionsCSV(resp, transactions)
+		return
+	}
+	utils.WriteAsJSON(transactionList{Transactions: transactions}, resp)
+}
+
+func parseTransactionsQuery(request *http.Request) (ledger.Filter, string, *validation.FieldErrorMap) {
+	query := request.URL.Query()
+	errorMap := validation.NewErrorMap()
+	filter := ledger.Filter{
+		SessionID: query.Get("sessionId"),
+		Kind:      ledger.Kind(query.Get("kind")),
+		Direction: ledger.Direction(query.Get("direction")),
+	}
+	if address := query.Get("identity"); address != "" {
+		filter.Identity = identity.FromAddress(address)
+	}
+	if filter.Kind != "" && !filter.Kind.IsValid() {
+		errorMap.ForField("kind").AddError("invalid", "Unknown transaction kind")
+	}
+	if filter.Direction != "" && filter.Direction != ledger.DirectionIncoming && filter.Direction != ledger.DirectionOutgoing {
+		errorMap.ForField("direction").AddError("invalid", "Direction must be incoming or outgoing")
+	}
+	filter.From = parseQueryTime(query, "from", errorMap)
+	filter.To = parseQueryTime(query, "to", errorMap)
+
+	if value := query.Get("offset"); value != "" {
+		offset, err := strconv.Atoi(value)
+		if err != nil || offset < 0 {
+			errorMap.ForField("offset").AddError("invalid", "Offset must be a non-negative number")
+		}
+		filter.Offset = offset
+	}
+	if value := query.Get("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit <= 0 {
+			errorMap.ForField("limit").AddError("invalid", "Limit must be a positive number")
+		}
+		filter.Limit = limit
+	}
+
+	format := query.Get("format")
+	if format != "" && format != "json" && format != "csv" {
+		errorMap.ForField("format").AddError("invalid", "Format must be json or csv")
+	}
+	return filter, format, errorMap
+}
+
+func parseQueryTime(query url.Values, field string, errorMap *validation.FieldErrorMap) time.Time {
+	value := query.Get(field)
+	if value == "" {
+		return time.Time{}
+	}
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		errorMap.ForField(field).AddError("invalid", "Time must be in RFC3339 format")
+	}
+	return parsed
+}
+
+func writeTransactionsCSV(resp http.ResponseWriter, transactions []transaction) {
+	records := make([][]string, 0, len(transactions)+1)
+	records = append(records, transactionCSVHeader)
+	for _, t := range transactions {
+		records = append(records, t.csvRecord())
+	}
+
+	var buf bytes.Buffer
+	if err := csv.NewWriter(&buf).WriteAll(records); err != nil {
+		utils.SendError(resp, err, http.StatusInternalServerError)
+		return
+	}
+
+	resp.Header().Set("Content-Type", "text/csv")
+	resp.Header().Set("Content-Disposition", `attachment; filename="transactions.csv"`)
+	if _, err := buf.WriteTo(resp); err != nil {
+		log.Error().Err(err).Msg("Failed to write transactions CSV")
+	}
+}
+
+func transactionToDto(e ledger.Entry) transaction {
+	return transaction{
+		ID:           e.ID,
+		Kind:         string(e.Kind),
+		Direction:    string(e.Direction),
+		Time:         e.Time.Format(time.RFC3339),
+		SessionID:    e.SessionID,
+		Identity:     e.Identity.Address,
+		Counterparty: e.Counterparty.Address,
+		AccountantID: e.AccountantID.Address,
+		ServiceType:  e.ServiceType,
+		Country:      e.Country,
+		Amount:       e.Amount,
+		Total:        e.Total,
+		Fee:          e.Fee,
+		Hashlock:     e.Hashlock,
+		TxHash:       e.TxHash,
+	}
+}
+
+// AddRoutesForTransactions attaches payment transactions endpoints to router
+func AddRoutesForTransactions(router *httprouter.Router, ledger transactionLedger) {
+	te := NewTransactionsEndpoint(ledger)
+	router.GET("/transactions", te.List)
+}
@@ -0,0 +1,136 @@
+/*
+ * Copyright (C) 2020 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package endpoints
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/mysteriumnetwork/node/core/ledger"
+	"github.com/mysteriumnetwork/node/identity"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockTransactionLedger struct {
+	entries []ledger.Entry
+	filter  ledger.Filter
+}
+
+func (mtl *mockTransactionLedger) Entries(filter ledger.Filter) ([]ledger.Entry, error) {
+	mtl.filter = filter
+	return mtl.entries, nil
+}
+
+func newMockTransactionLedger() *mockTransactionLedger {
+	return &mockTransactionLedger{entries: []ledger.Entry{
+		{
+			ID:           1,
+			Kind:         ledger.KindExchangeMessage,
+			Direction:    ledger.DirectionOutgoing,
+			Time:         time.Date(2020, 3, 1, 12, 0, 0, 0, time.UTC),
+			SessionID:    "session1",
+			Identity:     identity.FromAddress("0x1"),
+			Counterparty: identity.FromAddress("0x2"),
+			AccountantID: identity.FromAddress("0x3"),
+			ServiceType:  "wireguard",
+			Amount:       500,
+			Total:        1500,
+			Hashlock:     "abc",
+		},
+	}}
+}
+
+func Test_Transactions(t *testing.T) {
+	mockLedger := newMockTransactionLedger()
+	router := httprouter.New()
+	AddRoutesForTransactions(router, mockLedger)
+
+	req, err := http.NewRequest(http.MethodGet, "/transactions?identity=0x1&kind=exchange_message&from=2020-03-01T00:00:00Z&offset=10&limit=5", nil)
+	assert.NoError(t, err)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, ledger.Filter{
+		Identity: identity.FromAddress("0x1"),
+		Kind:     ledger.KindExchangeMessage,
+		From:     time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC),
+		Offset:   10,
+		Limit:    5,
+	}, mockLedger.filter)
+	assert.JSONEq(t, `{
+		"transactions": [{
+			"id": 1,
+			"kind": "exchange_message",
+			"direction": "outgoing",
+			"time": "2020-03-01T12:00:00Z",
+			"sessionId": "session1",
+			"identity": "0x1",
+			"counterparty": "0x2",
+			"accountantId": "0x3",
+			"serviceType": "wireguard",
+			"amount": 500,
+			"total": 1500,
+			"fee": 0,
+			"hashlock": "abc"
+		}]
+	}`, resp.Body.String())
+}
+
+func Test_TransactionsCSV(t *testing.T) {
+	router := httprouter.New()
+	AddRoutesForTransactions(router, newMockTransactionLedger())
+
+	req, err := http.NewRequest(http.MethodGet, "/transactions?format=csv", nil)
+	assert.NoError(t, err)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, "text/csv", resp.Header().Get("Content-Type"))
+	assert.Equal(t,
+		"id,kind,direction,time,session_id,identity,counterparty,accountant_id,service_type,country,amount,total,fee,hashlock,tx_hash\n"+
+			"1,exchange_message,outgoing,2020-03-01T12:00:00Z,session1,0x1,0x2,0x3,wireguard,,500,1500,0,abc,\n",
+		resp.Body.String(),
+	)
+}
+
+func Test_TransactionsValidation(t *testing.T) {
+	router := httprouter.New()
+	AddRoutesForTransactions(router, newMockTransactionLedger())
+
+	req, err := http.NewRequest(http.MethodGet, "/transactions?kind=refund&to=yesterday&format=xml&offset=-1&limit=0", nil)
+	assert.NoError(t, err)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.Code)
+	assert.JSONEq(t, `{
+		"message": "validation_error",
+		"errors": {
+			"kind": [{"code": "invalid", "message": "Unknown transaction kind"}],
+			"to": [{"code": "invalid", "message": "Time must be in RFC3339 format"}],
+			"format": [{"code": "invalid", "message": "Format must be json or csv"}],
+			"offset": [{"code": "invalid", "message": "Offset must be a non-negative number"}],
+			"limit": [{"code": "invalid", "message": "Limit must be a positive number"}]
+		}
+	}`, resp.Body.String())
+}
@@ -27,6 +27,7 @@ import (
 
 	"github.com/mysteriumnetwork/node/identity"
 	"github.com/mysteriumnetwork/node/identity/registry"
+	"github.com/mysteriumnetwork/node/money"
 	"github.com/mysteriumnetwork/node/tequilapi/utils"
 )
 
@@ -46,13 +47,15 @@ type promiseSettler interface {
 type transactorEndpoint struct {
 	transactor     Transactor
 	promiseSettler promiseSettler
+	moneyFormatter moneyFormatter
 }
 
 // NewTransactorEndpoint creates and returns transactor endpoint
-func NewTransactorEndpoint(transactor Transactor, promiseSettler promiseSettler) *transactorEndpoint {
+func NewTransactorEndpoint(transactor Transactor, promiseSettler promiseSettler, moneyFormatter moneyFormatter) *transactorEndpoint {
 	return &transactorEndpoint{
 		transactor:     transactor,
 		promiseSettler: promiseSettler,
+		moneyFormatter: moneyFormatter,
 	}
 }
 
@@ -61,6 +64,12 @@ func NewTransactorEndpoint(transactor Transactor, promiseSettler promiseSettler)
 type Fees struct {
 	Registration uint64 `json:"registration"`
 	Settlement   uint64 `json:"settlement"`
+
+	// human readable registration fee
+	RegistrationDisplay moneyDTO `json:"registrationDisplay"`
+
+	// human readable settlement fee
+	SettlementDisplay moneyDTO `json:"settlementDisplay"`
 }
 
 // swagger:operation GET /transactor/fees Fees
@@ -89,8 +98,10 @@ func (te *transactorEndpoint) TransactorFees(resp http.ResponseWriter, _ *http.R
 	}
 
 	f := Fees{
-		Registration: registrationFees.Fee,
-		Settlement:   settlementFees.Fee,
+		Registration:        registrationFees.Fee,
+		Settlement:          settlementFees.Fee,
+		RegistrationDisplay: newMoneyDTO(money.NewMoney(registrationFees.Fee, money.CurrencyMyst), te.moneyFormatter),
+		SettlementDisplay:   newMoneyDTO(money.NewMoney(settlementFees.Fee, money.CurrencyMyst), te.moneyFormatter),
 	}
 
 	utils.WriteAsJSON(f, resp)
@@ -262,8 +273,8 @@ func (te *transactorEndpoint) RegisterIdentity(resp http.ResponseWriter, request
 }
 
 // AddRoutesForTransactor attaches Transactor endpoints to router
-func AddRoutesForTransactor(router *httprouter.Router, transactor Transactor, promiseSettler promiseSettler) {
-	te := NewTransactorEndpoint(transactor, promiseSettler)
+func AddRoutesForTransactor(router *httprouter.Router, transactor Transactor, promiseSettler promiseSettler, moneyFormatter moneyFormatter) {
+	te := NewTransactorEndpoint(transactor, promiseSettler, moneyFormatter)
 	router.POST("/identities/:id/register", te.RegisterIdentity)
 	router.GET("/transactor/fees", te.TransactorFees)
 	router.POST("/transactor/topup", te.TopUp)
@@ -31,6 +31,7 @@ import (
 
 	"github.com/mysteriumnetwork/node/identity"
 	"github.com/mysteriumnetwork/node/identity/registry"
+	"github.com/mysteriumnetwork/node/money"
 )
 
 var identityRegData = `{
@@ -50,7 +51,7 @@ func Test_RegisterIdentity(t *testing.T) {
 	router := httprouter.New()
 
 	tr := registry.NewTransactor(requests.NewHTTPClient(server.URL, requests.DefaultTimeout), server.URL, "0xbe180c8CA53F280C7BE8669596fF7939d933AA10", "0xbe180c8CA53F280C7BE8669596fF7939d933AA10", "0xbe180c8CA53F280C7BE8669596fF7939d933AA10", fakeSignerFactory, &mockPublisher{})
-	AddRoutesForTransactor(router, tr, nil)
+	AddRoutesForTransactor(router, tr, nil, nil)
 
 	req, err := http.NewRequest(
 		http.MethodPost,
@@ -73,7 +74,7 @@ func Test_Get_TransactorFees(t *testing.T) {
 	router := httprouter.New()
 
 	tr := registry.NewTransactor(requests.NewHTTPClient(server.URL, requests.DefaultTimeout), server.URL, "registryAddress", "0xbe180c8CA53F280C7BE8669596fF7939d933AA10", "accountantID", fakeSignerFactory, &mockPublisher{})
-	AddRoutesForTransactor(router, tr, nil)
+	AddRoutesForTransactor(router, tr, nil, nil)
 
 	req, err := http.NewRequest(
 		http.MethodGet,
@@ -86,7 +87,36 @@ func Test_Get_TransactorFees(t *testing.T) {
 	router.ServeHTTP(resp, req)
 
 	assert.Equal(t, http.StatusOK, resp.Code)
-	assert.JSONEq(t, `{"registration":1, "settlement":1}`, resp.Body.String())
+	assert.JSONEq(t, `{
+		"registration": 1,
+		"settlement": 1,
+		"registrationDisplay": {"amount": 1, "currency": "MYST", "human": "0.00000001 MYST"},
+		"settlementDisplay": {"amount": 1, "currency": "MYST", "human": "0.00000001 MYST"}
+	}`, resp.Body.String())
+}
+
+func Test_Get_TransactorFees_InDisplayCurrency(t *testing.T) {
+	mockResponse := `{ "fee": 100000000 }`
+	server := newTestTransactorServer(http.StatusOK, mockResponse)
+
+	router := httprouter.New()
+
+	tr := registry.NewTransactor(requests.NewHTTPClient(server.URL, requests.DefaultTimeout), server.URL, "registryAddress", "0xbe180c8CA53F280C7BE8669596fF7939d933AA10", "accountantID", fakeSignerFactory, &mockPublisher{})
+	AddRoutesForTransactor(router, tr, nil, money.NewConverter(money.StaticRates{"USD": 0.25}, "USD"))
+
+	req, err := http.NewRequest(http.MethodGet, "/transactor/fees", nil)
+	assert.Nil(t, err)
+
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.JSONEq(t, `{
+		"registration": 100000000,
+		"settlement": 100000000,
+		"registrationDisplay": {"amount": 100000000, "currency": "MYST", "human": "1 MYST", "fiat": "~0.25 USD"},
+		"settlementDisplay": {"amount": 100000000, "currency": "MYST", "human": "1 MYST", "fiat": "~0.25 USD"}
+	}`, resp.Body.String())
 }
 
 func Test_TopUp_OK(t *testing.T) {
@@ -96,7 +126,7 @@ func Test_TopUp_OK(t *testing.T) {
 	router := httprouter.New()
 
 	tr := registry.NewTransactor(requests.NewHTTPClient(server.URL, requests.DefaultTimeout), server.URL, "0xbe180c8CA53F280C7BE8669596fF7939d933AA10", "0xbe180c8CA53F280C7BE8669596fF7939d933AA10", "0xbe180c8CA53F280C7BE8669596fF7939d933AA10", fakeSignerFactory, &mockPublisher{})
-	AddRoutesForTransactor(router, tr, nil)
+	AddRoutesForTransactor(router, tr, nil, nil)
 
 	topUpData := `{"identity": "0xbe180c8CA53F280C7BE8669596fF7939d933AA10"}`
 	req, err := http.NewRequest(
@@ -121,7 +151,7 @@ func Test_TopUp_BubblesErrors(t *testing.T) {
 	router := httprouter.New()
 
 	tr := registry.NewTransactor(requests.NewHTTPClient(server.URL, requests.DefaultTimeout), server.URL, "0x599d43715DF3070f83355D9D90AE62c159E62A75", "0x599d43715DF3070f83355D9D90AE62c159E62A75", "0x599d43715DF3070f83355D9D90AE62c159E62A75", fakeSignerFactory, &mockPublisher{})
-	AddRoutesForTransactor(router, tr, nil)
+	AddRoutesForTransactor(router, tr, nil, nil)
 
 	topUpData := `{"identity": "0x599d43715DF3070f83355D9D90AE62c159E62A75"}`
 	req, err := http.NewRequest(
@@ -149,7 +179,7 @@ func Test_SettleAsync_OK(t *testing.T) {
 	router := httprouter.New()
 
 	tr := registry.NewTransactor(requests.NewHTTPClient(server.URL, requests.DefaultTimeout), server.URL, "0xbe180c8CA53F280C7BE8669596fF7939d933AA10", "0xbe180c8CA53F280C7BE8669596fF7939d933AA10", "0xbe180c8CA53F280C7BE8669596fF7939d933AA10", fakeSignerFactory, &mockPublisher{})
-	AddRoutesForTransactor(router, tr, &mockSettler{})
+	AddRoutesForTransactor(router, tr, &mockSettler{}, nil)
 
 	settleRequest := `{"accountant_id": "0xbe180c8CA53F280C7BE8669596fF7939d933AA10", "provider_id": "0xbe180c8CA53F280C7BE8669596fF7939d933AA10"}`
 	req, err := http.NewRequest(
@@ -173,7 +203,7 @@ func Test_SettleAsync_ReturnsError(t *testing.T) {
 	router := httprouter.New()
 
 	tr := registry.NewTransactor(requests.NewHTTPClient(server.URL, requests.DefaultTimeout), server.URL, "0xbe180c8CA53F280C7BE8669596fF7939d933AA10", "0xbe180c8CA53F280C7BE8669596fF7939d933AA10", "0xbe180c8CA53F280C7BE8669596fF7939d933AA10", fakeSignerFactory, &mockPublisher{})
-	AddRoutesForTransactor(router, tr, &mockSettler{errToReturn: errors.New("explosions everywhere")})
+	AddRoutesForTransactor(router, tr, &mockSettler{errToReturn: errors.New("explosions everywhere")}, nil)
 
 	settleRequest := `asdasdasd`
 	req, err := http.NewRequest(
@@ -197,7 +227,7 @@ func Test_SettleSync_OK(t *testing.T) {
 	router := httprouter.New()
 
 	tr := registry.NewTransactor(requests.NewHTTPClient(server.URL, requests.DefaultTimeout), server.URL, "0xbe180c8CA53F280C7BE8669596fF7939d933AA10", "0xbe180c8CA53F280C7BE8669596fF7939d933AA10", "0xbe180c8CA53F280C7BE8669596fF7939d933AA10", fakeSignerFactory, &mockPublisher{})
-	AddRoutesForTransactor(router, tr, &mockSettler{})
+	AddRoutesForTransactor(router, tr, &mockSettler{}, nil)
 
 	settleRequest := `{"accountant_id": "0xbe180c8CA53F280C7BE8669596fF7939d933AA10", "provider_id": "0xbe180c8CA53F280C7BE8669596fF7939d933AA10"}`
 	req, err := http.NewRequest(
@@ -221,7 +251,7 @@ func Test_SettleSync_ReturnsError(t *testing.T) {
 	router := httprouter.New()
 
 	tr := registry.NewTransactor(requests.NewHTTPClient(server.URL, requests.DefaultTimeout), server.URL, "0xbe180c8CA53F280C7BE8669596fF7939d933AA10", "0xbe180c8CA53F280C7BE8669596fF7939d933AA10", "0xbe180c8CA53F280C7BE8669596fF7939d933AA10", fakeSignerFactory, &mockPublisher{})
-	AddRoutesForTransactor(router, tr, &mockSettler{errToReturn: errors.New("explosions everywhere")})
+	AddRoutesForTransactor(router, tr, &mockSettler{errToReturn: errors.New("explosions everywhere")}, nil)
 
 	settleRequest := `{"accountant_id": "0xbe180c8CA53F280C7BE8669596fF7939d933AA10", "provider_id": "0xbe180c8CA53F280C7BE8669596fF7939d933AA10"}`
 	req, err := http.NewRequest(
@@ -0,0 +1,55 @@
+/*
+ * Copyright (C) 2020 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package endpoints
+
+import (
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// WebSocketHandler represents the websocket event handler
+type WebSocketHandler interface {
+	Sub(resp http.ResponseWriter, req *http.Request, params httprouter.Params)
+}
+
+// AddRoutesForWebSocket adds route for websocket events
+// swagger:operation GET /events/ws Events websocketEvents
+// ---
+// summary: Subscribes to node events via websocket
+// description: |
+//   Upgrades the connection to websocket streaming node state, connection statistics, session and balance events
+//   as JSON messages {"type": "...", "payload": {...}}.
+//   Client may send commands {"id": "1", "command": "...", "params": {...}}, answered by messages of type "response"
+//   with the same id, HTTP status and payload.
+//   Command "subscribe" with params {"events": [...]} limits the streamed event types, all of them are streamed by default.
+//   Commands "connect", "disconnect", "start-service" and "stop-service" (params {"id": "..."}) are executed the same
+//   as PUT /connection, DELETE /connection, POST /services and DELETE /services/{id}, params being the request body.
+// parameters:
+// - in: query
+//   name: events
+//   description: Comma separated event types to stream initially
+//   type: string
+// responses:
+//   101:
+//     description: Switched to websocket protocol
+//   400:
+//     description: Not a websocket request
+func AddRoutesForWebSocket(router *httprouter.Router, handler WebSocketHandler) {
+	router.GET("/events/ws", handler.Sub)
+}
@@ -83,6 +83,9 @@ func (server *apiServer) serve(handler http.Handler) {
 
 func extractBoundAddress(listener net.Listener) (string, error) {
 	addr := listener.Addr()
+	if addr.Network() == "unix" {
+		return addr.String(), nil
+	}
 	parts := strings.Split(addr.String(), ":")
 	if len(parts) < 2 {
 		return "", errors.New("Unable to locate address: " + addr.String())
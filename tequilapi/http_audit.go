@@ -0,0 +1,139 @@
+/*
+ * Copyright (C) 2020 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package tequilapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/mysteriumnetwork/node/core/audit"
+	"github.com/mysteriumnetwork/node/core/auth"
+	"github.com/rs/zerolog/log"
+)
+
+// maxAuditedBodySize limits the size of request body recorded to the audit log
+const maxAuditedBodySize = 64 * 1024
+
+type auditLog interface {
+	Record(record audit.Record) error
+}
+
+type auditHandler struct {
+	originalHandler http.Handler
+	log             auditLog
+}
+
+// ApplyAudit wraps original handler by recording the requests which may change the node state to the audit log.
+// It should be applied after authorization, so that the authenticated user is known.
+func ApplyAudit(original http.Handler, log auditLog) http.Handler {
+	return &auditHandler{
+		originalHandler: original,
+		log:             log,
+	}
+}
+
+func (handler *auditHandler) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		handler.originalHandler.ServeHTTP(resp, req)
+		return
+	}
+
+	record := audit.Record{
+		RemoteAddr: req.RemoteAddr,
+		Method:     req.Method,
+		Path:       req.URL.Path,
+		Params:     requestParams(req),
+	}
+	if record.RemoteAddr == "@" {
+		record.RemoteAddr = ""
+	}
+	if user, ok := auth.UserFromContext(req.Context()); ok {
+		record.Username = user.Username
+		record.Role = string(user.Role)
+	}
+
+	recorder := &statusRecorder{ResponseWriter: resp, status: http.StatusOK}
+	started := time.Now()
+	handler.originalHandler.ServeHTTP(recorder, req)
+	record.Duration = time.Since(started)
+	record.Status = recorder.status
+
+	if err := handler.log.Record(record); err != nil {
+		log.Warn().Err(err).Msgf("Could not record %s %s to audit log", record.Method, record.Path)
+	}
+}
+
+// requestParams returns query and JSON body parameters of the request with secrets redacted.
+// The body is left readable for the original handler.
+func requestParams(req *http.Request) map[string]interface{} {
+	params := make(map[string]interface{})
+
+	query := make(map[string]interface{})
+	for name, values := range req.URL.Query() {
+		if audit.IsSecret(name) {
+			query[name] = audit.Redacted
+		} else if len(values) == 1 {
+			query[name] = values[0]
+		} else {
+			query[name] = values
+		}
+	}
+	if len(query) > 0 {
+		params["query"] = query
+	}
+
+	if req.Body == nil {
+		return params
+	}
+	body, err := ioutil.ReadAll(io.LimitReader(req.Body, maxAuditedBodySize+1))
+	req.Body = readCloser{Reader: io.MultiReader(bytes.NewReader(body), req.Body), Closer: req.Body}
+	switch {
+	case err != nil:
+		params["body"] = "unreadable"
+	case len(body) > maxAuditedBodySize:
+		params["body"] = "too large"
+	case len(body) > 0:
+		var decoded interface{}
+		if err := json.Unmarshal(body, &decoded); err != nil {
+			params["body"] = "not JSON"
+		} else {
+			params["body"] = audit.Redact(decoded)
+		}
+	}
+	return params
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (recorder *statusRecorder) WriteHeader(status int) {
+	recorder.status = status
+	recorder.ResponseWriter.WriteHeader(status)
+}
@@ -0,0 +1,79 @@
+/*
+ * Copyright (C) 2020 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package tequilapi
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mysteriumnetwork/node/core/audit"
+	"github.com/mysteriumnetwork/node/core/auth"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockAuditLog struct {
+	records []audit.Record
+}
+
+func (mal *mockAuditLog) Record(record audit.Record) error {
+	mal.records = append(mal.records, record)
+	return nil
+}
+
+func Test_ApplyAudit_RecordsRequestWithSecretsRedacted(t *testing.T) {
+	auditLog := &mockAuditLog{}
+	var handledBody string
+	handler := ApplyAudit(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		body, _ := ioutil.ReadAll(req.Body)
+		handledBody = string(body)
+		resp.WriteHeader(http.StatusAccepted)
+	}), auditLog)
+
+	body := `{"providerId":"0x1","passphrase":"secret"}`
+	req := httptest.NewRequest(http.MethodPut, "/identities/0x1/unlock?token=abc&force=true", strings.NewReader(body))
+	req = req.WithContext(auth.WithUser(req.Context(), auth.User{Username: "myst", Role: auth.RoleAdmin}))
+	resp := httptest.NewRecorder()
+	handler.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusAccepted, resp.Code)
+	assert.Equal(t, body, handledBody)
+	assert.Len(t, auditLog.records, 1)
+	record := auditLog.records[0]
+	assert.Equal(t, "myst", record.Username)
+	assert.Equal(t, "admin", record.Role)
+	assert.Equal(t, http.MethodPut, record.Method)
+	assert.Equal(t, "/identities/0x1/unlock", record.Path)
+	assert.Equal(t, http.StatusAccepted, record.Status)
+	assert.Equal(t, map[string]interface{}{
+		"query": map[string]interface{}{"token": audit.Redacted, "force": "true"},
+		"body":  map[string]interface{}{"providerId": "0x1", "passphrase": audit.Redacted},
+	}, record.Params)
+}
+
+func Test_ApplyAudit_SkipsReadOnlyRequests(t *testing.T) {
+	auditLog := &mockAuditLog{}
+	handler := ApplyAudit(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {}), auditLog)
+
+	req := httptest.NewRequest(http.MethodGet, "/services", nil)
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	assert.Len(t, auditLog.records, 0)
+}
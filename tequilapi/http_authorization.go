@@ -0,0 +1,218 @@
+/*
+ * Copyright (C) 2020 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package tequilapi
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/mysteriumnetwork/node/core/auth"
+	"github.com/mysteriumnetwork/node/tequilapi/endpoints"
+	"github.com/mysteriumnetwork/node/tequilapi/utils"
+)
+
+// routePermission determines the permission required to call the routes matching method and path pattern.
+// Path segments starting with ':' match any value, public routes do not require authentication at all.
+type routePermission struct {
+	method, pattern string
+	permission      auth.Permission
+	public          bool
+}
+
+// routePermissions are checked in order, the first matching one applies.
+// Unlisted reads require PermissionRead, while unlisted modifications require PermissionAdmin.
+var routePermissions = []routePermission{
+	{method: http.MethodGet, pattern: "/healthcheck", public: true},
+	{method: http.MethodGet, pattern: endpoints.OpenAPIEndpointPath, public: true},
+	{method: http.MethodPost, pattern: endpoints.TequilapiLoginEndpointPath, public: true},
+	{method: http.MethodPut, pattern: "/auth/password", permission: auth.PermissionRead},
+	{method: http.MethodGet, pattern: "/auth/users", permission: auth.PermissionAdmin},
+	{method: http.MethodPost, pattern: "/auth/tokens", permission: auth.PermissionRead},
+	{method: http.MethodDelete, pattern: "/auth/tokens/:id", permission: auth.PermissionRead},
+	{method: http.MethodGet, pattern: "/config/user", permission: auth.PermissionAdmin},
+	{method: http.MethodGet, pattern: "/audit", permission: auth.PermissionAdmin},
+
+	{method: http.MethodPut, pattern: "/connection", permission: auth.PermissionConsume},
+	{method: http.MethodDelete, pattern: "/connection", permission: auth.PermissionConsume},
+	{method: http.MethodPut, pattern: "/identities/:id/budget", permission: auth.PermissionConsume},
+
+	{method: http.MethodPost, pattern: "/services", permission: auth.PermissionProvide},
+	{method: http.MethodPut, pattern: "/services/:id", permission: auth.PermissionProvide},
+	{method: http.MethodDelete, pattern: "/services/:id", permission: auth.PermissionProvide},
+	{method: http.MethodPost, pattern: "/transactor/settle/sync", permission: auth.PermissionProvide},
+	{method: http.MethodPost, pattern: "/transactor/settle/async", permission: auth.PermissionProvide},
+	{method: http.MethodPut, pattern: "/identities/:id/payout", permission: auth.PermissionProvide},
+	{method: http.MethodPut, pattern: "/identities/:id/email", permission: auth.PermissionProvide},
+	{method: http.MethodPut, pattern: "/identities/:id/referral", permission: auth.PermissionProvide},
+}
+
+// operatorRoutes can be called by both consumer and provider operators
+var operatorRoutes = []routePermission{
+	{method: http.MethodPut, pattern: "/identities/:id/unlock"},
+	{method: http.MethodPost, pattern: "/feedback/issue"},
+}
+
+type tokenParser interface {
+	ParseToken(token string) (auth.User, error)
+}
+
+type apiTokenAuthenticator interface {
+	AuthenticateAPIToken(token string) (auth.User, error)
+}
+
+type userStorage interface {
+	User(username string) (auth.User, error)
+}
+
+type authorizationHandler struct {
+	originalHandler http.Handler
+	tokens          tokenParser
+	apiTokens       apiTokenAuthenticator
+	users           userStorage
+}
+
+// ApplyAuthorization wraps original handler by checking that the user is permitted to call the route.
+// Users are identified by the JWT token given in the cookie or in the bearer authorization header,
+// or by the API token given in the bearer authorization header.
+// Requests without token are rejected on all the routes except the public ones.
+func ApplyAuthorization(original http.Handler, tokens tokenParser, apiTokens apiTokenAuthenticator, users userStorage) http.Handler {
+	return &authorizationHandler{
+		originalHandler: original,
+		tokens:          tokens,
+		apiTokens:       apiTokens,
+		users:           users,
+	}
+}
+
+func (handler *authorizationHandler) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+	if isPublicRoute(req.Method, req.URL.Path) {
+		handler.originalHandler.ServeHTTP(resp, req)
+		return
+	}
+
+	// requests executed on behalf of the already authenticated user, e.g. websocket commands
+	if user, ok := auth.UserFromContext(req.Context()); ok {
+		if !isRouteAllowed(user.Role, req.Method, req.URL.Path) {
+			utils.SendErrorMessage(resp, "role "+string(user.Role)+" is not permitted to call this route", http.StatusForbidden)
+			return
+		}
+		handler.originalHandler.ServeHTTP(resp, req)
+		return
+	}
+
+	token, bearer := requestToken(req)
+	if token == "" {
+		utils.SendErrorMessage(resp, "authentication required", http.StatusUnauthorized)
+		return
+	}
+
+	var user auth.User
+	var err error
+	if bearer && auth.IsAPIToken(token) {
+		user, err = handler.apiTokens.AuthenticateAPIToken(token)
+	} else {
+		user, err = handler.authenticate(token)
+	}
+	if err != nil {
+		utils.SendError(resp, err, http.StatusUnauthorized)
+		return
+	}
+	if !isRouteAllowed(user.Role, req.Method, req.URL.Path) {
+		utils.SendErrorMessage(resp, "role "+string(user.Role)+" is not permitted to call this route", http.StatusForbidden)
+		return
+	}
+	handler.originalHandler.ServeHTTP(resp, req.WithContext(auth.WithUser(req.Context(), user)))
+}
+
+// authenticate checks that the token was issued to the existing user and neither the role nor the password of user changed since then
+func (handler *authorizationHandler) authenticate(token string) (auth.User, error) {
+	tokenUser, err := handler.tokens.ParseToken(token)
+	if err != nil {
+		return auth.User{}, err
+	}
+
+	user, err := handler.users.User(tokenUser.Username)
+	if err != nil {
+		return auth.User{}, err
+	}
+	if user.Role != tokenUser.Role || user.PasswordGeneration != tokenUser.PasswordGeneration {
+		return auth.User{}, auth.ErrUnauthorized
+	}
+	return user, nil
+}
+
+func requestToken(req *http.Request) (token string, bearer bool) {
+	if header := req.Header.Get("Authorization"); strings.HasPrefix(header, "Bearer ") {
+		return strings.TrimPrefix(header, "Bearer "), true
+	}
+	if cookie, err := req.Cookie(auth.JWTCookieName); err == nil {
+		return cookie.Value, false
+	}
+	return "", false
+}
+
+func isPublicRoute(method, path string) bool {
+	route, ok := matchRoute(routePermissions, method, path)
+	return ok && route.public
+}
+
+func isRouteAllowed(role auth.Role, method, path string) bool {
+	if _, ok := matchRoute(operatorRoutes, method, path); ok {
+		return role.Allows(auth.PermissionConsume) || role.Allows(auth.PermissionProvide)
+	}
+	if route, ok := matchRoute(routePermissions, method, path); ok {
+		return route.public || role.Allows(route.permission)
+	}
+	if method == http.MethodGet || method == http.MethodHead {
+		return role.Allows(auth.PermissionRead)
+	}
+	return role.Allows(auth.PermissionAdmin)
+}
+
+func matchRoute(routes []routePermission, method, path string) (routePermission, bool) {
+	for _, route := range routes {
+		if route.method == method && matchPattern(route.pattern, path) {
+			return route, true
+		}
+	}
+	return routePermission{}, false
+}
+
+func matchPattern(pattern, path string) bool {
+	patternSegments := strings.Split(strings.Trim(pattern, "/"), "/")
+	pathSegments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(patternSegments) != len(pathSegments) {
+		return false
+	}
+
+	for i, segment := range patternSegments {
+		if !strings.HasPrefix(segment, ":") && segment != pathSegments[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// ApplySocketTrust wraps original handler serving requests received via unix domain socket.
+// Access to the socket is restricted by its file permissions, so requests are trusted as admin without authentication.
+func ApplySocketTrust(original http.Handler) http.Handler {
+	return http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		user := auth.User{Role: auth.RoleAdmin}
+		original.ServeHTTP(resp, req.WithContext(auth.WithUser(req.Context(), user)))
+	})
+}
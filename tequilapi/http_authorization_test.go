@@ -0,0 +1,184 @@
+/*
+ * Copyright (C) 2020 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package tequilapi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mysteriumnetwork/node/core/auth"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockUserStorage map[string]auth.User
+
+func (m mockUserStorage) User(username string) (auth.User, error) {
+	user, ok := m[username]
+	if !ok {
+		return auth.User{}, auth.ErrUserNotFound
+	}
+	return user, nil
+}
+
+type mockAPITokens map[string]auth.User
+
+func (m mockAPITokens) AuthenticateAPIToken(token string) (auth.User, error) {
+	user, ok := m[token]
+	if !ok {
+		return auth.User{}, auth.ErrAPITokenNotFound
+	}
+	return user, nil
+}
+
+func newAuthorizationTestHandler(t *testing.T) (http.Handler, *mockedHTTPHandler, map[auth.Role]string) {
+	jwtAuth := auth.NewJWTAuthenticator([]byte("key"))
+	users := mockUserStorage{}
+	tokens := map[auth.Role]string{}
+	for _, role := range auth.Roles() {
+		user := auth.User{Username: string(role), Role: role}
+		users[user.Username] = user
+		token, err := jwtAuth.CreateToken(user)
+		assert.NoError(t, err)
+		tokens[role] = token.Token
+	}
+
+	mock := &mockedHTTPHandler{}
+	return ApplyAuthorization(mock, jwtAuth, mockAPITokens{}, users), mock, tokens
+}
+
+func serveWithToken(handler http.Handler, method, path, token string) int {
+	req := httptest.NewRequest(method, path, nil)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp := httptest.NewRecorder()
+	handler.ServeHTTP(resp, req)
+	return resp.Code
+}
+
+func TestAuthorization_EnforcesRoles(t *testing.T) {
+	handler, _, tokens := newAuthorizationTestHandler(t)
+
+	var tests = []struct {
+		method, path string
+		allowed      []auth.Role
+	}{
+		{http.MethodGet, "/identities", []auth.Role{auth.RoleMonitor, auth.RoleConsumer, auth.RoleProvider, auth.RoleAdmin}},
+		{http.MethodPut, "/connection", []auth.Role{auth.RoleConsumer, auth.RoleAdmin}},
+		{http.MethodDelete, "/services/123", []auth.Role{auth.RoleProvider, auth.RoleAdmin}},
+		{http.MethodPut, "/identities/0x1/unlock", []auth.Role{auth.RoleConsumer, auth.RoleProvider, auth.RoleAdmin}},
+		{http.MethodPost, "/identities", []auth.Role{auth.RoleAdmin}},
+		{http.MethodGet, "/auth/users", []auth.Role{auth.RoleAdmin}},
+		{http.MethodPost, "/stop", []auth.Role{auth.RoleAdmin}},
+	}
+	for _, tt := range tests {
+		for _, role := range auth.Roles() {
+			expected := http.StatusForbidden
+			for _, allowed := range tt.allowed {
+				if role == allowed {
+					expected = http.StatusOK
+				}
+			}
+			assert.Equal(t, expected, serveWithToken(handler, tt.method, tt.path, tokens[role]), "%s %s as %s", tt.method, tt.path, role)
+		}
+	}
+}
+
+func TestAuthorization_RequiresToken(t *testing.T) {
+	handler, _, _ := newAuthorizationTestHandler(t)
+
+	assert.Equal(t, http.StatusUnauthorized, serveWithToken(handler, http.MethodGet, "/identities", ""))
+	assert.Equal(t, http.StatusUnauthorized, serveWithToken(handler, http.MethodGet, "/identities", "invalid"))
+	assert.Equal(t, http.StatusOK, serveWithToken(handler, http.MethodGet, "/healthcheck", ""))
+	assert.Equal(t, http.StatusOK, serveWithToken(handler, http.MethodPost, "/auth/login", ""))
+}
+
+func TestAuthorization_RejectsTokenOfChangedUser(t *testing.T) {
+	jwtAuth := auth.NewJWTAuthenticator([]byte("key"))
+	token, err := jwtAuth.CreateToken(auth.User{Username: "noc", Role: auth.RoleAdmin})
+	assert.NoError(t, err)
+
+	mock := &mockedHTTPHandler{}
+	handler := ApplyAuthorization(mock, jwtAuth, mockAPITokens{}, mockUserStorage{"noc": {Username: "noc", Role: auth.RoleMonitor}})
+	assert.Equal(t, http.StatusUnauthorized, serveWithToken(handler, http.MethodGet, "/identities", token.Token))
+
+	handler = ApplyAuthorization(mock, jwtAuth, mockAPITokens{}, mockUserStorage{"noc": {Username: "noc", Role: auth.RoleAdmin, PasswordGeneration: 1}})
+	assert.Equal(t, http.StatusUnauthorized, serveWithToken(handler, http.MethodGet, "/identities", token.Token), "token issued before password change")
+
+	handler = ApplyAuthorization(mock, jwtAuth, mockAPITokens{}, mockUserStorage{})
+	assert.Equal(t, http.StatusUnauthorized, serveWithToken(handler, http.MethodGet, "/identities", token.Token))
+	assert.False(t, mock.wasCalled)
+}
+
+func TestAuthorization_ReadsTokenFromCookie(t *testing.T) {
+	handler, _, tokens := newAuthorizationTestHandler(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/identities", nil)
+	req.AddCookie(&http.Cookie{Name: auth.JWTCookieName, Value: tokens[auth.RoleMonitor]})
+	resp := httptest.NewRecorder()
+	handler.ServeHTTP(resp, req)
+	assert.Equal(t, http.StatusOK, resp.Code)
+}
+
+func TestAuthorization_AcceptsAPITokenAsBearer(t *testing.T) {
+	var requestUser auth.User
+	handler := ApplyAuthorization(
+		http.HandlerFunc(func(_ http.ResponseWriter, req *http.Request) {
+			requestUser, _ = auth.UserFromContext(req.Context())
+		}),
+		auth.NewJWTAuthenticator([]byte("key")),
+		mockAPITokens{"myst_1_secret": {Username: "noc", Role: auth.RoleMonitor}},
+		mockUserStorage{},
+	)
+
+	assert.Equal(t, http.StatusOK, serveWithToken(handler, http.MethodGet, "/identities", "myst_1_secret"))
+	assert.Equal(t, auth.User{Username: "noc", Role: auth.RoleMonitor}, requestUser)
+	assert.Equal(t, http.StatusForbidden, serveWithToken(handler, http.MethodPost, "/identities", "myst_1_secret"))
+	assert.Equal(t, http.StatusUnauthorized, serveWithToken(handler, http.MethodGet, "/identities", "myst_2_secret"))
+
+	req := httptest.NewRequest(http.MethodGet, "/identities", nil)
+	req.AddCookie(&http.Cookie{Name: auth.JWTCookieName, Value: "myst_1_secret"})
+	resp := httptest.NewRecorder()
+	handler.ServeHTTP(resp, req)
+	assert.Equal(t, http.StatusUnauthorized, resp.Code, "API tokens are accepted as bearer header only")
+}
+
+func TestSocketTrust_ServesRequestsAsAdmin(t *testing.T) {
+	var requestUser auth.User
+	handler := ApplySocketTrust(http.HandlerFunc(func(_ http.ResponseWriter, req *http.Request) {
+		requestUser, _ = auth.UserFromContext(req.Context())
+	}))
+
+	assert.Equal(t, http.StatusOK, serveWithToken(handler, http.MethodPost, "/identities", ""))
+	assert.Equal(t, auth.RoleAdmin, requestUser.Role)
+}
+
+func TestAuthorization_ChecksRoleOfUserInContext(t *testing.T) {
+	handler, _, _ := newAuthorizationTestHandler(t)
+
+	serve := func(method, path string, role auth.Role) int {
+		req := httptest.NewRequest(method, path, nil)
+		req = req.WithContext(auth.WithUser(req.Context(), auth.User{Username: "myst", Role: role}))
+		resp := httptest.NewRecorder()
+		handler.ServeHTTP(resp, req)
+		return resp.Code
+	}
+	assert.Equal(t, http.StatusOK, serve(http.MethodPut, "/connection", auth.RoleConsumer))
+	assert.Equal(t, http.StatusForbidden, serve(http.MethodPost, "/services", auth.RoleConsumer))
+}
@@ -17,13 +17,78 @@
 
 package tequilapi
 
-import "net"
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+)
 
 // NewListener returns tequilapi listener.
 func NewListener(network, address string) (net.Listener, error) {
 	return net.Listen(network, address)
 }
 
+// NewUnixListener returns tequilapi listener on unix domain socket, accessible according to the given file mode.
+// Socket is created in a private directory and moved into place only after its permissions are set,
+// so that it is never accessible with the default permissions. Socket file left by the previous run is replaced,
+// while any other file at the path is left intact and reported as an error.
+func NewUnixListener(path string, mode os.FileMode) (net.Listener, error) {
+	info, err := os.Lstat(path)
+	switch {
+	case os.IsNotExist(err):
+	case err != nil:
+		return nil, errors.Wrap(err, "could not check socket path")
+	case info.Mode()&os.ModeSocket == 0:
+		return nil, errors.Errorf("could not listen on %s: file exists and is not a socket", path)
+	default:
+		if err := os.Remove(path); err != nil {
+			return nil, errors.Wrap(err, "could not remove stale socket")
+		}
+	}
+
+	dir, err := ioutil.TempDir(filepath.Dir(path), ".tequilapi")
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create socket directory")
+	}
+	defer os.RemoveAll(dir)
+
+	privatePath := filepath.Join(dir, "sock")
+	listener, err := net.Listen("unix", privatePath)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not listen on socket")
+	}
+	listener.(*net.UnixListener).SetUnlinkOnClose(false)
+	if err := os.Chmod(privatePath, mode); err != nil {
+		listener.Close()
+		return nil, errors.Wrap(err, "could not set socket permissions")
+	}
+	// linking fails if a file appeared at the path meanwhile, unlike renaming which would replace it
+	if err := os.Link(privatePath, path); err != nil {
+		listener.Close()
+		return nil, errors.Wrap(err, "could not move socket into place")
+	}
+	return &unixListener{Listener: listener, addr: &net.UnixAddr{Name: path, Net: "unix"}}, nil
+}
+
+// unixListener reports and removes the socket file at its final path
+type unixListener struct {
+	net.Listener
+	addr *net.UnixAddr
+}
+
+func (l *unixListener) Addr() net.Addr {
+	return l.addr
+}
+
+func (l *unixListener) Close() error {
+	err := l.Listener.Close()
+	os.Remove(l.addr.Name)
+	return err
+}
+
 // NewNoopListener returns noop tequilapi listener.
 func NewNoopListener() (net.Listener, error) {
 	return &noopListener{}, nil
@@ -0,0 +1,60 @@
+/*
+ * Copyright (C) 2020 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package tequilapi
+
+// NewMultiServer combines api servers serving the same API on different listeners into a single one.
+// Address of the first server is reported as address of combined server.
+func NewMultiServer(servers ...APIServer) APIServer {
+	return &multiServer{
+		servers: servers,
+	}
+}
+
+type multiServer struct {
+	servers []APIServer
+}
+
+// Wait waits for any of the servers to finish serving
+func (server *multiServer) Wait() error {
+	errs := make(chan error, len(server.servers))
+	for _, s := range server.servers {
+		go func(s APIServer) {
+			errs <- s.Wait()
+		}(s)
+	}
+	return <-errs
+}
+
+// StartServing starts all servers
+func (server *multiServer) StartServing() {
+	for _, s := range server.servers {
+		s.StartServing()
+	}
+}
+
+// Stop stops all servers
+func (server *multiServer) Stop() {
+	for _, s := range server.servers {
+		s.Stop()
+	}
+}
+
+// Address returns address of the first server
+func (server *multiServer) Address() (string, error) {
+	return server.servers[0].Address()
+}
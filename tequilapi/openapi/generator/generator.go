@@ -0,0 +1,87 @@
+/*
+ * Copyright (C) 2020 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"text/template"
+
+	"github.com/mysteriumnetwork/node/tequilapi/openapi/scanner"
+)
+
+var rootDirectory = flag.String("root", ".", "Root directory of the module to scan for swagger annotations")
+var outputFile = flag.String("output", "spec.go", "Name of output source file with OpenAPI document")
+
+func main() {
+	flag.Parse()
+
+	doc, err := scanner.Generate(*rootDirectory)
+	exitOnError(err)
+	specJSON, err := json.MarshalIndent(doc, "", "  ")
+	exitOnError(err)
+
+	tmpl, err := template.New("specTemplate").Parse(specFileOutput)
+	exitOnError(err)
+	var output bytes.Buffer
+	exitOnError(tmpl.Execute(&output, quote(specJSON)))
+	exitOnError(ioutil.WriteFile(*outputFile, output.Bytes(), 0644))
+}
+
+// quote returns Go string literal of the document, keeping it readable in diffs when possible
+func quote(data []byte) string {
+	if bytes.ContainsRune(data, '`') {
+		return fmt.Sprintf("%q", data)
+	}
+	return "`" + string(data) + "`"
+}
+
+func exitOnError(err error) {
+	if err != nil {
+		fmt.Println("Error: ", err.Error())
+		os.Exit(1)
+	}
+}
+
+var specFileOutput = `/*
+ * Copyright (C) 2020 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package openapi
+
+// generated by generator.go - DO NOT EDIT
+
+const specJSON = {{.}}
+`
@@ -0,0 +1,39 @@
+/*
+ * Copyright (C) 2020 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+// Package openapi provides OpenAPI 3 document of Tequilapi generated from swagger annotations of the endpoints
+package openapi
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+)
+
+//go:generate go run generator/generator.go --root ../.. --output spec.go
+
+// Spec returns OpenAPI document of Tequilapi for the given node version
+func Spec(version string) ([]byte, error) {
+	var doc map[string]interface{}
+	if err := json.Unmarshal([]byte(specJSON), &doc); err != nil {
+		return nil, errors.Wrap(err, "invalid OpenAPI document")
+	}
+	if info, ok := doc["info"].(map[string]interface{}); ok {
+		info["version"] = version
+	}
+	return json.Marshal(doc)
+}
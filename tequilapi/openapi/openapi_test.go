@@ -0,0 +1,50 @@
+/*
+ * Copyright (C) 2020 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package openapi
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/mysteriumnetwork/node/tequilapi/openapi/scanner"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Spec_IsUpToDate(t *testing.T) {
+	doc, err := scanner.Generate("../..")
+	assert.NoError(t, err)
+	expected, err := json.MarshalIndent(doc, "", "  ")
+	assert.NoError(t, err)
+
+	assert.JSONEq(t, string(expected), specJSON, "OpenAPI document is outdated, run go generate ./tequilapi/openapi")
+}
+
+func Test_Spec_SetsVersion(t *testing.T) {
+	spec, err := Spec("1.2.3")
+	assert.NoError(t, err)
+
+	var doc struct {
+		OpenAPI string `json:"openapi"`
+		Info    struct {
+			Version string `json:"version"`
+		} `json:"info"`
+	}
+	assert.NoError(t, json.Unmarshal(spec, &doc))
+	assert.Equal(t, scanner.OpenAPIVersion, doc.OpenAPI)
+	assert.Equal(t, "1.2.3", doc.Info.Version)
+}
@@ -0,0 +1,319 @@
+/*
+ * Copyright (C) 2020 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package scanner
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// parameterSchemaKeys are swagger 2.0 parameter properties moved to parameter schema in OpenAPI 3
+var parameterSchemaKeys = []string{"type", "format", "items", "enum", "default", "minimum", "maximum", "pattern"}
+
+func (s *scanner) document() (map[string]interface{}, error) {
+	info, servers := s.info()
+
+	paths := make(map[string]interface{})
+	for _, op := range s.operations {
+		operation, err := s.operation(op)
+		if err != nil {
+			return nil, errors.Wrapf(err, "operation %s %s at %s", op.method, op.path, op.position)
+		}
+
+		item, ok := paths[op.path].(map[string]interface{})
+		if !ok {
+			item = make(map[string]interface{})
+			paths[op.path] = item
+		}
+		method := strings.ToLower(op.method)
+		if _, exists := item[method]; exists {
+			return nil, errors.Errorf("operation %s %s is documented more than once", op.method, op.path)
+		}
+		item[method] = operation
+	}
+
+	for _, key := range s.modelKeys() {
+		if _, err := s.modelSchema(s.types[key]); err != nil {
+			return nil, err
+		}
+	}
+
+	doc := map[string]interface{}{
+		"openapi": OpenAPIVersion,
+		"info":    info,
+		"paths":   paths,
+		"components": map[string]interface{}{
+			"schemas": s.schemas,
+			"securitySchemes": map[string]interface{}{
+				"bearerAuth": map[string]interface{}{"type": "http", "scheme": "bearer"},
+				"cookieAuth": map[string]interface{}{"type": "apiKey", "in": "cookie", "name": "token"},
+			},
+		},
+		"security": []interface{}{
+			map[string]interface{}{"bearerAuth": []interface{}{}},
+			map[string]interface{}{"cookieAuth": []interface{}{}},
+		},
+	}
+	if len(servers) > 0 {
+		doc["servers"] = servers
+	}
+	return doc, s.checkReferences(doc)
+}
+
+// modelKeys returns all annotated models in a stable order
+func (s *scanner) modelKeys() []typeKey {
+	var keys []typeKey
+	for key, decl := range s.types {
+		if decl.isModel {
+			keys = append(keys, key)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].pkg != keys[j].pkg {
+			return keys[i].pkg < keys[j].pkg
+		}
+		return keys[i].name < keys[j].name
+	})
+	return keys
+}
+
+// info builds API information from the swagger:meta package documentation
+func (s *scanner) info() (map[string]interface{}, []interface{}) {
+	info := map[string]interface{}{"title": "API", "version": "dev"}
+	if s.meta == nil {
+		return info, nil
+	}
+
+	var servers []interface{}
+	var description []string
+	lines := strings.Split(strings.TrimSpace(s.meta.Text()), "\n")
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case i == 0:
+			fields := strings.Fields(trimmed)
+			if len(fields) > 2 {
+				info["title"] = strings.Join(fields[2:], " ")
+			}
+		case strings.HasPrefix(trimmed, "Host:"):
+			servers = append(servers, map[string]interface{}{
+				"url": "http://" + strings.TrimSpace(strings.TrimPrefix(trimmed, "Host:")),
+			})
+		case strings.HasPrefix(trimmed, "Version:"):
+			info["version"] = strings.TrimSpace(strings.TrimPrefix(trimmed, "Version:"))
+		case strings.HasPrefix(line, " "), strings.HasPrefix(trimmed, "swagger:"):
+			// swagger 2.0 meta properties not used by OpenAPI 3
+		default:
+			description = append(description, trimmed)
+		}
+	}
+	if text := strings.TrimSpace(strings.Join(description, " ")); text != "" {
+		info["description"] = text
+	}
+	return info, servers
+}
+
+func (s *scanner) operation(op operationDecl) (map[string]interface{}, error) {
+	var spec map[interface{}]interface{}
+	if err := yaml.Unmarshal([]byte(op.yaml), &spec); err != nil {
+		return nil, errors.Wrap(err, "invalid operation YAML")
+	}
+	source, _ := normalizeYAML(spec).(map[string]interface{})
+	if source == nil {
+		source = make(map[string]interface{})
+	}
+
+	operation := make(map[string]interface{})
+	if fields := strings.Fields(op.annotation); len(fields) > 0 {
+		operation["operationId"] = fields[len(fields)-1]
+		if len(fields) > 1 {
+			var tags []interface{}
+			for _, tag := range fields[:len(fields)-1] {
+				tags = append(tags, tag)
+			}
+			operation["tags"] = tags
+		}
+	}
+	for _, key := range []string{"summary", "description", "deprecated", "tags", "operationId"} {
+		if value, ok := source[key]; ok {
+			operation[key] = value
+		}
+	}
+
+	contentTypes := []interface{}{"application/json"}
+	if produces, ok := source["produces"].([]interface{}); ok && len(produces) > 0 {
+		contentTypes = produces
+	}
+
+	parameters, requestBody, err := convertParameters(source["parameters"])
+	if err != nil {
+		return nil, err
+	}
+	if len(parameters) > 0 {
+		operation["parameters"] = parameters
+	}
+	if requestBody != nil {
+		operation["requestBody"] = requestBody
+	}
+
+	responses, err := convertResponses(source["responses"], contentTypes)
+	if err != nil {
+		return nil, err
+	}
+	operation["responses"] = responses
+
+	return operation, nil
+}
+
+func convertParameters(value interface{}) (parameters []interface{}, requestBody map[string]interface{}, err error) {
+	if value == nil {
+		return nil, nil, nil
+	}
+	list, ok := value.([]interface{})
+	if !ok {
+		return nil, nil, errors.New("parameters should be a list")
+	}
+
+	for _, item := range list {
+		param, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, nil, errors.New("parameter should be an object")
+		}
+
+		if param["in"] == "body" {
+			requestBody = map[string]interface{}{
+				"content": map[string]interface{}{
+					"application/json": map[string]interface{}{"schema": convertSchema(param["schema"])},
+				},
+			}
+			if description, ok := param["description"]; ok {
+				requestBody["description"] = description
+			}
+			if required, ok := param["required"]; ok {
+				requestBody["required"] = required
+			}
+			continue
+		}
+
+		parameter := map[string]interface{}{"name": param["name"], "in": param["in"]}
+		if parameter["name"] == nil || parameter["in"] == nil {
+			return nil, nil, errors.New("parameter should have name and location")
+		}
+		for _, key := range []string{"description", "required"} {
+			if value, ok := param[key]; ok {
+				parameter[key] = value
+			}
+		}
+		if param["in"] == "path" {
+			parameter["required"] = true
+		}
+
+		paramSchema := make(map[string]interface{})
+		for _, key := range parameterSchemaKeys {
+			if value, ok := param[key]; ok {
+				paramSchema[key] = value
+			}
+		}
+		if schema, ok := param["schema"]; ok {
+			paramSchema = convertSchema(schema).(map[string]interface{})
+		}
+		parameter["schema"] = convertSchema(paramSchema)
+		parameters = append(parameters, parameter)
+	}
+	return parameters, requestBody, nil
+}
+
+func convertResponses(value interface{}, contentTypes []interface{}) (map[string]interface{}, error) {
+	source, ok := value.(map[string]interface{})
+	if !ok || len(source) == 0 {
+		return nil, errors.New("operation should describe responses")
+	}
+
+	responses := make(map[string]interface{})
+	for code, item := range source {
+		resp, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, errors.Errorf("response %s should be an object", code)
+		}
+
+		response := map[string]interface{}{"description": fmt.Sprint(resp["description"])}
+		if resp["description"] == nil {
+			response["description"] = ""
+		}
+		if schema, ok := resp["schema"]; ok {
+			content := make(map[string]interface{})
+			for _, contentType := range contentTypes {
+				content[fmt.Sprint(contentType)] = map[string]interface{}{"schema": convertSchema(schema)}
+			}
+			response["content"] = content
+		}
+		responses[code] = response
+	}
+	return responses, nil
+}
+
+// convertSchema replaces swagger 2.0 definition references by OpenAPI 3 component references
+func convertSchema(value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(value))
+		for key, item := range value {
+			if ref, ok := item.(string); ok && key == "$ref" {
+				item = strings.Replace(ref, definitionsPrefix, schemasPrefix, 1)
+			}
+			result[key] = convertSchema(item)
+		}
+		return result
+	case []interface{}:
+		result := make([]interface{}, len(value))
+		for i, item := range value {
+			result[i] = convertSchema(item)
+		}
+		return result
+	}
+	return value
+}
+
+// checkReferences ensures that all the referenced schemas are defined
+func (s *scanner) checkReferences(value interface{}) error {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		for key, item := range value {
+			if ref, ok := item.(string); ok && key == "$ref" {
+				if _, defined := s.schemas[strings.TrimPrefix(ref, schemasPrefix)]; !defined {
+					return errors.Errorf("undefined schema %s", ref)
+				}
+				continue
+			}
+			if err := s.checkReferences(item); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		for _, item := range value {
+			if err := s.checkReferences(item); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
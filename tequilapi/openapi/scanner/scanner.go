@@ -0,0 +1,340 @@
+/*
+ * Copyright (C) 2020 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package scanner
+
+import (
+	"bufio"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// OpenAPIVersion is the version of OpenAPI specification of generated documents
+const OpenAPIVersion = "3.0.3"
+
+const (
+	definitionsPrefix = "#/definitions/"
+	schemasPrefix     = "#/components/schemas/"
+)
+
+var (
+	operationAnnotation = regexp.MustCompile(`^swagger:operation\s+(\S+)\s+(\S+)(.*)$`)
+	modelAnnotation     = regexp.MustCompile(`^swagger:model\s*(\S*)`)
+	typeAnnotation      = regexp.MustCompile(`^openapi:type\s+(.+)$`)
+	versionElement      = regexp.MustCompile(`^v[0-9]+$`)
+	versionSuffix       = regexp.MustCompile(`\.v[0-9]+$`)
+	skippedDirs         = map[string]bool{"vendor": true, "testdata": true, "node_modules": true, "gendb": true}
+)
+
+// Generate scans Go sources of the module in root directory for swagger annotations
+// and builds OpenAPI 3 document of all annotated operations and models.
+func Generate(root string) (map[string]interface{}, error) {
+	module, err := modulePath(root)
+	if err != nil {
+		return nil, err
+	}
+
+	s := newScanner(root, module)
+	if err := s.parse(); err != nil {
+		return nil, err
+	}
+	return s.document()
+}
+
+type typeKey struct {
+	pkg, name string
+}
+
+type fileInfo struct {
+	pkg     string
+	imports map[string]string
+}
+
+type typeDecl struct {
+	key       typeKey
+	spec      *ast.TypeSpec
+	doc       *ast.CommentGroup
+	file      *fileInfo
+	modelName string
+	isModel   bool
+	// encoded is the type given by openapi:type annotation, describing JSON encoding of the type with custom marshaler
+	encoded ast.Expr
+}
+
+type operationDecl struct {
+	method, path string
+	annotation   string
+	yaml         string
+	position     token.Position
+}
+
+type scanner struct {
+	root, module string
+	fset         *token.FileSet
+
+	packageNames map[string]string
+	files        []*fileInfo
+	fileImports  []map[string]string
+	types        map[typeKey]*typeDecl
+	marshalers   map[typeKey]string
+	operations   []operationDecl
+	meta         *ast.CommentGroup
+
+	schemas map[string]interface{}
+	names   map[typeKey]string
+}
+
+func newScanner(root, module string) *scanner {
+	return &scanner{
+		root:         root,
+		module:       module,
+		fset:         token.NewFileSet(),
+		packageNames: make(map[string]string),
+		types:        make(map[typeKey]*typeDecl),
+		marshalers:   make(map[typeKey]string),
+		schemas:      make(map[string]interface{}),
+		names:        make(map[typeKey]string),
+	}
+}
+
+func modulePath(root string) (string, error) {
+	file, err := os.Open(filepath.Join(root, "go.mod"))
+	if err != nil {
+		return "", errors.Wrap(err, "could not read module definition")
+	}
+	defer file.Close()
+
+	lines := bufio.NewScanner(file)
+	for lines.Scan() {
+		if line := strings.TrimSpace(lines.Text()); strings.HasPrefix(line, "module ") {
+			return strings.TrimSpace(strings.TrimPrefix(line, "module ")), nil
+		}
+	}
+	return "", errors.New("module path not found in go.mod")
+}
+
+func (s *scanner) parse() error {
+	type parsedFile struct {
+		ast  *ast.File
+		info *fileInfo
+	}
+	var parsed []parsedFile
+
+	err := filepath.Walk(s.root, func(file string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if file != s.root && (skippedDirs[info.Name()] || strings.HasPrefix(info.Name(), ".")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(file, ".go") || strings.HasSuffix(file, "_test.go") {
+			return nil
+		}
+
+		f, err := parser.ParseFile(s.fset, file, nil, parser.ParseComments)
+		if err != nil {
+			return errors.Wrapf(err, "could not parse %s", file)
+		}
+		if f.Name.Name == "main" {
+			return nil
+		}
+
+		rel, err := filepath.Rel(s.root, filepath.Dir(file))
+		if err != nil {
+			return err
+		}
+		pkg := s.module
+		if rel != "." {
+			pkg = path.Join(s.module, filepath.ToSlash(rel))
+		}
+		s.packageNames[pkg] = f.Name.Name
+		parsed = append(parsed, parsedFile{ast: f, info: &fileInfo{pkg: pkg}})
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, file := range parsed {
+		file.info.imports = s.resolveImports(file.ast)
+		if err := s.collect(file.ast, file.info); err != nil {
+			return err
+		}
+	}
+	sort.Slice(s.operations, func(i, j int) bool {
+		if s.operations[i].path != s.operations[j].path {
+			return s.operations[i].path < s.operations[j].path
+		}
+		return s.operations[i].method < s.operations[j].method
+	})
+	return nil
+}
+
+func (s *scanner) resolveImports(file *ast.File) map[string]string {
+	imports := make(map[string]string)
+	for _, spec := range file.Imports {
+		importPath := strings.Trim(spec.Path.Value, `"`)
+		name := s.packageName(importPath)
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+		imports[name] = importPath
+	}
+	return imports
+}
+
+// packageName returns the name of package for the import path, guessing it for packages outside of the module
+func (s *scanner) packageName(importPath string) string {
+	if name, ok := s.packageNames[importPath]; ok {
+		return name
+	}
+
+	elements := strings.Split(importPath, "/")
+	name := elements[len(elements)-1]
+	if versionElement.MatchString(name) && len(elements) > 1 {
+		name = elements[len(elements)-2]
+	}
+	name = versionSuffix.ReplaceAllString(name, "")
+	name = strings.TrimPrefix(name, "go-")
+	return strings.Replace(name, "-", "_", -1)
+}
+
+func (s *scanner) collect(file *ast.File, info *fileInfo) error {
+	for _, decl := range file.Decls {
+		switch decl := decl.(type) {
+		case *ast.GenDecl:
+			if decl.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range decl.Specs {
+				typeSpec := spec.(*ast.TypeSpec)
+				doc := typeSpec.Doc
+				if doc == nil && len(decl.Specs) == 1 {
+					doc = decl.Doc
+				}
+				if err := s.addType(typeSpec, doc, info); err != nil {
+					return err
+				}
+			}
+		case *ast.FuncDecl:
+			if decl.Recv == nil || len(decl.Recv.List) != 1 {
+				continue
+			}
+			if decl.Name.Name != "MarshalJSON" && decl.Name.Name != "MarshalText" {
+				continue
+			}
+			if receiver := receiverName(decl.Recv.List[0].Type); receiver != "" {
+				key := typeKey{pkg: info.pkg, name: receiver}
+				if s.marshalers[key] != "MarshalJSON" {
+					s.marshalers[key] = decl.Name.Name
+				}
+			}
+		}
+	}
+
+	for _, group := range file.Comments {
+		text := group.Text()
+		if strings.HasPrefix(text, "Package ") && strings.Contains(text, "swagger:meta") {
+			s.meta = group
+		}
+		s.addOperations(group)
+	}
+	return nil
+}
+
+func receiverName(expr ast.Expr) string {
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	if ident, ok := expr.(*ast.Ident); ok {
+		return ident.Name
+	}
+	return ""
+}
+
+func (s *scanner) addType(spec *ast.TypeSpec, doc *ast.CommentGroup, info *fileInfo) error {
+	key := typeKey{pkg: info.pkg, name: spec.Name.Name}
+	if _, exists := s.types[key]; exists {
+		// the same type declared for different platforms
+		return nil
+	}
+
+	decl := &typeDecl{key: key, spec: spec, doc: doc, file: info}
+	if doc != nil {
+		for _, line := range strings.Split(doc.Text(), "\n") {
+			if match := modelAnnotation.FindStringSubmatch(strings.TrimSpace(line)); match != nil {
+				decl.isModel = true
+				decl.modelName = match[1]
+			}
+			if match := typeAnnotation.FindStringSubmatch(strings.TrimSpace(line)); match != nil {
+				encoded, err := parser.ParseExpr(match[1])
+				if err != nil {
+					return errors.Wrapf(err, "invalid openapi:type of %s.%s", key.pkg, key.name)
+				}
+				decl.encoded = encoded
+			}
+		}
+	}
+	if decl.modelName == "" {
+		decl.modelName = spec.Name.Name
+	}
+	s.types[key] = decl
+	return nil
+}
+
+func (s *scanner) addOperations(group *ast.CommentGroup) {
+	lines := strings.Split(group.Text(), "\n")
+	for i, line := range lines {
+		match := operationAnnotation.FindStringSubmatch(strings.TrimSpace(line))
+		if match == nil {
+			continue
+		}
+
+		var body []string
+		started := false
+		for _, bodyLine := range lines[i+1:] {
+			if operationAnnotation.MatchString(strings.TrimSpace(bodyLine)) {
+				break
+			}
+			if !started {
+				started = strings.TrimSpace(bodyLine) == "---"
+				continue
+			}
+			body = append(body, bodyLine)
+		}
+
+		s.operations = append(s.operations, operationDecl{
+			method:     strings.ToUpper(match[1]),
+			path:       match[2],
+			annotation: strings.TrimSpace(match[3]),
+			yaml:       strings.Join(body, "\n"),
+			position:   s.fset.Position(group.Pos()),
+		})
+	}
+}
@@ -0,0 +1,94 @@
+/*
+ * Copyright (C) 2020 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package scanner
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Generate_BuildsOpenAPIDocument(t *testing.T) {
+	doc, err := Generate("testdata/module")
+	assert.NoError(t, err)
+
+	actual, err := json.Marshal(doc)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"openapi": "3.0.3",
+		"info": {"title": "Example API", "description": "Example API description.", "version": "1.0"},
+		"servers": [{"url": "http://127.0.0.1:8080"}],
+		"paths": {
+			"/items/{id}": {
+				"put": {
+					"operationId": "updateItem",
+					"tags": ["Items"],
+					"summary": "Updates item",
+					"parameters": [{"name": "id", "in": "path", "required": true, "schema": {"type": "string"}}],
+					"requestBody": {
+						"content": {"application/json": {"schema": {"$ref": "#/components/schemas/ItemDTO"}}}
+					},
+					"responses": {
+						"200": {
+							"description": "Updated item",
+							"content": {"application/json": {"schema": {"$ref": "#/components/schemas/ItemDTO"}}}
+						},
+						"404": {"description": "Not found"}
+					}
+				}
+			}
+		},
+		"components": {
+			"schemas": {
+				"ItemDTO": {
+					"description": "item represents an item",
+					"type": "object",
+					"required": ["name"],
+					"properties": {
+						"createdAt": {"type": "string", "format": "date-time", "description": "creation time"},
+						"name": {"type": "string", "description": "item name", "example": "apple"},
+						"count": {"type": "integer", "format": "int64", "minimum": 0},
+						"tags": {"type": "array", "items": {"type": "string"}},
+						"parent": {"$ref": "#/components/schemas/ItemDTO"}
+					}
+				}
+			},
+			"securitySchemes": {
+				"bearerAuth": {"type": "http", "scheme": "bearer"},
+				"cookieAuth": {"type": "apiKey", "in": "cookie", "name": "token"}
+			}
+		},
+		"security": [{"bearerAuth": []}, {"cookieAuth": []}]
+	}`, string(actual))
+}
+
+func Test_Generate_FailsOnUndefinedReference(t *testing.T) {
+	s := newScanner("testdata/module", "example.com/api")
+	assert.NoError(t, s.parse())
+	s.operations[0].yaml = `
+responses:
+  200:
+    description: Missing model
+    schema:
+      "$ref": "#/definitions/MissingDTO"
+`
+
+	_, err := s.document()
+	assert.EqualError(t, err, "undefined schema #/components/schemas/MissingDTO")
+}
@@ -0,0 +1,404 @@
+/*
+ * Copyright (C) 2020 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package scanner
+
+import (
+	"fmt"
+	"go/ast"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+type schema = map[string]interface{}
+
+var basicSchemas = map[string]schema{
+	"string":      {"type": "string"},
+	"bool":        {"type": "boolean"},
+	"int":         {"type": "integer", "format": "int64"},
+	"int8":        {"type": "integer", "format": "int32"},
+	"int16":       {"type": "integer", "format": "int32"},
+	"int32":       {"type": "integer", "format": "int32"},
+	"int64":       {"type": "integer", "format": "int64"},
+	"uint":        {"type": "integer", "format": "int64", "minimum": 0},
+	"uint8":       {"type": "integer", "format": "int32", "minimum": 0},
+	"uint16":      {"type": "integer", "format": "int32", "minimum": 0},
+	"uint32":      {"type": "integer", "format": "int64", "minimum": 0},
+	"uint64":      {"type": "integer", "format": "int64", "minimum": 0},
+	"byte":        {"type": "integer", "format": "int32", "minimum": 0},
+	"rune":        {"type": "integer", "format": "int32"},
+	"float32":     {"type": "number", "format": "float"},
+	"float64":     {"type": "number", "format": "double"},
+	"error":       {"type": "string"},
+	"interface{}": {},
+}
+
+// externalSchemas describes how the types declared outside of the module are encoded to JSON
+var externalSchemas = map[typeKey]schema{
+	{pkg: "time", name: "Time"}:                                      {"type": "string", "format": "date-time"},
+	{pkg: "time", name: "Duration"}:                                  {"type": "integer", "format": "int64"},
+	{pkg: "math/big", name: "Int"}:                                   {"type": "integer"},
+	{pkg: "net", name: "IP"}:                                         {"type": "string"},
+	{pkg: "encoding/json", name: "RawMessage"}:                       {},
+	{pkg: "github.com/ethereum/go-ethereum/common", name: "Address"}: {"type": "string"},
+	{pkg: "github.com/ethereum/go-ethereum/common", name: "Hash"}:    {"type": "string"},
+}
+
+// modelSchema registers the component schema of the type and returns reference to it
+func (s *scanner) modelSchema(decl *typeDecl) (schema, error) {
+	if name, ok := s.names[decl.key]; ok {
+		return schema{"$ref": schemasPrefix + name}, nil
+	}
+
+	name := decl.modelName
+	if _, taken := s.schemas[name]; taken {
+		name = strings.Title(s.packageNames[decl.key.pkg]) + name
+	}
+	if _, taken := s.schemas[name]; taken {
+		return nil, errors.Errorf("duplicate model name %s of %s.%s", name, decl.key.pkg, decl.key.name)
+	}
+	s.names[decl.key] = name
+	s.schemas[name] = schema{}
+
+	model, err := s.exprSchema(decl.typeExpr(), decl.file)
+	if err != nil {
+		return nil, errors.Wrapf(err, "model %s", name)
+	}
+	if description := docDescription(decl.doc); description != "" {
+		model["description"] = description
+	}
+	s.schemas[name] = model
+	return schema{"$ref": schemasPrefix + name}, nil
+}
+
+// typeExpr returns the type describing JSON encoding of the declared type
+func (decl *typeDecl) typeExpr() ast.Expr {
+	if decl.encoded != nil {
+		return decl.encoded
+	}
+	return decl.spec.Type
+}
+
+// namedSchema returns reference to the component schema of struct types and inline schema of other named types
+func (s *scanner) namedSchema(key typeKey) (schema, error) {
+	if known, ok := externalSchemas[key]; ok {
+		return copySchema(known), nil
+	}
+
+	decl, ok := s.types[key]
+	if !ok {
+		// type declared outside of the module without known JSON encoding
+		return schema{}, nil
+	}
+	if decl.encoded != nil {
+		if decl.isModel {
+			return s.modelSchema(decl)
+		}
+		return s.exprSchema(decl.encoded, decl.file)
+	}
+	switch s.marshalers[key] {
+	case "MarshalText":
+		return schema{"type": "string"}, nil
+	case "MarshalJSON":
+		if !decl.isModel {
+			return schema{}, nil
+		}
+	}
+
+	if _, isStruct := decl.spec.Type.(*ast.StructType); isStruct || decl.isModel {
+		return s.modelSchema(decl)
+	}
+	return s.exprSchema(decl.spec.Type, decl.file)
+}
+
+func (s *scanner) exprSchema(expr ast.Expr, file *fileInfo) (schema, error) {
+	switch expr := expr.(type) {
+	case *ast.Ident:
+		if basic, ok := basicSchemas[expr.Name]; ok {
+			return copySchema(basic), nil
+		}
+		return s.namedSchema(typeKey{pkg: file.pkg, name: expr.Name})
+	case *ast.SelectorExpr:
+		pkg, ok := expr.X.(*ast.Ident)
+		if !ok {
+			return nil, errors.Errorf("unsupported type selector %T", expr.X)
+		}
+		importPath, ok := file.imports[pkg.Name]
+		if !ok {
+			return nil, errors.Errorf("unknown package %s in %s", pkg.Name, file.pkg)
+		}
+		return s.namedSchema(typeKey{pkg: importPath, name: expr.Sel.Name})
+	case *ast.StarExpr:
+		return s.exprSchema(expr.X, file)
+	case *ast.ArrayType:
+		if ident, ok := expr.Elt.(*ast.Ident); ok && ident.Name == "byte" && expr.Len == nil {
+			return schema{"type": "string", "format": "byte"}, nil
+		}
+		items, err := s.exprSchema(expr.Elt, file)
+		if err != nil {
+			return nil, err
+		}
+		return schema{"type": "array", "items": items}, nil
+	case *ast.MapType:
+		values, err := s.exprSchema(expr.Value, file)
+		if err != nil {
+			return nil, err
+		}
+		return schema{"type": "object", "additionalProperties": values}, nil
+	case *ast.InterfaceType:
+		return schema{}, nil
+	case *ast.StructType:
+		return s.structSchema(expr, file)
+	}
+	return nil, errors.Errorf("unsupported type %T", expr)
+}
+
+func (s *scanner) structSchema(st *ast.StructType, file *fileInfo) (schema, error) {
+	properties := schema{}
+	var required []string
+	if err := s.addStructFields(st, file, properties, &required); err != nil {
+		return nil, err
+	}
+
+	result := schema{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		result["required"] = required
+	}
+	return result, nil
+}
+
+func (s *scanner) addStructFields(st *ast.StructType, file *fileInfo, properties schema, required *[]string) error {
+	for _, field := range st.Fields.List {
+		tag := reflect.StructTag("")
+		if field.Tag != nil {
+			value, err := strconv.Unquote(field.Tag.Value)
+			if err != nil {
+				return err
+			}
+			tag = reflect.StructTag(value)
+		}
+		jsonName, jsonOptions := parseJSONTag(tag.Get("json"))
+		if jsonName == "-" && jsonOptions == "" {
+			continue
+		}
+
+		if len(field.Names) == 0 && jsonName == "" {
+			if err := s.addEmbeddedFields(field.Type, file, properties, required); err != nil {
+				return err
+			}
+			continue
+		}
+
+		names := field.Names
+		if len(names) == 0 {
+			names = []*ast.Ident{ast.NewIdent(embeddedName(field.Type))}
+		}
+		for _, name := range names {
+			if !name.IsExported() {
+				continue
+			}
+			if _, isFunc := field.Type.(*ast.FuncType); isFunc {
+				continue
+			}
+			if _, isChan := field.Type.(*ast.ChanType); isChan {
+				continue
+			}
+
+			property, err := s.exprSchema(field.Type, file)
+			if err != nil {
+				return errors.Wrapf(err, "field %s", name.Name)
+			}
+			if strings.Contains(jsonOptions, "string") {
+				property = schema{"type": "string"}
+			}
+			doc := field.Doc
+			if doc == nil {
+				doc = field.Comment
+			}
+			isRequired, err := applyFieldDoc(doc, &property)
+			if err != nil {
+				return errors.Wrapf(err, "field %s", name.Name)
+			}
+
+			propertyName := jsonName
+			if propertyName == "" {
+				propertyName = name.Name
+			}
+			properties[propertyName] = property
+			if isRequired {
+				*required = append(*required, propertyName)
+			}
+		}
+	}
+	return nil
+}
+
+// addEmbeddedFields promotes fields of embedded struct the same way as JSON encoding does
+func (s *scanner) addEmbeddedFields(expr ast.Expr, file *fileInfo, properties schema, required *[]string) error {
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+
+	var key typeKey
+	switch expr := expr.(type) {
+	case *ast.Ident:
+		key = typeKey{pkg: file.pkg, name: expr.Name}
+	case *ast.SelectorExpr:
+		pkg, ok := expr.X.(*ast.Ident)
+		if !ok {
+			return nil
+		}
+		key = typeKey{pkg: file.imports[pkg.Name], name: expr.Sel.Name}
+	default:
+		return nil
+	}
+
+	decl, ok := s.types[key]
+	if !ok {
+		return nil
+	}
+	st, ok := decl.spec.Type.(*ast.StructType)
+	if !ok {
+		return nil
+	}
+	return s.addStructFields(st, decl.file, properties, required)
+}
+
+func embeddedName(expr ast.Expr) string {
+	switch expr := expr.(type) {
+	case *ast.StarExpr:
+		return embeddedName(expr.X)
+	case *ast.SelectorExpr:
+		return expr.Sel.Name
+	case *ast.Ident:
+		return expr.Name
+	}
+	return ""
+}
+
+func parseJSONTag(tag string) (name, options string) {
+	if i := strings.Index(tag, ","); i >= 0 {
+		return tag[:i], tag[i+1:]
+	}
+	return tag, ""
+}
+
+// applyFieldDoc sets description and the validations given in the field documentation
+func applyFieldDoc(doc *ast.CommentGroup, property *schema) (required bool, err error) {
+	if doc == nil {
+		return false, nil
+	}
+
+	target := *property
+	var description []string
+	for _, line := range strings.Split(strings.TrimSpace(doc.Text()), "\n") {
+		key, value := docKeyword(line)
+		switch key {
+		case "example", "default":
+			var parsed interface{}
+			if err := yaml.Unmarshal([]byte(value), &parsed); err != nil {
+				return false, errors.Wrapf(err, "invalid %s", key)
+			}
+			if target["type"] == "string" {
+				parsed = value
+			}
+			target[key] = normalizeYAML(parsed)
+		case "enum":
+			var values []interface{}
+			for _, value := range strings.Split(value, ",") {
+				values = append(values, strings.TrimSpace(value))
+			}
+			target["enum"] = values
+		case "required":
+			required = value == "true"
+		default:
+			if line = strings.TrimSpace(line); line != "" {
+				description = append(description, line)
+			}
+		}
+	}
+
+	if len(description) > 0 {
+		if _, isRef := target["$ref"]; isRef {
+			// siblings of reference are ignored, so the reference is wrapped to keep the description
+			target = schema{"allOf": []interface{}{target}}
+		}
+		target["description"] = strings.Join(description, " ")
+	}
+	*property = target
+	return required, nil
+}
+
+// docDescription returns type documentation without annotations
+func docDescription(doc *ast.CommentGroup) string {
+	if doc == nil {
+		return ""
+	}
+
+	var description []string
+	for _, line := range strings.Split(strings.TrimSpace(doc.Text()), "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "swagger:") || strings.HasPrefix(line, "openapi:") {
+			continue
+		}
+		if key, _ := docKeyword(line); key != "" {
+			continue
+		}
+		description = append(description, line)
+	}
+	return strings.TrimSpace(strings.Join(description, " "))
+}
+
+func docKeyword(line string) (key, value string) {
+	line = strings.TrimSpace(line)
+	for _, keyword := range []string{"example", "default", "enum", "required"} {
+		if strings.HasPrefix(strings.ToLower(line), keyword+":") {
+			return keyword, strings.TrimSpace(line[len(keyword)+1:])
+		}
+	}
+	return "", ""
+}
+
+func copySchema(original schema) schema {
+	result := make(schema, len(original))
+	for key, value := range original {
+		result[key] = value
+	}
+	return result
+}
+
+// normalizeYAML converts maps decoded from YAML to the ones encodable to JSON
+func normalizeYAML(value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[interface{}]interface{}:
+		result := make(map[string]interface{}, len(value))
+		for key, item := range value {
+			result[fmt.Sprint(key)] = normalizeYAML(item)
+		}
+		return result
+	case []interface{}:
+		for i, item := range value {
+			value[i] = normalizeYAML(item)
+		}
+		return value
+	}
+	return value
+}
@@ -0,0 +1,38 @@
+package api
+
+import "example.com/api/dto"
+
+// item represents an item
+// swagger:model ItemDTO
+type item struct {
+	dto.Base
+	// item name
+	// required: true
+	// example: apple
+	Name   string   `json:"name"`
+	Count  uint64   `json:"count,omitempty"`
+	Tags   dto.Tags `json:"tags"`
+	Parent *item    `json:"parent"`
+	secret string
+	Hidden string `json:"-"`
+}
+
+// swagger:operation PUT /items/{id} Items updateItem
+// ---
+// summary: Updates item
+// parameters:
+// - in: path
+//   name: id
+//   type: string
+// - in: body
+//   name: body
+//   schema:
+//     $ref: "#/definitions/ItemDTO"
+// responses:
+//   200:
+//     description: Updated item
+//     schema:
+//       "$ref": "#/definitions/ItemDTO"
+//   404:
+//     description: Not found
+func updateItem() {}
@@ -0,0 +1,9 @@
+// Package api Example API
+//
+// Example API description.
+//
+//   Host: 127.0.0.1:8080
+//   Version: 1.0
+//
+// swagger:meta
+package api
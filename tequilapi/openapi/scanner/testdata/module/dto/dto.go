@@ -0,0 +1,20 @@
+package dto
+
+import "time"
+
+// Base holds common fields
+type Base struct {
+	// creation time
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// Tags is encoded as a list of strings
+// openapi:type []string
+type Tags struct {
+	tags map[string]bool
+}
+
+// MarshalJSON encodes tags as a list
+func (t Tags) MarshalJSON() ([]byte, error) {
+	return nil, nil
+}
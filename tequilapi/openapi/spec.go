@@ -0,0 +1,4804 @@
+/*
+ * Copyright (C) 2020 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package openapi
+
+// generated by generator.go - DO NOT EDIT
+
+const specJSON = `{
+  "components": {
+    "schemas": {
+      "APITokenDTO": {
+        "properties": {
+          "created_at": {
+            "format": "date-time",
+            "type": "string"
+          },
+          "expires_at": {
+            "format": "date-time",
+            "type": "string"
+          },
+          "id": {
+            "type": "string"
+          },
+          "name": {
+            "type": "string"
+          },
+          "owner": {
+            "type": "string"
+          },
+          "scope": {
+            "enum": [
+              "monitor",
+              "consumer",
+              "provider",
+              "admin"
+            ],
+            "type": "string"
+          }
+        },
+        "type": "object"
+      },
+      "APITokenListDTO": {
+        "properties": {
+          "tokens": {
+            "items": {
+              "$ref": "#/components/schemas/APITokenDTO"
+            },
+            "type": "array"
+          }
+        },
+        "type": "object"
+      },
+      "AccessPolicies": {
+        "properties": {
+          "entries": {
+            "items": {
+              "$ref": "#/components/schemas/accessPolicy"
+            },
+            "type": "array"
+          }
+        },
+        "type": "object"
+      },
+      "AccessPolicy": {
+        "description": "AccessPolicy represents the access controls for proposal",
+        "properties": {
+          "id": {
+            "type": "string"
+          },
+          "source": {
+            "type": "string"
+          }
+        },
+        "type": "object"
+      },
+      "AccessPolicyRequest": {
+        "description": "accessPolicy represents the access controls",
+        "properties": {
+          "ids": {
+            "items": {
+              "type": "string"
+            },
+            "type": "array"
+          }
+        },
+        "type": "object"
+      },
+      "AccountantPromiseDTO": {
+        "description": "accountantPromise represents a promise issued by the accountant",
+        "properties": {
+          "agreementId": {
+            "example": 1234,
+            "format": "int64",
+            "minimum": 0,
+            "type": "integer"
+          },
+          "amount": {
+            "example": 500000,
+            "format": "int64",
+            "minimum": 0,
+            "type": "integer"
+          },
+          "channelId": {
+            "example": "0000000000000000000000000000000000000000000000000000000000000001",
+            "type": "string"
+          },
+          "fee": {
+            "example": 1000,
+            "format": "int64",
+            "minimum": 0,
+            "type": "integer"
+          },
+          "hashlock": {
+            "example": "0000000000000000000000000000000000000000000000000000000000000002",
+            "type": "string"
+          },
+          "revealed": {
+            "example": false,
+            "type": "boolean"
+          }
+        },
+        "type": "object"
+      },
+      "AuditRecordDTO": {
+        "description": "auditRecord represents a single API request recorded in the audit log",
+        "properties": {
+          "durationMs": {
+            "description": "request handling duration in milliseconds",
+            "example": 12,
+            "format": "int64",
+            "type": "integer"
+          },
+          "id": {
+            "example": 1,
+            "format": "int64",
+            "type": "integer"
+          },
+          "method": {
+            "example": "POST",
+            "type": "string"
+          },
+          "params": {
+            "additionalProperties": {},
+            "description": "query and body parameters with the secrets redacted",
+            "example": {
+              "body": {
+                "providerId": "0x0000000000000000000000000000000000000001",
+                "type": "openvpn"
+              }
+            },
+            "type": "object"
+          },
+          "path": {
+            "example": "/services",
+            "type": "string"
+          },
+          "remoteAddr": {
+            "description": "address the request came from, empty for requests via unix domain socket",
+            "example": "127.0.0.1:52044",
+            "type": "string"
+          },
+          "role": {
+            "example": "admin",
+            "type": "string"
+          },
+          "status": {
+            "description": "response status code",
+            "example": 201,
+            "format": "int64",
+            "type": "integer"
+          },
+          "time": {
+            "example": "2020-03-01T12:00:00Z",
+            "type": "string"
+          },
+          "username": {
+            "description": "authenticated user, empty if the request was not authenticated",
+            "example": "myst",
+            "type": "string"
+          }
+        },
+        "type": "object"
+      },
+      "AuditRecordListDTO": {
+        "description": "auditRecordList defines audit record list representable as json",
+        "properties": {
+          "records": {
+            "items": {
+              "$ref": "#/components/schemas/AuditRecordDTO"
+            },
+            "type": "array"
+          }
+        },
+        "type": "object"
+      },
+      "BudgetDTO": {
+        "description": "budgetResponse represents the consumer spending limits and current spending",
+        "properties": {
+          "alertThresholds": {
+            "description": "budget usage percents to publish alerts at",
+            "example": [
+              50,
+              80
+            ],
+            "items": {
+              "format": "int64",
+              "type": "integer"
+            },
+            "type": "array"
+          },
+          "perDay": {
+            "description": "spending limit of a calendar day",
+            "format": "int64",
+            "minimum": 0,
+            "type": "integer"
+          },
+          "perMonth": {
+            "description": "spending limit of a calendar month",
+            "format": "int64",
+            "minimum": 0,
+            "type": "integer"
+          },
+          "perSession": {
+            "description": "spending limit of a single session",
+            "format": "int64",
+            "minimum": 0,
+            "type": "integer"
+          },
+          "spent": {
+            "$ref": "#/components/schemas/Spending"
+          }
+        },
+        "type": "object"
+      },
+      "BudgetRequestDTO": {
+        "description": "budgetRequest represents the consumer spending limits, zero limit means unlimited spending",
+        "properties": {
+          "alertThresholds": {
+            "description": "budget usage percents to publish alerts at",
+            "example": [
+              50,
+              80
+            ],
+            "items": {
+              "format": "int64",
+              "type": "integer"
+            },
+            "type": "array"
+          },
+          "perDay": {
+            "description": "spending limit of a calendar day",
+            "format": "int64",
+            "minimum": 0,
+            "type": "integer"
+          },
+          "perMonth": {
+            "description": "spending limit of a calendar month",
+            "format": "int64",
+            "minimum": 0,
+            "type": "integer"
+          },
+          "perSession": {
+            "description": "spending limit of a single session",
+            "format": "int64",
+            "minimum": 0,
+            "type": "integer"
+          }
+        },
+        "type": "object"
+      },
+      "BuildInfoDTO": {
+        "properties": {
+          "branch": {
+            "example": "\u003cunknown\u003e",
+            "type": "string"
+          },
+          "buildNumber": {
+            "example": "dev-build",
+            "type": "string"
+          },
+          "commit": {
+            "example": "\u003cunknown\u003e",
+            "type": "string"
+          }
+        },
+        "type": "object"
+      },
+      "ChangePasswordRequest": {
+        "properties": {
+          "new_password": {
+            "type": "string"
+          },
+          "old_password": {
+            "type": "string"
+          },
+          "username": {
+            "type": "string"
+          }
+        },
+        "type": "object"
+      },
+      "ChannelDTO": {
+        "description": "channelResponse represents the state of the identity's payment channel",
+        "properties": {
+          "accountantId": {
+            "example": "0x0000000000000000000000000000000000000002",
+            "type": "string"
+          },
+          "balance": {
+            "description": "amount of tokens the consumer channel holds",
+            "example": 1000000,
+            "format": "int64",
+            "minimum": 0,
+            "type": "integer"
+          },
+          "channelAddress": {
+            "description": "on-chain address of the consumer channel",
+            "example": "0x0000000000000000000000000000000000000003",
+            "type": "string"
+          },
+          "grandTotalPromised": {
+            "description": "total amount the consumer has promised through the accountant",
+            "example": 750000,
+            "format": "int64",
+            "minimum": 0,
+            "type": "integer"
+          },
+          "identity": {
+            "example": "0x0000000000000000000000000000000000000001",
+            "type": "string"
+          },
+          "lastPromise": {
+            "allOf": [
+              {
+                "$ref": "#/components/schemas/AccountantPromiseDTO"
+              }
+            ],
+            "description": "last promise the provider got from the accountant"
+          },
+          "registered": {
+            "example": true,
+            "type": "boolean"
+          },
+          "settled": {
+            "description": "amount the provider has settled with the accountant",
+            "example": 500000,
+            "format": "int64",
+            "minimum": 0,
+            "type": "integer"
+          }
+        },
+        "type": "object"
+      },
+      "ConnectCount": {
+        "description": "ConnectCount represents the connection count statistics",
+        "properties": {
+          "fail": {
+            "format": "int64",
+            "type": "integer"
+          },
+          "success": {
+            "format": "int64",
+            "type": "integer"
+          },
+          "timeout": {
+            "format": "int64",
+            "type": "integer"
+          }
+        },
+        "type": "object"
+      },
+      "ConnectOptionsDTO": {
+        "description": "ConnectOptions holds tequilapi connect options",
+        "properties": {
+          "dns": {
+            "default": "auto",
+            "description": "DNS to use",
+            "example": "auto, provider, system, \"1.1.1.1,8.8.8.8\"",
+            "type": "string"
+          },
+          "killSwitch": {
+            "description": "kill switch option restricting communication only through VPN",
+            "example": true,
+            "type": "boolean"
+          }
+        },
+        "type": "object"
+      },
+      "ConnectionRequestDTO": {
+        "properties": {
+          "accountantId": {
+            "description": "accountant identity, picked by the node's accountant selection policy if omitted",
+            "example": "0x0000000000000000000000000000000000000003",
+            "type": "string"
+          },
+          "connectOptions": {
+            "allOf": [
+              {
+                "$ref": "#/components/schemas/ConnectOptionsDTO"
+              }
+            ],
+            "description": "connect options"
+          },
+          "consumerId": {
+            "description": "consumer identity",
+            "example": "0x0000000000000000000000000000000000000001",
+            "type": "string"
+          },
+          "providerId": {
+            "description": "provider identity",
+            "example": "0x0000000000000000000000000000000000000002",
+            "type": "string"
+          },
+          "serviceType": {
+            "default": "openvpn",
+            "description": "service type. Possible values are \"openvpn\", \"wireguard\" and \"noop\"",
+            "example": "openvpn",
+            "type": "string"
+          }
+        },
+        "required": [
+          "consumerId",
+          "providerId"
+        ],
+        "type": "object"
+      },
+      "ConnectionSessionDTO": {
+        "description": "connectionSession represents the session object",
+        "properties": {
+          "bytesReceived": {
+            "example": 1024,
+            "format": "int64",
+            "minimum": 0,
+            "type": "integer"
+          },
+          "bytesSent": {
+            "example": 1024,
+            "format": "int64",
+            "minimum": 0,
+            "type": "integer"
+          },
+          "dateStarted": {
+            "example": "2018-10-29 16:22:05",
+            "type": "string"
+          },
+          "duration": {
+            "description": "duration in seconds",
+            "example": 120,
+            "format": "int64",
+            "minimum": 0,
+            "type": "integer"
+          },
+          "providerCountry": {
+            "example": "NL",
+            "type": "string"
+          },
+          "providerId": {
+            "example": "0x0000000000000000000000000000000000000001",
+            "type": "string"
+          },
+          "serviceType": {
+            "example": "openvpn",
+            "type": "string"
+          },
+          "sessionId": {
+            "example": "4cfb0324-daf6-4ad8-448b-e61fe0a1f918",
+            "type": "string"
+          },
+          "status": {
+            "example": "Completed",
+            "type": "string"
+          }
+        },
+        "type": "object"
+      },
+      "ConnectionSessionListDTO": {
+        "description": "connectionSessionsList defines session list representable as json",
+        "properties": {
+          "sessions": {
+            "items": {
+              "$ref": "#/components/schemas/ConnectionSessionDTO"
+            },
+            "type": "array"
+          }
+        },
+        "type": "object"
+      },
+      "ConnectionStatisticsDTO": {
+        "properties": {
+          "bytesReceived": {
+            "example": 1024,
+            "format": "int64",
+            "minimum": 0,
+            "type": "integer"
+          },
+          "bytesSent": {
+            "example": 1024,
+            "format": "int64",
+            "minimum": 0,
+            "type": "integer"
+          },
+          "duration": {
+            "description": "connection duration in seconds",
+            "example": 60,
+            "format": "int64",
+            "type": "integer"
+          }
+        },
+        "type": "object"
+      },
+      "ConnectionStatusDTO": {
+        "properties": {
+          "proposal": {
+            "$ref": "#/components/schemas/ProposalDTO",
+            "example": {
+              "id": 1,
+              "providerId": "0x71ccbdee7f6afe85a5bc7106323518518cd23b94",
+              "serviceDefinition": {
+                "locationOriginate": {
+                  "asn": "",
+                  "country": "CA"
+                }
+              },
+              "serviceType": "openvpn"
+            }
+          },
+          "sessionId": {
+            "example": "4cfb0324-daf6-4ad8-448b-e61fe0a1f918",
+            "type": "string"
+          },
+          "status": {
+            "example": "Connected",
+            "type": "string"
+          }
+        },
+        "type": "object"
+      },
+      "ConnectivityStatus": {
+        "properties": {
+          "entries": {
+            "items": {
+              "$ref": "#/components/schemas/sessionConnectivityStatus"
+            },
+            "type": "array"
+          }
+        },
+        "type": "object"
+      },
+      "CreateAPITokenRequest": {
+        "properties": {
+          "expires_at": {
+            "description": "token never expires if omitted",
+            "format": "date-time",
+            "type": "string"
+          },
+          "name": {
+            "type": "string"
+          },
+          "scope": {
+            "description": "role the token acts with, role of the creating user if omitted",
+            "enum": [
+              "monitor",
+              "consumer",
+              "provider",
+              "admin"
+            ],
+            "type": "string"
+          }
+        },
+        "type": "object"
+      },
+      "CreateUserRequest": {
+        "properties": {
+          "password": {
+            "type": "string"
+          },
+          "role": {
+            "enum": [
+              "monitor",
+              "consumer",
+              "provider",
+              "admin"
+            ],
+            "type": "string"
+          },
+          "username": {
+            "type": "string"
+          }
+        },
+        "type": "object"
+      },
+      "CreatedAPITokenDTO": {
+        "properties": {
+          "created_at": {
+            "format": "date-time",
+            "type": "string"
+          },
+          "expires_at": {
+            "format": "date-time",
+            "type": "string"
+          },
+          "id": {
+            "type": "string"
+          },
+          "name": {
+            "type": "string"
+          },
+          "owner": {
+            "type": "string"
+          },
+          "scope": {
+            "enum": [
+              "monitor",
+              "consumer",
+              "provider",
+              "admin"
+            ],
+            "type": "string"
+          },
+          "token": {
+            "description": "bearer token, it is shown only once",
+            "type": "string"
+          }
+        },
+        "type": "object"
+      },
+      "CurrentIdentityDTO": {
+        "properties": {
+          "passphrase": {
+            "type": "string"
+          }
+        },
+        "type": "object"
+      },
+      "EarningsDTO": {
+        "description": "earningsResponse represents the provider earnings",
+        "properties": {
+          "countries": {
+            "description": "earnings grouped by the consumer country, unknown if consumer did not tell it",
+            "items": {
+              "$ref": "#/components/schemas/EarningsGroupDTO"
+            },
+            "type": "array"
+          },
+          "earned": {
+            "description": "sum of payments received within the requested time range",
+            "example": 1500000,
+            "format": "int64",
+            "minimum": 0,
+            "type": "integer"
+          },
+          "groupBy": {
+            "example": "day",
+            "type": "string"
+          },
+          "periods": {
+            "description": "earnings grouped by the beginning of the period payments were received in",
+            "items": {
+              "$ref": "#/components/schemas/EarningsGroupDTO"
+            },
+            "type": "array"
+          },
+          "serviceTypes": {
+            "description": "earnings grouped by the service type",
+            "items": {
+              "$ref": "#/components/schemas/EarningsGroupDTO"
+            },
+            "type": "array"
+          },
+          "sessions": {
+            "items": {
+              "$ref": "#/components/schemas/SessionEarningsDTO"
+            },
+            "type": "array"
+          },
+          "settled": {
+            "description": "amount settled on the blockchain",
+            "example": 9000000,
+            "format": "int64",
+            "minimum": 0,
+            "type": "integer"
+          },
+          "settlementFee": {
+            "description": "projected transactor fee of settling the unsettled amount",
+            "example": 100000,
+            "format": "int64",
+            "minimum": 0,
+            "type": "integer"
+          },
+          "unsettled": {
+            "description": "amount promised by the accountant but not settled yet",
+            "example": 3000000,
+            "format": "int64",
+            "minimum": 0,
+            "type": "integer"
+          },
+          "unsettledNetOfFees": {
+            "description": "unsettled amount left after the settlement fee is paid",
+            "example": 2900000,
+            "format": "int64",
+            "minimum": 0,
+            "type": "integer"
+          }
+        },
+        "type": "object"
+      },
+      "EarningsGroupDTO": {
+        "description": "earningsGroup represents the amount earned by a group of sessions",
+        "properties": {
+          "earned": {
+            "example": 1500000,
+            "format": "int64",
+            "minimum": 0,
+            "type": "integer"
+          },
+          "key": {
+            "example": "2020-03-01",
+            "type": "string"
+          },
+          "sessions": {
+            "example": 3,
+            "format": "int64",
+            "type": "integer"
+          }
+        },
+        "type": "object"
+      },
+      "EmailInfoDTO": {
+        "properties": {
+          "email": {
+            "type": "string"
+          }
+        },
+        "required": [
+          "email"
+        ],
+        "type": "object"
+      },
+      "ErrorMessageDTO": {
+        "properties": {
+          "message": {
+            "example": "error message",
+            "type": "string"
+          }
+        },
+        "type": "object"
+      },
+      "Fees": {
+        "description": "Fees represents the transactor fees",
+        "properties": {
+          "registration": {
+            "format": "int64",
+            "minimum": 0,
+            "type": "integer"
+          },
+          "registrationDisplay": {
+            "allOf": [
+              {
+                "$ref": "#/components/schemas/MoneyDTO"
+              }
+            ],
+            "description": "human readable registration fee"
+          },
+          "settlement": {
+            "format": "int64",
+            "minimum": 0,
+            "type": "integer"
+          },
+          "settlementDisplay": {
+            "allOf": [
+              {
+                "$ref": "#/components/schemas/MoneyDTO"
+              }
+            ],
+            "description": "human readable settlement fee"
+          }
+        },
+        "type": "object"
+      },
+      "FieldError": {
+        "description": "FieldError structure is produced by validator",
+        "properties": {
+          "code": {
+            "type": "string"
+          },
+          "message": {
+            "type": "string"
+          }
+        },
+        "type": "object"
+      },
+      "HealthCheckDTO": {
+        "properties": {
+          "buildInfo": {
+            "$ref": "#/components/schemas/BuildInfoDTO"
+          },
+          "process": {
+            "example": 10449,
+            "format": "int64",
+            "type": "integer"
+          },
+          "uptime": {
+            "example": "25h53m33.540493171s",
+            "type": "string"
+          },
+          "version": {
+            "example": "0.0.6",
+            "type": "string"
+          }
+        },
+        "type": "object"
+      },
+      "IPDTO": {
+        "properties": {
+          "ip": {
+            "description": "public IP address",
+            "example": "127.0.0.1",
+            "type": "string"
+          }
+        },
+        "type": "object"
+      },
+      "IdentityCreationDTO": {
+        "properties": {
+          "passphrase": {
+            "type": "string"
+          }
+        },
+        "type": "object"
+      },
+      "IdentityDTO": {
+        "properties": {
+          "id": {
+            "description": "identity in Ethereum address format",
+            "example": "0x0000000000000000000000000000000000000001",
+            "type": "string"
+          }
+        },
+        "required": [
+          "id"
+        ],
+        "type": "object"
+      },
+      "IdentityExportDTO": {
+        "properties": {
+          "exportPassphrase": {
+            "description": "passphrase exported identity is encrypted with, passphrase identity is stored with if omitted",
+            "type": "string"
+          },
+          "passphrase": {
+            "description": "passphrase identity is stored with",
+            "type": "string"
+          }
+        },
+        "type": "object"
+      },
+      "IdentityImportDTO": {
+        "properties": {
+          "blob": {
+            "description": "exported identity, JSON keystore blob"
+          },
+          "newPassphrase": {
+            "description": "passphrase identity is stored with after the import, passphrase of exported identity if omitted",
+            "type": "string"
+          },
+          "passphrase": {
+            "description": "passphrase exported identity is encrypted with",
+            "type": "string"
+          }
+        },
+        "type": "object"
+      },
+      "IdentityList": {
+        "properties": {
+          "identities": {
+            "items": {
+              "$ref": "#/components/schemas/IdentityDTO"
+            },
+            "type": "array"
+          }
+        },
+        "type": "object"
+      },
+      "IdentityPassphraseChangeDTO": {
+        "properties": {
+          "newPassphrase": {
+            "description": "passphrase identity is stored with after the change",
+            "type": "string"
+          },
+          "passphrase": {
+            "description": "passphrase identity is stored with",
+            "type": "string"
+          }
+        },
+        "type": "object"
+      },
+      "IdentityRegistrationRequestDTO": {
+        "description": "IdentityRegistrationRequestDTO represents the identity registration user input parameters",
+        "properties": {
+          "beneficiary": {
+            "description": "Cache out address for Provider",
+            "type": "string"
+          },
+          "fee": {
+            "description": "Fee: negotiated fee with transactor",
+            "format": "int64",
+            "minimum": 0,
+            "type": "integer"
+          },
+          "stake": {
+            "description": "Stake is used by Provider, default 0",
+            "format": "int64",
+            "minimum": 0,
+            "type": "integer"
+          }
+        },
+        "type": "object"
+      },
+      "IdentityUnlockingDTO": {
+        "properties": {
+          "passphrase": {
+            "type": "string"
+          }
+        },
+        "type": "object"
+      },
+      "LocationDTO": {
+        "properties": {
+          "asn": {
+            "description": "Autonomous system number",
+            "example": 62179,
+            "format": "int64",
+            "type": "integer"
+          },
+          "city": {
+            "description": "Node City",
+            "example": "Vilnius",
+            "type": "string"
+          },
+          "continent": {
+            "description": "Continent",
+            "example": "EU",
+            "type": "string"
+          },
+          "country": {
+            "description": "Node Country",
+            "example": "LT",
+            "type": "string"
+          },
+          "ip": {
+            "description": "IP address",
+            "example": "1.2.3.4",
+            "type": "string"
+          },
+          "isp": {
+            "description": "Internet Service Provider name",
+            "example": "Telia Lietuva, AB",
+            "type": "string"
+          },
+          "node_type": {
+            "description": "User type (DEPRECATED)",
+            "example": "residential",
+            "type": "string"
+          },
+          "userType": {
+            "description": "User type (data_center, residential, etc.)",
+            "example": "residential",
+            "type": "string"
+          }
+        },
+        "type": "object"
+      },
+      "LoginRequest": {
+        "properties": {
+          "password": {
+            "type": "string"
+          },
+          "username": {
+            "type": "string"
+          }
+        },
+        "type": "object"
+      },
+      "MoneyDTO": {
+        "properties": {
+          "amount": {
+            "description": "amount in token base units",
+            "example": 50000000,
+            "format": "int64",
+            "minimum": 0,
+            "type": "integer"
+          },
+          "currency": {
+            "example": "MYST",
+            "type": "string"
+          },
+          "fiat": {
+            "description": "approximate value in the display currency, omitted if the exchange rate is unknown",
+            "example": "~0.13 USD",
+            "type": "string"
+          },
+          "human": {
+            "description": "human readable amount",
+            "example": "0.5 MYST",
+            "type": "string"
+          }
+        },
+        "type": "object"
+      },
+      "NATStatusDTO": {
+        "description": "NATStatus stores the nat status related information",
+        "properties": {
+          "error": {
+            "type": "string"
+          },
+          "status": {
+            "type": "string"
+          }
+        },
+        "type": "object"
+      },
+      "PaymentMethodDTO": {
+        "properties": {
+          "perBytes": {
+            "description": "rate period in bytes",
+            "example": 0,
+            "format": "int64",
+            "minimum": 0,
+            "type": "integer"
+          },
+          "perSeconds": {
+            "description": "rate period in seconds",
+            "example": 60,
+            "format": "int64",
+            "minimum": 0,
+            "type": "integer"
+          },
+          "price": {
+            "allOf": [
+              {
+                "$ref": "#/components/schemas/MoneyDTO"
+              }
+            ],
+            "description": "price charged for every rate period"
+          },
+          "pricePerGB": {
+            "allOf": [
+              {
+                "$ref": "#/components/schemas/MoneyDTO"
+              }
+            ],
+            "description": "price of a gigabyte of transferred data, charged in addition to the price per rate period"
+          },
+          "type": {
+            "example": "WG",
+            "type": "string"
+          }
+        },
+        "type": "object"
+      },
+      "PayoutInfoDTO": {
+        "properties": {
+          "ethAddress": {
+            "description": "in Ethereum address format",
+            "example": "0x000000000000000000000000000000000000000a",
+            "type": "string"
+          }
+        },
+        "required": [
+          "ethAddress"
+        ],
+        "type": "object"
+      },
+      "PayoutInfoResponseDTO": {
+        "properties": {
+          "email": {
+            "type": "string"
+          },
+          "ethAddress": {
+            "type": "string"
+          },
+          "referralCode": {
+            "type": "string"
+          }
+        },
+        "type": "object"
+      },
+      "PriceDTO": {
+        "description": "priceRequest represents the price provider asks for the service",
+        "properties": {
+          "perGB": {
+            "description": "price per gigabyte of transferred data, in the smallest MYST units",
+            "example": 100000,
+            "format": "int64",
+            "minimum": 0,
+            "type": "integer"
+          },
+          "perMinute": {
+            "description": "price per minute of the service, in the smallest MYST units",
+            "example": 50000,
+            "format": "int64",
+            "minimum": 0,
+            "type": "integer"
+          }
+        },
+        "type": "object"
+      },
+      "ProposalDTO": {
+        "properties": {
+          "accessPolicies": {
+            "description": "AccessPolicies",
+            "items": {
+              "$ref": "#/components/schemas/AccessPolicy"
+            },
+            "type": "array"
+          },
+          "id": {
+            "description": "per provider unique serial number of service description provided",
+            "example": 5,
+            "format": "int64",
+            "type": "integer"
+          },
+          "metrics": {
+            "allOf": [
+              {
+                "$ref": "#/components/schemas/metricsRes"
+              }
+            ],
+            "description": "Metrics of the service"
+          },
+          "paymentMethod": {
+            "allOf": [
+              {
+                "$ref": "#/components/schemas/PaymentMethodDTO"
+              }
+            ],
+            "description": "price of the service, omitted if the payment method is unknown"
+          },
+          "providerId": {
+            "description": "provider who offers service",
+            "example": "0x0000000000000000000000000000000000000001",
+            "type": "string"
+          },
+          "serviceDefinition": {
+            "allOf": [
+              {
+                "$ref": "#/components/schemas/ServiceDefinitionDTO"
+              }
+            ],
+            "description": "qualitative service definition"
+          },
+          "serviceType": {
+            "description": "type of service provider offers",
+            "example": "openvpn",
+            "type": "string"
+          },
+          "trial": {
+            "allOf": [
+              {
+                "$ref": "#/components/schemas/TrialDTO"
+              }
+            ],
+            "description": "free trial offered to new consumers, omitted if there's none"
+          }
+        },
+        "type": "object"
+      },
+      "ProposalsList": {
+        "properties": {
+          "proposals": {
+            "items": {
+              "$ref": "#/components/schemas/ProposalDTO"
+            },
+            "type": "array"
+          }
+        },
+        "type": "object"
+      },
+      "ReferralInfoDTO": {
+        "properties": {
+          "referralCode": {
+            "example": "ABC123",
+            "type": "string"
+          }
+        },
+        "required": [
+          "referralCode"
+        ],
+        "type": "object"
+      },
+      "RegistrationDataDTO": {
+        "description": "RegistrationDataDTO represents registration status and needed data for registering of given identity",
+        "properties": {
+          "registered": {
+            "description": "Returns true if identity is registered in payments smart contract",
+            "type": "boolean"
+          }
+        },
+        "type": "object"
+      },
+      "ReportIssueError": {
+        "description": "ReportIssueError issue report error",
+        "properties": {
+          "errors": {
+            "items": {
+              "properties": {
+                "message": {
+                  "type": "string"
+                }
+              },
+              "type": "object"
+            },
+            "type": "array"
+          }
+        },
+        "type": "object"
+      },
+      "ReportIssueRequest": {
+        "description": "ReportIssueRequest params for issue report",
+        "properties": {
+          "description": {
+            "type": "string"
+          },
+          "email": {
+            "type": "string"
+          }
+        },
+        "type": "object"
+      },
+      "ReportIssueSuccess": {
+        "description": "ReportIssueSuccess successful issue report",
+        "properties": {
+          "issueId": {
+            "type": "string"
+          }
+        },
+        "type": "object"
+      },
+      "RestartPolicyDTO": {
+        "description": "restartPolicyRequest represents the service restart policy",
+        "properties": {
+          "maxRestarts": {
+            "description": "maximum number of restarts, zero means unlimited",
+            "example": 5,
+            "format": "int64",
+            "type": "integer"
+          },
+          "type": {
+            "description": "restart policy type. Possible values are \"never\", \"on-failure\" and \"always\"",
+            "example": "on-failure",
+            "type": "string"
+          }
+        },
+        "type": "object"
+      },
+      "ServiceDefinitionDTO": {
+        "properties": {
+          "locationOriginate": {
+            "$ref": "#/components/schemas/ServiceLocationDTO"
+          }
+        },
+        "type": "object"
+      },
+      "ServiceInfoDTO": {
+        "properties": {
+          "accessPolicies": {
+            "items": {
+              "$ref": "#/components/schemas/AccessPolicy"
+            },
+            "type": "array"
+          },
+          "id": {
+            "example": "6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+            "type": "string"
+          },
+          "lastFailure": {
+            "description": "reason of the last service failure",
+            "example": "liveness probe failed",
+            "type": "string"
+          },
+          "lastFailureAt": {
+            "example": "2019-06-06T11:04:43.910035Z",
+            "format": "date-time",
+            "type": "string"
+          },
+          "options": {
+            "description": "options with which service was started. Every service has a unique list of allowed options.",
+            "example": {
+              "port": 1123,
+              "protocol": "udp"
+            }
+          },
+          "price": {
+            "$ref": "#/components/schemas/PriceDTO"
+          },
+          "proposal": {
+            "$ref": "#/components/schemas/ProposalDTO"
+          },
+          "providerId": {
+            "description": "provider identity",
+            "example": "0x0000000000000000000000000000000000000002",
+            "type": "string"
+          },
+          "restartCount": {
+            "description": "number of times the service was restarted",
+            "example": 1,
+            "format": "int64",
+            "type": "integer"
+          },
+          "restartPolicy": {
+            "$ref": "#/components/schemas/RestartPolicyDTO"
+          },
+          "status": {
+            "example": "Running",
+            "type": "string"
+          },
+          "type": {
+            "description": "service type. Possible values are \"openvpn\", \"wireguard\" and \"noop\"",
+            "example": "openvpn",
+            "type": "string"
+          }
+        },
+        "type": "object"
+      },
+      "ServiceListDTO": {
+        "items": {
+          "$ref": "#/components/schemas/ServiceInfoDTO"
+        },
+        "type": "array"
+      },
+      "ServiceLocationDTO": {
+        "properties": {
+          "asn": {
+            "description": "Autonomous System Number",
+            "example": 1,
+            "format": "int64",
+            "type": "integer"
+          },
+          "city": {
+            "example": "Amsterdam",
+            "type": "string"
+          },
+          "continent": {
+            "example": "EU",
+            "type": "string"
+          },
+          "country": {
+            "example": "NL",
+            "type": "string"
+          },
+          "isp": {
+            "example": "Telia Lietuva, AB",
+            "type": "string"
+          },
+          "node_type": {
+            "example": "residential",
+            "type": "string"
+          }
+        },
+        "type": "object"
+      },
+      "ServiceRequestDTO": {
+        "properties": {
+          "accessPolicies": {
+            "allOf": [
+              {
+                "$ref": "#/components/schemas/AccessPolicyRequest"
+              }
+            ],
+            "description": "access list which determines which identities will be able to receive the service"
+          },
+          "options": {
+            "description": "service options. Every service has a unique list of allowed options.",
+            "example": {
+              "port": 1123,
+              "protocol": "udp"
+            }
+          },
+          "price": {
+            "allOf": [
+              {
+                "$ref": "#/components/schemas/PriceDTO"
+              }
+            ],
+            "description": "price of the service, default price is used if not given"
+          },
+          "providerId": {
+            "description": "provider identity",
+            "example": "0x0000000000000000000000000000000000000002",
+            "type": "string"
+          },
+          "restartPolicy": {
+            "allOf": [
+              {
+                "$ref": "#/components/schemas/RestartPolicyDTO"
+              }
+            ],
+            "description": "policy which determines whether service is restarted once it exits"
+          },
+          "type": {
+            "description": "service type. Possible values are \"openvpn\", \"wireguard\" and \"noop\"",
+            "example": "openvpn",
+            "type": "string"
+          }
+        },
+        "required": [
+          "providerId",
+          "type"
+        ],
+        "type": "object"
+      },
+      "ServiceSessionDTO": {
+        "description": "ServiceSession represents the session object",
+        "properties": {
+          "bytesIn": {
+            "example": 23451,
+            "format": "int64",
+            "minimum": 0,
+            "type": "integer"
+          },
+          "bytesOut": {
+            "example": 12345,
+            "format": "int64",
+            "minimum": 0,
+            "type": "integer"
+          },
+          "consumerId": {
+            "example": "0x0000000000000000000000000000000000000001",
+            "type": "string"
+          },
+          "createdAt": {
+            "example": "2019-06-06T11:04:43.910035Z",
+            "format": "date-time",
+            "type": "string"
+          },
+          "id": {
+            "example": "4cfb0324-daf6-4ad8-448b-e61fe0a1f918",
+            "type": "string"
+          },
+          "serviceId": {
+            "example": "4cfb0324-daf6-4ad8-448b-e61fe0a1f918",
+            "type": "string"
+          }
+        },
+        "type": "object"
+      },
+      "ServiceSessionListDTO": {
+        "description": "serviceSessionsList defines session list representable as json",
+        "properties": {
+          "sessions": {
+            "items": {
+              "$ref": "#/components/schemas/ServiceSessionDTO"
+            },
+            "type": "array"
+          }
+        },
+        "type": "object"
+      },
+      "ServiceUpdateRequestDTO": {
+        "properties": {
+          "accessPolicies": {
+            "allOf": [
+              {
+                "$ref": "#/components/schemas/AccessPolicyRequest"
+              }
+            ],
+            "description": "access list which determines which identities will be able to receive the service, left unchanged if not given"
+          },
+          "price": {
+            "allOf": [
+              {
+                "$ref": "#/components/schemas/PriceDTO"
+              }
+            ],
+            "description": "price of the service, left unchanged if not given"
+          }
+        },
+        "type": "object"
+      },
+      "SessionEarningsDTO": {
+        "description": "sessionEarnings represents the amounts invoiced and paid during a single session",
+        "properties": {
+          "consumerCountry": {
+            "example": "NL",
+            "type": "string"
+          },
+          "consumerId": {
+            "example": "0x0000000000000000000000000000000000000001",
+            "type": "string"
+          },
+          "invoiced": {
+            "example": 550000,
+            "format": "int64",
+            "minimum": 0,
+            "type": "integer"
+          },
+          "paid": {
+            "example": 500000,
+            "format": "int64",
+            "minimum": 0,
+            "type": "integer"
+          },
+          "serviceType": {
+            "example": "openvpn",
+            "type": "string"
+          },
+          "sessionId": {
+            "example": "4cfb0324-daf6-4ad8-448b-e61fe0a1f918",
+            "type": "string"
+          },
+          "started": {
+            "example": "2020-03-01T12:00:00Z",
+            "type": "string"
+          }
+        },
+        "type": "object"
+      },
+      "SettleRequest": {
+        "description": "SettleRequest represents the request to settle accountant promises",
+        "properties": {
+          "accountant_id": {
+            "type": "string"
+          },
+          "provider_id": {
+            "type": "string"
+          }
+        },
+        "type": "object"
+      },
+      "Spending": {
+        "description": "Spending represents the amount consumer spent in the current day and month.",
+        "properties": {
+          "day": {
+            "format": "int64",
+            "minimum": 0,
+            "type": "integer"
+          },
+          "month": {
+            "format": "int64",
+            "minimum": 0,
+            "type": "integer"
+          }
+        },
+        "type": "object"
+      },
+      "StatusDTO": {
+        "properties": {
+          "balance": {
+            "format": "int64",
+            "minimum": 0,
+            "type": "integer"
+          },
+          "channel_address": {
+            "type": "string"
+          },
+          "is_registered": {
+            "type": "boolean"
+          }
+        },
+        "type": "object"
+      },
+      "TopUpRequestDTO": {
+        "description": "TopUpRequest represents the myst top up request",
+        "properties": {
+          "identity": {
+            "description": "Identity to top up with myst",
+            "type": "string"
+          }
+        },
+        "type": "object"
+      },
+      "TransactionDTO": {
+        "description": "transaction represents a single payment transaction recorded in the ledger",
+        "properties": {
+          "accountantId": {
+            "example": "0x0000000000000000000000000000000000000003",
+            "type": "string"
+          },
+          "amount": {
+            "description": "value of this transaction alone",
+            "example": 500000,
+            "format": "int64",
+            "minimum": 0,
+            "type": "integer"
+          },
+          "counterparty": {
+            "example": "0x0000000000000000000000000000000000000002",
+            "type": "string"
+          },
+          "country": {
+            "description": "country of the counterparty",
+            "example": "NL",
+            "type": "string"
+          },
+          "direction": {
+            "example": "outgoing",
+            "type": "string"
+          },
+          "fee": {
+            "example": 0,
+            "format": "int64",
+            "minimum": 0,
+            "type": "integer"
+          },
+          "hashlock": {
+            "type": "string"
+          },
+          "id": {
+            "example": 1,
+            "format": "int64",
+            "type": "integer"
+          },
+          "identity": {
+            "example": "0x0000000000000000000000000000000000000001",
+            "type": "string"
+          },
+          "kind": {
+            "example": "exchange_message",
+            "type": "string"
+          },
+          "serviceType": {
+            "example": "openvpn",
+            "type": "string"
+          },
+          "sessionId": {
+            "example": "4cfb0324-daf6-4ad8-448b-e61fe0a1f918",
+            "type": "string"
+          },
+          "time": {
+            "example": "2020-03-01T12:00:00Z",
+            "type": "string"
+          },
+          "total": {
+            "description": "cumulative amount stated by the transaction",
+            "example": 1500000,
+            "format": "int64",
+            "minimum": 0,
+            "type": "integer"
+          },
+          "txHash": {
+            "type": "string"
+          }
+        },
+        "type": "object"
+      },
+      "TransactionListDTO": {
+        "description": "transactionList defines payment transaction list representable as json",
+        "properties": {
+          "transactions": {
+            "items": {
+              "$ref": "#/components/schemas/TransactionDTO"
+            },
+            "type": "array"
+          }
+        },
+        "type": "object"
+      },
+      "TrialDTO": {
+        "properties": {
+          "bytes": {
+            "description": "data free of charge for new consumers, in bytes",
+            "example": 104857600,
+            "format": "int64",
+            "minimum": 0,
+            "type": "integer"
+          },
+          "durationSeconds": {
+            "description": "service duration free of charge for new consumers, in seconds",
+            "example": 600,
+            "format": "int64",
+            "minimum": 0,
+            "type": "integer"
+          }
+        },
+        "type": "object"
+      },
+      "UpdateUserRequest": {
+        "properties": {
+          "password": {
+            "description": "password is left unchanged if omitted",
+            "type": "string"
+          },
+          "role": {
+            "enum": [
+              "monitor",
+              "consumer",
+              "provider",
+              "admin"
+            ],
+            "type": "string"
+          }
+        },
+        "type": "object"
+      },
+      "UserDTO": {
+        "properties": {
+          "role": {
+            "enum": [
+              "monitor",
+              "consumer",
+              "provider",
+              "admin"
+            ],
+            "type": "string"
+          },
+          "username": {
+            "type": "string"
+          }
+        },
+        "type": "object"
+      },
+      "UserListDTO": {
+        "properties": {
+          "users": {
+            "items": {
+              "$ref": "#/components/schemas/UserDTO"
+            },
+            "type": "array"
+          }
+        },
+        "type": "object"
+      },
+      "ValidationErrorDTO": {
+        "properties": {
+          "errors": {
+            "additionalProperties": {
+              "items": {
+                "$ref": "#/components/schemas/FieldError"
+              },
+              "type": "array"
+            },
+            "type": "object"
+          },
+          "message": {
+            "example": "error message",
+            "type": "string"
+          }
+        },
+        "type": "object"
+      },
+      "accessPolicy": {
+        "properties": {
+          "allow": {
+            "items": {
+              "$ref": "#/components/schemas/accessRule"
+            },
+            "type": "array"
+          },
+          "description": {
+            "type": "string"
+          },
+          "id": {
+            "type": "string"
+          },
+          "title": {
+            "type": "string"
+          }
+        },
+        "type": "object"
+      },
+      "accessRule": {
+        "properties": {
+          "type": {
+            "type": "string"
+          },
+          "value": {
+            "type": "string"
+          }
+        },
+        "type": "object"
+      },
+      "configPayload": {
+        "properties": {
+          "data": {
+            "additionalProperties": {},
+            "example": {
+              "data": {
+                "access-policy": {
+                  "list": "mysterium"
+                },
+                "openvpn": {
+                  "port": 5522
+                }
+              }
+            },
+            "type": "object"
+          }
+        },
+        "type": "object"
+      },
+      "metricsRes": {
+        "properties": {
+          "connectCount": {
+            "$ref": "#/components/schemas/ConnectCount"
+          }
+        },
+        "type": "object"
+      },
+      "sessionConnectivityStatus": {
+        "properties": {
+          "code": {
+            "format": "int64",
+            "minimum": 0,
+            "type": "integer"
+          },
+          "created_at_utc": {
+            "format": "date-time",
+            "type": "string"
+          },
+          "message": {
+            "type": "string"
+          },
+          "peer_address": {
+            "type": "string"
+          },
+          "session_id": {
+            "type": "string"
+          }
+        },
+        "type": "object"
+      }
+    },
+    "securitySchemes": {
+      "bearerAuth": {
+        "scheme": "bearer",
+        "type": "http"
+      },
+      "cookieAuth": {
+        "in": "cookie",
+        "name": "token",
+        "type": "apiKey"
+      }
+    }
+  },
+  "info": {
+    "description": "The purpose of this documentation is to provide developers an insight of how to interact with Mysterium Node via Tequila API. This should demonstrate all the possible API calls with described parameters and responses.",
+    "title": "Tequila API",
+    "version": "dev"
+  },
+  "openapi": "3.0.3",
+  "paths": {
+    "/access-policies": {
+      "get": {
+        "description": "Returns list of access policies",
+        "operationId": "AccessPolicies",
+        "responses": {
+          "200": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/AccessPolicies"
+                }
+              }
+            },
+            "description": "List of access policies"
+          },
+          "500": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorMessageDTO"
+                }
+              }
+            },
+            "description": "Internal server error"
+          }
+        },
+        "summary": "Returns access policies"
+      }
+    },
+    "/audit": {
+      "get": {
+        "description": "Returns API requests which may have changed the node state, latest first",
+        "operationId": "audit",
+        "parameters": [
+          {
+            "description": "User who made the requests",
+            "in": "query",
+            "name": "username",
+            "schema": {
+              "type": "string"
+            }
+          },
+          {
+            "description": "HTTP method of the requests",
+            "in": "query",
+            "name": "method",
+            "schema": {
+              "type": "string"
+            }
+          },
+          {
+            "description": "Include requests to paths starting with the given prefix",
+            "in": "query",
+            "name": "path",
+            "schema": {
+              "type": "string"
+            }
+          },
+          {
+            "description": "Include requests made at or after the given RFC3339 time",
+            "in": "query",
+            "name": "from",
+            "schema": {
+              "type": "string"
+            }
+          },
+          {
+            "description": "Include requests made before the given RFC3339 time",
+            "in": "query",
+            "name": "to",
+            "schema": {
+              "type": "string"
+            }
+          },
+          {
+            "description": "Maximum number of records to return, 100 by default",
+            "in": "query",
+            "name": "limit",
+            "schema": {
+              "type": "integer"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/AuditRecordListDTO"
+                }
+              }
+            },
+            "description": "List of audit records"
+          },
+          "422": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ValidationErrorDTO"
+                }
+              }
+            },
+            "description": "Parameters validation error"
+          },
+          "500": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorMessageDTO"
+                }
+              }
+            },
+            "description": "Internal server error"
+          }
+        },
+        "summary": "Returns audit log",
+        "tags": [
+          "Audit"
+        ]
+      }
+    },
+    "/auth/login": {
+      "post": {
+        "description": "Checks user credentials and sets JWT session cookie",
+        "operationId": "Login",
+        "requestBody": {
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/LoginRequest"
+              }
+            }
+          }
+        },
+        "responses": {
+          "200": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/UserDTO"
+                }
+              }
+            },
+            "description": "Logged in successfully"
+          },
+          "400": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorMessageDTO"
+                }
+              }
+            },
+            "description": "Body parsing error"
+          },
+          "401": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorMessageDTO"
+                }
+              }
+            },
+            "description": "Unauthorized"
+          }
+        },
+        "summary": "Login",
+        "tags": [
+          "Authentication"
+        ]
+      }
+    },
+    "/auth/password": {
+      "put": {
+        "description": "Changes user password, session tokens issued with the previous password are revoked",
+        "operationId": "changePassword",
+        "requestBody": {
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/ChangePasswordRequest"
+              }
+            }
+          }
+        },
+        "responses": {
+          "200": {
+            "description": "Password changed successfully"
+          },
+          "400": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorMessageDTO"
+                }
+              }
+            },
+            "description": "Body parsing error"
+          },
+          "401": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorMessageDTO"
+                }
+              }
+            },
+            "description": "Unauthorized"
+          }
+        },
+        "summary": "Change password",
+        "tags": [
+          "Authentication"
+        ]
+      }
+    },
+    "/auth/tokens": {
+      "get": {
+        "description": "Returns API tokens of the user, admins get the tokens of all users",
+        "operationId": "listAPITokens",
+        "responses": {
+          "200": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/APITokenListDTO"
+                }
+              }
+            },
+            "description": "List of API tokens"
+          },
+          "500": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorMessageDTO"
+                }
+              }
+            },
+            "description": "Internal server error"
+          }
+        },
+        "summary": "Returns API tokens",
+        "tags": [
+          "Authentication"
+        ]
+      },
+      "post": {
+        "description": "Creates long-lived API token, which is accepted as a bearer authorization header",
+        "operationId": "createAPIToken",
+        "requestBody": {
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/CreateAPITokenRequest"
+              }
+            }
+          }
+        },
+        "responses": {
+          "200": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/CreatedAPITokenDTO"
+                }
+              }
+            },
+            "description": "API token created"
+          },
+          "400": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorMessageDTO"
+                }
+              }
+            },
+            "description": "Body parsing error"
+          },
+          "401": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorMessageDTO"
+                }
+              }
+            },
+            "description": "Authentication required"
+          },
+          "403": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorMessageDTO"
+                }
+              }
+            },
+            "description": "Scope exceeds the role of user or the user has no username, e.g. when calling via unix socket"
+          },
+          "422": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ValidationErrorDTO"
+                }
+              }
+            },
+            "description": "Parameters validation error"
+          },
+          "500": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorMessageDTO"
+                }
+              }
+            },
+            "description": "Internal server error"
+          }
+        },
+        "summary": "Creates API token",
+        "tags": [
+          "Authentication"
+        ]
+      }
+    },
+    "/auth/tokens/{id}": {
+      "delete": {
+        "description": "Revokes API token, so that it is not accepted anymore",
+        "operationId": "revokeAPIToken",
+        "parameters": [
+          {
+            "in": "path",
+            "name": "id",
+            "required": true,
+            "schema": {
+              "type": "string"
+            }
+          }
+        ],
+        "responses": {
+          "202": {
+            "description": "API token revoked"
+          },
+          "404": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorMessageDTO"
+                }
+              }
+            },
+            "description": "API token not found"
+          },
+          "500": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorMessageDTO"
+                }
+              }
+            },
+            "description": "Internal server error"
+          }
+        },
+        "summary": "Revokes API token",
+        "tags": [
+          "Authentication"
+        ]
+      }
+    },
+    "/auth/users": {
+      "get": {
+        "description": "Returns users allowed to access Tequilapi and their roles",
+        "operationId": "listUsers",
+        "responses": {
+          "200": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/UserListDTO"
+                }
+              }
+            },
+            "description": "List of users"
+          },
+          "500": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorMessageDTO"
+                }
+              }
+            },
+            "description": "Internal server error"
+          }
+        },
+        "summary": "Returns users",
+        "tags": [
+          "Authentication"
+        ]
+      },
+      "post": {
+        "description": "Creates user allowed to access Tequilapi with the given role",
+        "operationId": "createUser",
+        "requestBody": {
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/CreateUserRequest"
+              }
+            }
+          }
+        },
+        "responses": {
+          "200": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/UserDTO"
+                }
+              }
+            },
+            "description": "User created"
+          },
+          "400": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorMessageDTO"
+                }
+              }
+            },
+            "description": "Body parsing error"
+          },
+          "409": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorMessageDTO"
+                }
+              }
+            },
+            "description": "User already exists"
+          },
+          "422": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ValidationErrorDTO"
+                }
+              }
+            },
+            "description": "Parameters validation error"
+          },
+          "500": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorMessageDTO"
+                }
+              }
+            },
+            "description": "Internal server error"
+          }
+        },
+        "summary": "Creates user",
+        "tags": [
+          "Authentication"
+        ]
+      }
+    },
+    "/auth/users/{username}": {
+      "delete": {
+        "description": "Deletes user, so that it can't access Tequilapi anymore",
+        "operationId": "deleteUser",
+        "parameters": [
+          {
+            "in": "path",
+            "name": "username",
+            "required": true,
+            "schema": {
+              "type": "string"
+            }
+          }
+        ],
+        "responses": {
+          "202": {
+            "description": "User deleted"
+          },
+          "404": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorMessageDTO"
+                }
+              }
+            },
+            "description": "User not found"
+          },
+          "409": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorMessageDTO"
+                }
+              }
+            },
+            "description": "The only admin can't be deleted"
+          },
+          "500": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorMessageDTO"
+                }
+              }
+            },
+            "description": "Internal server error"
+          }
+        },
+        "summary": "Deletes user",
+        "tags": [
+          "Authentication"
+        ]
+      },
+      "put": {
+        "description": "Changes the role of user and the password if it is given",
+        "operationId": "updateUser",
+        "parameters": [
+          {
+            "in": "path",
+            "name": "username",
+            "required": true,
+            "schema": {
+              "type": "string"
+            }
+          }
+        ],
+        "requestBody": {
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/UpdateUserRequest"
+              }
+            }
+          }
+        },
+        "responses": {
+          "200": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/UserDTO"
+                }
+              }
+            },
+            "description": "User updated"
+          },
+          "400": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorMessageDTO"
+                }
+              }
+            },
+            "description": "Body parsing error"
+          },
+          "404": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorMessageDTO"
+                }
+              }
+            },
+            "description": "User not found"
+          },
+          "409": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorMessageDTO"
+                }
+              }
+            },
+            "description": "The only admin can't be demoted"
+          },
+          "422": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ValidationErrorDTO"
+                }
+              }
+            },
+            "description": "Parameters validation error"
+          },
+          "500": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorMessageDTO"
+                }
+              }
+            },
+            "description": "Internal server error"
+          }
+        },
+        "summary": "Updates user",
+        "tags": [
+          "Authentication"
+        ]
+      }
+    },
+    "/config/user": {
+      "get": {
+        "description": "Returns current user configuration",
+        "operationId": "getUserConfig",
+        "responses": {
+          "200": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/configPayload"
+                }
+              }
+            },
+            "description": "User configuration"
+          },
+          "500": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorMessageDTO"
+                }
+              }
+            },
+            "description": "Internal server error"
+          }
+        },
+        "summary": "Returns current user configuration",
+        "tags": [
+          "Configuration"
+        ]
+      },
+      "post": {
+        "description": "For keys present in the payload, it will set or remove the user config values (if the key is null). Changes are persisted to the config file.",
+        "operationId": "serUserConfig",
+        "requestBody": {
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/configPayload"
+              }
+            }
+          },
+          "description": "configuration keys/values"
+        },
+        "responses": {
+          "200": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/configPayload"
+                }
+              }
+            },
+            "description": "User configuration"
+          },
+          "500": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorMessageDTO"
+                }
+              }
+            },
+            "description": "Internal server error"
+          }
+        },
+        "summary": "Sets and returns user configuration",
+        "tags": [
+          "Configuration"
+        ]
+      }
+    },
+    "/connection": {
+      "delete": {
+        "description": "Stops current connection",
+        "operationId": "connectionCancel",
+        "responses": {
+          "202": {
+            "description": "Connection Stopped"
+          },
+          "409": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorMessageDTO"
+                }
+              }
+            },
+            "description": "Conflict. No connection exists"
+          },
+          "500": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorMessageDTO"
+                }
+              }
+            },
+            "description": "Internal server error"
+          }
+        },
+        "summary": "Stops connection",
+        "tags": [
+          "Connection"
+        ]
+      },
+      "get": {
+        "description": "Returns status of current connection",
+        "operationId": "connectionStatus",
+        "responses": {
+          "200": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ConnectionStatusDTO"
+                }
+              }
+            },
+            "description": "Status"
+          },
+          "500": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorMessageDTO"
+                }
+              }
+            },
+            "description": "Internal server error"
+          }
+        },
+        "summary": "Returns connection status",
+        "tags": [
+          "Connection"
+        ]
+      },
+      "put": {
+        "description": "Consumer opens connection to provider",
+        "operationId": "connectionCreate",
+        "requestBody": {
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/ConnectionRequestDTO"
+              }
+            }
+          },
+          "description": "Parameters in body (consumerId, providerId, serviceType) required for creating new connection"
+        },
+        "responses": {
+          "201": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ConnectionStatusDTO"
+                }
+              }
+            },
+            "description": "Connection started"
+          },
+          "400": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorMessageDTO"
+                }
+              }
+            },
+            "description": "Bad request"
+          },
+          "402": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorMessageDTO"
+                }
+              }
+            },
+            "description": "Consumer spending limit reached"
+          },
+          "409": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorMessageDTO"
+                }
+              }
+            },
+            "description": "Conflict. Connection already exists"
+          },
+          "422": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ValidationErrorDTO"
+                }
+              }
+            },
+            "description": "Parameters validation error"
+          },
+          "499": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorMessageDTO"
+                }
+              }
+            },
+            "description": "Connection was cancelled"
+          },
+          "500": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorMessageDTO"
+                }
+              }
+            },
+            "description": "Internal server error"
+          }
+        },
+        "summary": "Starts new connection",
+        "tags": [
+          "Connection"
+        ]
+      }
+    },
+    "/connection-sessions": {
+      "get": {
+        "description": "Returns list of sessions history",
+        "operationId": "connectionSessions",
+        "responses": {
+          "200": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ConnectionSessionListDTO"
+                }
+              }
+            },
+            "description": "List of sessions"
+          },
+          "500": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorMessageDTO"
+                }
+              }
+            },
+            "description": "Internal server error"
+          }
+        },
+        "summary": "Returns sessions history",
+        "tags": [
+          "Connection"
+        ]
+      }
+    },
+    "/connection/ip": {
+      "get": {
+        "description": "Returns current public IP address",
+        "operationId": "getConnectionIP",
+        "responses": {
+          "200": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/IPDTO"
+                }
+              }
+            },
+            "description": "Public IP address"
+          },
+          "500": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorMessageDTO"
+                }
+              }
+            },
+            "description": "Internal server error"
+          },
+          "503": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorMessageDTO"
+                }
+              }
+            },
+            "description": "Service unavailable"
+          }
+        },
+        "summary": "Returns IP address",
+        "tags": [
+          "Connection"
+        ]
+      }
+    },
+    "/connection/location": {
+      "get": {
+        "description": "Returns connection locations",
+        "operationId": "getConnectionLocation",
+        "responses": {
+          "200": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/LocationDTO"
+                }
+              }
+            },
+            "description": "Connection locations"
+          },
+          "503": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorMessageDTO"
+                }
+              }
+            },
+            "description": "Service unavailable"
+          }
+        },
+        "summary": "Returns connection location",
+        "tags": [
+          "Connection"
+        ]
+      }
+    },
+    "/connection/statistics": {
+      "get": {
+        "description": "Returns statistics about current connection",
+        "operationId": "connectionStatistics",
+        "responses": {
+          "200": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ConnectionStatisticsDTO"
+                }
+              }
+            },
+            "description": "Connection statistics"
+          },
+          "500": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorMessageDTO"
+                }
+              }
+            },
+            "description": "Internal server error"
+          }
+        },
+        "summary": "Returns connection statistics",
+        "tags": [
+          "Connection"
+        ]
+      }
+    },
+    "/events/state": {
+      "get": {
+        "description": "Streams node state changes as server-sent events",
+        "operationId": "stateEvents",
+        "responses": {
+          "200": {
+            "content": {
+              "text/event-stream": {
+                "schema": {
+                  "type": "string"
+                }
+              }
+            },
+            "description": "Stream of state events"
+          }
+        },
+        "summary": "Subscribes to node state events",
+        "tags": [
+          "Events"
+        ]
+      }
+    },
+    "/events/ws": {
+      "get": {
+        "description": "Upgrades the connection to websocket streaming node state, connection statistics, session and balance events\nas JSON messages {\"type\": \"...\", \"payload\": {...}}.\nClient may send commands {\"id\": \"1\", \"command\": \"...\", \"params\": {...}}, answered by messages of type \"response\"\nwith the same id, HTTP status and payload.\nCommand \"subscribe\" with params {\"events\": [...]} limits the streamed event types, all of them are streamed by default.\nCommands \"connect\", \"disconnect\", \"start-service\" and \"stop-service\" (params {\"id\": \"...\"}) are executed the same\nas PUT /connection, DELETE /connection, POST /services and DELETE /services/{id}, params being the request body.\n",
+        "operationId": "websocketEvents",
+        "parameters": [
+          {
+            "description": "Comma separated event types to stream initially",
+            "in": "query",
+            "name": "events",
+            "schema": {
+              "type": "string"
+            }
+          }
+        ],
+        "responses": {
+          "101": {
+            "description": "Switched to websocket protocol"
+          },
+          "400": {
+            "description": "Not a websocket request"
+          }
+        },
+        "summary": "Subscribes to node events via websocket",
+        "tags": [
+          "Events"
+        ]
+      }
+    },
+    "/feedback/issue": {
+      "post": {
+        "description": "Reports user issue",
+        "operationId": "reportIssue",
+        "requestBody": {
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/ReportIssueRequest"
+              }
+            }
+          },
+          "description": "Report issue request"
+        },
+        "responses": {
+          "200": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ReportIssueSuccess"
+                }
+              }
+            },
+            "description": "Issue reported"
+          },
+          "400": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ReportIssueError"
+                }
+              }
+            },
+            "description": "Bad request"
+          },
+          "429": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ReportIssueError"
+                }
+              }
+            },
+            "description": "Too many requests (max. 1/minute)"
+          },
+          "500": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ReportIssueError"
+                }
+              }
+            },
+            "description": "Internal server error"
+          }
+        },
+        "summary": "Reports user issue",
+        "tags": [
+          "Feedback"
+        ]
+      }
+    },
+    "/healthcheck": {
+      "get": {
+        "description": "Returns health check information about client",
+        "operationId": "healthCheck",
+        "responses": {
+          "200": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/HealthCheckDTO"
+                }
+              }
+            },
+            "description": "Health check information"
+          },
+          "500": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorMessageDTO"
+                }
+              }
+            },
+            "description": "Internal server error"
+          }
+        },
+        "summary": "Returns information about client",
+        "tags": [
+          "Client"
+        ]
+      }
+    },
+    "/identities": {
+      "get": {
+        "description": "Returns list of identities",
+        "operationId": "listIdentities",
+        "responses": {
+          "200": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/IdentityList"
+                }
+              }
+            },
+            "description": "List of identities"
+          },
+          "500": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorMessageDTO"
+                }
+              }
+            },
+            "description": "Internal server error"
+          }
+        },
+        "summary": "Returns identities",
+        "tags": [
+          "Identity"
+        ]
+      },
+      "post": {
+        "description": "Creates identity and stores in keystore encrypted with passphrase",
+        "operationId": "createIdentity",
+        "requestBody": {
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/IdentityCreationDTO"
+              }
+            }
+          },
+          "description": "Parameter in body (passphrase) required for creating new identity"
+        },
+        "responses": {
+          "200": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/IdentityDTO"
+                }
+              }
+            },
+            "description": "Identity created"
+          },
+          "400": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorMessageDTO"
+                }
+              }
+            },
+            "description": "Bad Request"
+          },
+          "422": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ValidationErrorDTO"
+                }
+              }
+            },
+            "description": "Parameters validation error"
+          },
+          "500": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorMessageDTO"
+                }
+              }
+            },
+            "description": "Internal server error"
+          }
+        },
+        "summary": "Creates new identity",
+        "tags": [
+          "Identity"
+        ]
+      }
+    },
+    "/identities/import": {
+      "post": {
+        "description": "Stores identity exported as JSON keystore blob in keystore and restores its registration status",
+        "operationId": "importIdentity",
+        "requestBody": {
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/IdentityImportDTO"
+              }
+            }
+          },
+          "description": "Parameters in body (blob, passphrase, newPassphrase) required for importing identity"
+        },
+        "responses": {
+          "200": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/IdentityDTO"
+                }
+              }
+            },
+            "description": "Identity imported"
+          },
+          "400": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorMessageDTO"
+                }
+              }
+            },
+            "description": "Body parsing error"
+          },
+          "403": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorMessageDTO"
+                }
+              }
+            },
+            "description": "Forbidden"
+          },
+          "409": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorMessageDTO"
+                }
+              }
+            },
+            "description": "Identity already exists"
+          },
+          "422": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ValidationErrorDTO"
+                }
+              }
+            },
+            "description": "Parameters validation error"
+          },
+          "500": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorMessageDTO"
+                }
+              }
+            },
+            "description": "Internal server error"
+          }
+        },
+        "summary": "Imports identity",
+        "tags": [
+          "Identity"
+        ]
+      }
+    },
+    "/identities/{id}": {
+      "put": {
+        "description": "Tries to retrieve the last used identity, the first identity, or creates and returns a new identity",
+        "operationId": "currentIdentity",
+        "parameters": [
+          {
+            "description": "Identity to use, or \"current\" to use the last used identity",
+            "in": "path",
+            "name": "id",
+            "required": true,
+            "schema": {
+              "type": "string"
+            }
+          }
+        ],
+        "requestBody": {
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/CurrentIdentityDTO"
+              }
+            }
+          },
+          "description": "Parameter in body (passphrase) required for creating new identity"
+        },
+        "responses": {
+          "200": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/IdentityDTO"
+                }
+              }
+            },
+            "description": "Unlocked identity returned"
+          },
+          "400": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorMessageDTO"
+                }
+              }
+            },
+            "description": "Bad Request"
+          },
+          "422": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ValidationErrorDTO"
+                }
+              }
+            },
+            "description": "Parameters validation error"
+          },
+          "500": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorMessageDTO"
+                }
+              }
+            },
+            "description": "Internal server error"
+          }
+        },
+        "summary": "Returns my current identity",
+        "tags": [
+          "Identity"
+        ]
+      }
+    },
+    "/identities/{id}/budget": {
+      "get": {
+        "description": "Returns spending limits of the consumer identity and the amount spent in the current day and month",
+        "operationId": "Budget",
+        "parameters": [
+          {
+            "description": "Identity stored in keystore",
+            "in": "path",
+            "name": "id",
+            "required": true,
+            "schema": {
+              "type": "string"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/BudgetDTO"
+                }
+              }
+            },
+            "description": "Consumer budget"
+          },
+          "500": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorMessageDTO"
+                }
+              }
+            },
+            "description": "Internal server error"
+          }
+        },
+        "summary": "Returns consumer budget"
+      },
+      "put": {
+        "description": "Replaces spending limits of the consumer identity. Once a limit is reached, the ongoing session is disconnected and new sessions are refused.",
+        "operationId": "BudgetSet",
+        "parameters": [
+          {
+            "description": "Identity stored in keystore",
+            "in": "path",
+            "name": "id",
+            "required": true,
+            "schema": {
+              "type": "string"
+            }
+          }
+        ],
+        "requestBody": {
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/BudgetRequestDTO"
+              }
+            }
+          },
+          "description": "Spending limits"
+        },
+        "responses": {
+          "200": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/BudgetDTO"
+                }
+              }
+            },
+            "description": "Consumer budget"
+          },
+          "400": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorMessageDTO"
+                }
+              }
+            },
+            "description": "Bad request"
+          },
+          "422": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ValidationErrorDTO"
+                }
+              }
+            },
+            "description": "Parameters validation error"
+          },
+          "500": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorMessageDTO"
+                }
+              }
+            },
+            "description": "Internal server error"
+          }
+        },
+        "summary": "Sets consumer budget"
+      }
+    },
+    "/identities/{id}/channel": {
+      "get": {
+        "description": "Returns the on-chain channel address and balance, settled and promised amounts, the last accountant promise and the registration status of the identity",
+        "operationId": "Channel",
+        "parameters": [
+          {
+            "description": "Identity",
+            "in": "path",
+            "name": "id",
+            "required": true,
+            "schema": {
+              "type": "string"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ChannelDTO"
+                }
+              }
+            },
+            "description": "Payment channel state"
+          },
+          "500": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorMessageDTO"
+                }
+              }
+            },
+            "description": "Internal server error"
+          }
+        },
+        "summary": "Returns payment channel state"
+      }
+    },
+    "/identities/{id}/earnings": {
+      "get": {
+        "description": "Returns payments received by the provider grouped by period, service type and consumer country, together with settled and unsettled amounts and projected settlement fees",
+        "operationId": "Earnings",
+        "parameters": [
+          {
+            "description": "Provider identity",
+            "in": "path",
+            "name": "id",
+            "required": true,
+            "schema": {
+              "type": "string"
+            }
+          },
+          {
+            "description": "Period to group earnings by, one of day (default), week, month",
+            "in": "query",
+            "name": "groupBy",
+            "schema": {
+              "type": "string"
+            }
+          },
+          {
+            "description": "Include payments received at or after the given RFC3339 time",
+            "in": "query",
+            "name": "from",
+            "schema": {
+              "type": "string"
+            }
+          },
+          {
+            "description": "Include payments received before the given RFC3339 time",
+            "in": "query",
+            "name": "to",
+            "schema": {
+              "type": "string"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/EarningsDTO"
+                }
+              }
+            },
+            "description": "Provider earnings"
+          },
+          "422": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ValidationErrorDTO"
+                }
+              }
+            },
+            "description": "Parameters validation error"
+          },
+          "500": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorMessageDTO"
+                }
+              }
+            },
+            "description": "Internal server error"
+          }
+        },
+        "summary": "Returns provider earnings"
+      }
+    },
+    "/identities/{id}/email": {
+      "put": {
+        "description": "Registers email for identity",
+        "operationId": "updateEmail",
+        "parameters": [
+          {
+            "description": "Identity stored in keystore",
+            "in": "path",
+            "name": "id",
+            "required": true,
+            "schema": {
+              "type": "string"
+            }
+          }
+        ],
+        "requestBody": {
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/EmailInfoDTO"
+              }
+            }
+          },
+          "description": "Parameter in body (email) is required"
+        },
+        "responses": {
+          "200": {
+            "description": "Email updated"
+          },
+          "400": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorMessageDTO"
+                }
+              }
+            },
+            "description": "Bad request"
+          },
+          "500": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorMessageDTO"
+                }
+              }
+            },
+            "description": "Internal server error"
+          }
+        },
+        "summary": "Registers emailpayout_test.go",
+        "tags": [
+          "Identity"
+        ]
+      }
+    },
+    "/identities/{id}/export": {
+      "post": {
+        "description": "Returns identity stored in keystore as JSON keystore blob encrypted with export passphrase",
+        "operationId": "exportIdentity",
+        "parameters": [
+          {
+            "description": "Identity stored in keystore",
+            "in": "path",
+            "name": "id",
+            "required": true,
+            "schema": {
+              "type": "string"
+            }
+          }
+        ],
+        "requestBody": {
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/IdentityExportDTO"
+              }
+            }
+          },
+          "description": "Parameters in body (passphrase, exportPassphrase) required for exporting identity"
+        },
+        "responses": {
+          "200": {
+            "description": "Exported identity as JSON keystore blob"
+          },
+          "400": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorMessageDTO"
+                }
+              }
+            },
+            "description": "Body parsing error"
+          },
+          "403": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorMessageDTO"
+                }
+              }
+            },
+            "description": "Forbidden"
+          },
+          "404": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorMessageDTO"
+                }
+              }
+            },
+            "description": "Identity not found"
+          },
+          "422": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ValidationErrorDTO"
+                }
+              }
+            },
+            "description": "Parameters validation error"
+          },
+          "500": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorMessageDTO"
+                }
+              }
+            },
+            "description": "Internal server error"
+          }
+        },
+        "summary": "Exports identity",
+        "tags": [
+          "Identity"
+        ]
+      }
+    },
+    "/identities/{id}/passphrase": {
+      "put": {
+        "description": "Re-encrypts identity stored in keystore with new passphrase",
+        "operationId": "changeIdentityPassphrase",
+        "parameters": [
+          {
+            "description": "Identity stored in keystore",
+            "in": "path",
+            "name": "id",
+            "required": true,
+            "schema": {
+              "type": "string"
+            }
+          }
+        ],
+        "requestBody": {
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/IdentityPassphraseChangeDTO"
+              }
+            }
+          },
+          "description": "Parameters in body (passphrase, newPassphrase) required for changing identity passphrase"
+        },
+        "responses": {
+          "202": {
+            "description": "Identity passphrase changed"
+          },
+          "400": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorMessageDTO"
+                }
+              }
+            },
+            "description": "Body parsing error"
+          },
+          "403": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorMessageDTO"
+                }
+              }
+            },
+            "description": "Forbidden"
+          },
+          "404": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorMessageDTO"
+                }
+              }
+            },
+            "description": "Identity not found"
+          },
+          "422": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ValidationErrorDTO"
+                }
+              }
+            },
+            "description": "Parameters validation error"
+          },
+          "500": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorMessageDTO"
+                }
+              }
+            },
+            "description": "Internal server error"
+          },
+          "501": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorMessageDTO"
+                }
+              }
+            },
+            "description": "Not supported by keystore"
+          }
+        },
+        "summary": "Changes identity passphrase",
+        "tags": [
+          "Identity"
+        ]
+      }
+    },
+    "/identities/{id}/payout": {
+      "get": {
+        "description": "Returns payout address, referral code and email registered for identity",
+        "operationId": "getPayoutInfo",
+        "parameters": [
+          {
+            "description": "Identity stored in keystore",
+            "in": "path",
+            "name": "id",
+            "required": true,
+            "schema": {
+              "type": "string"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/PayoutInfoResponseDTO"
+                }
+              }
+            },
+            "description": "Payout info"
+          },
+          "404": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorMessageDTO"
+                }
+              }
+            },
+            "description": "Payout info not found"
+          }
+        },
+        "summary": "Returns payout info",
+        "tags": [
+          "Identity"
+        ]
+      },
+      "put": {
+        "description": "Registers payout address for identity",
+        "operationId": "updatePayoutInfo",
+        "parameters": [
+          {
+            "description": "Identity stored in keystore",
+            "in": "path",
+            "name": "id",
+            "required": true,
+            "schema": {
+              "type": "string"
+            }
+          }
+        ],
+        "requestBody": {
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/PayoutInfoDTO"
+              }
+            }
+          },
+          "description": "Parameter in body (ethAddress) is required"
+        },
+        "responses": {
+          "200": {
+            "description": "Payout info registered"
+          },
+          "400": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorMessageDTO"
+                }
+              }
+            },
+            "description": "Bad request"
+          },
+          "422": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ValidationErrorDTO"
+                }
+              }
+            },
+            "description": "Parameters validation error"
+          },
+          "500": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorMessageDTO"
+                }
+              }
+            },
+            "description": "Internal server error"
+          }
+        },
+        "summary": "Registers payout info",
+        "tags": [
+          "Identity"
+        ]
+      }
+    },
+    "/identities/{id}/referral": {
+      "put": {
+        "description": "Registers referral code for identity",
+        "operationId": "updateReferralInfo",
+        "parameters": [
+          {
+            "description": "Identity stored in keystore",
+            "in": "path",
+            "name": "id",
+            "required": true,
+            "schema": {
+              "type": "string"
+            }
+          }
+        ],
+        "requestBody": {
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/ReferralInfoDTO"
+              }
+            }
+          },
+          "description": "Parameter in body (referral_code) is required"
+        },
+        "responses": {
+          "200": {
+            "description": "Referral info registered"
+          },
+          "400": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorMessageDTO"
+                }
+              }
+            },
+            "description": "Bad request"
+          },
+          "500": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorMessageDTO"
+                }
+              }
+            },
+            "description": "Internal server error"
+          }
+        },
+        "summary": "Registers referral info",
+        "tags": [
+          "Identity"
+        ]
+      }
+    },
+    "/identities/{id}/register": {
+      "post": {
+        "description": "Registers identity on Mysterium Network smart contracts using Transactor",
+        "operationId": "RegisterIdentity",
+        "parameters": [
+          {
+            "description": "Identity address to register",
+            "in": "path",
+            "name": "id",
+            "required": true,
+            "schema": {
+              "type": "string"
+            }
+          }
+        ],
+        "requestBody": {
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/IdentityRegistrationRequestDTO"
+              }
+            }
+          },
+          "description": "all body parameters a optional"
+        },
+        "responses": {
+          "200": {
+            "description": "Payout info registered"
+          },
+          "400": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorMessageDTO"
+                }
+              }
+            },
+            "description": "Bad request"
+          },
+          "500": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorMessageDTO"
+                }
+              }
+            },
+            "description": "Internal server error"
+          }
+        },
+        "summary": "Registers identity",
+        "tags": [
+          "Identity"
+        ]
+      }
+    },
+    "/identities/{id}/registration": {
+      "get": {
+        "description": "Provides registration status for given identity, if identity is not registered - provides additional data required for identity registration",
+        "operationId": "identityRegistration",
+        "parameters": [
+          {
+            "description": "hex address of identity",
+            "in": "path",
+            "name": "id",
+            "required": true,
+            "schema": {
+              "type": "string"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/RegistrationDataDTO"
+                }
+              }
+            },
+            "description": "Registration status and data"
+          },
+          "500": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorMessageDTO"
+                }
+              }
+            },
+            "description": "Internal server error"
+          }
+        },
+        "summary": "Provide identity registration status",
+        "tags": [
+          "Identity"
+        ]
+      }
+    },
+    "/identities/{id}/status": {
+      "get": {
+        "description": "Returns channel address, registration status and balance of identity",
+        "operationId": "identityStatus",
+        "parameters": [
+          {
+            "description": "Identity stored in keystore, or \"current\" for the last used identity",
+            "in": "path",
+            "name": "id",
+            "required": true,
+            "schema": {
+              "type": "string"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/StatusDTO"
+                }
+              }
+            },
+            "description": "Identity status"
+          },
+          "500": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorMessageDTO"
+                }
+              }
+            },
+            "description": "Internal server error"
+          }
+        },
+        "summary": "Returns identity status",
+        "tags": [
+          "Identity"
+        ]
+      }
+    },
+    "/identities/{id}/unlock": {
+      "put": {
+        "description": "Uses passphrase to decrypt identity stored in keystore",
+        "operationId": "unlockIdentity",
+        "parameters": [
+          {
+            "description": "Identity stored in keystore",
+            "in": "path",
+            "name": "id",
+            "required": true,
+            "schema": {
+              "type": "string"
+            }
+          }
+        ],
+        "requestBody": {
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/IdentityUnlockingDTO"
+              }
+            }
+          },
+          "description": "Parameter in body (passphrase) required for unlocking identity"
+        },
+        "responses": {
+          "202": {
+            "description": "Identity unlocked"
+          },
+          "400": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorMessageDTO"
+                }
+              }
+            },
+            "description": "Body parsing error"
+          },
+          "403": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorMessageDTO"
+                }
+              }
+            },
+            "description": "Forbidden"
+          },
+          "500": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorMessageDTO"
+                }
+              }
+            },
+            "description": "Internal server error"
+          }
+        },
+        "summary": "Unlocks identity",
+        "tags": [
+          "Identity"
+        ]
+      }
+    },
+    "/location": {
+      "get": {
+        "description": "Returns original locations",
+        "operationId": "getOriginLocation",
+        "responses": {
+          "200": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/LocationDTO"
+                }
+              }
+            },
+            "description": "Original locations"
+          },
+          "503": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorMessageDTO"
+                }
+              }
+            },
+            "description": "Service unavailable"
+          }
+        },
+        "summary": "Returns original location",
+        "tags": [
+          "Location"
+        ]
+      }
+    },
+    "/metrics": {
+      "get": {
+        "description": "Returns node metrics in Prometheus text exposition format",
+        "operationId": "metrics",
+        "responses": {
+          "200": {
+            "content": {
+              "text/plain": {
+                "schema": {
+                  "type": "string"
+                }
+              }
+            },
+            "description": "Node metrics"
+          },
+          "500": {
+            "content": {
+              "text/plain": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorMessageDTO"
+                }
+              }
+            },
+            "description": "Internal server error"
+          }
+        },
+        "summary": "Returns node metrics",
+        "tags": [
+          "Client"
+        ]
+      }
+    },
+    "/nat/status": {
+      "get": {
+        "description": "NAT status returns the last known NAT traversal status",
+        "operationId": "NATStatusDTO",
+        "responses": {
+          "200": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/NATStatusDTO"
+                }
+              }
+            },
+            "description": "NAT status (\"not_finished\"/\"successful\"/\"failed\") and optionally error if status is \"failed\""
+          }
+        },
+        "summary": "Shows NAT status",
+        "tags": [
+          "NAT"
+        ]
+      }
+    },
+    "/openapi.json": {
+      "get": {
+        "description": "Returns OpenAPI 3 document describing all Tequilapi endpoints and models",
+        "operationId": "openAPISpec",
+        "responses": {
+          "200": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "type": "object"
+                }
+              }
+            },
+            "description": "OpenAPI document"
+          },
+          "500": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorMessageDTO"
+                }
+              }
+            },
+            "description": "Internal server error"
+          }
+        },
+        "summary": "Returns OpenAPI document",
+        "tags": [
+          "Client"
+        ]
+      }
+    },
+    "/proposals": {
+      "get": {
+        "description": "Returns list of proposals filtered by provider id",
+        "operationId": "listProposals",
+        "parameters": [
+          {
+            "description": "id of provider proposals",
+            "in": "query",
+            "name": "providerId",
+            "schema": {
+              "type": "string"
+            }
+          },
+          {
+            "description": "the service type of the proposal. Possible values are \"openvpn\", \"wireguard\" and \"noop\"",
+            "in": "query",
+            "name": "serviceType",
+            "schema": {
+              "type": "string"
+            }
+          },
+          {
+            "description": "the access policy id to filter the proposals by",
+            "in": "query",
+            "name": "accessPolicyId",
+            "schema": {
+              "type": "string"
+            }
+          },
+          {
+            "description": "the access policy source to filter the proposals by",
+            "in": "query",
+            "name": "accessPolicySource",
+            "schema": {
+              "type": "string"
+            }
+          },
+          {
+            "description": "if set to true, fetches the connection success metrics for nodes. False by default.",
+            "in": "query",
+            "name": "fetchConnectCounts",
+            "schema": {
+              "type": "boolean"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ProposalsList"
+                }
+              }
+            },
+            "description": "List of proposals"
+          },
+          "500": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorMessageDTO"
+                }
+              }
+            },
+            "description": "Internal server error"
+          }
+        },
+        "summary": "Returns proposals",
+        "tags": [
+          "Proposal"
+        ]
+      }
+    },
+    "/service-sessions": {
+      "get": {
+        "description": "Returns list of sessions in currently running service",
+        "operationId": "serviceSessions",
+        "responses": {
+          "200": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ServiceSessionListDTO"
+                }
+              }
+            },
+            "description": "List of sessions"
+          }
+        },
+        "summary": "Returns current sessions",
+        "tags": [
+          "Service"
+        ]
+      }
+    },
+    "/services": {
+      "get": {
+        "description": "ServiceList provides a list of running services on the node.",
+        "operationId": "serviceList",
+        "responses": {
+          "200": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ServiceListDTO"
+                }
+              }
+            },
+            "description": "List of running services"
+          }
+        },
+        "summary": "List of services",
+        "tags": [
+          "Service"
+        ]
+      },
+      "post": {
+        "description": "Provider starts serving new service to consumers",
+        "operationId": "serviceStart",
+        "requestBody": {
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/ServiceRequestDTO"
+              }
+            }
+          },
+          "description": "Parameters in body (providerID) required for starting new service"
+        },
+        "responses": {
+          "201": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ServiceInfoDTO"
+                }
+              }
+            },
+            "description": "Initiates service start"
+          },
+          "400": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorMessageDTO"
+                }
+              }
+            },
+            "description": "Bad request"
+          },
+          "409": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorMessageDTO"
+                }
+              }
+            },
+            "description": "Conflict. Service is already running"
+          },
+          "422": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ValidationErrorDTO"
+                }
+              }
+            },
+            "description": "Parameters validation error"
+          },
+          "500": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorMessageDTO"
+                }
+              }
+            },
+            "description": "Internal server error"
+          }
+        },
+        "summary": "Starts service",
+        "tags": [
+          "Service"
+        ]
+      }
+    },
+    "/services/:id": {
+      "delete": {
+        "description": "Initiates service stop",
+        "operationId": "serviceStop",
+        "responses": {
+          "202": {
+            "description": "Service Stop initiated"
+          },
+          "404": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorMessageDTO"
+                }
+              }
+            },
+            "description": "No service exists"
+          },
+          "500": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorMessageDTO"
+                }
+              }
+            },
+            "description": "Internal server error"
+          }
+        },
+        "summary": "Stops service",
+        "tags": [
+          "Service"
+        ]
+      },
+      "get": {
+        "description": "ServiceGet provides info for requested service on the node.",
+        "operationId": "serviceGet",
+        "responses": {
+          "200": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ServiceInfoDTO"
+                }
+              }
+            },
+            "description": "Service detailed information"
+          },
+          "404": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorMessageDTO"
+                }
+              }
+            },
+            "description": "Service not found"
+          }
+        },
+        "summary": "Information about service",
+        "tags": [
+          "Service"
+        ]
+      },
+      "put": {
+        "description": "Replaces access policies or price of the running service and re-announces its proposal. Existing sessions of consumers which are no longer allowed are terminated, other sessions keep their price.",
+        "operationId": "serviceUpdate",
+        "requestBody": {
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/ServiceUpdateRequestDTO"
+              }
+            }
+          },
+          "description": "Access policies and price to apply to the service"
+        },
+        "responses": {
+          "200": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ServiceInfoDTO"
+                }
+              }
+            },
+            "description": "Service updated"
+          },
+          "400": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorMessageDTO"
+                }
+              }
+            },
+            "description": "Bad request"
+          },
+          "404": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorMessageDTO"
+                }
+              }
+            },
+            "description": "Service not found"
+          },
+          "500": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorMessageDTO"
+                }
+              }
+            },
+            "description": "Internal server error"
+          }
+        },
+        "summary": "Updates service",
+        "tags": [
+          "Service"
+        ]
+      }
+    },
+    "/sessions-connectivity-status": {
+      "get": {
+        "description": "Returns list of session connectivity status",
+        "operationId": "ConnectivityStatus",
+        "responses": {
+          "200": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ConnectivityStatus"
+                }
+              }
+            },
+            "description": "List of connectivity statuses"
+          }
+        },
+        "summary": "Returns session connectivity status"
+      }
+    },
+    "/stop": {
+      "post": {
+        "description": "Initiates client termination",
+        "operationId": "applicationStop",
+        "responses": {
+          "202": {
+            "description": "Request accepted, stopping"
+          }
+        },
+        "summary": "Stops client",
+        "tags": [
+          "Client"
+        ]
+      }
+    },
+    "/transactions": {
+      "get": {
+        "description": "Returns invoices, exchange messages, accountant promises and settlements recorded in the payment ledger, oldest first",
+        "operationId": "transactions",
+        "parameters": [
+          {
+            "description": "Identity which sent or received the transactions",
+            "in": "query",
+            "name": "identity",
+            "schema": {
+              "type": "string"
+            }
+          },
+          {
+            "description": "Session the transactions belong to",
+            "in": "query",
+            "name": "sessionId",
+            "schema": {
+              "type": "string"
+            }
+          },
+          {
+            "description": "Transaction kind, one of invoice, exchange_message, accountant_promise, settlement",
+            "in": "query",
+            "name": "kind",
+            "schema": {
+              "type": "string"
+            }
+          },
+          {
+            "description": "Transaction direction, one of incoming, outgoing",
+            "in": "query",
+            "name": "direction",
+            "schema": {
+              "type": "string"
+            }
+          },
+          {
+            "description": "Include transactions made at or after the given RFC3339 time",
+            "in": "query",
+            "name": "from",
+            "schema": {
+              "type": "string"
+            }
+          },
+          {
+            "description": "Include transactions made before the given RFC3339 time",
+            "in": "query",
+            "name": "to",
+            "schema": {
+              "type": "string"
+            }
+          },
+          {
+            "description": "Number of the oldest matching transactions to skip",
+            "in": "query",
+            "name": "offset",
+            "schema": {
+              "type": "integer"
+            }
+          },
+          {
+            "description": "Maximum number of transactions to return, all by default",
+            "in": "query",
+            "name": "limit",
+            "schema": {
+              "type": "integer"
+            }
+          },
+          {
+            "description": "Export format, one of json (default), csv",
+            "in": "query",
+            "name": "format",
+            "schema": {
+              "type": "string"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/TransactionListDTO"
+                }
+              },
+              "text/csv": {
+                "schema": {
+                  "$ref": "#/components/schemas/TransactionListDTO"
+                }
+              }
+            },
+            "description": "List of transactions"
+          },
+          "422": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ValidationErrorDTO"
+                }
+              },
+              "text/csv": {
+                "schema": {
+                  "$ref": "#/components/schemas/ValidationErrorDTO"
+                }
+              }
+            },
+            "description": "Parameters validation error"
+          },
+          "500": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorMessageDTO"
+                }
+              },
+              "text/csv": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorMessageDTO"
+                }
+              }
+            },
+            "description": "Internal server error"
+          }
+        },
+        "summary": "Returns payment transactions history",
+        "tags": [
+          "Transactions"
+        ]
+      }
+    },
+    "/transactor/fees": {
+      "get": {
+        "description": "Returns fees applied by Transactor",
+        "operationId": "Fees",
+        "responses": {
+          "200": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/Fees"
+                }
+              }
+            },
+            "description": "fees applied by Transactor"
+          },
+          "500": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorMessageDTO"
+                }
+              }
+            },
+            "description": "Internal server error"
+          }
+        },
+        "summary": "Returns fees"
+      }
+    },
+    "/transactor/settle/async": {
+      "post": {
+        "description": "Forces a settlement for the accountant promises. Does not wait for completion.",
+        "operationId": "SettleAsync",
+        "requestBody": {
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/SettleRequest"
+              }
+            }
+          },
+          "description": "settle request body"
+        },
+        "responses": {
+          "202": {
+            "description": "settle request accepted"
+          },
+          "500": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorMessageDTO"
+                }
+              }
+            },
+            "description": "Internal server error"
+          }
+        },
+        "summary": "forces the settlement of promises for the given provider and accountant"
+      }
+    },
+    "/transactor/settle/sync": {
+      "post": {
+        "description": "Forces a settlement for the accountant promises and blocks until the settlement is complete.",
+        "operationId": "SettleSync",
+        "requestBody": {
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/SettleRequest"
+              }
+            }
+          },
+          "description": "settle request body"
+        },
+        "responses": {
+          "202": {
+            "description": "settle request accepted"
+          },
+          "500": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorMessageDTO"
+                }
+              }
+            },
+            "description": "Internal server error"
+          }
+        },
+        "summary": "forces the settlement of promises for the given provider and accountant"
+      }
+    },
+    "/transactor/topup": {
+      "post": {
+        "description": "tops up myst to the given identity",
+        "requestBody": {
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/TopUpRequestDTO"
+              }
+            }
+          },
+          "description": "top up request body"
+        },
+        "responses": {
+          "202": {
+            "description": "top up request accepted"
+          },
+          "400": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorMessageDTO"
+                }
+              }
+            },
+            "description": "Bad request"
+          },
+          "500": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/ErrorMessageDTO"
+                }
+              }
+            },
+            "description": "Internal server error"
+          }
+        },
+        "summary": "tops up myst to the given identity"
+      }
+    }
+  },
+  "security": [
+    {
+      "bearerAuth": []
+    },
+    {
+      "cookieAuth": []
+    }
+  ],
+  "servers": [
+    {
+      "url": "http://127.0.0.1:4050"
+    }
+  ]
+}`
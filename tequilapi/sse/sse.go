@@ -24,6 +24,7 @@ import (
 	"sync"
 
 	"github.com/julienschmidt/httprouter"
+	"github.com/mysteriumnetwork/node/consumer/budget"
 	nodeEvent "github.com/mysteriumnetwork/node/core/node/event"
 	stateEvent "github.com/mysteriumnetwork/node/core/state/event"
 	"github.com/pkg/errors"
@@ -46,6 +47,8 @@ const (
 	ServiceStatusEvent EventType = "service-status"
 	// StateChangeEvent represents the state change
 	StateChangeEvent EventType = "state-change"
+	// SpendingAlertEvent represents the consumer spending alert
+	SpendingAlertEvent EventType = "spending-alert"
 )
 
 // Handler represents an sse handler
@@ -199,3 +202,11 @@ func (h *Handler) ConsumeStateEvent(event stateEvent.State) {
 		Payload: event,
 	})
 }
+
+// ConsumeSpendingAlert consumes the consumer spending alert event
+func (h *Handler) ConsumeSpendingAlert(event budget.AlertEvent) {
+	h.send(Event{
+		Type:    SpendingAlertEvent,
+		Payload: event,
+	})
+}
@@ -0,0 +1,138 @@
+/*
+ * Copyright (C) 2020 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package tequilapi
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnsureSelfSignedCertificate_KeepsExistingCertificate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tequilapi-tls")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	certFile, keyFile := filepath.Join(dir, "tls", "tequilapi.crt"), filepath.Join(dir, "tls", "tequilapi.key")
+
+	assert.NoError(t, EnsureSelfSignedCertificate(certFile, keyFile, []string{"localhost", "127.0.0.1"}))
+	generated, err := ioutil.ReadFile(certFile)
+	assert.NoError(t, err)
+	keyInfo, err := os.Stat(keyFile)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), keyInfo.Mode().Perm())
+
+	block, _ := pem.Decode(generated)
+	certificate, err := x509.ParseCertificate(block.Bytes)
+	assert.NoError(t, err)
+	assert.False(t, certificate.IsCA)
+	assert.Zero(t, certificate.KeyUsage&x509.KeyUsageCertSign)
+
+	assert.NoError(t, EnsureSelfSignedCertificate(certFile, keyFile, []string{"localhost"}))
+	kept, err := ioutil.ReadFile(certFile)
+	assert.NoError(t, err)
+	assert.Equal(t, generated, kept)
+}
+
+func TestTLSListener_ServesWithSelfSignedCertificate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tequilapi-tls")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	certFile, keyFile := filepath.Join(dir, "tequilapi.crt"), filepath.Join(dir, "tequilapi.key")
+	assert.NoError(t, EnsureSelfSignedCertificate(certFile, keyFile, []string{"127.0.0.1"}))
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	tlsListener, err := NewTLSListener(listener, certFile, keyFile)
+	assert.NoError(t, err)
+	server := NewServer(tlsListener, http.NotFoundHandler(), RegexpCorsPolicy{})
+	server.StartServing()
+	defer server.Stop()
+
+	certPEM, err := ioutil.ReadFile(certFile)
+	assert.NoError(t, err)
+	roots := x509.NewCertPool()
+	assert.True(t, roots.AppendCertsFromPEM(certPEM))
+	client := http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: roots}}}
+
+	resp, err := client.Get("https://" + listener.Addr().String() + "/")
+	assert.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	_, err = http.Get("https://" + listener.Addr().String() + "/")
+	assert.Error(t, err, "certificate should not be trusted by default")
+}
+
+func TestUnixListener_SetsPermissionsAndReplacesStaleSocket(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tequilapi-socket")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	socket := filepath.Join(dir, "tequilapi.sock")
+
+	stale, err := net.Listen("unix", socket)
+	assert.NoError(t, err)
+	stale.(*net.UnixListener).SetUnlinkOnClose(false)
+	stale.Close()
+
+	listener, err := NewUnixListener(socket, 0600)
+	assert.NoError(t, err)
+	defer listener.Close()
+
+	info, err := os.Stat(socket)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	address, err := extractBoundAddress(listener)
+	assert.NoError(t, err)
+	assert.Equal(t, socket, address)
+
+	entries, err := ioutil.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1, "private socket directory is removed")
+
+	conn, err := net.Dial("unix", socket)
+	assert.NoError(t, err)
+	conn.Close()
+
+	assert.NoError(t, listener.Close())
+	_, err = os.Stat(socket)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestUnixListener_KeepsExistingFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tequilapi-socket")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "tequilapi.sock")
+	assert.NoError(t, ioutil.WriteFile(path, []byte("data"), 0600))
+
+	_, err = NewUnixListener(path, 0600)
+	assert.EqualError(t, err, "could not listen on "+path+": file exists and is not a socket")
+
+	content, err := ioutil.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "data", string(content))
+}
@@ -28,6 +28,7 @@ type FieldError struct {
 }
 
 // FieldErrorList contains list of FieldError
+// openapi:type []FieldError
 type FieldErrorList struct {
 	list []FieldError
 }
@@ -44,6 +45,7 @@ func (fel FieldErrorList) MarshalJSON() ([]byte, error) {
 }
 
 // FieldErrorMap represents a map of field name and corresponding list of errors for that field
+// openapi:type map[string]FieldErrorList
 type FieldErrorMap struct {
 	errorMap map[string]*FieldErrorList
 }
@@ -0,0 +1,134 @@
+/*
+ * Copyright (C) 2020 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package ws
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/mysteriumnetwork/node/core/connection"
+	"github.com/pkg/errors"
+)
+
+// commandRoute is the Tequilapi route executing the command
+type commandRoute struct {
+	method string
+	path   func(params json.RawMessage) (string, error)
+	body   bool
+}
+
+// commandRoutes maps the commands to Tequilapi routes, params are sent as the request body
+var commandRoutes = map[string]commandRoute{
+	"connect":       {method: http.MethodPut, path: staticPath("/connection"), body: true},
+	"disconnect":    {method: http.MethodDelete, path: staticPath("/connection")},
+	"start-service": {method: http.MethodPost, path: staticPath("/services"), body: true},
+	"stop-service":  {method: http.MethodDelete, path: servicePath},
+}
+
+func staticPath(path string) func(json.RawMessage) (string, error) {
+	return func(json.RawMessage) (string, error) {
+		return path, nil
+	}
+}
+
+func servicePath(params json.RawMessage) (string, error) {
+	var service struct {
+		ID string `json:"id"`
+	}
+	if len(params) > 0 {
+		if err := json.Unmarshal(params, &service); err != nil {
+			return "", err
+		}
+	}
+	if service.ID == "" {
+		return "", errors.New("service id is required")
+	}
+	return "/services/" + url.PathEscape(service.ID), nil
+}
+
+// newCommandRequest builds the Tequilapi request executing the command on behalf of the websocket client.
+// The context of the websocket request carries the authenticated user.
+func newCommandRequest(wsRequest *http.Request, cmd Command) (*http.Request, error) {
+	route, ok := commandRoutes[cmd.Command]
+	if !ok {
+		return nil, errors.Errorf("unknown command %q", cmd.Command)
+	}
+
+	path, err := route.path(cmd.Params)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid %s params", cmd.Command)
+	}
+	var body io.Reader = http.NoBody
+	if route.body && len(cmd.Params) > 0 {
+		body = bytes.NewReader(cmd.Params)
+	}
+
+	req, err := http.NewRequest(route.method, path, body)
+	if err != nil {
+		return nil, err
+	}
+	req.RemoteAddr = wsRequest.RemoteAddr
+	req.Header.Set("Content-Type", "application/json")
+	return req.WithContext(wsRequest.Context()), nil
+}
+
+type sessionPayload struct {
+	SessionID   string `json:"sessionId"`
+	ConsumerID  string `json:"consumerId"`
+	ProviderID  string `json:"providerId"`
+	ServiceType string `json:"serviceType"`
+}
+
+func newSessionPayload(info connection.SessionInfo) sessionPayload {
+	return sessionPayload{
+		SessionID:   string(info.SessionID),
+		ConsumerID:  info.ConsumerID.Address,
+		ProviderID:  info.Proposal.ProviderID,
+		ServiceType: info.Proposal.ServiceType,
+	}
+}
+
+type connectionStatePayload struct {
+	State string `json:"state"`
+	sessionPayload
+}
+
+type connectionSessionPayload struct {
+	Status string `json:"status"`
+	sessionPayload
+}
+
+type connectionStatisticsPayload struct {
+	SessionID     string `json:"sessionId"`
+	BytesSent     uint64 `json:"bytesSent"`
+	BytesReceived uint64 `json:"bytesReceived"`
+}
+
+type serviceSessionPayload struct {
+	Action string `json:"action"`
+	ID     string `json:"id"`
+}
+
+type balanceChangePayload struct {
+	Identity string `json:"identity"`
+	Previous uint64 `json:"previous"`
+	Current  uint64 `json:"current"`
+}
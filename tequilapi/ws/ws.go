@@ -0,0 +1,422 @@
+/*
+ * Copyright (C) 2020 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package ws
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/julienschmidt/httprouter"
+	"github.com/mysteriumnetwork/node/consumer/budget"
+	"github.com/mysteriumnetwork/node/core/connection"
+	nodeEvent "github.com/mysteriumnetwork/node/core/node/event"
+	stateEvent "github.com/mysteriumnetwork/node/core/state/event"
+	sessionEvent "github.com/mysteriumnetwork/node/session/event"
+	"github.com/mysteriumnetwork/node/session/pingpong"
+	"github.com/rs/zerolog/log"
+)
+
+// EventType represents all the event types we're streaming
+type EventType string
+
+const (
+	// StateChangeEvent represents the node state change
+	StateChangeEvent EventType = "state-change"
+	// SpendingAlertEvent represents the consumer spending alert
+	SpendingAlertEvent EventType = "spending-alert"
+	// ConnectionStateEvent represents the consumer connection state change
+	ConnectionStateEvent EventType = "connection-state"
+	// ConnectionStatisticsEvent represents the consumer connection statistics
+	ConnectionStatisticsEvent EventType = "connection-statistics"
+	// ConnectionSessionEvent represents the consumer session creation and end
+	ConnectionSessionEvent EventType = "connection-session"
+	// ServiceSessionEvent represents the provider service session change
+	ServiceSessionEvent EventType = "service-session"
+	// BalanceChangeEvent represents the identity balance change
+	BalanceChangeEvent EventType = "balance-change"
+
+	// responseType marks the messages answering the commands
+	responseType EventType = "response"
+)
+
+const (
+	writeTimeout = 10 * time.Second
+	pongTimeout  = 60 * time.Second
+	pingInterval = pongTimeout * 9 / 10
+	// clientQueueSize is the number of messages queued for a client, the client is dropped if it can't keep up
+	clientQueueSize = 64
+	maxMessageSize  = 64 * 1024
+)
+
+// Message is sent to the client either as an event, or as a response to the command
+type Message struct {
+	// ID of the command this message responds to
+	ID      string      `json:"id,omitempty"`
+	Type    EventType   `json:"type"`
+	Status  int         `json:"status,omitempty"`
+	Payload interface{} `json:"payload,omitempty"`
+	Error   string      `json:"error,omitempty"`
+}
+
+// Command is sent by the client to subscribe to events or to call the node
+type Command struct {
+	ID      string          `json:"id"`
+	Command string          `json:"command"`
+	Params  json.RawMessage `json:"params"`
+}
+
+type stateProvider interface {
+	GetState() stateEvent.State
+}
+
+// originPolicy resolves allowed origin, same as for the CORS requests
+type originPolicy interface {
+	AllowedOrigin(requestOrigin string) string
+}
+
+// Handler streams node events to the websocket clients and executes their commands
+type Handler struct {
+	upgrader      websocket.Upgrader
+	stateProvider stateProvider
+
+	lock     sync.Mutex
+	clients  map[*client]struct{}
+	commands http.Handler
+}
+
+// NewHandler returns a new instance of handler
+func NewHandler(stateProvider stateProvider, originPolicy originPolicy) *Handler {
+	h := &Handler{
+		stateProvider: stateProvider,
+		clients:       make(map[*client]struct{}),
+	}
+	h.upgrader = websocket.Upgrader{
+		CheckOrigin: func(req *http.Request) bool {
+			origin := req.Header.Get("Origin")
+			return origin == "" || originPolicy.AllowedOrigin(origin) == origin || isSameOrigin(origin, req.Host)
+		},
+	}
+	return h
+}
+
+// SetCommandHandler sets the handler executing the client commands as Tequilapi requests.
+// It should check the authorization of the user carried in the request context.
+func (h *Handler) SetCommandHandler(commands http.Handler) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.commands = commands
+}
+
+// Sub upgrades the request to websocket and streams the events until the client disconnects
+func (h *Handler) Sub(resp http.ResponseWriter, req *http.Request, _ httprouter.Params) {
+	conn, err := h.upgrader.Upgrade(resp, req, nil)
+	if err != nil {
+		log.Warn().Err(err).Msg("Could not upgrade to websocket")
+		return
+	}
+
+	c := newClient(conn, parseEventTypes(req.URL.Query().Get("events")))
+	h.register(c)
+	defer h.unregister(c)
+
+	go c.writeLoop()
+	c.send(Message{Type: StateChangeEvent, Payload: h.stateProvider.GetState()})
+	h.readLoop(c, req)
+}
+
+func (h *Handler) readLoop(c *client, req *http.Request) {
+	c.conn.SetReadLimit(maxMessageSize)
+	_ = c.conn.SetReadDeadline(time.Now().Add(pongTimeout))
+	c.conn.SetPongHandler(func(string) error {
+		return c.conn.SetReadDeadline(time.Now().Add(pongTimeout))
+	})
+
+	for {
+		var cmd Command
+		if err := c.conn.ReadJSON(&cmd); err != nil {
+			if _, ok := err.(*json.SyntaxError); ok {
+				c.send(Message{Type: responseType, Status: http.StatusBadRequest, Error: "invalid command: " + err.Error()})
+				continue
+			}
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+				log.Debug().Err(err).Msg("Websocket client disconnected")
+			}
+			return
+		}
+		c.send(h.execute(c, req, cmd))
+	}
+}
+
+func (h *Handler) execute(c *client, req *http.Request, cmd Command) Message {
+	if cmd.Command == "subscribe" {
+		var params struct {
+			Events []EventType `json:"events"`
+		}
+		if len(cmd.Params) > 0 {
+			if err := json.Unmarshal(cmd.Params, &params); err != nil {
+				return Message{ID: cmd.ID, Type: responseType, Status: http.StatusBadRequest, Error: err.Error()}
+			}
+		}
+		c.subscribe(params.Events)
+		return Message{ID: cmd.ID, Type: responseType, Status: http.StatusOK}
+	}
+
+	commandRequest, err := newCommandRequest(req, cmd)
+	if err != nil {
+		return Message{ID: cmd.ID, Type: responseType, Status: http.StatusBadRequest, Error: err.Error()}
+	}
+
+	h.lock.Lock()
+	commands := h.commands
+	h.lock.Unlock()
+	if commands == nil {
+		return Message{ID: cmd.ID, Type: responseType, Status: http.StatusServiceUnavailable, Error: "commands are not available"}
+	}
+
+	recorder := newResponseRecorder()
+	commands.ServeHTTP(recorder, commandRequest)
+	response := Message{ID: cmd.ID, Type: responseType, Status: recorder.status}
+	if recorder.body.Len() > 0 {
+		var payload interface{}
+		if err := json.Unmarshal(recorder.body.Bytes(), &payload); err != nil {
+			payload = recorder.body.String()
+		}
+		response.Payload = payload
+	}
+	return response
+}
+
+func (h *Handler) register(c *client) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.clients[c] = struct{}{}
+}
+
+func (h *Handler) unregister(c *client) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	if _, ok := h.clients[c]; ok {
+		delete(h.clients, c)
+		c.close()
+	}
+}
+
+func (h *Handler) broadcast(msg Message) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	for c := range h.clients {
+		if !c.isSubscribed(msg.Type) {
+			continue
+		}
+		if !c.send(msg) {
+			log.Warn().Msg("Websocket client is too slow, disconnecting")
+			delete(h.clients, c)
+			c.close()
+		}
+	}
+}
+
+// ConsumeNodeEvent consumes the node state event
+func (h *Handler) ConsumeNodeEvent(e nodeEvent.Payload) {
+	if e.Status != nodeEvent.StatusStopped {
+		return
+	}
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	for c := range h.clients {
+		delete(h.clients, c)
+		c.close()
+	}
+}
+
+// ConsumeStateEvent consumes the state change event
+func (h *Handler) ConsumeStateEvent(event stateEvent.State) {
+	h.broadcast(Message{Type: StateChangeEvent, Payload: event})
+}
+
+// ConsumeSpendingAlert consumes the consumer spending alert event
+func (h *Handler) ConsumeSpendingAlert(event budget.AlertEvent) {
+	h.broadcast(Message{Type: SpendingAlertEvent, Payload: event})
+}
+
+// ConsumeConnectionState consumes the consumer connection state event
+func (h *Handler) ConsumeConnectionState(event connection.StateEvent) {
+	payload := connectionStatePayload{State: string(event.State)}
+	payload.sessionPayload = newSessionPayload(event.SessionInfo)
+	h.broadcast(Message{Type: ConnectionStateEvent, Payload: payload})
+}
+
+// ConsumeConnectionStatistics consumes the consumer connection statistics event
+func (h *Handler) ConsumeConnectionStatistics(event connection.SessionStatsEvent) {
+	h.broadcast(Message{Type: ConnectionStatisticsEvent, Payload: connectionStatisticsPayload{
+		SessionID:     string(event.SessionInfo.SessionID),
+		BytesSent:     event.Stats.BytesSent,
+		BytesReceived: event.Stats.BytesReceived,
+	}})
+}
+
+// ConsumeConnectionSession consumes the consumer session event
+func (h *Handler) ConsumeConnectionSession(event connection.SessionEvent) {
+	payload := connectionSessionPayload{Status: event.Status}
+	payload.sessionPayload = newSessionPayload(event.SessionInfo)
+	h.broadcast(Message{Type: ConnectionSessionEvent, Payload: payload})
+}
+
+// ConsumeServiceSession consumes the provider service session event
+func (h *Handler) ConsumeServiceSession(event sessionEvent.Payload) {
+	h.broadcast(Message{Type: ServiceSessionEvent, Payload: serviceSessionPayload{
+		Action: string(event.Action),
+		ID:     event.ID,
+	}})
+}
+
+// ConsumeBalanceChange consumes the identity balance change event
+func (h *Handler) ConsumeBalanceChange(event pingpong.BalanceChangedEvent) {
+	h.broadcast(Message{Type: BalanceChangeEvent, Payload: balanceChangePayload{
+		Identity: event.Identity.Address,
+		Previous: event.Previous,
+		Current:  event.Current,
+	}})
+}
+
+func parseEventTypes(value string) []EventType {
+	var types []EventType
+	for _, t := range strings.Split(value, ",") {
+		if t = strings.TrimSpace(t); t != "" {
+			types = append(types, EventType(t))
+		}
+	}
+	return types
+}
+
+func isSameOrigin(origin, host string) bool {
+	origin = strings.TrimPrefix(strings.TrimPrefix(origin, "http://"), "https://")
+	return strings.EqualFold(origin, host)
+}
+
+type client struct {
+	conn     *websocket.Conn
+	messages chan Message
+	done     chan struct{}
+	once     sync.Once
+
+	lock       sync.Mutex
+	subscribed map[EventType]bool
+}
+
+func newClient(conn *websocket.Conn, types []EventType) *client {
+	c := &client{
+		conn:     conn,
+		messages: make(chan Message, clientQueueSize),
+		done:     make(chan struct{}),
+	}
+	c.subscribe(types)
+	return c
+}
+
+// subscribe replaces the subscribed event types, all of them are streamed if none are given
+func (c *client) subscribe(types []EventType) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if len(types) == 0 {
+		c.subscribed = nil
+		return
+	}
+	c.subscribed = make(map[EventType]bool, len(types))
+	for _, t := range types {
+		c.subscribed[t] = true
+	}
+}
+
+func (c *client) isSubscribed(t EventType) bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.subscribed == nil || c.subscribed[t]
+}
+
+// send queues the message without blocking, returns false if the queue is full
+func (c *client) send(msg Message) bool {
+	select {
+	case <-c.done:
+		return true
+	default:
+	}
+	select {
+	case c.messages <- msg:
+		return true
+	default:
+		return false
+	}
+}
+
+func (c *client) close() {
+	c.once.Do(func() { close(c.done) })
+}
+
+func (c *client) writeLoop() {
+	ticker := time.NewTicker(pingInterval)
+	defer func() {
+		ticker.Stop()
+		c.conn.Close()
+	}()
+
+	for {
+		select {
+		case msg := <-c.messages:
+			_ = c.conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+			if err := c.conn.WriteJSON(msg); err != nil {
+				log.Debug().Err(err).Msg("Could not write to websocket")
+				return
+			}
+		case <-ticker.C:
+			if err := c.conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(writeTimeout)); err != nil {
+				return
+			}
+		case <-c.done:
+			_ = c.conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), time.Now().Add(writeTimeout))
+			return
+		}
+	}
+}
+
+type responseRecorder struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func newResponseRecorder() *responseRecorder {
+	return &responseRecorder{header: make(http.Header), status: http.StatusOK}
+}
+
+func (r *responseRecorder) Header() http.Header {
+	return r.header
+}
+
+func (r *responseRecorder) Write(data []byte) (int, error) {
+	return r.body.Write(data)
+}
+
+func (r *responseRecorder) WriteHeader(status int) {
+	r.status = status
+}
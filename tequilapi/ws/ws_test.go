@@ -0,0 +1,179 @@
+/*
+ * Copyright (C) 2020 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package ws
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/julienschmidt/httprouter"
+	"github.com/mysteriumnetwork/node/core/connection"
+	stateEvent "github.com/mysteriumnetwork/node/core/state/event"
+	"github.com/mysteriumnetwork/node/identity"
+	sessionEvent "github.com/mysteriumnetwork/node/session/event"
+	"github.com/mysteriumnetwork/node/session/pingpong"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockStateProvider struct{}
+
+func (mockStateProvider) GetState() stateEvent.State {
+	return stateEvent.State{}
+}
+
+type mockOriginPolicy struct{}
+
+func (mockOriginPolicy) AllowedOrigin(requestOrigin string) string {
+	return "https://mysterium.network"
+}
+
+type mockCommandHandler struct {
+	method, path, body string
+}
+
+func (m *mockCommandHandler) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+	body, _ := ioutil.ReadAll(req.Body)
+	m.method, m.path, m.body = req.Method, req.URL.Path, string(body)
+	resp.WriteHeader(http.StatusCreated)
+	_, _ = resp.Write([]byte(`{"id":"service1"}`))
+}
+
+func newTestServer(t *testing.T) (*Handler, *httptest.Server) {
+	handler := NewHandler(mockStateProvider{}, mockOriginPolicy{})
+	router := httprouter.New()
+	router.GET("/events/ws", handler.Sub)
+	return handler, httptest.NewServer(router)
+}
+
+func dial(t *testing.T, server *httptest.Server, query string) *websocket.Conn {
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http")+"/events/ws"+query, nil)
+	assert.NoError(t, err)
+	return conn
+}
+
+func readMessage(t *testing.T, conn *websocket.Conn) map[string]interface{} {
+	_ = conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	var msg map[string]interface{}
+	assert.NoError(t, conn.ReadJSON(&msg))
+	return msg
+}
+
+func waitForClients(handler *Handler, count int) {
+	for i := 0; i < 100; i++ {
+		handler.lock.Lock()
+		registered := len(handler.clients)
+		handler.lock.Unlock()
+		if registered == count {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestHandler_StreamsInitialStateAndEvents(t *testing.T) {
+	handler, server := newTestServer(t)
+	defer server.Close()
+	conn := dial(t, server, "")
+	defer conn.Close()
+
+	assert.Equal(t, "state-change", readMessage(t, conn)["type"])
+
+	waitForClients(handler, 1)
+	handler.ConsumeConnectionStatistics(connection.SessionStatsEvent{
+		SessionInfo: connection.SessionInfo{SessionID: "session1"},
+	})
+	msg := readMessage(t, conn)
+	assert.Equal(t, "connection-statistics", msg["type"])
+	assert.Equal(t, "session1", msg["payload"].(map[string]interface{})["sessionId"])
+}
+
+func TestHandler_StreamsSubscribedEventsOnly(t *testing.T) {
+	handler, server := newTestServer(t)
+	defer server.Close()
+	conn := dial(t, server, "?events=balance-change")
+	defer conn.Close()
+
+	assert.Equal(t, "state-change", readMessage(t, conn)["type"])
+
+	waitForClients(handler, 1)
+	handler.ConsumeServiceSession(sessionEvent.Payload{Action: sessionEvent.Created, ID: "session1"})
+	handler.ConsumeBalanceChange(pingpong.BalanceChangedEvent{Identity: identity.FromAddress("0x1"), Previous: 10, Current: 5})
+	msg := readMessage(t, conn)
+	assert.Equal(t, "balance-change", msg["type"])
+	assert.Equal(t, map[string]interface{}{"identity": "0x1", "previous": float64(10), "current": float64(5)}, msg["payload"])
+
+	assert.NoError(t, conn.WriteJSON(Command{ID: "1", Command: "subscribe", Params: json.RawMessage(`{"events":["service-session"]}`)}))
+	msg = readMessage(t, conn)
+	assert.Equal(t, "response", msg["type"])
+	assert.Equal(t, "1", msg["id"])
+
+	handler.ConsumeBalanceChange(pingpong.BalanceChangedEvent{Identity: identity.FromAddress("0x1")})
+	handler.ConsumeServiceSession(sessionEvent.Payload{Action: sessionEvent.Removed, ID: "session1"})
+	msg = readMessage(t, conn)
+	assert.Equal(t, "service-session", msg["type"])
+	assert.Equal(t, map[string]interface{}{"action": "Removed", "id": "session1"}, msg["payload"])
+}
+
+func TestHandler_ExecutesCommands(t *testing.T) {
+	handler, server := newTestServer(t)
+	defer server.Close()
+	commands := &mockCommandHandler{}
+	handler.SetCommandHandler(commands)
+	conn := dial(t, server, "")
+	defer conn.Close()
+	readMessage(t, conn)
+
+	assert.NoError(t, conn.WriteJSON(Command{ID: "1", Command: "start-service", Params: json.RawMessage(`{"type":"wireguard"}`)}))
+	msg := readMessage(t, conn)
+	assert.Equal(t, map[string]interface{}{
+		"id":      "1",
+		"type":    "response",
+		"status":  float64(http.StatusCreated),
+		"payload": map[string]interface{}{"id": "service1"},
+	}, msg)
+	assert.Equal(t, http.MethodPost, commands.method)
+	assert.Equal(t, "/services", commands.path)
+	assert.Equal(t, `{"type":"wireguard"}`, commands.body)
+
+	assert.NoError(t, conn.WriteJSON(Command{ID: "2", Command: "stop-service", Params: json.RawMessage(`{"id":"service1"}`)}))
+	readMessage(t, conn)
+	assert.Equal(t, http.MethodDelete, commands.method)
+	assert.Equal(t, "/services/service1", commands.path)
+
+	assert.NoError(t, conn.WriteJSON(Command{ID: "3", Command: "reboot"}))
+	msg = readMessage(t, conn)
+	assert.Equal(t, "3", msg["id"])
+	assert.Equal(t, float64(http.StatusBadRequest), msg["status"])
+	assert.Equal(t, `unknown command "reboot"`, msg["error"])
+}
+
+func TestHandler_RejectsForeignOrigin(t *testing.T) {
+	_, server := newTestServer(t)
+	defer server.Close()
+
+	header := http.Header{"Origin": []string{"https://evil.example.com"}}
+	_, resp, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http")+"/events/ws", header)
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+}
@@ -18,6 +18,11 @@
 package ui
 
 import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"io/ioutil"
 	"net"
 	"net/http"
 	"net/http/httputil"
@@ -29,11 +34,37 @@ import (
 
 	"github.com/mysteriumnetwork/node/core/auth"
 	"github.com/mysteriumnetwork/node/tequilapi/endpoints"
+	"github.com/pkg/errors"
 )
 
-func buildTransport() *http.Transport {
+// NewPinnedTLSConfig returns TLS config trusting only the certificate from the given file.
+// Tequilapi may be bound to any address with self-signed certificate, so the certificate is pinned instead of verifying its host name.
+func NewPinnedTLSConfig(certFile string) (*tls.Config, error) {
+	data, err := ioutil.ReadFile(certFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read Tequilapi certificate")
+	}
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, errors.Errorf("no certificate found in %s", certFile)
+	}
+
+	pinned := block.Bytes
+	return &tls.Config{
+		InsecureSkipVerify: true,
+		VerifyPeerCertificate: func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			if len(rawCerts) == 0 || !bytes.Equal(rawCerts[0], pinned) {
+				return errors.New("unexpected Tequilapi certificate")
+			}
+			return nil
+		},
+	}, nil
+}
+
+func buildTransport(tlsConfig *tls.Config) *http.Transport {
 	return &http.Transport{
-		Proxy: http.ProxyFromEnvironment,
+		TLSClientConfig: tlsConfig,
+		Proxy:           http.ProxyFromEnvironment,
 		DialContext: (&net.Dialer{
 			Timeout:   20 * time.Second,
 			KeepAlive: 20 * time.Second,
@@ -44,9 +75,13 @@ func buildTransport() *http.Transport {
 }
 
 func buildReverseProxy(bindAddress string, transport *http.Transport, tequilapiPort int) *httputil.ReverseProxy {
+	scheme := "http"
+	if transport.TLSClientConfig != nil {
+		scheme = "https"
+	}
 	proxy := &httputil.ReverseProxy{
 		Director: func(req *http.Request) {
-			req.URL.Scheme = "http"
+			req.URL.Scheme = scheme
 			req.URL.Host = bindAddress + ":" + strconv.Itoa(tequilapiPort)
 			req.URL.Path = strings.Replace(req.URL.Path, tequilapiUrlPrefix, "", 1)
 			req.URL.Path = strings.TrimRight(req.URL.Path, "/")
@@ -67,9 +102,9 @@ func buildReverseProxy(bindAddress string, transport *http.Transport, tequilapiP
 	return proxy
 }
 
-// ReverseTequilapiProxy proxies UIServer requests to the TequilAPI server
-func ReverseTequilapiProxy(bindAddress string, tequilapiPort int, authenticator jwtAuthenticator) gin.HandlerFunc {
-	proxy := buildReverseProxy(bindAddress, buildTransport(), tequilapiPort)
+// ReverseTequilapiProxy proxies UIServer requests to the TequilAPI server, using TLS when tlsConfig is given
+func ReverseTequilapiProxy(bindAddress string, tequilapiPort int, tlsConfig *tls.Config, authenticator jwtAuthenticator) gin.HandlerFunc {
+	proxy := buildReverseProxy(bindAddress, buildTransport(tlsConfig), tequilapiPort)
 
 	return func(c *gin.Context) {
 		// skip non Tequilapi routes
@@ -19,6 +19,7 @@ package ui
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"net/http"
 	"time"
@@ -70,12 +71,13 @@ var corsConfig = cors.Config{
 	},
 }
 
-// NewServer creates a new instance of the server for the given port
-func NewServer(bindAddress string, port int, tequilapiPort int, authenticator jwtAuthenticator, httpClient *requests.HTTPClient) *Server {
+// NewServer creates a new instance of the server for the given port.
+// Tequilapi is reached over TLS when tequilapiTLS is given.
+func NewServer(bindAddress string, port int, tequilapiPort int, tequilapiTLS *tls.Config, authenticator jwtAuthenticator, httpClient *requests.HTTPClient) *Server {
 	gin.SetMode(gin.ReleaseMode)
 	r := gin.New()
 	r.Use(gin.Recovery())
-	r.NoRoute(ReverseTequilapiProxy(bindAddress, tequilapiPort, authenticator))
+	r.NoRoute(ReverseTequilapiProxy(bindAddress, tequilapiPort, tequilapiTLS, authenticator))
 	r.Use(cors.New(corsConfig))
 
 	r.StaticFS("/", godvpnweb.Assets)